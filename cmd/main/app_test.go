@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"go-framebuffer-console/internal/config"
+	"go-framebuffer-console/pkg/font"
+	"go-framebuffer-console/pkg/framebuffer"
+	"go-framebuffer-console/pkg/input"
+	"go-framebuffer-console/pkg/menu"
+)
+
+// newTestApplication 创建不依赖显示设备和键盘的应用程序：界面绘制到内存，按键来自keys
+// 与NewApplication相比不打开任何设备，也不采样系统状态
+func newTestApplication(t *testing.T, keys input.Source) *Application {
+	t.Helper()
+	fb, err := framebuffer.NewMemoryFrameBuffer(headlessWidth, headlessHeight)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := font.NewEmbeddedRenderer(14, 72)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	app := &Application{
+		config:       config.NewConfig(),
+		fb:           fb,
+		fontRenderer: r,
+		keyboard:     keys,
+		menuRenderer: menu.NewMenuRenderer(fb, r),
+		ctx:          ctx,
+		cancel:       cancel,
+		headless:     true,
+		hotkeys:      input.NewHotkeyDispatcher(),
+	}
+	app.registerHotkeys()
+	return app
+}
+
+// runKeys 注入按键后关闭输入源并运行主循环，按键全部处理完毕、事件通道关闭后主循环返回
+func runKeys(t *testing.T, app *Application, keys *input.FakeSource, pressed ...string) {
+	t.Helper()
+	if err := keys.Press(pressed...); err != nil {
+		t.Fatal(err)
+	}
+	keys.Close()
+	if err := app.Run(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnterOpensConfigMenu(t *testing.T) {
+	keys := input.NewFakeSource()
+	app := newTestApplication(t, keys)
+	runKeys(t, app, keys, "Enter")
+
+	if app.configMenu == nil || app.nav.Top() != app.configMenu {
+		t.Fatalf("按回车后当前页面为%T，期望为配置菜单", app.nav.Top())
+	}
+}
+
+func TestEscLeavesConfigMenu(t *testing.T) {
+	keys := input.NewFakeSource()
+	app := newTestApplication(t, keys)
+	runKeys(t, app, keys, "Enter", "Esc")
+
+	if app.configMenu == nil {
+		t.Fatal("配置菜单没有打开过")
+	}
+	if _, ok := app.nav.Top().(*mainPage); !ok || !app.nav.AtRoot() {
+		t.Fatalf("退出配置菜单后当前页面为%T，期望回到首页", app.nav.Top())
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"time"
+
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/input"
+	"go-framebuffer-console/pkg/menu"
+	"go-framebuffer-console/pkg/system"
+)
+
+// containerRefresh 容器页面重新查询容器状态的间隔
+const containerRefresh = 5 * time.Second
+
+// containersPage 容器页面
+// 列出docker或podman中的容器及其镜像、运行状态和自动重启次数；
+// 方向键或数字键选择容器，r确认后重启选中的容器，q返回
+type containersPage struct {
+	menu.BasePage
+	app        *Application
+	runtime    *system.ContainerRuntime
+	containers []system.Container
+	err        error
+	list       *menu.List
+	status     *menu.Label // 最近一次操作的结果
+}
+
+// showContainers 显示容器页面，找不到容器运行时的套接字时提示
+func (app *Application) showContainers(nav *menu.Navigator) error {
+	runtime, err := system.FindContainerRuntime(app.config.Containers.Socket)
+	if err != nil {
+		return nav.Push(app.messagePage(menu.LevelInfo, i18n.Translate("没有找到容器运行时（docker或podman）")))
+	}
+	return nav.Push(&containersPage{
+		app:     app,
+		runtime: runtime,
+		list:    &menu.List{Selectable: true},
+		status:  &menu.Label{},
+	})
+}
+
+// OnEnter 查询容器状态
+func (p *containersPage) OnEnter(nav *menu.Navigator) error {
+	p.query()
+	return nil
+}
+
+// query 查询容器状态，更新列表；只能用单个数字键选择，因此最多列出9个容器
+func (p *containersPage) query() {
+	p.containers, p.err = p.runtime.ListContainers()
+	if p.err != nil {
+		log.Printf("%v", p.err)
+	}
+	if len(p.containers) > 9 {
+		p.containers = p.containers[:9]
+	}
+	p.list.Items = p.list.Items[:0]
+	for i, c := range p.containers {
+		text := fmt.Sprintf("%d. %s (%s): %s", i+1, c.Name, c.Image, c.Status)
+		if c.Restarts > 0 {
+			text += i18n.Translatef("，已自动重启 %d 次", c.Restarts)
+		}
+		p.list.Items = append(p.list.Items, menu.ListItem{Text: text, Key: byte('1' + i), Color: containerColor(c.State)})
+	}
+	if p.list.Selected >= len(p.list.Items) {
+		p.list.Selected = 0
+	}
+}
+
+// containerColor 返回容器状态对应的颜色：运行中为正常色，反复重启或已失效为错误色，其它为警告色
+func containerColor(state string) color.Color {
+	switch state {
+	case "running":
+		return menu.LevelSuccess.Color()
+	case "restarting", "dead":
+		return menu.LevelError.Color()
+	}
+	return menu.LevelWarning.Color()
+}
+
+// Render 绘制容器列表和使用的套接字
+func (p *containersPage) Render(mr *menu.MenuRenderer) error {
+	theme := mr.Theme()
+	layout := mr.NewLayout(&menu.Label{Text: i18n.Translate("容器"), Color: theme.Accent}, menu.NewSeparator())
+	switch {
+	case p.err != nil:
+		layout.Add(&menu.Label{Text: p.err.Error(), Color: theme.Error})
+	case len(p.containers) == 0:
+		layout.Add(menu.NewLabel(i18n.Translate("没有容器")))
+	default:
+		layout.Add(p.list, p.status)
+	}
+	return mr.RenderLayout(layout.Add(menu.NewSeparator(), menu.NewLabel(i18n.Translatef("运行时：%s", p.runtime.Socket()))))
+}
+
+// Refresh 重新查询容器状态并重绘
+func (p *containersPage) Refresh(nav *menu.Navigator) error {
+	p.query()
+	nav.Invalidate()
+	return nil
+}
+
+// RefreshInterval 每隔containerRefresh自动查询一次容器状态
+func (p *containersPage) RefreshInterval() time.Duration {
+	return containerRefresh
+}
+
+// Hints 页脚的按键提示
+func (p *containersPage) Hints() []menu.Hint {
+	return []menu.Hint{
+		{Key: i18n.Translate("上下键"), Text: i18n.Translate("选择")},
+		{Key: "r", Text: i18n.Translate("重启")},
+		{Key: "q", Text: i18n.Translate("返回")},
+	}
+}
+
+// Help 说明容器状态的颜色和数据来源
+func (p *containersPage) Help() []menu.HelpItem {
+	return []menu.HelpItem{
+		{Name: "running", Text: i18n.Translate("正在运行，以正常色显示")},
+		{Name: "restarting", Text: i18n.Translate("容器反复退出、正在按重启策略重启，以错误色显示")},
+		{Name: i18n.Translate("其它状态"), Text: i18n.Translate("已停止、已暂停或尚未启动，以警告色显示")},
+		{Name: i18n.Translate("运行时"), Text: i18n.Translate("通过docker或podman的套接字查询，可在[containers]的socket中指定")},
+	}
+}
+
+// HandleKey 方向键、数字键选择容器，r重启选中的容器，q、ESC、退格返回
+func (p *containersPage) HandleKey(nav *menu.Navigator, ev input.KeyEvent) error {
+	switch ev.Code {
+	case input.KeyUp:
+		p.list.Move(-1)
+	case input.KeyDown:
+		p.list.Move(1)
+	default:
+		switch key := ev.Byte(); key {
+		case 'q', 'Q', 27, 0x7F: // q, Q, ESC, 退格
+			return nav.Pop()
+		case 'r', 'R':
+			if len(p.containers) > 0 {
+				return p.confirmRestart(nav)
+			}
+			return nil
+		default:
+			if !p.list.Select(key) {
+				return nil // 忽略其他键
+			}
+		}
+	}
+	nav.Invalidate()
+	return nil
+}
+
+// confirmRestart 确认后重启选中的容器，执行期间显示忙碌画面
+func (p *containersPage) confirmRestart(nav *menu.Navigator) error {
+	name := p.containers[p.list.Selected].Name
+	dialog := menu.NewConfirmDialog(i18n.Translate("重启容器"), i18n.Translatef("确认要重启容器 %s 吗？\n\n按y确认，按n或ESC取消", name))
+	return nav.Push(menu.NewDialogPage(dialog, func(nav *menu.Navigator, key byte, _ string) error {
+		if key != menu.ButtonOK.Key {
+			return nil
+		}
+		log.Printf("重启容器 %s", name)
+		busy := p.app.menuRenderer.StartBusy(i18n.Translate("容器"), i18n.Translatef("正在重启容器 %s...", name))
+		err := p.runtime.RestartContainer(name)
+		busy.Stop()
+
+		p.query()
+		nav.Invalidate()
+		if err != nil {
+			log.Printf("%v", err)
+			p.status.Text, p.status.Color = "", nil
+			return nav.Push(p.app.messagePage(menu.LevelError, err.Error()))
+		}
+		p.status.Text = i18n.Translatef("已重启容器 %s", name)
+		p.status.Color = menu.LevelSuccess.Color()
+		return nil
+	}))
+}
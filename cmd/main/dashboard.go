@@ -0,0 +1,263 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"go-framebuffer-console/internal/config"
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/menu"
+	"go-framebuffer-console/pkg/system"
+)
+
+// defaultTempLimit 温度磁贴未配置告警温度时使用的值（摄氏度）
+const defaultTempLimit = 90
+
+// tileTitles 各种磁贴的默认标题
+var tileTitles = map[string]string{
+	"cpu":         "CPU",
+	"memory":      "内存",
+	"disk":        "根分区",
+	"network":     "网络",
+	"diskio":      "磁盘读写",
+	"services":    "服务",
+	"temperature": "温度",
+	"clock":       "时间",
+}
+
+// defaultTiles 配置文件中没有[tile]段落时仪表盘显示的磁贴
+// 参数services: 服务磁贴显示的服务，与服务管理页面相同
+func defaultTiles(services []string) []config.TileConfig {
+	return []config.TileConfig{
+		{Type: "cpu", Interval: 2},
+		{Type: "memory", Interval: config.DefaultTileRefresh},
+		{Type: "disk", Interval: 30},
+		{Type: "network", Interval: config.DefaultTileRefresh},
+		{Type: "temperature", Interval: config.DefaultTileRefresh},
+		{Type: "services", Interval: 10, Services: services},
+	}
+}
+
+// showDashboard 显示仪表盘，每次进入时按配置重新创建磁贴
+func (app *Application) showDashboard(nav *menu.Navigator) error {
+	configs := app.config.Dashboard.Tiles
+	if len(configs) == 0 {
+		configs = defaultTiles(app.config.Services.Units)
+	}
+
+	var tiles []*menu.Tile
+	for _, tc := range configs {
+		title := tc.Title
+		if title == "" {
+			title = tileTitles[tc.Type]
+		}
+		// 时钟磁贴每秒只重绘变化的数字，不需要数据源
+		if tc.Type == "clock" {
+			tiles = append(tiles, &menu.Tile{Title: i18n.Translate(title), Clock: menu.NewClock(true)})
+			continue
+		}
+		update := app.tileSource(tc)
+		if update == nil {
+			log.Printf("未知的磁贴类型 %s，已忽略", tc.Type)
+			continue
+		}
+		tiles = append(tiles, &menu.Tile{
+			Title:    i18n.Translate(title),
+			Interval: time.Duration(tc.Interval) * time.Second,
+			Update:   update,
+		})
+	}
+	return nav.Push(menu.NewDashboardPage(i18n.Translate("仪表盘"), app.config.Dashboard.Columns, tiles...))
+}
+
+// tileSource 返回磁贴获取内容的函数，类型未知时返回nil
+func (app *Application) tileSource(tc config.TileConfig) func() menu.TileData {
+	switch tc.Type {
+	case "cpu":
+		// 每个CPU磁贴使用自己的采样器，按磁贴的刷新间隔计算平均使用率
+		sampler := &system.CPUSampler{}
+		return func() menu.TileData {
+			usage, err := sampler.Sample()
+			if err != nil {
+				return errorTile(err)
+			}
+			return usageTile(usage, 100, "")
+		}
+	case "memory":
+		return func() menu.TileData {
+			used, total, err := system.GetMemoryStats()
+			if err != nil {
+				return errorTile(err)
+			}
+			text := system.FormatBytes(used) + " / " + system.FormatBytes(total)
+			// 有交换空间时附上交换空间的使用量
+			if swapUsed, swapTotal, err := system.GetSwapStats(); err == nil && swapTotal > 0 {
+				text += i18n.Translatef("，交换 %s / %s", system.FormatBytes(swapUsed), system.FormatBytes(swapTotal))
+			}
+			return usageTile(float64(used), float64(total), text)
+		}
+	case "disk":
+		return func() menu.TileData {
+			used, total, err := system.GetRootUsage()
+			if err != nil {
+				return errorTile(err)
+			}
+			return usageTile(float64(used), float64(total), system.FormatBytes(used)+" / "+system.FormatBytes(total))
+		}
+	case "network":
+		return app.networkTile
+	case "diskio":
+		return app.diskIOTile
+	case "services":
+		services := tc.Services
+		return func() menu.TileData { return servicesTile(services) }
+	case "temperature":
+		limit := tc.Limit
+		if limit <= 0 {
+			limit = defaultTempLimit
+		}
+		return func() menu.TileData { return temperatureTile(limit) }
+	}
+	return nil
+}
+
+// usageTile 生成使用率类磁贴的内容：百分比、进度条和一行说明
+// 参数text: 说明，如"1.2 GB / 4.0 GB"，可以为空
+func usageTile(value, max float64, text string) menu.TileData {
+	gauge := menu.NewGauge("", value, max, "")
+	data := menu.TileData{Value: fmt.Sprintf("%.1f%%", gauge.Ratio()*100), Color: gauge.Color(), Gauge: gauge}
+	if text != "" {
+		data.Lines = []string{text}
+	}
+	return data
+}
+
+// errorTile 生成获取内容失败时磁贴显示的内容
+func errorTile(err error) menu.TileData {
+	return menu.TileData{Value: i18n.Translate("未知"), Color: menu.LevelError.Color(), Lines: []string{err.Error()}}
+}
+
+// networkTile 显示设备IP和所有物理网卡最近一次采样的收发速率之和
+// 速率由主循环每5秒采样一次，磁贴刷新得更快也不会更新
+func (app *Application) networkTile() menu.TileData {
+	data := menu.TileData{Value: system.GetIPAddress()}
+	interfaces, err := system.GetNetworkInterfaces()
+	if err != nil {
+		data.Lines = []string{err.Error()}
+		return data
+	}
+	var total system.Bandwidth
+	up := 0
+	for _, nic := range interfaces {
+		if strings.HasPrefix(nic.Status, "Up") {
+			up++
+		}
+		if history := app.bandwidth.History(nic.Name); len(history) > 0 {
+			total.RX += history[len(history)-1].RX
+			total.TX += history[len(history)-1].TX
+		}
+	}
+	data.Lines = []string{
+		i18n.Translatef("接收 %s", system.FormatRate(total.RX)),
+		i18n.Translatef("发送 %s", system.FormatRate(total.TX)),
+		i18n.Translatef("已连接网卡 %d/%d", up, len(interfaces)),
+	}
+	return data
+}
+
+// diskIOTile 显示所有磁盘最近一次采样的读写速率之和和IOPS，以及读写最多的磁盘
+// 速率由主循环每5秒采样一次，磁贴刷新得更快也不会更新
+func (app *Application) diskIOTile() menu.TileData {
+	total := app.diskIO.Latest()
+	data := menu.TileData{
+		Value: system.FormatRate(total.Read + total.Write),
+		Lines: []string{
+			i18n.Translatef("读取 %s（%.0f IOPS）", system.FormatRate(total.Read), total.ReadIOPS),
+			i18n.Translatef("写入 %s（%.0f IOPS）", system.FormatRate(total.Write), total.WriteIOPS),
+		},
+	}
+	busiest, max := "", -1.0
+	for _, name := range app.diskIO.Devices() {
+		history := app.diskIO.History(name)
+		if io := history[len(history)-1]; io.Read+io.Write > max {
+			busiest, max = name, io.Read+io.Write
+		}
+	}
+	if busiest == "" {
+		data.Lines = append(data.Lines, i18n.Translate("正在采样"))
+	} else {
+		data.Lines = append(data.Lines, i18n.Translatef("最忙的磁盘 %s", busiest))
+	}
+	return data
+}
+
+// servicesTile 显示运行中的服务数和各服务的状态，系统中有失败的单元时在第一行注明个数
+// 每次刷新调用两次systemctl，服务较多时应适当加大刷新间隔
+func servicesTile(services []string) menu.TileData {
+	failed, err := system.FailedUnits()
+	if err != nil {
+		log.Printf("%v", err)
+	}
+	var failedLine []string
+	if len(failed) > 0 {
+		failedLine = []string{i18n.Translatef("失败的单元: %d", len(failed))}
+	}
+	if len(services) == 0 {
+		data := menu.TileData{Value: "-", Lines: append(failedLine, i18n.Translate("没有配置服务"))}
+		if len(failed) > 0 {
+			data.Color = menu.LevelError.Color()
+		}
+		return data
+	}
+
+	statuses, err := system.GetServiceStatus(services)
+	if err != nil {
+		log.Printf("%v", err)
+	}
+	active := 0
+	var lines []string
+	for i, name := range services {
+		state := i18n.Translate("未知")
+		if statuses != nil {
+			state = statuses[i].ActiveState
+		}
+		if state == "active" {
+			active++
+		}
+		lines = append(lines, name+": "+state)
+	}
+	data := menu.TileData{Value: fmt.Sprintf("%d/%d", active, len(services)), Color: menu.LevelSuccess.Color(), Lines: lines}
+	if active < len(services) || len(failed) > 0 {
+		data.Color = menu.LevelError.Color()
+	}
+	if len(lines) > 3 {
+		// 磁贴只有三行说明，没有运行的服务排在前面
+		var down, up []string
+		for _, line := range lines {
+			if strings.HasSuffix(line, ": active") {
+				up = append(up, line)
+			} else {
+				down = append(down, line)
+			}
+		}
+		data.Lines = append(down, up...)
+	}
+	data.Lines = append(failedLine, data.Lines...)
+	return data
+}
+
+// temperatureTile 显示读数最高的温度传感器，达到告警温度时显示为错误色
+// 参数limit: 告警温度（摄氏度）
+func temperatureTile(limit float64) menu.TileData {
+	t, err := system.MaxTemperature()
+	if err != nil {
+		return menu.TileData{Value: "-", Lines: []string{err.Error()}}
+	}
+	return menu.TileData{
+		Value: fmt.Sprintf("%.1f°C", t.Celsius),
+		Color: temperatureColor(t.Celsius, limit),
+		Lines: []string{t.Zone, i18n.Translatef("告警温度 %.0f°C", limit)},
+	}
+}
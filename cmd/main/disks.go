@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/input"
+	"go-framebuffer-console/pkg/menu"
+	"go-framebuffer-console/pkg/system"
+)
+
+// diskRefresh 磁盘页面重新统计各挂载点的间隔
+const diskRefresh = 10 * time.Second
+
+// disksPage 磁盘使用情况页面
+// 以表格列出各挂载点的容量、已用、可用空间和使用率，使用率超过[alerts]中mounts的挂载点以错误色标出；
+// 表格下方是各磁盘最近5分钟的读写速率曲线；任意键返回
+type disksPage struct {
+	menu.BasePage
+	app    *Application
+	mounts []system.Mount
+	err    error
+}
+
+// showDisks 显示磁盘使用情况页面
+func (app *Application) showDisks(nav *menu.Navigator) error {
+	return nav.Push(&disksPage{app: app})
+}
+
+// OnEnter 统计各挂载点
+func (p *disksPage) OnEnter(nav *menu.Navigator) error {
+	p.mounts, p.err = system.GetMounts()
+	return nil
+}
+
+// Render 绘制挂载点表格和超过阈值的挂载点个数
+func (p *disksPage) Render(mr *menu.MenuRenderer) error {
+	theme := mr.Theme()
+	layout := mr.NewLayout(&menu.Label{Text: i18n.Translate("磁盘使用情况"), Color: theme.Accent}, menu.NewSeparator())
+	if p.err != nil {
+		return mr.RenderLayout(layout.Add(&menu.Label{Text: p.err.Error(), Color: theme.Error}))
+	}
+	if len(p.mounts) == 0 {
+		return mr.RenderLayout(layout.Add(menu.NewLabel(i18n.Translate("没有找到挂载的磁盘"))))
+	}
+
+	limit := p.app.config.Alerts.Mounts
+	table := menu.NewTable(i18n.Translate("挂载点"), i18n.Translate("设备"), i18n.Translate("类型"),
+		i18n.Translate("容量"), i18n.Translate("已用"), i18n.Translate("可用"), i18n.Translate("使用率"))
+	for i := 3; i < len(table.Columns); i++ {
+		table.Columns[i].Align = menu.AlignRight
+	}
+	over := 0
+	for _, m := range p.mounts {
+		percent := m.UsePercent()
+		c := theme.Foreground
+		if limit > 0 && percent > limit {
+			c = theme.Error
+			over++
+		}
+		table.AddColoredRow(c, m.Path, m.Device, m.FSType, system.FormatBytes(m.Total),
+			system.FormatBytes(m.Used), system.FormatBytes(m.Avail), fmt.Sprintf("%.1f%%", percent))
+	}
+	layout.Add(table, menu.NewSeparator())
+
+	switch {
+	case limit <= 0:
+	case over > 0:
+		layout.Add(&menu.Label{Text: i18n.Translatef("%d 个挂载点的使用率超过%.0f%%", over, limit), Color: theme.Error})
+	default:
+		layout.Add(&menu.Label{Text: i18n.Translatef("所有挂载点的使用率均未超过%.0f%%", limit), Color: theme.Success})
+	}
+
+	if lines := p.diskIOSparklines(mr); len(lines) > 0 {
+		layout.Add(menu.NewLabel(i18n.Translate("磁盘读写（最近5分钟）:")))
+		for _, s := range lines {
+			layout.Add(s)
+		}
+	}
+	return mr.RenderLayout(layout)
+}
+
+// diskIOSparklines 为每个有采样的磁盘生成读取和写入两条迷你曲线
+// 同一磁盘的两条曲线使用相同的纵轴范围，说明文字为最近一次采样的速率和IOPS
+func (p *disksPage) diskIOSparklines(mr *menu.MenuRenderer) []*menu.Sparkline {
+	sampler := p.app.diskIO
+	var lines []*menu.Sparkline
+	for _, name := range sampler.Devices() {
+		history := sampler.History(name)
+		read := make([]float64, len(history))
+		write := make([]float64, len(history))
+		max := 0.0
+		for i, io := range history {
+			read[i], write[i] = io.Read, io.Write
+			if io.Read > max {
+				max = io.Read
+			}
+			if io.Write > max {
+				max = io.Write
+			}
+		}
+		latest := history[len(history)-1]
+		lines = append(lines,
+			&menu.Sparkline{Label: i18n.Translatef("%s 读取", name), Samples: read, Max: max,
+				Text: fmt.Sprintf("%s  %.0f IOPS", system.FormatRate(latest.Read), latest.ReadIOPS), Color: mr.Theme().Success},
+			&menu.Sparkline{Label: i18n.Translatef("%s 写入", name), Samples: write, Max: max,
+				Text: fmt.Sprintf("%s  %.0f IOPS", system.FormatRate(latest.Write), latest.WriteIOPS)},
+		)
+	}
+	mr.AlignSparklines(lines, sampler.Capacity())
+	return lines
+}
+
+// Refresh 重新统计各挂载点并重绘
+func (p *disksPage) Refresh(nav *menu.Navigator) error {
+	p.mounts, p.err = system.GetMounts()
+	nav.Invalidate()
+	return nil
+}
+
+// RefreshInterval 每隔diskRefresh自动刷新一次
+func (p *disksPage) RefreshInterval() time.Duration {
+	return diskRefresh
+}
+
+// Hints 页脚的按键提示
+func (p *disksPage) Hints() []menu.Hint {
+	return []menu.Hint{{Key: i18n.Translate("任意键"), Text: i18n.Translate("返回")}}
+}
+
+// Help 说明各列的含义和颜色
+func (p *disksPage) Help() []menu.HelpItem {
+	return []menu.HelpItem{
+		{Name: i18n.Translate("挂载点"), Text: i18n.Translate("占用存储空间的文件系统，不含proc、tmpfs等虚拟文件系统；同一设备只列出第一个挂载点")},
+		{Name: i18n.Translate("可用"), Text: i18n.Translate("普通用户可用的空间，不含为root保留的空间")},
+		{Name: i18n.Translate("使用率"), Text: i18n.Translate("与df相同，超过[alerts]中mounts的挂载点以错误色显示")},
+		{Name: i18n.Translate("磁盘读写"), Text: i18n.Translate("各磁盘每5秒采样一次的平均读写速率和IOPS，不含分区、loop和ram设备")},
+	}
+}
+
+// HandleKey 任意键返回上一页
+func (p *disksPage) HandleKey(nav *menu.Navigator, ev input.KeyEvent) error {
+	return nav.Pop()
+}
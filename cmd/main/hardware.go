@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/input"
+	"go-framebuffer-console/pkg/menu"
+	"go-framebuffer-console/pkg/system"
+)
+
+// hardwarePage 设备信息页面
+// 以表格列出BIOS中记录的整机厂商、型号、序列号，以及主板和BIOS的信息，供资产登记时核对；任意键返回
+type hardwarePage struct {
+	menu.BasePage
+	hardware *system.Hardware
+	err      error
+}
+
+// showHardware 显示设备信息页面
+func (app *Application) showHardware(nav *menu.Navigator) error {
+	return nav.Push(&hardwarePage{})
+}
+
+// OnEnter 读取设备信息，设备信息不会变化，因此只在进入页面时读取
+func (p *hardwarePage) OnEnter(nav *menu.Navigator) error {
+	p.hardware, p.err = system.GetHardware()
+	return nil
+}
+
+// Render 绘制设备信息表格，未填写的项显示为"(未填写)"，序列号无权读取时注明需要root权限
+func (p *hardwarePage) Render(mr *menu.MenuRenderer) error {
+	theme := mr.Theme()
+	layout := mr.NewLayout(&menu.Label{Text: i18n.Translate("设备信息"), Color: theme.Accent}, menu.NewSeparator())
+	if p.err != nil {
+		return mr.RenderLayout(layout.Add(&menu.Label{Text: p.err.Error(), Color: theme.Error}))
+	}
+
+	hw := p.hardware
+	serial := hw.Serial
+	if serial == "" && os.Geteuid() != 0 {
+		serial = i18n.Translate("(需要root权限)")
+	}
+	table := menu.NewTable(i18n.Translate("项目"), i18n.Translate("内容"))
+	for _, row := range []struct{ name, value string }{
+		{"厂商", hw.Vendor},
+		{"型号", hw.Product},
+		{"序列号", serial},
+		{"主板厂商", hw.BoardVendor},
+		{"主板型号", hw.BoardName},
+		{"BIOS厂商", hw.BIOSVendor},
+		{"BIOS版本", hw.BIOSVersion},
+		{"BIOS日期", hw.BIOSDate},
+	} {
+		value := row.value
+		if value == "" {
+			value = i18n.Translate("(未填写)")
+		}
+		table.AddRow(i18n.Translate(row.name), value)
+	}
+	return mr.RenderLayout(layout.Add(table))
+}
+
+// Hints 页脚的按键提示
+func (p *hardwarePage) Hints() []menu.Hint {
+	return []menu.Hint{{Key: i18n.Translate("任意键"), Text: i18n.Translate("返回")}}
+}
+
+// Help 说明设备信息的来源
+func (p *hardwarePage) Help() []menu.HelpItem {
+	return []menu.HelpItem{
+		{Name: i18n.Translate("设备信息"), Text: i18n.Translate("主板BIOS中记录的DMI信息，来自/sys/class/dmi/id，与dmidecode显示的相同")},
+		{Name: i18n.Translate("序列号"), Text: i18n.Translate("整机序列号只有root可以读取；厂商未填写或为占位内容时显示为(未填写)")},
+		{Name: i18n.Translate("二维码"), Text: i18n.Translate("在[qrcode]的content中使用{vendor}、{model}、{serial}可以把设备信息编入首页二维码")},
+	}
+}
+
+// HandleKey 任意键返回上一页
+func (p *hardwarePage) HandleKey(nav *menu.Navigator, ev input.KeyEvent) error {
+	return nav.Pop()
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/menu"
+	"go-framebuffer-console/pkg/system"
+)
+
+// showLogs 查看日志：没有配置[logs]中的服务时直接跟踪程序日志，否则先选择要查看的日志
+func (app *Application) showLogs(nav *menu.Navigator) error {
+	units := app.config.Logs.Units
+	if len(units) == 0 {
+		return nav.Push(app.appLogPage())
+	}
+
+	items := []menu.MenuItem{{Text: "1. " + i18n.Translate("程序日志"), Key: '1', Action: func(nav *menu.Navigator) error {
+		return nav.Push(app.appLogPage())
+	}}}
+	for i, unit := range units {
+		unit := unit
+		item := menu.MenuItem{Text: unit, Action: func(nav *menu.Navigator) error {
+			return nav.Push(app.journalPage(unit))
+		}}
+		// 快捷键接着程序日志编号，超过9项后只能用方向键选择
+		if n := i + 2; n <= 9 {
+			item.Key = byte('0' + n)
+			item.Text = fmt.Sprintf("%d. %s", n, unit)
+		}
+		items = append(items, item)
+	}
+	return nav.Push(menu.NewMenuPage(i18n.Translate("查看日志"), i18n.Translate("方向键选择，回车确认；按q返回"), items...))
+}
+
+// appLogPage 创建跟踪程序日志的页面，每次进入时打开当天的日志文件
+func (app *Application) appLogPage() *menu.LogPage {
+	backlog := app.config.Logs.Backlog
+	return menu.NewLogPage(i18n.Translate("程序日志"), func() (menu.LogSource, error) {
+		return system.FollowFile(getLogFileName(), backlog)
+	})
+}
+
+// journalPage 创建通过journalctl跟踪服务日志的页面
+// 参数unit: systemd服务名称
+func (app *Application) journalPage(unit string) *menu.LogPage {
+	backlog := app.config.Logs.Backlog
+	return menu.NewLogPage(i18n.Translatef("服务日志：%s", unit), func() (menu.LogSource, error) {
+		return system.GetJournal(unit, backlog, true)
+	})
+}
@@ -6,9 +6,12 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"image/color"
+	"io"
 	"log"
 	"os"
 	"os/signal"
+	"runtime/debug"
 	"strings"
 	"sync"
 	"syscall"
@@ -17,6 +20,7 @@ import (
 	"go-framebuffer-console/internal/config"
 	"go-framebuffer-console/pkg/font"
 	"go-framebuffer-console/pkg/framebuffer"
+	"go-framebuffer-console/pkg/i18n"
 	"go-framebuffer-console/pkg/input"
 	"go-framebuffer-console/pkg/menu"
 	"go-framebuffer-console/pkg/system"
@@ -130,22 +134,82 @@ type Application struct {
 	config         *config.Config           // 配置管理器
 	fb             *framebuffer.FrameBuffer // 帧缓冲区操作对象
 	fontRenderer   *font.Renderer           // 字体渲染器
-	keyboard       *input.KeyboardInput     // 键盘输入处理器
+	keyboard       input.Source             // 按键输入源（键盘、串口或回放）
 	menuRenderer   *menu.MenuRenderer       // 菜单渲染器
 	ctx            context.Context          // 上下文管理器
 	cancel         context.CancelFunc       // 取消函数
 	mu             sync.RWMutex             // 读写锁
 	running        bool                     // 运行状态
-	keyEventChan   chan byte                // 键盘事件通道
+	keyEvents      <-chan input.KeyEvent    // 结构化键盘事件通道
+	mouse          *input.MouseInput        // 鼠标输入处理器，没有鼠标时为nil
+	touch          *input.TouchInput        // 触摸屏输入处理器，没有触摸屏时为nil
+	mouseEvents    <-chan input.MouseEvent  // 鼠标与触摸屏合并后的事件通道，两者都没有时为nil
+	cursor         *framebuffer.Cursor      // 屏幕上的鼠标指针
+	hotkeys        *input.HotkeyDispatcher  // 全局热键分发器
+	headless       bool                     // 没有显示器，界面只绘制到内存中
+	opts           appOptions               // 命令行指定的运行选项
+	recordFile     *os.File                 // 按键录制文件，未录制时为nil
+	serialPort     io.Writer                // 串口控制台的输出端，不使用串口时为nil
+	replayDone     <-chan struct{}          // 按键回放结束时关闭，不回放时为nil
+	idle           *input.IdleNotifier      // 空闲检测器，未启用时为nil
+	idleEvents     <-chan bool              // 空闲状态变化，未启用时为nil
+	saver          *input.IdleNotifier      // 屏幕保护的空闲检测器，未启用时为nil
+	saverEvents    <-chan bool              // 屏幕保护空闲状态变化，未启用时为nil
+	scanner        *input.ScanCapture       // 扫码捕获器
+	scanCodes      chan string              // 在首页完成的扫码内容
+	nav            *menu.Navigator          // 页面导航栈，只在主循环中访问
+	configMenu     *menu.MenuPage           // 配置菜单页面，首次进入时创建
+	cpuSampler     system.CPUSampler        // CPU使用率采样器
+	cpuChart       *menu.Chart              // 首页的CPU使用率曲线
+	cpuUsage       float64                  // 最近一次采样的CPU使用率，即曲线的最后一个点，采样失败时为-1
+	bandwidth      *system.BandwidthSampler // 网卡收发速率采样器
+	diskIO         *system.DiskIOSampler    // 磁盘读写速率采样器
+	alerts         int                      // 最近一次采样时超过[alerts]阈值的指标数，即横幅消息的条数，显示在状态栏
+	banner         []string                 // 最近一次采样时超过横幅阈值的告警消息，显示在首页顶部
+	pin            pinGuard                 // 管理员PIN的验证状态
+	netTargets     []netTarget              // 网络测试目标及是否参与测试，首次使用时按配置生成
 	disableCtrlC   bool                     // 是否禁用Ctrl+C退出功能
 }
 
+// appOptions 命令行指定的运行选项
+type appOptions struct {
+	disableCtrlC bool    // 是否禁用Ctrl+C退出功能
+	recordPath   string  // 按键录制文件路径，为空时不录制
+	replayPath   string  // 按键回放文件路径，为空时使用真实键盘
+	replaySpeed  float64 // 回放速度倍数
+}
+
+// version 程序版本号，显示在启动画面和帮助信息中
+// 发布时通过 -ldflags "-X main.version=1.2.0" 设置
+var version = "dev"
+
+// sampleInterval 采样CPU使用率和网卡流量的间隔，同时决定曲线中两个点的时间间隔
+// 各页面的刷新间隔由页面自己决定，与采样无关
+const sampleInterval = 5 * time.Second
+
+// 错误提示音的频率（Hz）和时长
+const (
+	errorBeepFrequency = 880
+	errorBeepDuration  = 200 * time.Millisecond
+)
+
+// statsHistory CPU使用率和网卡流量曲线显示的时间范围，每次自动刷新首页时采样一次
+const statsHistory = 5 * time.Minute
+
+// replaySettle 回放结束后等待最后一个按键处理完毕的时间，之后程序自动退出
+const replaySettle = 2 * time.Second
+
 // main 主函数 - 程序入口点
 // 负责初始化应用程序并启动主运行循环
 func main() {
 	// 解析命令行参数
 	var disableCtrlC = flag.Bool("d", false, "禁用Ctrl+C退出功能，使程序持续运行")
 	var showHelp = flag.Bool("h", false, "显示帮助信息")
+	var configPath = flag.String("c", config.DefaultConfigPath, "配置文件路径")
+	var recordPath = flag.String("record", "", "把按键录制到指定文件")
+	var replayPath = flag.String("replay", "", "回放指定文件中录制的按键，回放结束后退出")
+	var replaySpeed = flag.Float64("replay-speed", 1, "回放速度倍数，0表示不等待")
+	var language = flag.String("lang", "", "界面语言（zh-CN、en-US），覆盖配置文件中的设置")
 	flag.Usage = printUsage
 	flag.Parse()
 
@@ -158,18 +222,42 @@ func main() {
 	initLog()
 
 	// 记录启动参数
-	log.Printf("程序启动，参数: 禁用Ctrl+C = %v", *disableCtrlC)
+	log.Printf("程序启动，版本: %s，参数: 禁用Ctrl+C = %v", version, *disableCtrlC)
+
+	// 加载配置文件，失败时使用默认配置继续运行
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Printf("加载配置文件失败，使用默认配置: %v", err)
+	}
+
+	// 界面语言：命令行参数优先于配置文件
+	if *language != "" {
+		cfg.Language = *language
+	}
+	if err := i18n.SetLanguage(cfg.Language); err != nil {
+		log.Printf("%v，使用%s", err, i18n.DefaultLanguage)
+	}
+	log.Printf("界面语言: %s", i18n.Language())
 
 	// 创建并初始化应用程序
-	app, err := NewApplication(*disableCtrlC)
+	app, err := NewApplication(cfg, appOptions{
+		disableCtrlC: *disableCtrlC,
+		recordPath:   *recordPath,
+		replayPath:   *replayPath,
+		replaySpeed:  *replaySpeed,
+	})
 	if err != nil {
-		log.Fatalf("应用程序初始化失败: %v", err)
+		// 初始化可能已修改终端属性，退出前恢复
+		log.Printf("应用程序初始化失败: %v", err)
+		input.Exit(1)
 	}
 	log.Printf("应用程序初始化成功，禁用Ctrl+C = %v", app.disableCtrlC)
 	// 确保程序退出时清理资源
 	defer func() {
 		if r := recover(); r != nil {
-			log.Printf("程序异常退出: %v", r)
+			// 先恢复终端：panic时可能持有锁，Cleanup未必能完成
+			input.RestoreTerminals()
+			log.Printf("程序异常退出: %v\n%s", r, debug.Stack())
 		}
 		app.Cleanup()
 	}()
@@ -185,11 +273,16 @@ func main() {
 
 // printUsage 打印使用帮助信息
 func printUsage() {
-	fmt.Printf("Go Framebuffer Console - 系统状态监控应用\n\n")
+	fmt.Printf("Go Framebuffer Console - 系统状态监控应用 %s\n\n", version)
 	fmt.Printf("用法:\n")
 	fmt.Printf("  %s [选项]\n\n", os.Args[0])
 	fmt.Printf("选项:\n")
 	fmt.Printf("  -d    禁用Ctrl+C退出功能，使程序持续运行（默认启用Ctrl+C退出）\n")
+	fmt.Printf("  -c    指定配置文件路径（默认 %s）\n", config.DefaultConfigPath)
+	fmt.Printf("  -record 文件        把按键录制到文件\n")
+	fmt.Printf("  -replay 文件        回放录制的按键，回放结束后退出（用于自动化测试）\n")
+	fmt.Printf("  -replay-speed 倍数  回放速度，0表示不等待（默认1）\n")
+	fmt.Printf("  -lang 语言          界面语言：zh-CN（默认）、en-US\n")
 	fmt.Printf("  -h    显示此帮助信息\n\n")
 	fmt.Printf("示例:\n")
 	fmt.Printf("  %s           # 正常运行，支持Ctrl+C退出\n", os.Args[0])
@@ -198,25 +291,38 @@ func printUsage() {
 	fmt.Printf("说明:\n")
 	fmt.Printf("  - 默认情况下，可以使用Ctrl+C或在配置菜单中退出程序\n")
 	fmt.Printf("  - 使用-d参数后，只能通过配置菜单退出程序\n")
-	fmt.Printf("  - 程序每5秒自动刷新系统状态信息\n")
+	fmt.Printf("  - 首页默认每5秒自动刷新系统状态信息，间隔由配置文件的main_refresh设置（0表示不自动刷新）\n")
+	fmt.Printf("  - 在可以刷新的页面按F5或r立即刷新并重新开始计时\n")
 	fmt.Printf("  - 按回车键进入配置菜单进行系统管理\n")
 }
 
-func NewApplication(disableCtrlC bool) (*Application, error) {
+func NewApplication(cfg *config.Config, opts appOptions) (*Application, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	app := &Application{
-		config:       config.NewConfig(),
+		config:       cfg,
 		ctx:          ctx,
 		cancel:       cancel,
 		running:      false,
-		keyEventChan: make(chan byte, 1),
-		disableCtrlC: disableCtrlC,
+		disableCtrlC: opts.disableCtrlC,
+		hotkeys:      input.NewHotkeyDispatcher(),
+		opts:         opts,
 	}
-
-	// 1. 首先初始化Framebuffer来获取屏幕尺寸
+	app.hotkeys.SetWindows(input.HotkeyWindows{
+		Sequence:    time.Duration(cfg.KeyWindows.Sequence) * time.Millisecond,
+		DoublePress: time.Duration(cfg.KeyWindows.DoublePress) * time.Millisecond,
+		Chord:       time.Duration(cfg.KeyWindows.Chord) * time.Millisecond,
+	})
+	app.registerHotkeys()
+
+	// 1. 首先初始化Framebuffer来获取屏幕尺寸，没有显示器时回退到内存帧缓冲区
 	if err := app.initFramebuffer(); err != nil {
-		cancel()
-		return nil, fmt.Errorf("failed to initialize framebuffer: %v", err)
+		if !cfg.Serial.Enabled && opts.replayPath == "" {
+			cancel()
+			return nil, fmt.Errorf("failed to initialize framebuffer: %v", err)
+		}
+		log.Printf("无法打开帧缓冲区，界面改为绘制到内存中: %v", err)
+		app.fb, _ = framebuffer.NewMemoryFrameBuffer(headlessWidth, headlessHeight)
+		app.headless = true
 	}
 
 	// 2. 根据屏幕高度动态计算字体大小
@@ -239,12 +345,157 @@ func NewApplication(disableCtrlC bool) (*Application, error) {
 		return nil, fmt.Errorf("failed to initialize keyboard: %v", err)
 	}
 
-	// 5. 初始化菜单渲染器
+	// 5. 初始化鼠标和触摸屏（可选），都没有时仅使用键盘操作；
+	// 触摸手势并入按键事件流，需要在空闲检测之前完成，手势同样视为操作
+	if !app.headless {
+		app.initPointer()
+	}
+
+	// 空闲检测：一段时间无操作后自动返回首页
+	if cfg.IdleTimeout > 0 {
+		app.idle = input.NewIdleNotifier(time.Duration(cfg.IdleTimeout) * time.Second)
+		app.idleEvents = app.idle.Changes()
+		app.keyboard = input.WithIdle(app.keyboard, app.idle)
+	}
+
+	// 屏幕保护：更长时间无操作后显示移动的时钟，避免烧屏
+	if cfg.Screensaver > 0 {
+		app.saver = input.NewIdleNotifier(time.Duration(cfg.Screensaver) * time.Second)
+		app.saverEvents = app.saver.Changes()
+		app.keyboard = input.WithIdle(app.keyboard, app.saver)
+	}
+
+	// 扫码枪：整次扫描捕获为一个字符串，不会被当作菜单按键
+	app.scanCodes = make(chan string, 4)
+	app.scanner = input.NewScanCapture(app.onScan)
+	app.scanner.SetDevices(cfg.Scanner.Devices)
+	app.keyboard = input.WithScanCapture(app.keyboard, app.scanner)
+
+	// 6. 初始化菜单渲染器，使用串口时把页面文本镜像到串口终端
 	app.menuRenderer = menu.NewMenuRenderer(app.fb, app.fontRenderer)
+	if app.serialPort != nil && cfg.Serial.Mirror {
+		app.menuRenderer.SetTextMirror(app.serialPort)
+	}
+	app.menuRenderer.SetTheme(loadTheme(cfg.Theme))
+	app.menuRenderer.SetMarqueeSpeed(cfg.Marquee)
+	if style, err := menu.ParseTransitionStyle(cfg.Transition); err != nil {
+		log.Printf("%v，不使用过渡效果", err)
+	} else {
+		app.menuRenderer.SetTransition(style)
+	}
+
+	// 7. 首页的CPU使用率曲线和网卡信息页面的流量曲线，第一次采样得到开机以来的平均CPU使用率
+	app.cpuChart = menu.NewChart(i18n.Translate("CPU使用率（最近5分钟）"), int(statsHistory/sampleInterval), 0, 100)
+	app.cpuChart.Unit = "%"
+	app.menuRenderer.SetStatusChart(app.cpuChart)
+	app.bandwidth = system.NewBandwidthSampler(int(statsHistory / sampleInterval))
+	app.diskIO = system.NewDiskIOSampler(int(statsHistory / sampleInterval))
+	app.sampleStats()
+	app.menuRenderer.SetAlertBanner(func() []string { return app.banner })
+
+	// 8. 页面顶部的状态栏：主机名、时钟和告警数
+	if cfg.Header {
+		host, err := os.Hostname()
+		if err != nil {
+			log.Printf("获取主机名失败: %v", err)
+			host = i18n.Translate("未知")
+		}
+		app.menuRenderer.SetHeader(&menu.Header{Host: host, Alerts: func() int { return app.alerts }})
+	}
+
+	// 9. 显示错误消息时让PC喇叭鸣响，鸣响在后台进行，不阻塞页面绘制
+	if cfg.ErrorBeep {
+		app.menuRenderer.SetErrorBeep(func() {
+			go func() {
+				if err := system.Beep(errorBeepFrequency, errorBeepDuration); err != nil {
+					log.Printf("蜂鸣失败: %v", err)
+				}
+			}()
+		})
+	}
+
+	// 10. 首页二维码的内容模板、尺寸和纠错等级
+	app.menuRenderer.SetQRCode(loadQRCode(cfg.QRCode))
+
+	// 11. 首页分栏：宽屏上系统信息在左列，二维码和客服信息在右列
+	app.menuRenderer.SetMainLayout(menu.MainLayout{
+		Columns:  cfg.MainLayout.Columns,
+		MinWidth: cfg.MainLayout.TwoColumnWidth,
+		Split:    cfg.MainLayout.Split,
+	})
+	app.loadMainTemplate(cfg.MainLayout.Template)
 
 	return app, nil
 }
 
+// loadQRCode 根据配置文件的[qrcode]段落生成首页二维码的参数
+// 纠错等级无效时记录日志并使用默认等级
+func loadQRCode(qc config.QRConfig) menu.QRCode {
+	q := menu.DefaultQRCode()
+	q.Content = qc.Content
+	q.URL = qc.URL
+	q.Caption = qc.Caption
+	q.ModuleSize = qc.ModuleSize
+	q.QuietZone = qc.QuietZone
+	if level, err := menu.ParseQRLevel(qc.Level); err != nil {
+		log.Printf("%v，使用默认纠错等级", err)
+	} else {
+		q.Level = level
+	}
+	return q
+}
+
+// loadTheme 根据配置文件的[theme]段落生成界面主题
+// 以预设主题为基础，配置了的颜色覆盖预设；名称或样式无效时记录日志并使用默认值
+func loadTheme(tc config.ThemeConfig) menu.Theme {
+	theme, err := menu.ThemeNamed(tc.Name)
+	if err != nil {
+		log.Printf("%v，使用默认主题", err)
+	}
+	if tc.Separator != "" {
+		if style, err := menu.ParseSeparatorStyle(tc.Separator); err != nil {
+			log.Printf("%v，使用实线", err)
+		} else {
+			theme.Separator = style
+		}
+	}
+
+	overrides := []struct {
+		dst *color.Color
+		src color.Color
+	}{
+		{&theme.Background, tc.Background},
+		{&theme.Foreground, tc.Foreground},
+		{&theme.Accent, tc.Accent},
+		{&theme.Line, tc.Line},
+		{&theme.Success, tc.Success},
+		{&theme.Warning, tc.Warning},
+		{&theme.Error, tc.Error},
+	}
+	for _, o := range overrides {
+		if o.src != nil {
+			*o.dst = o.src
+		}
+	}
+	return theme
+}
+
+// loadMainTemplate 读取首页内容的模板文件
+// 参数path: 模板文件路径，为空时使用内置的首页；读取或解析失败时记录日志，同样使用内置的首页
+func (app *Application) loadMainTemplate(path string) {
+	if path == "" {
+		return
+	}
+	text, err := os.ReadFile(path)
+	if err != nil {
+		log.Printf("无法读取首页模板: %v", err)
+		return
+	}
+	if err := app.menuRenderer.SetMainTemplate(string(text)); err != nil {
+		log.Printf("%s: %v，使用内置的首页", path, err)
+	}
+}
+
 func (app *Application) initFramebuffer() error {
 	device := framebuffer.GetBestFramebufferDevice()
 	fb, err := framebuffer.NewFrameBuffer(device)
@@ -256,509 +507,555 @@ func (app *Application) initFramebuffer() error {
 }
 
 func (app *Application) initFontRenderer() error {
-	renderer, err := font.NewRenderer(app.config.FontPath, app.config.FontSize, app.config.DPI)
-	if err != nil {
+	renderer, fallback, err := font.NewRendererWithFallback(app.config.FontPath, app.config.FontIndex, app.config.FontSize, app.config.DPI)
+	if renderer == nil {
 		return err
 	}
+	if fallback {
+		// 外部字体不可用时使用内置字体，保证界面仍可显示
+		log.Printf("外部字体加载失败，改用内置字体: %v", err)
+	}
+	renderer.SetTabWidth(app.config.TabWidth)
 	app.fontRenderer = renderer
 	return nil
 }
 
+// headlessWidth、headlessHeight 没有显示器时内存帧缓冲区的分辨率
+const (
+	headlessWidth  = 800
+	headlessHeight = 600
+)
+
 func (app *Application) initKeyboard() error {
-	keyboard, err := input.NewKeyboardInput()
+	var keyboard *input.KeyboardInput
+	var err error
+	switch {
+	case app.opts.replayPath != "":
+		keyboard, err = app.openReplay()
+	case app.headless:
+		// 没有显示器时本地键盘也无从操作，直接使用串口
+		keyboard, err = app.openSerial()
+	default:
+		if keyboard, err = input.NewKeyboardInput(); err != nil && app.config.Serial.Enabled {
+			log.Printf("没有可用的键盘，改用串口控制台: %v", err)
+			keyboard, err = app.openSerial()
+		}
+	}
 	if err != nil {
 		return err
 	}
+	if app.opts.recordPath != "" {
+		f, err := os.Create(app.opts.recordPath)
+		if err != nil {
+			keyboard.Close()
+			return fmt.Errorf("创建按键录制文件失败: %v", err)
+		}
+		keyboard.SetRecorder(input.NewRecorder(f))
+		app.recordFile = f
+		log.Printf("按键将录制到: %s", app.opts.recordPath)
+	}
+	log.Printf("键盘输入后端: %s", keyboard.Backend())
+	keyboard.SetRepeat(input.RepeatConfig{
+		Delay: time.Duration(app.config.RepeatDelay) * time.Millisecond,
+		Rate:  app.config.RepeatRate,
+	})
+	// 键盘布局只影响evdev输入，加载失败时沿用美式布局
+	if km, err := input.LoadKeymap(app.config.Keymap); err != nil {
+		log.Printf("加载键盘布局失败，使用美式布局: %v", err)
+	} else {
+		keyboard.SetKeymap(km)
+	}
+	// 额外的输入设备不是必需的，打开失败时只使用主键盘；
+	// evdev后端会自动识别扫码枪，其它后端需要单独打开扫码枪设备才能区分扫码输入
+	devices := append([]string(nil), app.config.InputDevices...)
+	if keyboard.Backend() != "evdev" {
+		devices = append(devices, app.config.Scanner.Devices...)
+	}
+	if len(devices) > 0 {
+		if err := keyboard.AddDevices(devices); err != nil {
+			log.Printf("添加输入设备失败: %v", err)
+		}
+	}
 	app.keyboard = keyboard
+	app.serialPort = keyboard.SerialPort()
+	app.replayDone = keyboard.ReplayDone()
 	return nil
 }
 
-func (app *Application) setupSignalHandler() {
-	c := make(chan os.Signal, 1)
-	// 监听所有可能导致程序退出的信号
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGTSTP, syscall.SIGQUIT)
-	go func() {
-		for {
-			select {
-			case sig := <-c:
-				// 如果禁用了退出功能，则拦截所有退出信号
-				if app.disableCtrlC {
-					log.Printf("接收到信号: %v，但退出功能已禁用，继续运行", sig)
-					continue // 不退出，继续监听
-				}
-				log.Printf("接收到信号: %v，开始优雅退出", sig)
-				app.mu.Lock()
-				app.running = false
-				app.mu.Unlock()
-				app.cancel()
-				// 给程序时间进行清理
-				time.Sleep(1 * time.Second)
-				app.Cleanup()
-				os.Exit(0)
-			case <-app.ctx.Done():
-				return
-			}
-		}
-	}()
+// openSerial 打开配置的串口作为按键输入
+func (app *Application) openSerial() (*input.KeyboardInput, error) {
+	s := app.config.Serial
+	keyboard, err := input.NewSerialKeyboardInput(s.Device, s.Baud)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("已启用串口控制台: %s (%d baud)", s.Device, s.Baud)
+	return keyboard, nil
 }
 
-func (app *Application) startKeyboardListener() {
-	defer func() {
-		if r := recover(); r != nil {
-			log.Printf("键盘监听goroutine异常: %v", r)
-		}
-	}()
-
-	for {
-		// 检查上下文是否已取消
-		select {
-		case <-app.ctx.Done():
-			return
-		default:
-		}
-
-		// 使用带超时的非阻塞读取，避免永久阻塞
-		key, available, err := app.keyboard.ReadKeyNonBlockingWithTimeout(100 * time.Millisecond)
-		if err != nil {
-			if app.isContextError(err) || !app.isRunning() {
-				return
-			}
-			// 只有在不是预期的中断错误时才记录日志
-			if !strings.Contains(err.Error(), "interrupted system call") && !strings.Contains(err.Error(), "select调用失败") {
-				log.Printf("读取键盘输入时发生错误: %v", err)
-			}
-			continue
-		}
-
-		if available {
-			// 将按键事件发送到通道
-			select {
-			case app.keyEventChan <- key:
-			case <-app.ctx.Done():
-				return
-			case <-time.After(50 * time.Millisecond):
-				// 超时，防止阻塞
-			}
-		}
+// openReplay 读取录制文件，创建回放按键的输入
+func (app *Application) openReplay() (*input.KeyboardInput, error) {
+	keys, err := input.LoadRecording(app.opts.replayPath)
+	if err != nil {
+		return nil, err
 	}
+	log.Printf("回放按键录制: %s（%d个按键，速度%.1f倍）", app.opts.replayPath, len(keys), app.opts.replaySpeed)
+	return input.NewReplayKeyboardInput(keys, app.opts.replaySpeed), nil
 }
 
-func (app *Application) Run() error {
-	app.mu.Lock()
-	app.running = true
-	app.mu.Unlock()
-
-	// 启动键盘监听
-	go app.startKeyboardListener()
-
-	// 创建5秒定时器用于自动刷新
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
-
-	// 立即显示第一次系统状态
-	if err := app.showMainMenu(); err != nil {
-		return fmt.Errorf("初始显示主菜单失败: %v", err)
+// exitAfterReplay 回放结束并等待最后的按键处理完毕后退出程序
+func (app *Application) exitAfterReplay() {
+	defer input.RecoverTerminal()
+	select {
+	case <-app.replayDone:
+	case <-app.ctx.Done():
+		return
+	}
+	select {
+	case <-time.After(replaySettle):
+		log.Printf("按键回放结束，程序即将退出")
+		app.cancel()
+	case <-app.ctx.Done():
 	}
+}
 
-	log.Printf("系统状态监控已启动，每5秒自动刷新")
+// initPointer 初始化鼠标、屏幕指针和触摸屏
+// 二者都不是必需的，初始化失败时只记录日志
+func (app *Application) initPointer() {
+	width, height := app.fb.GetDimensions()
 
-	for {
-		select {
-		case <-app.ctx.Done():
-			log.Printf("接收到退出信号，程序即将退出")
-			return nil
-		case <-ticker.C:
-			// 5秒定时器触发，刷新系统状态
-			if app.isRunning() {
-				// 强制使缓存失效，确保重新渲染
-				app.menuRenderer.InvalidateCache()
-				if err := app.showMainMenu(); err != nil {
-					log.Printf("自动刷新系统状态失败: %v", err)
-				}
-			}
-		case key := <-app.keyEventChan:
-			// 如果程序当前不在运行状态（例如在配置菜单中），则忽略按键
-			if !app.isRunning() {
-				continue
-			}
-			// 处理键盘输入
-			switch key {
-			case '\n', '\r':
-				// 按下回车键，进入配置菜单
-				log.Printf("检测到回车键，进入配置菜单")
-				if err := app.enterConfigMenu(ticker); err != nil {
-					log.Printf("配置菜单操作失败: %v", err)
-				}
-				// 从配置菜单返回后，立即刷新主菜单
-				app.menuRenderer.InvalidateCache()
-				if err := app.showMainMenu(); err != nil {
-					log.Printf("返回主菜单时刷新失败: %v", err)
-				}
-			case 3: // Ctrl+C
-				if !app.disableCtrlC {
-					log.Printf("在主页面检测到Ctrl+C，程序即将退出")
-					app.cancel()
-				} else {
-					log.Printf("在主页面检测到Ctrl+C，但退出功能已禁用")
-				}
-			case 26: // Ctrl+Z
-				if app.disableCtrlC {
-					log.Printf("在主页面检测到Ctrl+Z，但退出功能已禁用")
-				} else {
-					log.Printf("在主页面检测到Ctrl+Z，程序即将退出")
-					app.cancel()
-				}
-			case 28: // Ctrl+\
-				if app.disableCtrlC {
-					log.Printf("在主页面检测到Ctrl+\\，但退出功能已禁用")
-				} else {
-					log.Printf("在主页面检测到Ctrl+\\，程序即将退出")
-					app.cancel()
-				}
-			case 4: // Ctrl+D (EOF)
-				if app.disableCtrlC {
-					log.Printf("在主页面检测到Ctrl+D，但退出功能已禁用")
-				} else {
-					log.Printf("在主页面检测到Ctrl+D，程序即将退出")
-					app.cancel()
-				}
-			}
-		}
+	var mouseEvents, touchEvents <-chan input.MouseEvent
+	if mouse, err := input.NewMouseInput(width, height); err != nil {
+		log.Printf("未启用鼠标: %v", err)
+	} else {
+		app.mouse = mouse
+		app.cursor = framebuffer.NewCursor(app.fb)
+		mouseEvents = mouse.Events()
+		log.Printf("已启用鼠标输入")
 	}
-}
 
-func (app *Application) showMainMenu() error {
-	sysInfo, err := system.GetSystemInfo()
-	if err != nil {
-		return fmt.Errorf("failed to get system info: %v", err)
+	t := app.config.Touch
+	calibration := input.TouchCalibration{
+		MinX: t.MinX, MaxX: t.MaxX, MinY: t.MinY, MaxY: t.MaxY,
+		SwapXY: t.SwapXY, InvertX: t.InvertX, InvertY: t.InvertY,
+	}
+	if touch, err := input.NewTouchInput(width, height, calibration); err != nil {
+		log.Printf("未启用触摸屏: %v", err)
+	} else {
+		app.touch = touch
+		touch.SetGestures(input.GestureConfig{
+			SwipeDistance: t.Swipe,
+			LongPress:     time.Duration(t.LongPress) * time.Millisecond,
+		})
+		touchEvents = touch.Events()
+		// 滑动翻页和长按转换为导航按键，与键盘按键走同一条事件流
+		app.keyboard = input.WithKeyEvents(app.keyboard, touch.Gestures())
+		log.Printf("已启用触摸屏输入")
 	}
 
-	return app.menuRenderer.RenderMainMenu(sysInfo)
+	app.mouseEvents = input.MergeMouseEvents(mouseEvents, touchEvents)
 }
 
-func (app *Application) showConfigMenu() error {
-	return app.menuRenderer.RenderConfigMenu()
+// handleMouseEvent 根据鼠标事件移动屏幕指针，并返回事件是否为点击
+// 触摸屏的轻触直接作为点击，不移动指针
+func (app *Application) handleMouseEvent(ev input.MouseEvent) bool {
+	if app.idle != nil {
+		app.idle.Activity()
+	}
+	if app.saver != nil {
+		app.saver.Activity()
+	}
+	if !ev.Touch && app.cursor != nil {
+		app.cursor.MoveTo(ev.X, ev.Y)
+	}
+	return ev.IsClick()
 }
 
-func (app *Application) handleMenuChoice(choice int) error {
-	switch choice {
-	case 1:
-		return app.showNetworkInfo()
-	case 2:
-		return app.showSystemServiceMenu()
-	case 3:
-		return app.testNetworkConnectivity()
-	case 4:
-		return app.confirmAndReboot()
-	case 5:
-		return app.confirmAndShutdown()
+// onScan 扫码捕获器完成一次扫描时调用
+// 只接受首页上的扫码，交给主循环处理；其它页面中的扫码被丢弃，避免返回首页后才意外生效
+func (app *Application) onScan(code string) {
+	if !app.isRunning() {
+		log.Printf("当前页面不接受扫码，已忽略: %s", code)
+		return
+	}
+	select {
+	case app.scanCodes <- code:
 	default:
-		return app.showMessage("无效选项，请重新选择")
+		log.Printf("扫码结果尚未处理完毕，已忽略: %s", code)
 	}
 }
 
-func (app *Application) showNetworkInfo() error {
-	interfaces, err := system.GetNetworkInterfaces()
-	if err != nil {
-		return app.showMessage(fmt.Sprintf("获取网卡信息失败: %v", err))
+// handleScan 处理首页上的扫码结果
+// 配置了save_to时把内容写入该文件（如激活码），否则只显示扫码内容
+func (app *Application) handleScan(code string) error {
+	log.Printf("收到扫码: %s", code)
+	message := i18n.Translatef("扫码结果：\n%s", code)
+	if path := app.config.Scanner.SaveTo; path != "" {
+		if err := os.WriteFile(path, []byte(code+"\n"), 0600); err != nil {
+			return fmt.Errorf("写入%s失败: %v", path, err)
+		}
+		log.Printf("扫码内容已保存到: %s", path)
+		message = i18n.Translatef("已保存扫码内容：\n%s", code)
 	}
-
-	if err := app.menuRenderer.RenderNetworkInfo(interfaces); err != nil {
+	if err := app.nav.Push(app.messagePage(menu.LevelSuccess, message)); err != nil {
 		return err
 	}
+	return app.nav.Flush()
+}
 
-	// 循环等待按键，处理控制键
-	for {
-		key, err := app.keyboard.ReadKey()
-		if err != nil {
-			return err
-		}
-		
-		// 处理控制键
-		if app.handleControlKey(key, "网卡信息页面") {
-			return nil // 控制键触发退出
-		}
-		
-		// 其他任意按键都返回
-		return nil
+// redrawCursor 屏幕重绘后重新绘制鼠标指针
+func (app *Application) redrawCursor() {
+	if app.cursor != nil {
+		app.cursor.Redraw()
 	}
 }
 
-func (app *Application) showSystemServiceMenu() error {
-	message := "系统服务管理\n\n" +
-		"此功能暂时未实现\n" +
-		"将来可以添加以下功能：\n" +
-		"- 重启网络服务\n" +
-		"- 重启SSH服务\n" +
-		"- 重启防火墙服务\n" +
-		"- 查看服务状态\n\n" +
-		"按任意键返回"
+// 全局热键的功能说明，同时用于页脚的按键提示
+const (
+	hotkeyExit    = "退出程序"
+	hotkeyHome    = "返回首页"
+	hotkeyRefresh = "刷新"
+	hotkeyHelp    = "帮助"
+	hotkeyBanner  = "彩蛋"
+)
 
-	if err := app.menuRenderer.RenderMessage(message); err != nil {
-		return err
+// registerHotkeys 注册全局热键
+// 退出热键来自配置文件的exit_keys，可以是组合键或按键序列；
+// 在禁用退出功能（-d）时不做处理，按普通按键交给当前页面
+func (app *Application) registerHotkeys() {
+	for _, key := range app.config.ExitKeys {
+		name := key
+		if err := app.hotkeys.RegisterString(name, hotkeyExit, func(ev input.KeyEvent) bool {
+			if app.disableCtrlC {
+				log.Printf("检测到%s，但退出功能已禁用", name)
+				return false
+			}
+			log.Printf("检测到%s，程序即将退出", name)
+			app.cancel()
+			return true
+		}); err != nil {
+			log.Printf("注册热键%s失败: %v", name, err)
+		}
 	}
 
-	// 循环等待按键，处理控制键
-	for {
-		key, err := app.keyboard.ReadKey()
-		if err != nil {
-			return err
-		}
-		
-		// 处理控制键
-		if app.handleControlKey(key, "系统服务菜单页面") {
-			return nil // 控制键触发退出
+	if key := app.config.HomeKey; key != "" {
+		if err := app.hotkeys.RegisterString(key, hotkeyHome, func(ev input.KeyEvent) bool {
+			if app.nav.AtRoot() {
+				return false // 已在首页
+			}
+			log.Printf("检测到%s，返回首页", key)
+			app.handlePageError(app.nav.PopToRoot())
+			return true
+		}); err != nil {
+			log.Printf("注册热键%s失败: %v", key, err)
 		}
-		
-		// 其他任意按键都返回
-		return nil
 	}
-}
 
-func (app *Application) testNetworkConnectivity() error {
-	// 显示开始测试的消息
-	if err := app.menuRenderer.RenderMessage("正在初始化网络连通性测试...\n\n请稍候..."); err != nil {
-		return err
+	// F5或r立即刷新当前页面，只在可以刷新的页面生效；输入文字时，或页面自己使用r时（如服务管理页面的重启），r交给页面
+	for _, key := range []string{"F5", "r"} {
+		key := key
+		if err := app.hotkeys.RegisterString(key, hotkeyRefresh, func(ev input.KeyEvent) bool {
+			if app.inScreensaver() || !app.nav.CanRefresh() {
+				return false
+			}
+			if key == "r" && (app.nav.EditingText() || app.nav.UsesKey(key)) {
+				return false
+			}
+			log.Printf("检测到%s，刷新当前页面", key)
+			_, err := app.nav.Refresh()
+			app.handlePageError(err)
+			return true
+		}); err != nil {
+			log.Printf("注册热键%s失败: %v", key, err)
+		}
 	}
 
-	// 创建进度回调函数
-	progressCallback := func(target string, current, total int, message string) {
-		progressText := fmt.Sprintf("网络连通性测试进度: %d/%d\n\n当前测试: %s\n%s", current, total, target, message)
-		app.menuRenderer.RenderMessage(progressText)
+	// F1或?显示当前页面的帮助，帮助页面中再按一次关闭；输入文字时?作为普通字符交给页面
+	for _, key := range []string{"F1", "?"} {
+		key := key
+		if err := app.hotkeys.RegisterString(key, hotkeyHelp, func(ev input.KeyEvent) bool {
+			if app.inScreensaver() || (key == "?" && app.nav.EditingText()) {
+				return false
+			}
+			app.handlePageError(app.nav.ShowHelp())
+			return true
+		}); err != nil {
+			log.Printf("注册热键%s失败: %v", key, err)
+		}
 	}
 
-	// 执行高级网络测试
-	results, err := system.TestAdvancedNetworkConnectivity(progressCallback)
-	if err != nil {
-		message := fmt.Sprintf("网络测试执行失败: %v\n\n按任意键返回", err)
-		if err := app.menuRenderer.RenderMessage(message); err != nil {
-			return err
+	// 隐藏的彩蛋热键打开字符画页面，已在该页面时不重复打开；输入文字时交给页面
+	if key := app.config.Splash.Hotkey; key != "" {
+		if err := app.hotkeys.RegisterString(key, hotkeyBanner, func(ev input.KeyEvent) bool {
+			if app.inScreensaver() || app.nav.EditingText() {
+				return false
+			}
+			if _, ok := app.nav.Top().(*menu.BannerPage); ok {
+				return false
+			}
+			app.handlePageError(app.nav.Push(&menu.BannerPage{}))
+			return true
+		}); err != nil {
+			log.Printf("注册热键%s失败: %v", key, err)
 		}
-		_, err = app.keyboard.ReadKey()
-		return err
 	}
+}
 
-	// 格式化并显示测试结果
-	resultMessage := app.formatNetworkTestResults(results)
-	if err := app.menuRenderer.RenderMessage(resultMessage); err != nil {
-		return err
+// hotkeyHints 根据已注册的全局热键生成页脚的按键提示
+// 只列出在当前页面生效的热键：禁用退出功能时不列出退出热键，刷新只在可以刷新的页面列出，
+// 返回首页只在其它页面列出；功能相同的几个热键合并为一条提示，如"Ctrl+C/Ctrl+D:退出程序"
+func (app *Application) hotkeyHints() []menu.Hint {
+	atRoot := app.nav.AtRoot()
+	active := map[string]bool{
+		hotkeyExit:    !app.disableCtrlC,
+		hotkeyHome:    !atRoot,
+		hotkeyRefresh: app.nav.CanRefresh(),
+		hotkeyBanner:  false, // 隐藏热键，不在页脚列出
 	}
 
-	// 循环等待按键，处理控制键
-	for {
-		key, err := app.keyboard.ReadKey()
-		if err != nil {
-			return err
+	var hints []menu.Hint
+	index := make(map[string]int)
+	for _, b := range app.hotkeys.Bindings() {
+		if on, ok := active[b.Description]; ok && !on {
+			continue
 		}
-		
-		// 处理控制键
-		if app.handleControlKey(key, "网络测试结果页面") {
-			return nil // 控制键触发退出
+		if i, ok := index[b.Description]; ok {
+			hints[i].Key += "/" + b.String()
+			continue
 		}
-		
-		// 其他任意按键都返回
-		return nil
+		index[b.Description] = len(hints)
+		hints = append(hints, menu.Hint{Key: b.String(), Text: i18n.Translate(b.Description)})
 	}
+	return hints
 }
 
-// formatNetworkTestResults 格式化网络测试结果
-func (app *Application) formatNetworkTestResults(results []system.NetworkTestResult) string {
-	var builder strings.Builder
-	builder.WriteString("=== 网络连通性测试结果 ===\n\n")
-
-	successCount := 0
-	for _, result := range results {
-		// 状态显示
-		status := "异常"
-		if result.Success && result.PacketLoss == 0 {
-			status = "正常"
-			successCount++
-		} else if result.Success && result.PacketLoss > 0 {
-			status = "部分正常"
-		}
-
-		builder.WriteString(fmt.Sprintf("• %s (%s):\n", result.Target.Name, result.Target.Host))
-		builder.WriteString(fmt.Sprintf("  状态: %s\n", status))
-		
-		if result.Success || result.PacketsRecv > 0 {
-			builder.WriteString(fmt.Sprintf("  数据包: 发送%d 接收%d 丢失%.1f%%\n", 
-				result.PacketsSent, result.PacketsRecv, result.PacketLoss))
-			if result.AvgLatency != "N/A" && result.AvgLatency != "" {
-				builder.WriteString(fmt.Sprintf("  平均延迟: %s\n", result.AvgLatency))
+func (app *Application) setupSignalHandler() {
+	c := make(chan os.Signal, 1)
+	// 监听所有可能导致程序退出的信号
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGTSTP, syscall.SIGQUIT)
+	go func() {
+		defer input.RecoverTerminal()
+		for {
+			select {
+			case sig := <-c:
+				// 如果禁用了退出功能，则拦截所有退出信号
+				if app.disableCtrlC {
+					log.Printf("接收到信号: %v，但退出功能已禁用，继续运行", sig)
+					continue // 不退出，继续监听
+				}
+				log.Printf("接收到信号: %v，开始优雅退出", sig)
+				app.mu.Lock()
+				app.running = false
+				app.mu.Unlock()
+				app.cancel()
+				// 给程序时间进行清理
+				time.Sleep(1 * time.Second)
+				app.Cleanup()
+				input.Exit(0)
+			case <-app.ctx.Done():
+				return
 			}
 		}
-		
-		if result.ErrorMsg != "" {
-			builder.WriteString(fmt.Sprintf("  详情: %s\n", result.ErrorMsg))
-		}
-		builder.WriteString("\n")
-	}
-
-	// 总结
-	builder.WriteString("----------------------------------------\n")
-	if successCount == len(results) {
-		builder.WriteString("✓ 网络连接状态: 良好\n")
-		builder.WriteString("所有测试目标均可正常访问")
-	} else if successCount > 0 {
-		builder.WriteString("⚠ 网络连接状态: 部分异常\n")
-		builder.WriteString(fmt.Sprintf("可访问 %d/%d 个测试目标", successCount, len(results)))
-	} else {
-		builder.WriteString("✗ 网络连接状态: 异常\n")
-		builder.WriteString("所有测试目标均无法访问")
-	}
-
-	builder.WriteString("\n\n按任意键返回")
-	return builder.String()
+	}()
 }
 
-func (app *Application) confirmAndReboot() error {
-	message := "确认要重启设备吗？\n\n" +
-		"按 'y' 确认重启\n" +
-		"按任意其他键取消"
+func (app *Application) Run() error {
+	app.mu.Lock()
+	app.running = true
+	app.mu.Unlock()
 
-	if err := app.menuRenderer.RenderMessage(message); err != nil {
-		return err
+	// 启动键盘事件泵，按键以KeyEvent形式送达
+	app.keyEvents = app.keyboard.Events()
+	if app.opts.replayPath != "" {
+		go app.exitAfterReplay()
 	}
 
-	// 循环等待按键，处理控制键
-	for {
-		key, err := app.keyboard.ReadKey()
-		if err != nil {
-			return err
-		}
-		
-		// 处理控制键
-		if app.handleControlKey(key, "重启确认页面") {
-			return nil // 控制键触发退出
-		}
-		
-		if key == 'y' || key == 'Y' {
-			if err := app.menuRenderer.RenderMessage("正在重启设备..."); err != nil {
-				return err
-			}
+	// 定时采样系统状态，页面的自动刷新由导航栈按各页面的间隔进行
+	ticker := time.NewTicker(sampleInterval)
+	defer ticker.Stop()
 
-			time.Sleep(2 * time.Second)
-			return system.RebootSystem()
-		}
+	// 屏幕保护的时钟每秒刷新
+	clockTicker := time.NewTicker(time.Second)
+	defer clockTicker.Stop()
+
+	// 放不下的长文字（如CPU型号、IPv6地址）按帧来回滚动
+	marqueeTicker := time.NewTicker(menu.MarqueeFrame)
+	defer marqueeTicker.Stop()
 
-		// 其他任意按键都取消
-		return nil
+	// 启动画面：显示到首页的系统信息获取完成，回放按键时跳过，避免影响录制的按键时序
+	root := &mainPage{app: app}
+	if app.config.Splash.Enabled && app.opts.replayPath == "" {
+		root.info = app.showSplash()
 	}
-}
 
-func (app *Application) confirmAndShutdown() error {
-	message := "确认要关机吗？\n\n" +
-		"按 'y' 确认关机\n" +
-		"按任意其他键取消"
+	// 页面导航栈：首页位于栈底，配置菜单和各子页面按需压入
+	app.nav = menu.NewNavigator(app.menuRenderer, root)
+	app.nav.AfterRender = app.redrawCursor
+	app.nav.ShowFooter = app.config.Footer
+	app.nav.GlobalHints = app.hotkeyHints
 
-	if err := app.menuRenderer.RenderMessage(message); err != nil {
-		return err
+	// 立即显示第一次系统状态
+	if err := app.nav.Start(); err != nil {
+		return fmt.Errorf("初始显示主菜单失败: %v", err)
 	}
 
-	// 循环等待按键，处理控制键
+	log.Printf("系统状态监控已启动")
+
 	for {
-		key, err := app.keyboard.ReadKey()
-		if err != nil {
-			return err
-		}
-		
-		// 处理控制键
-		if app.handleControlKey(key, "关机确认页面") {
-			return nil // 控制键触发退出
-		}
-		
-		if key == 'y' || key == 'Y' {
-			if err := app.menuRenderer.RenderMessage("正在关机..."); err != nil {
-				return err
+		select {
+		case <-app.ctx.Done():
+			log.Printf("接收到退出信号，程序即将退出")
+			return nil
+		case <-ticker.C:
+			// 不在首页时也采样，保证曲线是连续的
+			app.sampleStats()
+		case <-clockTicker.C:
+			if app.inScreensaver() {
+				app.handlePageError(app.nav.Tick(time.Now()))
+				continue
 			}
-
-			time.Sleep(2 * time.Second)
-			return system.ShutdownSystem()
+			// 其它页面只重绘状态栏中的时钟，需要定时更新的页面（如仪表盘）自行重绘变化的部分，
+			// 到了刷新时间的页面（如首页）由导航栈刷新
+			if err := app.menuRenderer.RefreshHeader(); err != nil {
+				log.Printf("刷新状态栏失败: %v", err)
+			}
+			app.handlePageError(app.nav.Tick(time.Now()))
+			app.redrawCursor()
+		case now := <-marqueeTicker.C:
+			if app.inScreensaver() {
+				continue
+			}
+			drawn, err := app.menuRenderer.AnimateMarquees(now)
+			if err != nil {
+				log.Printf("滚动文字失败: %v", err)
+			}
+			if drawn {
+				app.redrawCursor()
+			}
+		case idle := <-app.saverEvents:
+			if !idle || !app.saver.Idle() || app.inScreensaver() {
+				continue
+			}
+			log.Printf("超过%d秒无操作，显示屏幕保护", app.config.Screensaver)
+			app.handlePageError(app.nav.PopToRoot())
+			app.handlePageError(app.nav.Push(&screensaverPage{}))
+			app.handlePageError(app.nav.Flush())
+		case idle := <-app.idleEvents:
+			if !app.idleTimedOut(idle) || app.inScreensaver() {
+				continue
+			}
+			if app.nav.AtRoot() {
+				log.Printf("超过%d秒无操作，进入空闲状态", app.config.IdleTimeout)
+				continue
+			}
+			log.Printf("超过%d秒无操作，返回首页", app.config.IdleTimeout)
+			app.handlePageError(app.nav.PopToRoot())
+			app.handlePageError(app.nav.Flush())
+		case code := <-app.scanCodes:
+			app.handlePageError(app.handleScan(code))
+		case mev := <-app.mouseEvents:
+			isClick := app.handleMouseEvent(mev)
+			if app.inScreensaver() {
+				// 屏幕保护期间移动鼠标或触摸屏幕都返回首页，这次点击不再生效
+				app.handlePageError(app.nav.PopToRoot())
+				app.handlePageError(app.nav.Flush())
+				continue
+			}
+			if !isClick {
+				continue
+			}
+			if app.nav.AtRoot() {
+				// 在首页任意位置点击，与按下回车键相同，进入配置菜单
+				log.Printf("检测到鼠标点击，进入配置菜单")
+				app.handlePageError(app.openConfigMenu(app.nav))
+				app.handlePageError(app.nav.Flush())
+				continue
+			}
+			// 点击菜单选项、按钮等可点击区域等同于按下对应的按键
+			_, err := app.nav.HandleClick(mev.X, mev.Y)
+			app.handlePageError(err)
+		case ev, ok := <-app.keyEvents:
+			if !ok {
+				log.Printf("键盘事件通道已关闭，程序即将退出")
+				return nil
+			}
+			// 全局热键（退出、返回首页、刷新等）优先处理，其余按键交给当前页面
+			if app.hotkeys.Dispatch(ev) {
+				app.handlePageError(app.nav.Flush())
+				continue
+			}
+			app.handlePageError(app.nav.HandleKey(ev))
 		}
-
-		// 其他任意按键都取消
-		return nil
 	}
 }
 
-func (app *Application) showMessage(message string) error {
-	fullMessage := message + "\n\n按任意键继续"
-	if err := app.menuRenderer.RenderMessage(fullMessage); err != nil {
-		return err
+// sampleStats 采样一次CPU使用率、网卡收发速率和磁盘读写速率，并重新统计告警数和首页横幅的告警消息
+// CPU使用率追加到首页的曲线，网卡和磁盘速率保存在采样器中，打开网卡信息页面、磁盘页面或显示仪表盘时读取；
+// 根分区、内存使用率或温度超过[alerts]中的阈值时各生成一条横幅消息，状态栏的告警数即横幅消息的条数
+func (app *Application) sampleStats() {
+	app.cpuUsage = -1
+	if usage, err := app.cpuSampler.Sample(); err != nil {
+		log.Printf("采样CPU使用率失败: %v", err)
+	} else {
+		app.cpuUsage = usage
+		app.cpuChart.Push(usage)
+	}
+	if err := app.bandwidth.Sample(); err != nil {
+		log.Printf("采样网卡速率失败: %v", err)
+	}
+	if err := app.diskIO.Sample(); err != nil {
+		log.Printf("采样磁盘读写速率失败: %v", err)
 	}
 
-	// 循环等待按键，处理控制键
-	for {
-		key, err := app.keyboard.ReadKey()
-		if err != nil {
-			return err
+	var banner []string
+	limits := app.config.Alerts
+	if used, total, err := system.GetRootUsage(); err == nil && total > 0 {
+		percent := float64(used) * 100 / float64(total)
+		if limits.Disk > 0 && percent > limits.Disk {
+			banner = append(banner, i18n.Translatef("根分区使用率 %.1f%%，超过%.0f%%", percent, limits.Disk))
 		}
-		
-		// 处理控制键
-		if app.handleControlKey(key, "消息页面") {
-			return nil // 控制键触发退出
+	}
+	if used, total, err := system.GetMemoryStats(); err == nil && total > 0 {
+		percent := float64(used) * 100 / float64(total)
+		if limits.Memory > 0 && percent > limits.Memory {
+			banner = append(banner, i18n.Translatef("内存使用率 %.1f%%，超过%.0f%%", percent, limits.Memory))
+		}
+	}
+	if limits.Temperature > 0 {
+		// 没有温度传感器（如部分虚拟机）时不检查温度
+		if t, err := system.MaxTemperature(); err == nil && t.Celsius > limits.Temperature {
+			banner = append(banner, i18n.Translatef("温度 %.1f°C（%s），超过%.0f°C", t.Celsius, t.Zone, limits.Temperature))
 		}
-		
-		// 其他任意按键都返回
-		return nil
 	}
+	app.alerts = len(banner)
+	app.banner = banner
 }
 
-func (app *Application) enterConfigMenu(ticker *time.Ticker) error {
-	// 标记程序状态为非运行（暂停主界面的任何活动）
-	app.mu.Lock()
-	app.running = false
-	app.mu.Unlock()
-
-	// 函数退出时恢复状态并重启定时器
-	defer func() {
-		app.mu.Lock()
-		app.running = true
-		app.mu.Unlock()
-		ticker.Reset(5 * time.Second)
-		log.Printf("已退出配置菜单，恢复主界面自动刷新")
-	}()
-
-	// 暂停主屏幕的自动刷新
-	ticker.Stop()
-	log.Printf("已进入配置菜单，暂停主界面自动刷新")
-
-	for {
-		// 显示配置菜单
-		if err := app.showConfigMenu(); err != nil {
-			return fmt.Errorf("显示配置菜单失败: %v", err)
-		}
+// inScreensaver 返回当前是否显示着屏幕保护
+func (app *Application) inScreensaver() bool {
+	_, ok := app.nav.Top().(*screensaverPage)
+	return ok
+}
 
-		// 等待用户选择 (1-5, q)
-		// 注意：这里的WaitForKey是阻塞的，它会阻止Run循环的进行
-		// 但由于我们在独立的goroutine中监听键盘，这里需要换一种方式
-		// 我们改为从keyEventChan读取
-		select {
-		case key := <-app.keyEventChan:
-			// 处理控制键
-			if app.handleControlKey(key, "配置菜单") {
-				return nil // 控制键触发退出
-			}
-			
-			var choice int
-			switch key {
-			case '1', '2', '3', '4', '5':
-				choice = int(key - '0')
-			case 'q', 'Q', 27: // q, Q, ESC
-				return nil // 退出配置菜单
-			default:
-				continue // 忽略其他键
-			}
+// idleTimedOut 判断收到的空闲通知是否仍然有效
+// 通知可能在处理其它事件期间积压，以检测器的当前状态为准
+func (app *Application) idleTimedOut(idle bool) bool {
+	return idle && app.idle.Idle()
+}
 
-			// 处理菜单选择
-			if err := app.handleMenuChoice(choice); err != nil {
-				log.Printf("处理菜单选择失败: %v", err)
-				// 显示错误信息后继续
-				app.showMessage(fmt.Sprintf("操作失败: %v", err))
-			}
-		case <-app.ctx.Done():
-			return nil
-		}
+// handlePageError 处理页面操作返回的错误
+// 程序正在退出时忽略，其它错误记录日志并显示给用户，按任意键返回出错前的页面
+func (app *Application) handlePageError(err error) {
+	if err == nil || app.isContextError(err) {
+		return
+	}
+	log.Printf("页面操作失败: %v", err)
+	if err := app.nav.Push(app.messagePage(menu.LevelError, i18n.Translatef("操作失败: %v", err))); err != nil {
+		log.Printf("显示错误信息失败: %v", err)
+		return
+	}
+	if err := app.nav.Flush(); err != nil {
+		log.Printf("显示错误信息失败: %v", err)
 	}
 }
 
@@ -766,56 +1063,19 @@ func (app *Application) isContextError(err error) bool {
 	return err == context.Canceled || err == context.DeadlineExceeded
 }
 
-// handleControlKey 处理控制键，如果禁用了退出功能则拦截，否则退出程序
-// 返回true表示应该退出当前函数，false表示继续处理
-func (app *Application) handleControlKey(key byte, location string) bool {
-	switch key {
-	case 3: // Ctrl+C
-		if app.disableCtrlC {
-			log.Printf("在%s检测到Ctrl+C，但退出功能已禁用", location)
-			return false // 继续运行
-		} else {
-			log.Printf("在%s检测到Ctrl+C，程序即将退出", location)
-			app.cancel()
-			return true // 退出当前函数
-		}
-	case 26: // Ctrl+Z
-		if app.disableCtrlC {
-			log.Printf("在%s检测到Ctrl+Z，但退出功能已禁用", location)
-			return false // 继续运行
-		} else {
-			log.Printf("在%s检测到Ctrl+Z，程序即将退出", location)
-			app.cancel()
-			return true // 退出当前函数
-		}
-	case 28: // Ctrl+\
-		if app.disableCtrlC {
-			log.Printf("在%s检测到Ctrl+\\，但退出功能已禁用", location)
-			return false // 继续运行
-		} else {
-			log.Printf("在%s检测到Ctrl+\\，程序即将退出", location)
-			app.cancel()
-			return true // 退出当前函数
-		}
-	case 4: // Ctrl+D (EOF)
-		if app.disableCtrlC {
-			log.Printf("在%s检测到Ctrl+D，但退出功能已禁用", location)
-			return false // 继续运行
-		} else {
-			log.Printf("在%s检测到Ctrl+D，程序即将退出", location)
-			app.cancel()
-			return true // 退出当前函数
-		}
-	}
-	return false // 不是控制键，继续处理
-}
-
 func (app *Application) isRunning() bool {
 	app.mu.RLock()
 	defer app.mu.RUnlock()
 	return app.running
 }
 
+// setRunning 设置是否停留在首页，供扫码等后台goroutine查询
+func (app *Application) setRunning(running bool) {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	app.running = running
+}
+
 func (app *Application) Cleanup() {
 	app.mu.Lock()
 	defer app.mu.Unlock()
@@ -824,16 +1084,42 @@ func (app *Application) Cleanup() {
 		app.cancel()
 	}
 
-	if app.keyboard != nil {
-		if err := app.keyboard.RestoreTerminal(); err != nil {
-			log.Printf("恢复终端状态失败: %v", err)
+	if app.mouse != nil {
+		if err := app.mouse.Close(); err != nil {
+			log.Printf("关闭鼠标设备失败: %v", err)
+		}
+		app.mouse = nil
+	}
+
+	if app.touch != nil {
+		if err := app.touch.Close(); err != nil {
+			log.Printf("关闭触摸屏设备失败: %v", err)
 		}
+		app.touch = nil
+	}
+
+	if app.keyboard != nil {
+		// 关闭键盘时会一并恢复终端状态
 		if err := app.keyboard.Close(); err != nil {
 			log.Printf("关闭键盘设备失败: %v", err)
 		}
 		app.keyboard = nil
 	}
 
+	if app.idle != nil {
+		app.idle.Stop()
+	}
+	if app.saver != nil {
+		app.saver.Stop()
+	}
+
+	if app.recordFile != nil {
+		if err := app.recordFile.Close(); err != nil {
+			log.Printf("关闭按键录制文件失败: %v", err)
+		}
+		app.recordFile = nil
+	}
+
 	if app.fb != nil {
 		if err := app.fb.Close(); err != nil {
 			log.Printf("关闭帧缓冲区失败: %v", err)
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/menu"
+	"go-framebuffer-console/pkg/system"
+)
+
+// commandTimeout 配置菜单中自定义命令的最长执行时间
+const commandTimeout = 5 * time.Minute
+
+// menuAction 配置文件中[menu_item]段落的action可以引用的内置功能
+type menuAction struct {
+	label  string                          // 默认显示的名称
+	action func(nav *menu.Navigator) error // 选择该项时执行
+}
+
+// builtinMenuActions 返回内置功能和通过menu.RegisterPage登记的页面，键为配置文件中action的取值
+// 登记页面的ID与内置功能重名时以内置功能为准
+func (app *Application) builtinMenuActions() map[string]menuAction {
+	actions := map[string]menuAction{
+		"network":    {"查看网卡信息", app.showNetworkInfo},
+		"services":   {"系统服务管理", app.showServices},
+		"nettest":    {"检测设备网络", app.testNetworkConnectivity},
+		"nettargets": {"网络测试目标", app.showNetworkTargets},
+		"reboot":     {"重启设备", app.confirmAndReboot},
+		"shutdown":   {"关机", app.confirmAndShutdown},
+		"font":       {"切换字体", app.switchFont},
+		"qrcodes":    {"扫码", app.showQRCodes},
+		"dashboard":  {"仪表盘", app.showDashboard},
+		"logs":       {"查看日志", app.showLogs},
+		"sensors":    {"硬件传感器", app.showSensors},
+		"disks":      {"磁盘使用情况", app.showDisks},
+		"hardware":   {"设备信息", app.showHardware},
+		"processes":  {"进程列表", app.showProcesses},
+		"containers": {"容器", app.showContainers},
+		"speedtest":  {"测速", app.runSpeedTest},
+		"restart":    {"重启系统服务", app.showRestartMenu},
+	}
+	for _, t := range app.restartTargets() {
+		actions[t.action] = menuAction{t.title, app.restartService(t.title, t.unit)}
+	}
+	for _, entry := range menu.RegisteredPages() {
+		if _, ok := actions[entry.ID]; ok {
+			log.Printf("登记的页面 %s 与内置功能重名，已忽略", entry.ID)
+			continue
+		}
+		actions[entry.ID] = menuAction{entry.Title, app.openRegisteredPage(entry)}
+	}
+	return actions
+}
+
+// registeredMenuItems 为通过menu.RegisterPage登记的页面生成内置菜单末尾的选项
+// 快捷键接着内置选项编号，超过9项后只能用方向键选择
+// 参数first: 内置选项的个数
+func (app *Application) registeredMenuItems(first int) []menu.MenuItem {
+	var items []menu.MenuItem
+	for i, entry := range menu.RegisteredPages() {
+		items = append(items, numberedMenuItem(first+i+1, entry.Title, app.openRegisteredPage(entry)))
+	}
+	return items
+}
+
+// numberedMenuItem 创建第n个菜单选项，前9项以数字作为快捷键并在名称前加上编号
+// 参数title: 选项名称，显示前翻译为当前界面语言
+func numberedMenuItem(n int, title string, action func(nav *menu.Navigator) error) menu.MenuItem {
+	item := menu.MenuItem{Text: i18n.Translate(title), Action: action}
+	if n <= 9 {
+		item.Key = byte('0' + n)
+		item.Text = fmt.Sprintf("%d. %s", n, item.Text)
+	}
+	return item
+}
+
+// openRegisteredPage 返回进入登记页面的菜单动作，每次进入时创建新的页面
+func (app *Application) openRegisteredPage(entry menu.PageEntry) func(nav *menu.Navigator) error {
+	return func(nav *menu.Navigator) error {
+		page, err := entry.Factory()
+		if err != nil {
+			log.Printf("打开页面 %s 失败: %v", entry.ID, err)
+			return nav.Push(app.messagePage(menu.LevelError, i18n.Translatef("打开页面失败: %v", err)))
+		}
+		return nav.Push(page)
+	}
+}
+
+// configuredMenuItems 按配置文件中的[menu_item]段落生成配置菜单的选项
+// 未启用的选项被跳过；功能名称无效、缺少命令或快捷键冲突的选项记录日志后跳过；
+// 没有配置快捷键的选项按位置编号为1-9，超过9项后只能用方向键选择
+func (app *Application) configuredMenuItems() []menu.MenuItem {
+	builtins := app.builtinMenuActions()
+	used := map[byte]bool{'q': true, 'Q': true} // q用于返回首页
+	var items []menu.MenuItem
+	for _, mc := range app.config.Menu {
+		if !mc.Enabled {
+			continue
+		}
+
+		label := mc.Label
+		var action func(nav *menu.Navigator) error
+		if mc.Action == "command" {
+			if mc.Command == "" {
+				log.Printf("菜单项 %q 没有配置command，已忽略", label)
+				continue
+			}
+			if err := system.CheckCommand(mc.Command, app.config.Commands); err != nil {
+				log.Printf("菜单项 %q 的命令不允许执行，已忽略: %v", label, err)
+				continue
+			}
+			if label == "" {
+				label = mc.Command
+			}
+			action = app.commandAction(label, mc.Command)
+		} else if builtin, ok := builtins[mc.Action]; ok {
+			if label == "" {
+				label = builtin.label
+			}
+			action = builtin.action
+		} else {
+			log.Printf("未知的菜单功能: %s，已忽略", mc.Action)
+			continue
+		}
+		if mc.Pin {
+			// 没有配置PIN时该选项无法执行，而不是不加保护
+			if app.config.Pin == "" {
+				log.Printf("菜单项 %q 设置了require_pin，但没有配置管理员PIN", label)
+			}
+			action = app.requirePin(label, action)
+		}
+
+		var key byte
+		switch {
+		case len(mc.Key) == 1:
+			key = mc.Key[0]
+		case mc.Key != "":
+			log.Printf("菜单项 %q 的快捷键 %q 不是单个字符，已忽略该快捷键", label, mc.Key)
+		case len(items) < 9:
+			key = byte('1' + len(items))
+		}
+		if used[key] {
+			log.Printf("菜单项 %q 的快捷键 %c 已被占用，已忽略该快捷键", label, key)
+			key = 0
+		} else if key != 0 {
+			used[key] = true
+		}
+
+		text := i18n.Translate(label)
+		if key != 0 {
+			text = fmt.Sprintf("%c. %s", key, text)
+		}
+		items = append(items, menu.MenuItem{Text: text, Key: key, Action: action})
+	}
+	return items
+}
+
+// commandAction 返回执行自定义命令的菜单动作
+// 执行期间实时显示命令的输出，完成后显示退出状态和全部输出，失败时以错误级别显示。
+// 执行前再次检查白名单，防止配置在运行期间被替换
+// 参数label: 菜单项的名称，用作输出画面的标题
+// 参数command: 要执行的命令
+func (app *Application) commandAction(label, command string) func(nav *menu.Navigator) error {
+	return func(nav *menu.Navigator) error {
+		if err := system.CheckCommand(command, app.config.Commands); err != nil {
+			log.Printf("拒绝执行菜单命令: %v", err)
+			return nav.Push(app.messagePage(menu.LevelError, i18n.Translatef("命令执行失败: %v", err)))
+		}
+
+		log.Printf("执行菜单命令: %s", command)
+		view := app.menuRenderer.StartOutput(i18n.Translate(label), i18n.Translatef("正在执行: %s", command))
+		result, err := system.StreamCommand(command, commandTimeout, view.Append)
+		view.Stop()
+
+		output := view.Lines()
+		if len(output) == 0 {
+			output = []string{i18n.Translate("（命令没有输出）")}
+		}
+		output = append(output, "", i18n.Translate("按任意键继续"))
+
+		elapsed := result.Duration.Round(100 * time.Millisecond)
+		if err != nil {
+			log.Printf("菜单命令执行失败: %s: %v", command, err)
+			status := i18n.Translatef("命令执行失败: %v", err)
+			if result.TimedOut {
+				status = i18n.Translatef("命令执行超时（%v），已结束", commandTimeout)
+			} else if result.ExitCode > 0 {
+				status = i18n.Translatef("退出状态: %d（耗时%v）", result.ExitCode, elapsed)
+			}
+			return nav.Push(menu.NewCommandResultPage(menu.LevelError, status, output))
+		}
+		log.Printf("菜单命令执行完成: %s，耗时%v", command, elapsed)
+		return nav.Push(menu.NewCommandResultPage(menu.LevelSuccess, i18n.Translatef("退出状态: %d（耗时%v）", result.ExitCode, elapsed), output))
+	}
+}
@@ -0,0 +1,447 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-framebuffer-console/pkg/font"
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/input"
+	"go-framebuffer-console/pkg/menu"
+	"go-framebuffer-console/pkg/system"
+)
+
+// maxNetTargets 网络测试目标的最大个数，只能用单个数字键选择
+const maxNetTargets = 9
+
+// netTarget 网络测试目标及是否参与测试
+type netTarget struct {
+	system.NetworkTestTarget
+	enabled bool
+}
+
+// networkTargets 返回网络测试目标，首次调用时按配置文件的[nettest_target]生成，没有配置时使用内置的目标
+// 在"网络测试目标"页面中的修改只在本次运行中有效
+func (app *Application) networkTargets() []netTarget {
+	if app.netTargets != nil {
+		return app.netTargets
+	}
+	targets := []netTarget{}
+	for _, t := range app.config.NetTargets {
+		target := system.NetworkTestTarget{
+			Name:        t.Name,
+			Host:        t.Host,
+			Description: t.Description,
+			Type:        t.Type,
+			Count:       t.Count,
+			Timeout:     time.Duration(t.Timeout) * time.Second,
+			Port:        t.Port,
+
+			URL:          t.URL,
+			ExpectStatus: t.ExpectStatus,
+			Insecure:     !t.VerifyTLS,
+		}
+		targets = append(targets, netTarget{NetworkTestTarget: fillTargetNames(target), enabled: t.Enabled})
+	}
+	if len(app.config.NetTargets) == 0 {
+		for _, t := range system.DefaultNetworkTestTargets {
+			targets = append(targets, netTarget{NetworkTestTarget: t, enabled: true})
+		}
+	}
+	if len(targets) > maxNetTargets {
+		targets = targets[:maxNetTargets]
+	}
+	app.netTargets = targets
+	return targets
+}
+
+// fillTargetNames 主机地址为空时使用URL中的主机名，名称为空时使用主机地址，说明为空时使用名称
+func fillTargetNames(t system.NetworkTestTarget) system.NetworkTestTarget {
+	if t.Host == "" && t.URL != "" {
+		if u, err := url.Parse(t.URL); err == nil {
+			t.Host = u.Hostname()
+		}
+	}
+	if t.Name == "" {
+		t.Name = t.Host
+	}
+	if t.Description == "" {
+		t.Description = t.Name
+	}
+	return t
+}
+
+// selectedNetworkTargets 返回选中参与测试的目标
+func (app *Application) selectedNetworkTargets() []system.NetworkTestTarget {
+	var targets []system.NetworkTestTarget
+	for _, t := range app.networkTargets() {
+		if t.enabled {
+			targets = append(targets, t.NetworkTestTarget)
+		}
+	}
+	return targets
+}
+
+// netTargetsPage 网络测试目标页面
+// 列出测试目标及其类型，空格或回车切换是否参与测试，e、a、d编辑、添加、删除目标，t开始测试，r追踪到选中目标的路由，q返回
+type netTargetsPage struct {
+	menu.BasePage
+	app    *Application
+	list   *menu.List
+	status *menu.Label // 最近一次操作的提示
+}
+
+// showNetworkTargets 显示网络测试目标页面
+func (app *Application) showNetworkTargets(nav *menu.Navigator) error {
+	return nav.Push(&netTargetsPage{app: app, list: &menu.List{Selectable: true}, status: &menu.Label{}})
+}
+
+// OnEnter 按当前的测试目标生成列表
+func (p *netTargetsPage) OnEnter(nav *menu.Navigator) error {
+	p.update()
+	return nil
+}
+
+// update 按当前的测试目标重新生成列表
+func (p *netTargetsPage) update() {
+	targets := p.app.networkTargets()
+	p.list.Items = p.list.Items[:0]
+	for i, t := range targets {
+		mark := "[ ]"
+		if t.enabled {
+			mark = "[✓]"
+		}
+		typ := t.Type
+		if typ == "" {
+			typ = system.TargetPing
+		}
+		p.list.Items = append(p.list.Items, menu.ListItem{
+			Text: fmt.Sprintf("%d. %s %s (%s) %s", i+1, mark, t.Name, t.Address(), typ),
+			Key:  byte('1' + i),
+		})
+	}
+	if p.list.Selected >= len(p.list.Items) {
+		p.list.Selected = len(p.list.Items) - 1
+	}
+	if p.list.Selected < 0 {
+		p.list.Selected = 0
+	}
+}
+
+// Render 绘制测试目标列表
+func (p *netTargetsPage) Render(mr *menu.MenuRenderer) error {
+	layout := mr.NewLayout(&menu.Label{Text: i18n.Translate("网络测试目标"), Color: mr.Theme().Accent}, menu.NewSeparator())
+	if len(p.list.Items) == 0 {
+		layout.Add(menu.NewLabel(i18n.Translate("没有测试目标，按a添加")))
+	} else {
+		layout.Add(p.list)
+	}
+	return mr.RenderLayout(layout.Add(p.status))
+}
+
+// Hints 页脚的按键提示
+func (p *netTargetsPage) Hints() []menu.Hint {
+	return []menu.Hint{
+		{Key: i18n.Translate("空格"), Text: i18n.Translate("选中")},
+		{Key: "e", Text: i18n.Translate("编辑")},
+		{Key: "a", Text: i18n.Translate("添加")},
+		{Key: "d", Text: i18n.Translate("删除")},
+		{Key: "t", Text: i18n.Translate("开始测试")},
+		{Key: "r", Text: i18n.Translate("路由追踪")},
+		{Key: "q", Text: i18n.Translate("返回")},
+	}
+}
+
+// Help 说明各项的含义
+func (p *netTargetsPage) Help() []menu.HelpItem {
+	return []menu.HelpItem{
+		{Name: "[✓]", Text: i18n.Translate("参与测试的目标，\"检测设备网络\"只测试选中的目标")},
+		{Name: i18n.Translate("类型"), Text: i18n.Translate("ping：发送ICMP回显请求；http：请求URL，检查状态码和证书有效期，URL为空时请求http://主机/；tcp：连接主机的端口，检查防火墙是否放行")},
+		{Name: i18n.Translate("路由追踪"), Text: i18n.Translate("逐跳显示到选中目标的路径和各跳的往返时间，需要root权限")},
+		{Name: i18n.Translate("保存"), Text: i18n.Translate("修改只在本次运行中有效，需要长期使用时写入配置文件的[nettest_target]段落")},
+	}
+}
+
+// HandleKey 处理选择、切换、编辑、开始测试和路由追踪的按键
+func (p *netTargetsPage) HandleKey(nav *menu.Navigator, ev input.KeyEvent) error {
+	targets := p.app.networkTargets()
+	switch ev.Code {
+	case input.KeyUp:
+		p.list.Move(-1)
+	case input.KeyDown:
+		p.list.Move(1)
+	case input.KeyEnter:
+		p.toggle()
+	default:
+		switch key := ev.Byte(); key {
+		case 'q', 'Q', 27, 0x7F: // q, Q, ESC, 退格
+			return nav.Pop()
+		case ' ':
+			p.toggle()
+		case 'e', 'E':
+			if len(targets) > 0 {
+				return nav.Push(p.editForm(p.list.Selected))
+			}
+		case 'a', 'A':
+			if len(targets) >= maxNetTargets {
+				p.status.Text = i18n.Translatef("最多%d个测试目标", maxNetTargets)
+				break
+			}
+			return nav.Push(p.editForm(-1))
+		case 'd', 'D':
+			if len(targets) > 0 {
+				name := targets[p.list.Selected].Name
+				p.app.netTargets = append(targets[:p.list.Selected], targets[p.list.Selected+1:]...)
+				p.status.Text = i18n.Translatef("已删除测试目标 %s", name)
+				p.update()
+			}
+		case 't', 'T':
+			return p.app.testNetworkConnectivity(nav)
+		case 'r', 'R':
+			if len(targets) > 0 {
+				return p.app.traceRoute(nav, targets[p.list.Selected].Host)
+			}
+		default:
+			if !p.list.Select(key) {
+				return nil // 忽略其他键
+			}
+		}
+	}
+	nav.Invalidate()
+	return nil
+}
+
+// toggle 切换选中的目标是否参与测试
+func (p *netTargetsPage) toggle() {
+	targets := p.app.networkTargets()
+	if len(targets) == 0 {
+		return
+	}
+	t := &targets[p.list.Selected]
+	t.enabled = !t.enabled
+	p.status.Text = ""
+	p.update()
+}
+
+// editForm 创建编辑测试目标的表单
+// 参数index: 目标的下标，-1表示添加新的目标
+func (p *netTargetsPage) editForm(index int) *menu.FormPage {
+	target := netTarget{NetworkTestTarget: system.NetworkTestTarget{Type: system.TargetPing}, enabled: true}
+	title := i18n.Translate("添加测试目标")
+	if index >= 0 {
+		target = p.app.networkTargets()[index]
+		title = i18n.Translate("编辑测试目标")
+	}
+	if target.Type == "" {
+		target.Type = system.TargetPing
+	}
+	port, count, timeout, status, verify := "", "", "", "", "y"
+	if target.Port > 0 {
+		port = strconv.Itoa(target.Port)
+	}
+	if target.Count > 0 {
+		count = strconv.Itoa(target.Count)
+	}
+	if target.Timeout > 0 {
+		timeout = strconv.Itoa(int(target.Timeout / time.Second))
+	}
+	if target.ExpectStatus > 0 {
+		status = strconv.Itoa(target.ExpectStatus)
+	}
+	if target.Insecure {
+		verify = "n"
+	}
+
+	form := menu.NewForm(
+		menu.NewFormField(i18n.Translate("名称"), target.Name, nil),
+		menu.NewFormField(i18n.Translate("主机"), target.Host, menu.Optional(validateTargetHost)),
+		menu.NewFormField(i18n.Translate("端口"), port, menu.Optional(validateRange(1, 65535))),
+		menu.NewFormField(i18n.Translate("类型"), target.Type, validateTargetType),
+		menu.NewFormField(i18n.Translate("次数"), count, menu.Optional(validateRange(1, 100))),
+		menu.NewFormField(i18n.Translate("超时（秒）"), timeout, menu.Optional(validateRange(1, 60))),
+		menu.NewFormField("URL", target.URL, menu.Optional(validateTargetURL)),
+		menu.NewFormField(i18n.Translate("期望状态码"), status, menu.Optional(validateRange(100, 599))),
+		menu.NewFormField(i18n.Translate("校验证书(y/n)"), verify, validateYesNo),
+	)
+	page := menu.NewFormPage(title, form, func(nav *menu.Navigator, key byte, values []string) error {
+		if key != menu.ButtonOK.Key {
+			return nil
+		}
+		t := target.NetworkTestTarget
+		// 修改名称后说明随之改变，只有配置文件中单独写了说明时保留
+		if t.Description == t.Name {
+			t.Description = ""
+		}
+		t.Name, t.Host, t.Type = values[0], values[1], strings.ToLower(values[3])
+		t.Port, _ = strconv.Atoi(values[2])
+		t.Count, _ = strconv.Atoi(values[4])
+		seconds, _ := strconv.Atoi(values[5])
+		t.Timeout = time.Duration(seconds) * time.Second
+		t.URL = values[6]
+		t.ExpectStatus, _ = strconv.Atoi(values[7])
+		t.Insecure = strings.EqualFold(values[8], "n")
+		target.NetworkTestTarget = fillTargetNames(t)
+
+		if index >= 0 {
+			p.app.netTargets[index] = target
+		} else {
+			p.app.netTargets = append(p.app.networkTargets(), target)
+			p.list.Selected = len(p.app.netTargets) - 1
+		}
+		p.status.Text = i18n.Translatef("已保存测试目标 %s", target.Name)
+		p.update()
+		return nil
+	})
+	// 只有http目标可以只写URL，主机名从URL中取得；tcp目标必须指定端口
+	page.Validate = func(values []string) error {
+		typ := strings.ToLower(values[3])
+		if values[1] == "" && (typ != system.TargetHTTP || values[6] == "") {
+			return fmt.Errorf("%s", i18n.Translate("请输入主机名或IP地址"))
+		}
+		if typ == system.TargetTCP && values[2] == "" {
+			return fmt.Errorf("%s", i18n.Translate("tcp测试需要指定端口"))
+		}
+		return nil
+	}
+	return page
+}
+
+// validateTargetHost 检查测试目标的主机地址
+func validateTargetHost(text string) error {
+	if text == "" {
+		return fmt.Errorf("%s", i18n.Translate("请输入主机名或IP地址"))
+	}
+	if strings.ContainsAny(text, " /") {
+		return fmt.Errorf("%s", i18n.Translatef("%s 不是有效的主机名", text))
+	}
+	return nil
+}
+
+// validateTargetURL 检查http测试的URL
+func validateTargetURL(text string) error {
+	u, err := url.Parse(text)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s", i18n.Translatef("%s 不是有效的URL，应以http://或https://开头", text))
+	}
+	return nil
+}
+
+// validateYesNo 检查输入是否为y或n
+func validateYesNo(text string) error {
+	if !strings.EqualFold(text, "y") && !strings.EqualFold(text, "n") {
+		return fmt.Errorf("%s", i18n.Translate("请输入y或n"))
+	}
+	return nil
+}
+
+// validateTargetType 检查测试类型是否受支持
+func validateTargetType(text string) error {
+	for _, t := range system.NetworkTestTypes {
+		if strings.EqualFold(text, t) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s", i18n.Translatef("测试类型应为 %s", strings.Join(system.NetworkTestTypes, "、")))
+}
+
+// validateRange 返回检查输入是否为min到max之间整数的函数
+func validateRange(min, max int) func(string) error {
+	return func(text string) error {
+		n, err := strconv.Atoi(text)
+		if err != nil || n < min || n > max {
+			return fmt.Errorf("%s", i18n.Translatef("请输入%d到%d之间的整数", min, max))
+		}
+		return nil
+	}
+}
+
+// targetHosts 返回测试目标的主机地址
+func targetHosts(targets []system.NetworkTestTarget) []string {
+	hosts := make([]string, len(targets))
+	for i, t := range targets {
+		hosts[i] = t.Host
+	}
+	return hosts
+}
+
+// formatDNSResults 按DNS服务器分组格式化解析结果
+// 服务器行按解析成功的比例着色，各主机名一行，成功时列出耗时和第一个地址；目标都是IP地址时不显示
+func (app *Application) formatDNSResults(dns []system.DNSResult, dnsErr error) ([]string, []font.LineStyle) {
+	theme := app.menuRenderer.Theme()
+	dot := app.menuRenderer.StatusDot()
+	var lines []string
+	var styles []font.LineStyle
+	add := func(c color.Color, text string) {
+		lines = append(lines, text)
+		styles = append(styles, font.LineStyle{Color: c})
+	}
+
+	if dnsErr != nil {
+		add(theme.Error, i18n.Translatef("DNS解析: %v", dnsErr))
+		add(nil, "")
+		return lines, styles
+	}
+	if len(dns) == 0 {
+		return nil, nil
+	}
+	add(nil, i18n.Translate("DNS解析:"))
+	for start := 0; start < len(dns); {
+		end := start
+		ok := 0
+		for end < len(dns) && dns[end].Server == dns[start].Server {
+			if dns[end].Err == nil {
+				ok++
+			}
+			end++
+		}
+		c := theme.Success
+		switch {
+		case ok == 0:
+			c = theme.Error
+		case ok < end-start:
+			c = theme.Warning
+		}
+		add(c, i18n.Translatef("%s 服务器 %s: 成功 %d/%d", dot, dns[start].Server, ok, end-start))
+		for _, r := range dns[start:end] {
+			if r.Err != nil {
+				add(nil, i18n.Translatef("  %s: 失败: %v", r.Host, r.Err))
+			} else {
+				add(nil, fmt.Sprintf("  %s: %s → %s", r.Host, system.FormatRTT(r.Latency), r.Addrs[0]))
+			}
+		}
+		start = end
+	}
+	add(nil, "")
+	return lines, styles
+}
+
+// dnsDiagnosis 根据DNS解析结果给出诊断：全部解析失败时提示检查DNS设置，
+// 解析正常但有目标不通时提示问题在于防火墙或路由；不需要诊断时返回nil
+func (app *Application) dnsDiagnosis(results []system.NetworkTestResult, dns []system.DNSResult, dnsErr error) *menu.Label {
+	theme := app.menuRenderer.Theme()
+	if dnsErr != nil {
+		return &menu.Label{Text: i18n.Translatef("无法测试DNS解析: %v", dnsErr), Color: theme.Warning}
+	}
+	if len(dns) == 0 {
+		return nil
+	}
+	ok := 0
+	for _, r := range dns {
+		if r.Err == nil {
+			ok++
+		}
+	}
+	if ok == 0 {
+		return &menu.Label{Text: i18n.Translate("DNS解析全部失败，请检查DNS服务器设置"), Color: theme.Error}
+	}
+	for _, r := range results {
+		if !r.Success {
+			return &menu.Label{Text: i18n.Translate("DNS解析正常，无法访问的目标可能被防火墙或路由阻断"), Color: theme.Warning}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,507 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go-framebuffer-console/internal/config"
+	"go-framebuffer-console/pkg/font"
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/input"
+	"go-framebuffer-console/pkg/menu"
+	"go-framebuffer-console/pkg/system"
+)
+
+// mainPage 首页：显示系统信息，按main_refresh的间隔（默认5秒）自动刷新；回车或点击进入配置菜单
+type mainPage struct {
+	app     *Application
+	entered bool               // 是否已经显示过首页，用于区分启动和从其它页面返回
+	info    *system.SystemInfo // 启动画面期间预先获取的系统信息，首次绘制时使用
+}
+
+// Render 获取系统信息并绘制首页
+func (p *mainPage) Render(mr *menu.MenuRenderer) error {
+	sysInfo := p.info
+	p.info = nil
+	if sysInfo == nil {
+		var err error
+		if sysInfo, err = system.GetSystemInfo(); err != nil {
+			return fmt.Errorf("failed to get system info: %v", err)
+		}
+	}
+	// 与首页的CPU使用率曲线使用同一个采样器的结果，各处获取系统信息不会互相影响
+	sysInfo.CPUUsage = p.app.cpuUsage
+	return mr.RenderMainMenu(sysInfo)
+}
+
+// Hints 页脚的按键提示
+func (p *mainPage) Hints() []menu.Hint {
+	return []menu.Hint{{Key: "Enter", Text: i18n.Translate("菜单")}}
+}
+
+// Help 说明首页各项系统信息、告警和二维码的含义
+func (p *mainPage) Help() []menu.HelpItem {
+	return []menu.HelpItem{
+		{Name: i18n.Translate("主机名"), Text: i18n.Translate("设备的主机名，联系技术客服时可据此确认设备")},
+		{Name: i18n.Translate("操作系统"), Text: i18n.Translate("/etc/os-release中的发行版名称、内核版本（uname -r）和硬件架构（uname -m）")},
+		{Name: i18n.Translate("操作系统运行时间"), Text: i18n.Translate("系统启动以来经过的时间")},
+		{Name: i18n.Translate("处理器型号"), Text: i18n.Translate("CPU型号和逻辑核数")},
+		{Name: i18n.Translate("CPU使用率"), Text: i18n.Translate("最近一次采样（每5秒）的CPU使用率，即使用率曲线的最后一个点，颜色规则与内存相同")},
+		{Name: i18n.Translate("内存使用状态"), Text: i18n.Translate("已用和总内存，有交换空间时附上交换空间的使用量；进度条只按内存计算，达到70%显示警告色，达到90%显示错误色")},
+		{Name: i18n.Translate("根分区使用状态"), Text: i18n.Translate("根分区已用和总容量，颜色规则与内存相同")},
+		{Name: i18n.Translate("系统安装磁盘大小"), Text: i18n.Translate("系统所在磁盘的容量和磁盘个数")},
+		{Name: i18n.Translate("当前系统时间"), Text: i18n.Translate("括号中为chrony、ntpd或timedatectl报告的同步状态和偏差，时钟未同步时显示警告色")},
+		{Name: i18n.Translate("设备IP地址"), Text: i18n.Translate("默认路由所在网卡的IPv4地址")},
+		{Name: i18n.Translate("设备ID"), Text: i18n.Translate("联系技术客服时提供的设备标识")},
+		{Name: i18n.Translate("告警数"), Text: i18n.Translate("状态栏中超过[alerts]阈值的指标数，与告警横幅的条数相同")},
+		{Name: i18n.Translate("告警横幅"), Text: i18n.Translate("根分区、内存或温度超过[alerts]中的阈值时显示")},
+		{Name: i18n.Translate("二维码"), Text: i18n.Translate("内容由[qrcode]中的模板决定，默认为设备ID")},
+	}
+}
+
+// HandleKey 按下回车键进入配置菜单，其它按键忽略
+func (p *mainPage) HandleKey(nav *menu.Navigator, ev input.KeyEvent) error {
+	if ev.Code != input.KeyEnter {
+		return nil
+	}
+	log.Printf("检测到回车键，进入配置菜单")
+	return p.app.openConfigMenu(nav)
+}
+
+// OnEnter 回到首页时恢复自动刷新，并强制完整重绘；管理员模式在回到首页时结束
+func (p *mainPage) OnEnter(nav *menu.Navigator) error {
+	p.app.setRunning(true)
+	p.app.pin.lock()
+	if p.entered {
+		p.app.menuRenderer.InvalidateCache()
+		log.Printf("已返回首页，恢复主界面自动刷新")
+	}
+	p.entered = true
+	return nil
+}
+
+// Refresh 重新获取系统信息：使缓存失效，重绘时重新读取
+func (p *mainPage) Refresh(nav *menu.Navigator) error {
+	p.app.menuRenderer.InvalidateCache()
+	nav.Invalidate()
+	return nil
+}
+
+// RefreshInterval 返回配置文件中main_refresh指定的自动刷新间隔
+func (p *mainPage) RefreshInterval() time.Duration {
+	return time.Duration(p.app.config.MainRefresh) * time.Second
+}
+
+// OnExit 离开首页时暂停自动刷新
+func (p *mainPage) OnExit(nav *menu.Navigator) {
+	p.app.setRunning(false)
+	log.Printf("已离开首页，暂停主界面自动刷新")
+}
+
+// screensaverPage 屏幕保护：大号时钟和设备IP，位置每10秒移动一步；任意键返回首页
+// 两次移动之间每秒只重绘时钟中变化的数字，不清屏，避免闪烁
+type screensaverPage struct {
+	menu.BasePage
+	menu.FullScreen
+	ip    string      // 进入屏幕保护时获取的设备IP
+	clock *menu.Clock // 大号时钟
+	phase int         // 当前的位置序号
+	date  string      // 时钟下方显示的日期，日期变化时整屏重绘
+}
+
+// screensaverShift 屏幕保护内容每次移动的间隔
+const screensaverShift = 10 * time.Second
+
+// screensaverPhase 返回now时屏幕保护内容的位置序号
+func screensaverPhase(now time.Time) int {
+	return int(now.Unix() / int64(screensaverShift/time.Second))
+}
+
+// Render 在当前位置绘制时钟、日期和设备IP
+func (p *screensaverPage) Render(mr *menu.MenuRenderer) error {
+	now := time.Now()
+	if p.clock == nil {
+		p.clock = menu.NewClock(true)
+	}
+	p.phase = screensaverPhase(now)
+	p.date = now.Format("2006-01-02")
+	return mr.RenderScreensaver(p.clock, p.date+"  "+p.ip, p.phase)
+}
+
+// Tick 到了移动的时间或日期变化时整屏重绘，否则只更新时钟
+func (p *screensaverPage) Tick(nav *menu.Navigator, now time.Time) error {
+	if screensaverPhase(now) != p.phase || now.Format("2006-01-02") != p.date {
+		nav.Invalidate()
+		return nil
+	}
+	p.clock.Tick(now)
+	return nil
+}
+
+// HandleKey 任意键返回首页，按键本身不再生效
+func (p *screensaverPage) HandleKey(nav *menu.Navigator, ev input.KeyEvent) error {
+	return nav.PopToRoot()
+}
+
+// OnEnter 获取设备IP，屏幕保护期间不再重复获取
+func (p *screensaverPage) OnEnter(nav *menu.Navigator) error {
+	p.ip = system.GetIPAddress()
+	return nil
+}
+
+// openConfigMenu 进入配置菜单
+// 菜单页面只创建一次，再次进入时高亮条停留在上次选择的选项上；
+// 配置文件中有[menu_item]段落时按配置生成选项，否则使用内置的菜单
+func (app *Application) openConfigMenu(nav *menu.Navigator) error {
+	if app.configMenu == nil {
+		if len(app.config.Menu) > 0 {
+			app.configMenu = menu.NewMenuPage(i18n.Translate("配置菜单"), i18n.Translate("方向键选择，回车确认，或按快捷键；按q返回首页"), app.configuredMenuItems()...)
+		} else {
+			items := []menu.MenuItem{
+				{Text: i18n.Translate("1. 查看网卡信息"), Key: '1', Action: app.showNetworkInfo},
+				{Text: i18n.Translate("2. 系统服务管理"), Key: '2', Action: app.showServices},
+				{Text: i18n.Translate("3. 检测设备网络"), Key: '3', Action: app.testNetworkConnectivity},
+				{Text: i18n.Translate("4. 重启设备"), Key: '4', Action: app.protectAction("reboot", "重启设备", app.confirmAndReboot)},
+				{Text: i18n.Translate("5. 关机"), Key: '5', Action: app.protectAction("shutdown", "关机", app.confirmAndShutdown)},
+				{Text: i18n.Translate("6. 切换字体"), Key: '6', Action: app.switchFont},
+				{Text: i18n.Translate("7. 仪表盘"), Key: '7', Action: app.showDashboard},
+				{Text: i18n.Translate("8. 查看日志"), Key: '8', Action: app.showLogs},
+			}
+			// 配置了管理地址或无线网络时增加扫码页面
+			if app.config.QRCode.AdminURL != "" || app.config.QRCode.WiFiSSID != "" {
+				items = append(items, menu.MenuItem{Text: i18n.Translate("9. 扫码"), Key: '9', Action: app.showQRCodes})
+			}
+			// 硬件传感器、磁盘使用情况、设备信息、重启系统服务和进程列表接着编号，超过9项后只能用方向键选择
+			items = append(items, numberedMenuItem(len(items)+1, "硬件传感器", app.showSensors))
+			items = append(items, numberedMenuItem(len(items)+1, "磁盘使用情况", app.showDisks))
+			items = append(items, numberedMenuItem(len(items)+1, "设备信息", app.showHardware))
+			items = append(items, numberedMenuItem(len(items)+1, "重启系统服务", app.showRestartMenu))
+			items = append(items, numberedMenuItem(len(items)+1, "进程列表", app.showProcesses))
+			items = append(items, numberedMenuItem(len(items)+1, "网络测试目标", app.showNetworkTargets))
+			// 找到docker或podman的套接字时增加容器页面
+			if _, err := system.FindContainerRuntime(app.config.Containers.Socket); err == nil {
+				items = append(items, numberedMenuItem(len(items)+1, "容器", app.showContainers))
+			}
+			// 配置了测速地址时增加测速
+			if app.speedTestEnabled() {
+				items = append(items, numberedMenuItem(len(items)+1, "测速", app.runSpeedTest))
+			}
+			// 通过menu.RegisterPage登记的页面追加在末尾
+			if pages := app.registeredMenuItems(len(items)); len(pages) > 0 {
+				items = append(items, pages...)
+			}
+			app.configMenu = menu.NewMenuPage(i18n.Translate("配置菜单"), i18n.Translate("方向键选择，回车确认，或按快捷键；按q返回首页"), items...)
+		}
+	}
+	return nav.Push(app.configMenu)
+}
+
+// messagePage 创建提示信息页面，按任意键返回上一页
+// 参数level: 消息级别，决定消息框的颜色和图标
+func (app *Application) messagePage(level menu.MessageLevel, message string) menu.Page {
+	return menu.NewLevelMessagePage(level, message+"\n\n"+i18n.Translate("按任意键继续"))
+}
+
+// networkInterfaces 获取网卡信息，并附上各网卡最近的流量
+func (app *Application) networkInterfaces() ([]system.NetworkInterface, error) {
+	interfaces, err := system.GetNetworkInterfaces()
+	if err != nil {
+		return nil, fmt.Errorf("%s", i18n.Translatef("获取网卡信息失败: %v", err))
+	}
+	for i := range interfaces {
+		interfaces[i].Traffic = app.bandwidth.History(interfaces[i].Name)
+	}
+	return interfaces, nil
+}
+
+// showNetworkInfo 显示网卡信息页面，按network_refresh的间隔或按F5、r时重新获取
+func (app *Application) showNetworkInfo(nav *menu.Navigator) error {
+	interfaces, err := app.networkInterfaces()
+	if err != nil {
+		return nav.Push(app.messagePage(menu.LevelError, err.Error()))
+	}
+	page := menu.NewNetworkInfoPage(interfaces, app.config.NICsPerPage)
+	page.Reload = app.networkInterfaces
+	page.Settings = system.GetNetworkSettings
+	page.Interval = time.Duration(app.config.NetRefresh) * time.Second
+	return nav.Push(page)
+}
+
+// showQRCodes 显示扫码页面：首页的二维码、网页管理界面地址和连接无线网络的二维码
+// 模板用到的内容无法获取的二维码记录日志后不显示
+func (app *Application) showQRCodes(nav *menu.Navigator) error {
+	sysInfo, err := system.GetSystemInfo()
+	if err != nil {
+		log.Printf("获取系统信息失败: %v", err)
+		sysInfo = &system.SystemInfo{}
+	}
+	qc := app.config.QRCode
+
+	var codes []menu.QRCodeEntry
+	// add 展开模板并添加一个二维码，showText为true时在二维码上方显示编码的内容（如网址）
+	add := func(title, tmpl string, showText bool) {
+		content, err := app.menuRenderer.ExpandQRTemplate(tmpl, sysInfo)
+		if err != nil {
+			log.Printf("无法生成%s二维码: %v", title, err)
+			return
+		}
+		entry := menu.QRCodeEntry{Title: title, Content: content}
+		if showText {
+			entry.Text = content
+		}
+		codes = append(codes, entry)
+	}
+	add("设备二维码", qc.Content, false)
+	if qc.AdminURL != "" {
+		add("管理页面", qc.AdminURL, true)
+	}
+	if qc.WiFiSSID != "" {
+		wifi := menu.WiFiQRContent(qc.WiFiSSID, qc.WiFiPassword, qc.WiFiSecurity, qc.WiFiHidden)
+		codes = append(codes, menu.QRCodeEntry{Title: "连接无线网络", Content: wifi, Text: i18n.Translatef("网络名称：%s", qc.WiFiSSID)})
+	}
+	return nav.Push(menu.NewQRCodesPage(codes))
+}
+
+// testNetworkConnectivity 执行网络连通性测试并显示结果
+// 测试期间显示带动画的忙碌画面，不经过导航栈；完成后压入结果页面
+func (app *Application) testNetworkConnectivity(nav *menu.Navigator) error {
+	targets := app.selectedNetworkTargets()
+	if len(targets) == 0 {
+		return nav.Push(app.messagePage(menu.LevelWarning, i18n.Translate("没有选中的测试目标，请在\"网络测试目标\"中选择")))
+	}
+
+	// 显示开始测试的消息，ping等待期间由后台goroutine播放动画
+	busy := app.menuRenderer.StartBusy(i18n.Translate("网络连通性测试"), i18n.Translate("正在初始化网络连通性测试...\n\n请稍候..."))
+
+	// 创建进度回调函数
+	progressCallback := func(target string, current, total int, message string) {
+		busy.SetMessage(i18n.Translatef("网络连通性测试进度: %d/%d\n\n当前测试: %s\n%s", current, total, target, message))
+	}
+
+	// 测试"网络测试目标"页面中选中的目标
+	results, err := system.TestNetworkTargets(targets, progressCallback)
+	if err != nil {
+		busy.Stop()
+		return nav.Push(menu.NewLevelMessagePage(menu.LevelError, i18n.Translatef("网络测试执行失败: %v", err)+"\n\n"+i18n.Translate("按任意键返回")))
+	}
+
+	// 再用各DNS服务器解析目标的主机名，区分DNS故障和目标不通
+	busy.SetMessage(i18n.Translate("正在测试DNS解析...\n\n请稍候..."))
+	dns, dnsErr := system.TestDNS(targetHosts(targets), nil)
+	busy.Stop()
+
+	// 格式化并显示测试结果：总结在上方，各目标的详情可以滚动查看
+	resultLines, resultStyles := app.formatNetworkTestResults(results, dns, dnsErr)
+	return nav.Push(menu.NewTextPage(func(mr *menu.MenuRenderer) *menu.Layout {
+		layout := mr.NewLayout(&menu.Label{Text: i18n.Translate("网络连通性测试结果"), Color: mr.Theme().Accent}, menu.NewSeparator())
+		for _, label := range app.networkTestSummary(results) {
+			layout.Add(label)
+		}
+		if label := app.dnsDiagnosis(results, dns, dnsErr); label != nil {
+			layout.Add(label)
+		}
+		return layout.Add(menu.NewSeparator(), menu.NewScrollView(resultLines, resultStyles))
+	}))
+}
+
+// networkTestSummary 返回网络测试结果的总结：全部正常、部分异常和全部异常时两行都分别使用主题的正常、警告和错误颜色
+func (app *Application) networkTestSummary(results []system.NetworkTestResult) []*menu.Label {
+	theme := app.menuRenderer.Theme()
+	successCount := 0
+	for _, result := range results {
+		if result.Success && result.PacketLoss == 0 {
+			successCount++
+		}
+	}
+
+	switch {
+	case successCount == len(results):
+		return []*menu.Label{
+			{Text: i18n.Translate("✓ 网络连接状态: 良好"), Color: theme.Success},
+			{Text: i18n.Translate("所有测试目标均可正常访问"), Color: theme.Success},
+		}
+	case successCount > 0:
+		return []*menu.Label{
+			{Text: i18n.Translate("⚠ 网络连接状态: 部分异常"), Color: theme.Warning},
+			{Text: i18n.Translatef("可访问 %d/%d 个测试目标", successCount, len(results)), Color: theme.Warning},
+		}
+	}
+	return []*menu.Label{
+		{Text: i18n.Translate("✗ 网络连接状态: 异常"), Color: theme.Error},
+		{Text: i18n.Translate("所有测试目标均无法访问"), Color: theme.Error},
+	}
+}
+
+// formatNetworkTestResults 格式化各测试目标的结果，之后是DNS解析的结果
+// 返回结果文本行以及对应的行样式：正常、部分正常和异常的目标分别使用主题的正常、警告和错误颜色
+func (app *Application) formatNetworkTestResults(results []system.NetworkTestResult, dns []system.DNSResult, dnsErr error) ([]string, []font.LineStyle) {
+	theme := app.menuRenderer.Theme()
+	colorSuccess, colorWarning, colorFailure := theme.Success, theme.Warning, theme.Error
+	dot := app.menuRenderer.StatusDot()
+	var lines []string
+	var styles []font.LineStyle
+	add := func(c color.Color, format string, args ...interface{}) {
+		lines = append(lines, i18n.Translatef(format, args...))
+		styles = append(styles, font.LineStyle{Color: c})
+	}
+
+	for _, result := range results {
+		// 状态显示
+		status := i18n.Translate("异常")
+		statusColor := colorFailure
+		if result.Success && result.PacketLoss == 0 {
+			status = i18n.Translate("正常")
+			statusColor = colorSuccess
+		} else if result.Success && result.PacketLoss > 0 {
+			status = i18n.Translate("部分正常")
+			statusColor = colorWarning
+		}
+
+		add(statusColor, "%s %s (%s):", dot, result.Target.Name, result.Target.Address())
+		add(statusColor, "  状态: %s", status)
+
+		if result.Target.Type == system.TargetHTTP {
+			if result.Target.URL != "" {
+				add(nil, "  URL: %s", result.Target.URL)
+			}
+			if result.HTTP != nil {
+				add(nil, "  状态码: %s", result.HTTP.Status())
+				add(nil, "  响应时间: %s", result.AvgLatency)
+				if !result.HTTP.CertExpiry.IsZero() {
+					add(nil, "  证书到期: %s（剩余%d天）", result.HTTP.CertExpiry.Local().Format("2006-01-02"), result.HTTP.CertDaysLeft())
+				}
+			}
+		} else if result.Target.Type == system.TargetTCP {
+			if result.Ping != nil {
+				add(nil, "  连接: 尝试%d 成功%d 失败%.1f%%", result.PacketsSent, result.PacketsRecv, result.PacketLoss)
+			}
+			if result.PacketsRecv > 0 {
+				min, _, max := result.Ping.RTTStats()
+				add(nil, "  连接耗时: 平均%s，最小/最大 %s / %s", result.AvgLatency, system.FormatRTT(min), system.FormatRTT(max))
+			}
+		} else if result.Success || result.PacketsRecv > 0 {
+			add(nil, "  数据包: 发送%d 接收%d 丢失%.1f%%",
+				result.PacketsSent, result.PacketsRecv, result.PacketLoss)
+			if result.AvgLatency != "N/A" && result.AvgLatency != "" {
+				add(nil, "  平均延迟: %s", result.AvgLatency)
+			}
+			if result.Ping != nil && result.PacketsRecv > 0 {
+				min, _, max := result.Ping.RTTStats()
+				add(nil, "  最小/最大延迟: %s / %s，抖动: %s", system.FormatRTT(min), system.FormatRTT(max), system.FormatRTT(result.Ping.Jitter()))
+				add(nil, "  各包往返时间: %s", pingReplyText(result.Ping.Replies))
+			}
+		}
+
+		if result.ErrorMsg != "" {
+			add(nil, "  详情: %s", result.ErrorMsg)
+		}
+		add(nil, "")
+	}
+
+	dnsLines, dnsStyles := app.formatDNSResults(dns, dnsErr)
+	lines, styles = append(lines, dnsLines...), append(styles, dnsStyles...)
+
+	add(nil, "按任意键返回")
+	return lines, styles
+}
+
+// pingReplyText 把各数据包的往返时间连成一行，没有收到回复的数据包显示为"超时"
+func pingReplyText(replies []system.PingReply) string {
+	parts := make([]string, len(replies))
+	for i, reply := range replies {
+		parts[i] = i18n.Translate("超时")
+		if reply.Received {
+			parts[i] = system.FormatRTT(reply.RTT)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (app *Application) confirmAndReboot(nav *menu.Navigator) error {
+	dialog := menu.NewConfirmDialog(i18n.Translate("重启设备"), i18n.Translate("确认要重启设备吗？\n\n按y确认重启，按n或ESC取消"))
+	return nav.Push(menu.NewDialogPage(dialog, func(nav *menu.Navigator, key byte, _ string) error {
+		if key != menu.ButtonOK.Key {
+			return nil // 选择取消时返回配置菜单
+		}
+		return nav.Push(app.powerCountdown("重启设备", "将在 %d 秒后重启设备", "正在重启设备...", system.RebootSystem))
+	}))
+}
+
+func (app *Application) confirmAndShutdown(nav *menu.Navigator) error {
+	dialog := menu.NewConfirmDialog(i18n.Translate("关机"), i18n.Translate("确认要关机吗？\n\n按y确认关机，按n或ESC取消"))
+	return nav.Push(menu.NewDialogPage(dialog, func(nav *menu.Navigator, key byte, _ string) error {
+		if key != menu.ButtonOK.Key {
+			return nil // 选择取消时返回配置菜单
+		}
+		return nav.Push(app.powerCountdown("关机", "将在 %d 秒后关机", "正在关机...", system.ShutdownSystem))
+	}))
+}
+
+// powerCountdownSeconds 确认重启或关机后的倒计时秒数
+const powerCountdownSeconds = 10
+
+// powerCountdown 创建重启或关机前的倒计时页面，倒计时结束后执行操作，期间按任意键取消并返回配置菜单
+// 参数title: 操作名称，如"重启设备"
+// 参数message: 倒计时消息格式，含一个%d
+// 参数progress: 开始执行时显示的消息
+// 参数action: 要执行的操作
+func (app *Application) powerCountdown(title, message, progress string, action func() error) *menu.CountdownPage {
+	page := menu.NewCountdownPage(i18n.Translate(title), message, powerCountdownSeconds, func(nav *menu.Navigator) error {
+		log.Printf("倒计时结束，%s", title)
+		if err := app.menuRenderer.RenderLevelMessage(menu.LevelWarning, i18n.Translate(progress)); err != nil {
+			return err
+		}
+		return action()
+	})
+	page.OnCancel = func(nav *menu.Navigator) error {
+		log.Printf("已取消%s", title)
+		return nil
+	}
+	return page
+}
+
+// switchFont 显示可用字体列表，选择后在运行时切换字体
+// TTC字体集合中的每个字体各占一项，如"NotoSansCJK.ttc #2"
+func (app *Application) switchFont(nav *menu.Navigator) error {
+	items := []menu.MenuItem{{Text: i18n.Translate("0. 内置字体"), Key: '0', Action: func(nav *menu.Navigator) error {
+		return app.loadFont(nav, "内置字体", app.fontRenderer.LoadEmbeddedFont)
+	}}}
+	for _, path := range config.ListFontFiles(config.DefaultFontDir) {
+		count := font.FileFaceCount(path)
+		for index := 0; index < max(count, 1); index++ {
+			if len(items) > 8 {
+				break // 只能用单个数字键选择
+			}
+			path, index := path, index
+			name := filepath.Base(path)
+			if count > 1 {
+				name = fmt.Sprintf("%s #%d", name, index)
+			}
+			items = append(items, menu.MenuItem{
+				Text: fmt.Sprintf("%d. %s", len(items), name),
+				Key:  byte('0' + len(items)),
+				Action: func(nav *menu.Navigator) error {
+					return app.loadFont(nav, name, func() error { return app.fontRenderer.LoadFont(path, index) })
+				},
+			})
+		}
+	}
+	return nav.Push(menu.NewMenuPage(i18n.Translate("切换字体"), i18n.Translate("方向键选择，回车确认；按q返回"), items...))
+}
+
+// loadFont 切换字体并用结果页面替换字体列表，按任意键回到配置菜单
+// 参数name: 字体名称，用于日志
+// 参数load: 加载字体的函数
+func (app *Application) loadFont(nav *menu.Navigator, name string, load func() error) error {
+	if err := load(); err != nil {
+		return nav.Replace(app.messagePage(menu.LevelError, i18n.Translatef("切换字体失败: %v", err)))
+	}
+
+	log.Printf("已切换字体: %s", name)
+	app.menuRenderer.InvalidateCache()
+	return nav.Replace(app.messagePage(menu.LevelSuccess, i18n.Translate("字体切换成功")))
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"crypto/subtle"
+	"errors"
+	"log"
+	"time"
+
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/menu"
+)
+
+// 管理员PIN的输入限制
+const (
+	pinMaxLength   = 12               // PIN最多的位数，数字键盘输入框的长度
+	pinMaxAttempts = 3                // 连续输错多少次后暂时锁定
+	pinLockout     = 30 * time.Second // 锁定的时长
+)
+
+// pinGuard 管理员PIN的验证状态，零值即可使用，只在主循环中访问
+// 输入正确后进入管理员模式，之后的受保护操作不再询问PIN，直到返回首页
+type pinGuard struct {
+	failures int       // 连续输错的次数
+	locked   time.Time // 锁定结束的时间
+	unlocked bool      // 是否已进入管理员模式
+}
+
+// lock 退出管理员模式，返回首页时调用
+func (g *pinGuard) lock() {
+	if g.unlocked {
+		log.Printf("已退出管理员模式")
+	}
+	g.unlocked = false
+}
+
+// check 检查输入的PIN
+// 参数expected: 配置文件中的PIN
+// 参数pin: 输入的PIN
+// 返回不通过的原因，锁定期间即使输入正确也不通过
+func (g *pinGuard) check(expected, pin string) error {
+	if !validPin(expected) {
+		log.Printf("管理员PIN配置无效，应为1-%d位数字", pinMaxLength)
+		return errors.New(i18n.Translate("管理员PIN配置无效，请检查配置文件"))
+	}
+	if wait := time.Until(g.locked); wait > 0 {
+		return errors.New(i18n.Translatef("输错次数过多，请%d秒后再试", int(wait.Seconds())+1))
+	}
+	if subtle.ConstantTimeCompare([]byte(pin), []byte(expected)) != 1 {
+		g.failures++
+		log.Printf("管理员PIN输入错误（连续%d次）", g.failures)
+		if g.failures >= pinMaxAttempts {
+			g.failures = 0
+			g.locked = time.Now().Add(pinLockout)
+			return errors.New(i18n.Translatef("输错次数过多，请%d秒后再试", int(pinLockout.Seconds())))
+		}
+		return errors.New(i18n.Translatef("PIN错误，还可以再试%d次", pinMaxAttempts-g.failures))
+	}
+	g.failures = 0
+	g.unlocked = true
+	log.Printf("管理员PIN验证通过，进入管理员模式")
+	return nil
+}
+
+// validPin 检查PIN是否只由数字组成且不超过最大位数
+func validPin(pin string) bool {
+	if pin == "" || len(pin) > pinMaxLength {
+		return false
+	}
+	for i := 0; i < len(pin); i++ {
+		if pin[i] < '0' || pin[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// requirePin 返回先输入管理员PIN再执行action的菜单动作，已进入管理员模式时直接执行
+// 参数title: 操作名称，显示为PIN输入框的标题
+// 参数action: PIN正确后执行的动作
+func (app *Application) requirePin(title string, action func(nav *menu.Navigator) error) func(nav *menu.Navigator) error {
+	return func(nav *menu.Navigator) error {
+		if app.pin.unlocked {
+			return action(nav)
+		}
+		check := func(pin string) error {
+			return app.pin.check(app.config.Pin, pin)
+		}
+		return nav.Push(menu.NewPinPage(i18n.Translate(title), pinMaxLength, check, action))
+	}
+}
+
+// protectAction 内置功能name需要PIN时返回加上PIN验证的菜单动作，否则原样返回
+// 参数name: 内置功能的名称，如reboot
+// 参数title: 操作名称
+// 参数action: 菜单动作
+func (app *Application) protectAction(name, title string, action func(nav *menu.Navigator) error) func(nav *menu.Navigator) error {
+	if !app.config.RequiresPin(name) {
+		return action
+	}
+	return app.requirePin(title, action)
+}
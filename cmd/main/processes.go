@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/input"
+	"go-framebuffer-console/pkg/menu"
+	"go-framebuffer-console/pkg/system"
+)
+
+// 进程列表页面的参数
+const (
+	processRefresh = 3 * time.Second // 重新采样进程的间隔，也是CPU占用的统计周期
+	processRows    = 15              // 显示的进程数
+)
+
+// processesPage 进程列表页面
+// 以表格列出CPU或内存占用最高的进程，每隔processRefresh自动刷新；按m、c切换排序方式，其他键返回
+type processesPage struct {
+	menu.BasePage
+	order     system.ProcessOrder
+	processes []system.Process
+	err       error
+}
+
+// showProcesses 显示进程列表页面
+func (app *Application) showProcesses(nav *menu.Navigator) error {
+	return nav.Push(&processesPage{})
+}
+
+// OnEnter 采样进程，距上次采样较久时第一次显示的CPU占用为这段时间内的平均值
+func (p *processesPage) OnEnter(nav *menu.Navigator) error {
+	p.processes, p.err = system.GetTopProcessesBy(processRows, p.order)
+	return nil
+}
+
+// Render 绘制进程表格，僵尸进程和不可中断的进程以警告色显示
+func (p *processesPage) Render(mr *menu.MenuRenderer) error {
+	theme := mr.Theme()
+	title := i18n.Translate("进程（按CPU排序）")
+	if p.order == system.OrderByMemory {
+		title = i18n.Translate("进程（按内存排序）")
+	}
+	layout := mr.NewLayout(&menu.Label{Text: title, Color: theme.Accent}, menu.NewSeparator())
+	if p.err != nil {
+		return mr.RenderLayout(layout.Add(&menu.Label{Text: p.err.Error(), Color: theme.Error}))
+	}
+
+	table := menu.NewTable("PID", i18n.Translate("名称"), i18n.Translate("状态"), i18n.Translate("线程"), "CPU", i18n.Translate("内存"))
+	for _, i := range []int{0, 3, 4, 5} {
+		table.Columns[i].Align = menu.AlignRight
+	}
+	for _, proc := range p.processes {
+		row := []string{
+			fmt.Sprint(proc.PID),
+			proc.Name,
+			proc.State,
+			fmt.Sprint(proc.Threads),
+			fmt.Sprintf("%.1f%%", proc.CPU),
+			system.FormatBytes(proc.RSS),
+		}
+		if proc.State == "Z" || proc.State == "D" {
+			table.AddColoredRow(menu.LevelWarning.Color(), row...)
+		} else {
+			table.AddRow(row...)
+		}
+	}
+	return mr.RenderLayout(layout.Add(table))
+}
+
+// Refresh 重新采样进程并重绘
+func (p *processesPage) Refresh(nav *menu.Navigator) error {
+	p.processes, p.err = system.GetTopProcessesBy(processRows, p.order)
+	nav.Invalidate()
+	return nil
+}
+
+// RefreshInterval 每隔processRefresh自动刷新一次
+func (p *processesPage) RefreshInterval() time.Duration {
+	return processRefresh
+}
+
+// Hints 页脚的按键提示
+func (p *processesPage) Hints() []menu.Hint {
+	return []menu.Hint{
+		{Key: "c", Text: i18n.Translate("按CPU排序")},
+		{Key: "m", Text: i18n.Translate("按内存排序")},
+		{Key: i18n.Translate("其他键"), Text: i18n.Translate("返回")},
+	}
+}
+
+// Help 说明各列的含义
+func (p *processesPage) Help() []menu.HelpItem {
+	return []menu.HelpItem{
+		{Name: "CPU", Text: i18n.Translate("上次刷新以来占用所有CPU时间的百分比，所有进程合计不超过100%")},
+		{Name: i18n.Translate("内存"), Text: i18n.Translate("进程的常驻内存（RSS），内核线程为0")},
+		{Name: i18n.Translate("状态"), Text: i18n.Translate("R运行、S睡眠、D不可中断（通常在等待磁盘）、Z僵尸；D和Z以警告色显示")},
+	}
+}
+
+// HandleKey 按c、m切换排序方式并立即重新采样，其他键返回上一页
+func (p *processesPage) HandleKey(nav *menu.Navigator, ev input.KeyEvent) error {
+	switch ev.Byte() {
+	case 'c', 'C':
+		p.order = system.OrderByCPU
+	case 'm', 'M':
+		p.order = system.OrderByMemory
+	default:
+		return nav.Pop()
+	}
+	return p.Refresh(nav)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"go-framebuffer-console/pkg/input"
+)
+
+// TestReplaySwitchFont 回放testdata中录制的按键，检查最后停留的页面
+// 回放结束后主循环等待replaySettle再退出，与-replay运行时的流程相同
+func TestReplaySwitchFont(t *testing.T) {
+	const path = "testdata/switch_font.keys"
+	keys, err := input.LoadRecording(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyboard := input.NewReplayKeyboardInput(keys, 0)
+	t.Cleanup(func() { keyboard.Close() })
+
+	app := newTestApplication(t, keyboard)
+	app.opts.replayPath = path
+	app.replayDone = keyboard.ReplayDone()
+	var mirror strings.Builder
+	app.menuRenderer.SetTextMirror(&mirror)
+	if err := app.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 首页、配置菜单，以及取代字体列表的结果页面
+	if depth := app.nav.Depth(); depth != 3 {
+		t.Errorf("导航栈深度为%d，期望为3", depth)
+	}
+	screens := strings.Split(mirror.String(), "\033[2J")
+	if last := screens[len(screens)-1]; !strings.Contains(last, "字体切换成功") {
+		t.Errorf("最后的页面不是切换成功的消息:\n%s", last)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/menu"
+	"go-framebuffer-console/pkg/system"
+)
+
+// restartJournalLines 重启服务失败时显示的最近日志行数
+const restartJournalLines = 10
+
+// restartTarget "重启系统服务"菜单中的一个选项
+type restartTarget struct {
+	action string // 内置功能的名称，[menu_item]的action和pin_actions使用
+	title  string // 选项名称
+	unit   string // 重启的systemd服务，为空时不显示该选项
+}
+
+// restartTargets 返回可以重启的网络、SSH和防火墙服务，服务名称在[services]段落中配置
+func (app *Application) restartTargets() []restartTarget {
+	s := app.config.Services
+	return []restartTarget{
+		{"restart_network", "重启网络服务", s.NetworkUnit},
+		{"restart_ssh", "重启SSH服务", s.SSHUnit},
+		{"restart_firewall", "重启防火墙", s.FirewallUnit},
+	}
+}
+
+// showRestartMenu 显示"重启系统服务"菜单，列出配置了服务名称的选项，各选项按pin_actions决定是否需要PIN
+func (app *Application) showRestartMenu(nav *menu.Navigator) error {
+	var items []menu.MenuItem
+	for _, t := range app.restartTargets() {
+		if t.unit == "" {
+			continue
+		}
+		items = append(items, numberedMenuItem(len(items)+1, t.title, app.protectAction(t.action, t.title, app.restartService(t.title, t.unit))))
+	}
+	if len(items) == 0 {
+		return nav.Push(app.messagePage(menu.LevelInfo, i18n.Translate("没有配置要重启的服务")))
+	}
+	return nav.Push(menu.NewMenuPage(i18n.Translate("重启系统服务"), i18n.Translate("方向键选择，回车确认，或按快捷键；按q返回"), items...))
+}
+
+// restartService 返回确认后重启服务的菜单动作
+// 重启期间显示忙碌画面，完成后显示服务当前的状态；失败时显示原因和服务最近的日志
+// 参数title: 操作名称，如"重启网络服务"
+// 参数unit: systemd服务名称，为空时提示没有配置
+func (app *Application) restartService(title, unit string) func(nav *menu.Navigator) error {
+	return func(nav *menu.Navigator) error {
+		if unit == "" {
+			return nav.Push(app.messagePage(menu.LevelInfo, i18n.Translate("没有配置要重启的服务")))
+		}
+		name := i18n.Translate(title)
+		dialog := menu.NewConfirmDialog(name, i18n.Translatef("确认要重启服务 %s 吗？\n\n按y确认，按n或ESC取消", unit))
+		return nav.Push(menu.NewDialogPage(dialog, func(nav *menu.Navigator, key byte, _ string) error {
+			if key != menu.ButtonOK.Key {
+				return nil
+			}
+			log.Printf("%s: 重启服务 %s", title, unit)
+			busy := app.menuRenderer.StartBusy(name, i18n.Translatef("正在重启服务 %s...", unit))
+			err := system.RestartSystemService(unit)
+			busy.Stop()
+
+			if err != nil {
+				log.Printf("重启服务 %s 失败: %v", unit, err)
+				message := i18n.Translatef("重启服务 %s 失败: %v", unit, err)
+				if lines, jerr := system.ServiceJournal(unit, restartJournalLines); jerr == nil && len(lines) > 0 {
+					message += "\n\n" + i18n.Translate("最近日志：") + "\n" + strings.Join(lines, "\n")
+				}
+				return nav.Push(app.messagePage(menu.LevelError, message))
+			}
+
+			log.Printf("已重启服务 %s", unit)
+			message := i18n.Translatef("已重启服务 %s", unit)
+			if statuses, err := system.GetServiceStatus([]string{unit}); err == nil {
+				message += "\n" + i18n.Translatef("当前状态：%s", statuses[0].String())
+				if statuses[0].ActiveState != "active" {
+					return nav.Push(app.messagePage(menu.LevelWarning, message))
+				}
+			}
+			return nav.Push(app.messagePage(menu.LevelSuccess, message))
+		}))
+	}
+}
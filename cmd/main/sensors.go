@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/input"
+	"go-framebuffer-console/pkg/menu"
+	"go-framebuffer-console/pkg/system"
+)
+
+// sensorRefresh 硬件传感器页面重新读取传感器的间隔
+const sensorRefresh = 5 * time.Second
+
+// temperatureColor 返回温度对应的颜色：达到告警温度为错误色，低于告警温度10°C以内为警告色，其它为正常色
+// 参数limit: 告警温度（摄氏度）
+func temperatureColor(celsius, limit float64) color.Color {
+	switch {
+	case celsius >= limit:
+		return menu.LevelError.Color()
+	case celsius >= limit-10:
+		return menu.LevelWarning.Color()
+	}
+	return menu.LevelSuccess.Color()
+}
+
+// fanColor 返回风扇转速对应的颜色：停转（或未接风扇）为警告色，其它为正常色
+func fanColor(rpm int) color.Color {
+	if rpm == 0 {
+		return menu.LevelWarning.Color()
+	}
+	return menu.LevelSuccess.Color()
+}
+
+// sensorsPage 硬件传感器页面
+// 以表格列出所有温度传感器和风扇的读数，按告警温度着色，每隔sensorRefresh自动刷新；任意键返回
+type sensorsPage struct {
+	menu.BasePage
+	app     *Application
+	sensors *system.Sensors
+	err     error
+}
+
+// showSensors 显示硬件传感器页面
+func (app *Application) showSensors(nav *menu.Navigator) error {
+	return nav.Push(&sensorsPage{app: app})
+}
+
+// OnEnter 读取传感器
+func (p *sensorsPage) OnEnter(nav *menu.Navigator) error {
+	p.sensors, p.err = system.GetSensors()
+	return nil
+}
+
+// Render 绘制温度和风扇转速的表格
+func (p *sensorsPage) Render(mr *menu.MenuRenderer) error {
+	layout := mr.NewLayout(&menu.Label{Text: i18n.Translate("硬件传感器"), Color: mr.Theme().Accent}, menu.NewSeparator())
+	if p.err != nil {
+		return mr.RenderLayout(layout.Add(&menu.Label{Text: p.err.Error(), Color: mr.Theme().Error}))
+	}
+
+	// 关闭了温度告警时按仪表盘温度磁贴的默认告警温度着色
+	limit := p.app.config.Alerts.Temperature
+	if limit <= 0 {
+		limit = defaultTempLimit
+	}
+	if len(p.sensors.Temperatures) > 0 {
+		temps := menu.NewTable(i18n.Translate("温度传感器"), i18n.Translate("温度"))
+		temps.Columns[1].Align = menu.AlignRight
+		for _, t := range p.sensors.Temperatures {
+			temps.AddColoredRow(temperatureColor(t.Celsius, limit), t.Zone, fmt.Sprintf("%.1f°C", t.Celsius))
+		}
+		layout.Add(temps)
+	}
+	if len(p.sensors.Fans) > 0 {
+		fans := menu.NewTable(i18n.Translate("风扇"), i18n.Translate("转速"))
+		fans.Columns[1].Align = menu.AlignRight
+		for _, f := range p.sensors.Fans {
+			fans.AddColoredRow(fanColor(f.RPM), f.Name, i18n.Translatef("%d 转/分", f.RPM))
+		}
+		layout.Add(menu.NewSeparator(), fans)
+	}
+	layout.Add(menu.NewSeparator(), menu.NewLabel(i18n.Translatef("告警温度 %.0f°C", limit)))
+	return mr.RenderLayout(layout)
+}
+
+// Refresh 重新读取传感器并重绘
+func (p *sensorsPage) Refresh(nav *menu.Navigator) error {
+	p.sensors, p.err = system.GetSensors()
+	nav.Invalidate()
+	return nil
+}
+
+// RefreshInterval 每隔sensorRefresh自动刷新一次
+func (p *sensorsPage) RefreshInterval() time.Duration {
+	return sensorRefresh
+}
+
+// Hints 页脚的按键提示
+func (p *sensorsPage) Hints() []menu.Hint {
+	return []menu.Hint{{Key: i18n.Translate("任意键"), Text: i18n.Translate("返回")}}
+}
+
+// Help 说明读数的来源和颜色
+func (p *sensorsPage) Help() []menu.HelpItem {
+	return []menu.HelpItem{
+		{Name: i18n.Translate("温度传感器"), Text: i18n.Translate("/sys/class/thermal中的温度区域和/sys/class/hwmon中各芯片的温度")},
+		{Name: i18n.Translate("温度"), Text: i18n.Translate("达到告警温度显示错误色，低于告警温度10°C以内显示警告色")},
+		{Name: i18n.Translate("风扇"), Text: i18n.Translate("/sys/class/hwmon中各芯片的风扇转速，转速为0（停转或未接风扇）显示警告色")},
+	}
+}
+
+// HandleKey 任意键返回上一页
+func (p *sensorsPage) HandleKey(nav *menu.Navigator, ev input.KeyEvent) error {
+	return nav.Pop()
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"time"
+
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/input"
+	"go-framebuffer-console/pkg/menu"
+	"go-framebuffer-console/pkg/system"
+)
+
+// serviceRefresh 服务管理页面重新查询服务状态的间隔
+const serviceRefresh = 5 * time.Second
+
+// serviceActions 服务管理页面的操作：按键、systemctl命令、名称
+var serviceActions = []struct {
+	key    byte
+	action string
+	label  string
+}{
+	{'r', "restart", "重启"},
+	{'s', "start", "启动"},
+	{'t', "stop", "停止"},
+}
+
+// servicesPage 服务管理页面
+// 列出[services]中配置的服务及其运行状态，下方显示选中服务最近的日志；
+// 方向键或数字键选择服务，r、s、t确认后重启、启动、停止选中的服务，q返回
+type servicesPage struct {
+	menu.BasePage
+	app    *Application
+	units  []string
+	states []string // 与units一一对应的列表文字：运行状态和运行时间
+	list   *menu.List
+	status *menu.Label // 最近一次操作的结果
+	logs   *menu.ScrollView
+	frame  *menu.Frame // 框住日志区域，标题为选中的服务
+}
+
+// showServices 显示服务管理页面
+func (app *Application) showServices(nav *menu.Navigator) error {
+	units := app.config.Services.Units
+	if len(units) > 9 {
+		units = units[:9] // 只能用单个数字键选择
+	}
+	if len(units) == 0 {
+		return nav.Push(app.messagePage(menu.LevelInfo, i18n.Translate("没有配置可以管理的服务")))
+	}
+	logs := menu.NewScrollView(nil, nil)
+	return nav.Push(&servicesPage{
+		app:    app,
+		units:  units,
+		list:   &menu.List{Selectable: true},
+		status: &menu.Label{},
+		logs:   logs,
+		frame:  menu.NewFrame("", logs),
+	})
+}
+
+// OnEnter 查询服务状态和选中服务的日志
+func (p *servicesPage) OnEnter(nav *menu.Navigator) error {
+	p.queryStates()
+	p.loadJournal()
+	return nil
+}
+
+// queryStates 查询各服务的运行状态和运行时间，更新列表
+func (p *servicesPage) queryStates() {
+	statuses, err := system.GetServiceStatus(p.units)
+	if err != nil {
+		log.Printf("%v", err)
+	}
+	p.states = make([]string, len(p.units))
+	p.list.Items = p.list.Items[:0]
+	for i, unit := range p.units {
+		state := i18n.Translate("未知")
+		if statuses != nil {
+			state = serviceStatusText(statuses[i])
+		}
+		p.states[i] = state
+		active := ""
+		if statuses != nil {
+			active = statuses[i].ActiveState
+		}
+		p.list.Items = append(p.list.Items, menu.ListItem{
+			Text:  fmt.Sprintf("%d. %s: %s", i+1, unit, state),
+			Key:   byte('1' + i),
+			Color: serviceColor(active),
+		})
+	}
+}
+
+// serviceStatusText 返回服务列表中的状态文字，如"active (running)，已运行 3天 2小时"；服务不存在时注明
+func serviceStatusText(s system.ServiceStatus) string {
+	switch {
+	case s.LoadState == "not-found":
+		return i18n.Translate("服务不存在")
+	case s.Uptime > 0:
+		return i18n.Translatef("%s，已运行 %s", s.String(), system.FormatDuration(s.Uptime))
+	}
+	return s.String()
+}
+
+// serviceColor 返回服务状态对应的颜色：运行中为正常色，失败为错误色，其它为警告色
+func serviceColor(state string) color.Color {
+	switch state {
+	case "active":
+		return menu.LevelSuccess.Color()
+	case "failed":
+		return menu.LevelError.Color()
+	}
+	return menu.LevelWarning.Color()
+}
+
+// selected 返回选中的服务
+func (p *servicesPage) selected() string {
+	return p.units[p.list.Selected]
+}
+
+// loadJournal 读取选中服务最近的日志
+func (p *servicesPage) loadJournal() {
+	unit := p.selected()
+	p.frame.Title = i18n.Translatef("最近日志：%s", unit)
+	lines, err := system.ServiceJournal(unit, p.app.config.Services.JournalRows)
+	switch {
+	case err != nil:
+		lines = []string{err.Error()}
+	case len(lines) == 0:
+		lines = []string{i18n.Translate("（没有日志）")}
+	}
+	p.logs.Lines, p.logs.Offset = lines, 0
+}
+
+// Render 绘制服务列表和选中服务的日志
+func (p *servicesPage) Render(mr *menu.MenuRenderer) error {
+	return mr.RenderLayout(mr.NewLayout(
+		&menu.Label{Text: i18n.Translate("系统服务管理"), Color: mr.Theme().Accent},
+		menu.NewSeparator(),
+		p.list,
+		p.status,
+		p.frame,
+	))
+}
+
+// Refresh 重新查询服务状态，状态或运行时间变化时重绘
+func (p *servicesPage) Refresh(nav *menu.Navigator) error {
+	old := p.states
+	p.queryStates()
+	for i := range old {
+		if old[i] != p.states[i] {
+			nav.Invalidate()
+			break
+		}
+	}
+	return nil
+}
+
+// RefreshInterval 每隔serviceRefresh自动查询一次服务状态
+func (p *servicesPage) RefreshInterval() time.Duration {
+	return serviceRefresh
+}
+
+// Hints 页脚的按键提示
+func (p *servicesPage) Hints() []menu.Hint {
+	return []menu.Hint{
+		{Key: i18n.Translate("上下键"), Text: i18n.Translate("选择")},
+		{Key: "r", Text: i18n.Translate("重启")},
+		{Key: "s", Text: i18n.Translate("启动")},
+		{Key: "t", Text: i18n.Translate("停止")},
+		{Key: "q", Text: i18n.Translate("返回")},
+	}
+}
+
+// Help 说明服务状态的颜色和日志区域
+func (p *servicesPage) Help() []menu.HelpItem {
+	return []menu.HelpItem{
+		{Name: "active", Text: i18n.Translate("正在运行，以正常色显示")},
+		{Name: "failed", Text: i18n.Translate("运行失败，以错误色显示")},
+		{Name: i18n.Translate("括号中的状态"), Text: i18n.Translate("systemd的子状态，如running（正在运行）、exited（已执行完毕）；后面是进入当前状态以来的时间")},
+		{Name: i18n.Translate("其它状态"), Text: i18n.Translate("未运行、正在启动或停止等，以警告色显示")},
+		{Name: i18n.Translate("最近日志"), Text: i18n.Translate("选中服务最近的journal日志，执行操作后刷新")},
+	}
+}
+
+// HandleKey 选择服务、确认后执行操作或返回上一页
+func (p *servicesPage) HandleKey(nav *menu.Navigator, ev input.KeyEvent) error {
+	switch ev.Code {
+	case input.KeyUp:
+		p.list.Move(-1)
+	case input.KeyDown:
+		p.list.Move(1)
+	default:
+		key := ev.Byte()
+		switch key {
+		case 'q', 'Q', 27, 0x7F: // q, Q, ESC, 退格
+			return nav.Pop()
+		}
+		for _, a := range serviceActions {
+			if key == a.key || key == a.key-'a'+'A' {
+				return p.confirm(nav, a.action, a.label)
+			}
+		}
+		if !p.list.Select(key) {
+			return nil // 忽略其他键
+		}
+	}
+	p.loadJournal()
+	nav.Invalidate()
+	return nil
+}
+
+// confirm 确认后对选中的服务执行操作，执行期间显示忙碌画面
+// 参数action: systemctl命令
+// 参数label: 操作的名称，如"重启"
+func (p *servicesPage) confirm(nav *menu.Navigator, action, label string) error {
+	unit := p.selected()
+	name := i18n.Translate(label)
+	dialog := menu.NewConfirmDialog(i18n.Translatef("%s服务", name), i18n.Translatef("确认要%s服务 %s 吗？\n\n按y确认，按n或ESC取消", name, unit))
+	return nav.Push(menu.NewDialogPage(dialog, func(nav *menu.Navigator, key byte, _ string) error {
+		if key != menu.ButtonOK.Key {
+			return nil
+		}
+		log.Printf("%s服务 %s", label, unit)
+		busy := p.app.menuRenderer.StartBusy(i18n.Translate("系统服务管理"), i18n.Translatef("正在%s服务 %s...", name, unit))
+		err := system.ControlService(action, unit)
+		busy.Stop()
+
+		p.queryStates()
+		p.loadJournal()
+		nav.Invalidate()
+		if err != nil {
+			log.Printf("%s服务 %s 失败: %v", label, unit, err)
+			p.status.Text, p.status.Color = "", nil
+			return nav.Push(p.app.messagePage(menu.LevelError, i18n.Translatef("%s服务 %s 失败: %v", name, unit, err)))
+		}
+		p.status.Text = i18n.Translatef("已%s服务 %s", name, unit)
+		p.status.Color = menu.LevelSuccess.Color()
+		return nil
+	}))
+}
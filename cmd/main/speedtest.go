@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"go-framebuffer-console/internal/config"
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/menu"
+	"go-framebuffer-console/pkg/system"
+)
+
+// speedTestEnabled 返回是否配置了测速地址
+func (app *Application) speedTestEnabled() bool {
+	return app.config.SpeedTest.DownloadURL != "" || app.config.SpeedTest.UploadURL != ""
+}
+
+// runSpeedTest 依次测试下载和上传速率并显示结果
+// 测速期间显示按已用时间前进的进度条和当前速率，完成后压入结果页面
+func (app *Application) runSpeedTest(nav *menu.Navigator) error {
+	sc := app.config.SpeedTest
+	if !app.speedTestEnabled() {
+		return nav.Push(app.messagePage(menu.LevelWarning, i18n.Translate("没有配置测速地址，请在配置文件的[speedtest]段落中设置download_url或upload_url")))
+	}
+	duration := time.Duration(sc.Duration) * time.Second
+	if duration <= 0 {
+		duration = time.Duration(config.DefaultSpeedTime) * time.Second
+	}
+
+	title := i18n.Translate("测速")
+	busy := app.menuRenderer.StartBusy(title, i18n.Translate("正在准备测速...\n\n请稍候..."))
+	result, err := system.RunSpeedTest(sc.DownloadURL, sc.UploadURL, duration, func(phase string, ratio, mbps float64) {
+		message := i18n.Translatef("正在测试下载速率...\n\n当前: %s", system.FormatMbps(mbps))
+		if phase == system.SpeedUpload {
+			message = i18n.Translatef("正在测试上传速率...\n\n当前: %s", system.FormatMbps(mbps))
+		}
+		busy.SetProgress(ratio, message)
+	})
+	busy.Stop()
+	if err != nil {
+		return nav.Push(app.messagePage(menu.LevelError, i18n.Translatef("测速失败: %v", err)))
+	}
+
+	return nav.Push(menu.NewTextPage(func(mr *menu.MenuRenderer) *menu.Layout {
+		layout := mr.NewLayout(&menu.Label{Text: i18n.Translate("测速结果"), Color: mr.Theme().Accent}, menu.NewSeparator())
+		if result.Download != nil {
+			layout.Add(app.speedSampleLabels(i18n.Translate("下载"), sc.DownloadURL, result.Download)...)
+		}
+		if result.Upload != nil {
+			layout.Add(app.speedSampleLabels(i18n.Translate("上传"), sc.UploadURL, result.Upload)...)
+		}
+		return layout.Add(menu.NewSeparator(), menu.NewLabel(i18n.Translate("按任意键返回")))
+	}))
+}
+
+// speedSampleLabels 返回一个方向的结果：速率、传输量和用时，以及测速地址；失败时速率行使用错误颜色并显示原因
+func (app *Application) speedSampleLabels(name, url string, sample *system.SpeedSample) []menu.Widget {
+	theme := app.menuRenderer.Theme()
+	text := i18n.Translatef("%s: %s（传输 %s，用时 %.1f 秒）", name, system.FormatMbps(sample.Mbps()), system.FormatBytes(sample.Bytes), sample.Elapsed.Seconds())
+	labels := []menu.Widget{&menu.Label{Text: text, Color: theme.Success}}
+	if sample.Err != nil {
+		labels[0] = &menu.Label{Text: text, Color: theme.Error}
+		labels = append(labels, menu.NewLabel(i18n.Translatef("  失败: %v", sample.Err)))
+	}
+	return append(labels, menu.NewLabel(fmt.Sprintf("  %s", url)))
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"image"
+	"image/png"
+	"log"
+	"os"
+	"time"
+
+	"go-framebuffer-console/pkg/system"
+)
+
+// splashMaxWait 启动画面等待首页系统信息的最长时间，超时后直接进入首页
+const splashMaxWait = 10 * time.Second
+
+// showSplash 显示启动画面，同时在后台获取首页的系统信息
+// 系统信息就绪并且达到最短显示时间后返回获取到的信息；超时或程序退出时返回nil，由首页重新获取
+func (app *Application) showSplash() *system.SystemInfo {
+	sc := app.config.Splash
+	var err error
+	if sc.Banner {
+		err = app.menuRenderer.RenderBannerSplash(sc.Product, version)
+	} else {
+		err = app.menuRenderer.RenderSplash(loadLogo(sc.Logo), sc.Product, version)
+	}
+	if err != nil {
+		log.Printf("显示启动画面失败: %v", err)
+		return nil
+	}
+	minimum := time.After(time.Duration(sc.Duration) * time.Second)
+
+	ready := make(chan *system.SystemInfo, 1)
+	go func() {
+		info, err := system.GetSystemInfo()
+		if err != nil {
+			log.Printf("获取系统信息失败: %v", err)
+		}
+		ready <- info
+	}()
+
+	var info *system.SystemInfo
+	select {
+	case info = <-ready:
+	case <-time.After(splashMaxWait):
+		log.Printf("等待系统信息超时，结束启动画面")
+		return nil
+	case <-app.ctx.Done():
+		return nil
+	}
+
+	select {
+	case <-minimum:
+	case <-app.ctx.Done():
+	}
+	return info
+}
+
+// loadLogo 读取启动画面的logo图片
+// 参数path: PNG图片路径，为空或读取失败时返回nil，启动画面只显示文字
+func loadLogo(path string) image.Image {
+	if path == "" {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		log.Printf("无法打开logo图片: %v", err)
+		return nil
+	}
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		log.Printf("解析logo图片%s失败: %v", path, err)
+		return nil
+	}
+	return img
+}
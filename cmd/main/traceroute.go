@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-framebuffer-console/pkg/font"
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/menu"
+	"go-framebuffer-console/pkg/system"
+)
+
+// traceTimeout 一次路由追踪的最长时间，忙碌画面不能取消，超过后显示已经得到的各跳
+const traceTimeout = 3 * time.Minute
+
+// traceRoute 追踪到主机的路由并显示各跳
+// 追踪期间显示忙碌画面并随每一跳更新，完成后压入可以滚动的结果页面
+// 参数host: 主机名或IP地址
+func (app *Application) traceRoute(nav *menu.Navigator, host string) error {
+	title := i18n.Translate("路由追踪")
+	busy := app.menuRenderer.StartBusy(title, i18n.Translatef("正在追踪到 %s 的路由...\n\n请稍候...", host))
+
+	ctx, cancel := context.WithTimeout(context.Background(), traceTimeout)
+	defer cancel()
+	result, err := system.Traceroute(ctx, host, system.DefaultTracerouteOptions, func(hop system.TraceHop) {
+		busy.SetMessage(i18n.Translatef("正在追踪到 %s 的路由...\n\n第%d跳: %s", host, hop.TTL, hopAddrText(hop)))
+	})
+	busy.Stop()
+	if result == nil || len(result.Hops) == 0 {
+		return nav.Push(app.messagePage(menu.LevelError, i18n.Translatef("路由追踪失败: %v", err)))
+	}
+
+	lines, styles := app.formatTraceResult(result)
+	return nav.Push(menu.NewTextPage(func(mr *menu.MenuRenderer) *menu.Layout {
+		layout := mr.NewLayout(&menu.Label{Text: fmt.Sprintf("%s: %s (%s)", title, result.Host, result.Addr), Color: mr.Theme().Accent}, menu.NewSeparator())
+		return layout.Add(app.traceSummary(result, err), menu.NewSeparator(), menu.NewScrollView(lines, styles))
+	}))
+}
+
+// traceSummary 返回路由追踪的结论：到达目标、目标不可达或没有到达
+func (app *Application) traceSummary(result *system.TraceResult, err error) *menu.Label {
+	theme := app.menuRenderer.Theme()
+	last := result.Hops[len(result.Hops)-1]
+	switch {
+	case result.Reached:
+		return &menu.Label{Text: i18n.Translatef("✓ 已到达目标，共%d跳", last.TTL), Color: theme.Success}
+	case result.Unreachable:
+		return &menu.Label{Text: i18n.Translatef("✗ 第%d跳 %s 报告目标不可达", last.TTL, last.Addr), Color: theme.Error}
+	case err != nil:
+		return &menu.Label{Text: i18n.Translatef("⚠ 追踪中断: %v", err), Color: theme.Warning}
+	}
+	return &menu.Label{Text: i18n.Translatef("⚠ %d跳内没有到达目标，连接可能在最后一个有回复的路由器之后中断", last.TTL), Color: theme.Warning}
+}
+
+// formatTraceResult 每一跳一行：跳数、地址和各探测包的往返时间，没有回复的探测包显示为"*"
+// 没有任何回复的跳使用警告颜色，目标不可达时最后一跳使用错误颜色
+func (app *Application) formatTraceResult(result *system.TraceResult) ([]string, []font.LineStyle) {
+	theme := app.menuRenderer.Theme()
+	var lines []string
+	var styles []font.LineStyle
+	for i, hop := range result.Hops {
+		rtts := make([]string, len(hop.Replies))
+		for j, reply := range hop.Replies {
+			rtts[j] = "*"
+			if reply.Received {
+				rtts[j] = system.FormatRTT(reply.RTT)
+			}
+		}
+		var style font.LineStyle
+		switch {
+		case hop.Addr == nil:
+			style.Color = theme.Warning
+		case result.Unreachable && i == len(result.Hops)-1:
+			style.Color = theme.Error
+		}
+		lines = append(lines, fmt.Sprintf("%2d  %-15s  %s", hop.TTL, hopAddrText(hop), strings.Join(rtts, "  ")))
+		styles = append(styles, style)
+	}
+	lines = append(lines, "", i18n.Translate("按任意键返回"))
+	styles = append(styles, font.LineStyle{}, font.LineStyle{})
+	return lines, styles
+}
+
+// hopAddrText 返回一跳的地址，没有回复时为"*"
+func hopAddrText(hop system.TraceHop) string {
+	if hop.Addr == nil {
+		return "*"
+	}
+	return hop.Addr.String()
+}
@@ -5,5 +5,6 @@ go 1.21
 require (
 	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0
 	golang.org/x/image v0.15.0
+	golang.org/x/text v0.14.0
 	rsc.io/qr v0.2.0
 )
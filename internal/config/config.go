@@ -3,26 +3,288 @@
 package config
 
 import (
+	"image/color"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 // 默认配置常量
 // 这些值在程序初始化时使用，可以根据实际部署环境进行调整
 const (
-	DefaultFontPath = "./fonts/SourceHanSansSC-Regular.ttf" // 默认字体文件路径（TTF格式）
-	BackupFontPath  = "./fonts/SourceHanSansSC-Regular.otf" // 备用字体文件路径（OTF格式）
-	DefaultFontSize = 20.0                                  // 默认字体大小（点）
-	DefaultDPI      = 72.0                                  // 默认DPI分辨率
-	DefaultDevice   = "/dev/fb0"                            // 默认帧缓冲区设备路径
+	DefaultFontPath    = "./fonts/SourceHanSansSC-Regular.ttf" // 默认字体文件路径（TTF格式）
+	BackupFontPath     = "./fonts/SourceHanSansSC-Regular.otf" // 备用字体文件路径（OTF格式）
+	DefaultFontSize    = 20.0                                  // 默认字体大小（点）
+	DefaultDPI         = 72.0                                  // 默认DPI分辨率
+	DefaultDevice      = "/dev/fb0"                            // 默认帧缓冲区设备路径
+	DefaultTabWidth    = 4                                     // 默认制表位宽度（字符数）
+	DefaultFontIndex   = 0                                     // 默认使用TTC字体集合中的第一个字体
+	DefaultFontDir     = "./fonts"                             // 可切换字体所在的目录
+	DefaultRepeatDelay = 400                                   // 按键开始自动重复前的延迟（毫秒）
+	DefaultRepeatRate  = 20                                    // 按键自动重复速率（次/秒）
+	DefaultKeymap      = "us"                                  // 默认键盘布局（美式）
+	DefaultSerialPort  = "/dev/ttyS0"                          // 默认串口设备
+	DefaultSerialBaud  = 115200                                // 默认串口波特率
+	DefaultIdleTimeout = 300                                   // 无操作多久后视为空闲（秒）
+	DefaultScreensaver = 600                                   // 无操作多久后显示屏幕保护（秒）
+	DefaultSwipe       = 80                                    // 触摸屏判定为滑动的最小距离（像素）
+	DefaultLongPress   = 800                                   // 触摸屏判定为长按的按住时间（毫秒）
+	DefaultHomeKey     = "Esc*2"                               // 默认的返回首页热键（双击ESC）
+	DefaultSequenceMs  = 1000                                  // 按键序列相邻按键的最大间隔（毫秒）
+	DefaultDoubleMs    = 400                                   // 双击两次按下的最大间隔（毫秒）
+	DefaultChordMs     = 150                                   // 组合按键各键按下的最大间隔（毫秒）
+	DefaultLanguage    = "zh-CN"                               // 默认界面语言
+	DefaultProductName = "Go Framebuffer Console"              // 启动画面上显示的默认产品名称
+	DefaultSplashTime  = 2                                     // 启动画面的最短显示时间（秒）
+	DefaultNICsPerPage = 4                                     // 网卡信息页面每页显示的网卡数
+	DefaultMainRefresh = 5                                     // 首页自动刷新的间隔（秒）
+	DefaultMarquee     = 30                                    // 放不下的长文字滚动的速度（像素/秒）
+	DefaultQRContent   = "{deviceID}"                          // 首页二维码默认编码设备ID
+	DefaultQRLevel     = "M"                                   // 首页二维码默认的纠错等级
+	DefaultQRQuietZone = 2                                     // 首页二维码默认的四周留白（模块数）
+	DefaultTwoColumns  = 1280                                  // 屏幕宽度不小于该值时首页分为两列（像素）
+	DefaultColumnSplit = 55                                    // 首页两列时左列占的宽度百分比
+	DefaultTileColumns = 3                                     // 仪表盘每行的磁贴数
+	DefaultTileRefresh = 5                                     // 磁贴默认的刷新间隔（秒）
+	DefaultDiskAlert   = 90.0                                  // 根分区使用率超过该百分比时在首页显示告警横幅
+	DefaultMemoryAlert = 95.0                                  // 内存使用率超过该百分比时在首页显示告警横幅
+	DefaultTempAlert   = 85.0                                  // 温度超过该值（摄氏度）时在首页显示告警横幅
+	DefaultMountAlert  = 90.0                                  // 挂载点使用率超过该百分比时在磁盘页面中标出
+	DefaultLogBacklog  = 200                                   // 日志页面打开时显示的最近行数
+	DefaultJournalRows = 8                                     // 服务管理页面显示的最近日志行数
+	DefaultSpeedTime   = 10                                    // 测速时下载和上传各自持续的秒数
 )
 
 // Config 应用程序配置结构体
 // 包含了程序运行所需的各种配置参数
 type Config struct {
-	FontPath string  // 字体文件路径
-	FontSize float64 // 字体大小
-	DPI      float64 // 屏幕分辨率（每英寸点数）
-	Device   string  // 帧缓冲区设备路径
+	FontPath     string          // 字体文件路径
+	FontSize     float64         // 字体大小
+	DPI          float64         // 屏幕分辨率（每英寸点数）
+	Device       string          // 帧缓冲区设备路径
+	TabWidth     int             // 制表符展开的制表位宽度（字符数）
+	FontIndex    int             // TTC字体集合中使用的字体序号（普通TTF文件为0）
+	Touch        TouchConfig     // 触摸屏校准参数
+	RepeatDelay  int             // 按住按键后开始自动重复前的延迟（毫秒）
+	RepeatRate   int             // 按键自动重复速率（次/秒），0表示禁用
+	InputDevices []string        // 额外并入按键事件流的evdev设备（如前面板小键盘）
+	Keymap       string          // evdev键盘布局：内置布局名称（us、de）或布局文件路径
+	Serial       SerialConfig    // 串口控制台参数
+	IdleTimeout  int             // 无操作多久后视为空闲并返回首页（秒），0表示禁用
+	Screensaver  int             // 无操作多久后显示屏幕保护时钟（秒），0表示禁用
+	ExitKeys     []string        // 退出程序的热键或按键序列，如"Ctrl+C"、"Esc Esc Esc"
+	Scanner      ScannerConfig   // 扫码枪参数
+	HomeKey      string          // 在任意页面返回首页的热键，如"Esc*2"，为空表示禁用
+	KeyWindows   KeyWindows      // 按键序列、双击和组合按键的识别时间窗口
+	Theme        ThemeConfig     // 界面配色
+	Language     string          // 界面语言，如"zh-CN"、"en-US"
+	Splash       SplashConfig    // 启动画面
+	Footer       bool            // 是否在页面底部显示当前可用的按键提示
+	Header       bool            // 是否在页面顶部显示主机名、时钟和告警数
+	ErrorBeep    bool            // 显示错误消息时是否让PC喇叭鸣响
+	Menu         []MenuItem      // 配置菜单的选项，按显示顺序排列，为空时使用内置的菜单
+	Commands     []string        // 配置菜单中允许执行的程序（绝对路径），不在列表中的command选项不显示
+	NICsPerPage  int             // 网卡信息页面每页显示的网卡数，0表示不分页
+	MainRefresh  int             // 首页自动刷新的间隔（秒），0表示只在按F5或r时刷新
+	NetRefresh   int             // 网卡信息页面自动刷新的间隔（秒），0表示只在按F5或r时刷新
+	Marquee      int             // 放不下的长文字（如CPU型号）来回滚动的速度（像素/秒），0表示截断不滚动
+	Transition   string          // 页面切换的过渡效果：none、slide、fade
+	Pin          string          // 管理员PIN（数字），设置后执行PinActions中的操作前须在数字键盘上输入，为空表示不保护
+	PinActions   []string        // 需要输入PIN的内置功能，如reboot、shutdown；[menu_item]段落可用require_pin单独设置
+	QRCode       QRConfig        // 首页二维码
+	MainLayout   LayoutConfig    // 首页的分栏方式
+	Dashboard    DashboardConfig // 仪表盘页面
+	Alerts       AlertConfig     // 首页告警横幅的阈值
+	Logs         LogConfig       // 日志页面
+	Services     ServiceConfig   // 服务管理页面
+	Containers   ContainerConfig // 容器页面
+	NetTargets   []NetTestTarget // 网络测试的目标，为空时使用内置的目标
+	SpeedTest    SpeedTestConfig // 测速
+}
+
+// KeyWindows 多键热键的识别时间窗口（毫秒）
+type KeyWindows struct {
+	Sequence    int // 按键序列中相邻两次按键的最大间隔
+	DoublePress int // 双击两次按下的最大间隔
+	Chord       int // 组合按键从第一个到最后一个按键的最大间隔
+}
+
+// DefaultExitKeys 默认的退出热键
+var DefaultExitKeys = []string{"Ctrl+C", "Ctrl+Z", "Ctrl+\\", "Ctrl+D"}
+
+// DefaultPinActions 默认需要输入PIN的内置功能
+var DefaultPinActions = []string{"reboot", "shutdown"}
+
+// DefaultServices 默认在服务管理页面和仪表盘中显示的systemd服务
+var DefaultServices = []string{"sshd"}
+
+// "重启系统服务"菜单中默认重启的systemd服务
+const (
+	DefaultNetworkUnit  = "NetworkManager"
+	DefaultSSHUnit      = "sshd"
+	DefaultFirewallUnit = "firewalld"
+)
+
+// SerialConfig 串口控制台配置，对应配置文件中的[serial]段落
+// 没有键盘或没有显示器时改为通过串口读取按键，并可把页面文本镜像到串口
+type SerialConfig struct {
+	Enabled bool   // 是否允许回退到串口
+	Device  string // 串口设备路径
+	Baud    int    // 波特率
+	Mirror  bool   // 是否把页面文本镜像到串口
+}
+
+// ScannerConfig 扫码枪配置，对应配置文件中的[scanner]段落
+// 扫码枪以键盘方式输入，来自指定设备的按键整串捕获为一次扫码，不会被当作菜单按键
+type ScannerConfig struct {
+	Devices []string // 扫码枪的evdev设备路径，如/dev/input/by-id/usb-xxx-event-kbd
+	SaveTo  string   // 在首页扫码后把内容写入的文件（如激活码），为空时只显示扫码结果
+}
+
+// ThemeConfig 界面配色，对应配置文件中的[theme]段落
+// 颜色写作#RRGGBB或#RGB，未配置的颜色（nil）使用所选预设主题的颜色
+type ThemeConfig struct {
+	Name       string      // 预设主题名称（dark、light），为空时使用dark
+	Background color.Color // 背景色
+	Foreground color.Color // 文字颜色
+	Accent     color.Color // 强调色，用于表头和对话框标题
+	Line       color.Color // 分隔线、边框和滚动条颜色
+	Success    color.Color // 正常状态颜色
+	Warning    color.Color // 警告颜色
+	Error      color.Color // 错误颜色
+	Separator  string      // 分隔线样式：solid、dashed、double、none，为空时使用预设主题的样式
+}
+
+// MenuItem 配置菜单中的一个选项，对应配置文件中的一个[menu_item]段落
+// 配置了[menu_item]时菜单只包含这些选项，按段落出现的顺序排列；没有列出的内置功能不显示
+type MenuItem struct {
+	Action  string // 功能：内置功能的名称（network、services、nettest、reboot、shutdown、font、qrcodes、dashboard、logs），或command表示执行命令
+	Label   string // 显示的文字，为空时使用内置功能的默认名称
+	Key     string // 快捷键（单个字符），为空时按选项的位置编号为1-9
+	Enabled bool   // 是否显示该选项，false用于暂时隐藏
+	Command string // Action为command时执行的命令，程序路径后可跟参数，不经过shell；程序须列在allowed_commands中
+	Pin     bool   // 执行前是否需要输入管理员PIN，默认按功能是否在pin_actions中决定
+}
+
+// LayoutConfig 首页分栏配置，对应配置文件中的[layout]段落
+// 宽屏上系统信息在左列，二维码和客服信息在右列；窄屏上依次排列在同一列中
+type LayoutConfig struct {
+	Columns        int    // 列数：1为单列，2为两列，0（配置文件中写auto）表示按屏幕宽度自动选择
+	TwoColumnWidth int    // 自动选择时使用两列的最小屏幕宽度（像素）
+	Split          int    // 两列时左列占的宽度百分比
+	Template       string // 首页内容模板文件（Go text/template）的路径，为空时使用内置的内容
+}
+
+// DashboardConfig 仪表盘配置，对应配置文件中的[dashboard]段落和各[tile]段落
+type DashboardConfig struct {
+	Columns int          // 每行的磁贴数
+	Tiles   []TileConfig // 按显示顺序排列的磁贴，为空时使用内置的磁贴
+}
+
+// TileConfig 仪表盘中的一个磁贴，对应配置文件中的一个[tile]段落
+type TileConfig struct {
+	Type     string   // 内容：cpu、memory、disk、diskio、network、services、temperature、clock
+	Title    string   // 标题，为空时使用内容对应的默认标题
+	Interval int      // 刷新间隔（秒）
+	Services []string // Type为services时显示状态的systemd服务
+	Limit    float64  // Type为temperature时的告警温度（摄氏度），0表示使用默认值
+}
+
+// AlertConfig 首页告警横幅的阈值，对应配置文件中的[alerts]段落
+// 任一指标超过阈值时首页顶部显示红色横幅，恢复正常后横幅消失；阈值为0表示不检查该指标
+type AlertConfig struct {
+	Disk        float64 // 根分区使用率（百分比）
+	Memory      float64 // 内存使用率（百分比）
+	Temperature float64 // 温度传感器的最高读数（摄氏度）
+	Mounts      float64 // 各挂载点的使用率（百分比），超过时在磁盘页面中以错误色标出，不显示横幅
+}
+
+// ServiceConfig 服务管理页面配置，对应配置文件中的[services]段落
+type ServiceConfig struct {
+	Units        []string // 可以查看状态和启动、停止、重启的systemd服务，最多9个
+	JournalRows  int      // 选中服务时显示的最近日志行数
+	NetworkUnit  string   // "重启网络服务"重启的服务，为空时不显示该选项
+	SSHUnit      string   // "重启SSH服务"重启的服务，为空时不显示该选项
+	FirewallUnit string   // "重启防火墙"重启的服务，为空时不显示该选项
+}
+
+// NetTestTarget 网络测试目标，对应配置文件中的一个[nettest_target]段落
+type NetTestTarget struct {
+	Name        string // 显示名称，为空时使用Host
+	Host        string // 主机名或IP地址
+	Type        string // 测试类型：ping、http、tcp
+	Count       int    // 尝试次数（ping为发送的数据包数，tcp为连接次数），0使用默认值
+	Timeout     int    // 每次尝试等待的时间（秒），0使用默认值
+	Port        int    // 端口，仅用于tcp
+	Description string // 测试进度中显示的说明，为空时使用Name
+	Enabled     bool   // 是否默认选中，可以在"网络测试目标"页面中切换
+
+	// 以下仅用于http
+	URL          string // 请求的URL，为空时请求http://Host/；只写了URL时Host取URL中的主机名
+	ExpectStatus int    // 期望的状态码，0表示2xx和3xx都算正常
+	VerifyTLS    bool   // 是否校验HTTPS证书
+}
+
+// DefaultNetTestType 没有指定type时的网络测试类型
+const DefaultNetTestType = "ping"
+
+// ContainerConfig 容器页面配置，对应配置文件中的[containers]段落
+type ContainerConfig struct {
+	Socket string // docker或podman的套接字路径，为空时自动探测
+}
+
+// SpeedTestConfig 测速配置，对应配置文件中的[speedtest]段落
+// 两个地址都为空时不显示"测速"菜单项
+type SpeedTestConfig struct {
+	DownloadURL string // 下载测速的地址，应返回足够大的文件，为空时不测下载
+	UploadURL   string // 上传测速的地址，接受POST请求并丢弃内容，为空时不测上传
+	Duration    int    // 下载和上传各自持续的秒数
+}
+
+// LogConfig 日志页面配置，对应配置文件中的[logs]段落
+type LogConfig struct {
+	Units   []string // 除程序日志外可以通过journalctl跟踪的systemd服务
+	Backlog int      // 打开时显示的最近行数
+}
+
+// QRConfig 首页二维码配置，对应配置文件中的[qrcode]段落
+type QRConfig struct {
+	Content    string // 编码内容的模板，可使用{deviceID}、{ip}、{hostname}、{url}变量
+	URL        string // 模板中{url}的取值，如设备管理页面的地址
+	Caption    string // 二维码上方的说明文字，为空时使用默认说明
+	ModuleSize int    // 每个模块的边长（像素），0表示按首页剩余的空间自动缩放
+	QuietZone  int    // 四周留白（模块数）
+	Level      string // 纠错等级：L、M、Q、H
+
+	// 扫码页面中的其它二维码，未配置的不显示
+	AdminURL     string // 网页管理界面地址的模板，如"http://{ip}"
+	WiFiSSID     string // 无线网络名称，用于手机扫码连接
+	WiFiPassword string // 无线网络密码，开放网络为空
+	WiFiSecurity string // 加密方式：WPA、WEP、nopass，为空时按是否有密码判断
+	WiFiHidden   bool   // 无线网络是否隐藏
+}
+
+// SplashConfig 启动画面配置，对应配置文件中的[splash]段落
+// 启动画面一直显示到首页的系统信息获取完成，并且至少显示Duration秒
+type SplashConfig struct {
+	Enabled  bool   // 是否显示启动画面
+	Logo     string // logo图片（PNG）路径，为空或无法读取时只显示文字
+	Product  string // 产品名称
+	Duration int    // 最短显示时间（秒），0表示首页数据就绪后立即进入首页
+	Banner   bool   // 以"佛祖保佑 永不宕机"字符画代替logo
+	Hotkey   string // 打开字符画彩蛋页面的隐藏热键，不在页脚列出，为空表示禁用
+}
+
+// TouchConfig 触摸屏校准和手势配置，对应配置文件中的[touch]段落
+// 坐标范围为0时使用设备上报的范围
+type TouchConfig struct {
+	MinX, MaxX int  // X方向原始坐标范围
+	MinY, MaxY int  // Y方向原始坐标范围
+	SwapXY     bool // 交换X/Y轴
+	InvertX    bool // 翻转X轴
+	InvertY    bool // 翻转Y轴
+	Swipe      int  // 判定为水平滑动翻页的最小距离（像素），0表示禁用
+	LongPress  int  // 判定为长按（相当于回车键）的按住时间（毫秒），0表示禁用
 }
 
 // NewConfig 创建新的配置对象
@@ -30,13 +292,118 @@ type Config struct {
 // 返回包含默认配置的Config对象
 func NewConfig() *Config {
 	return &Config{
-		FontPath: GetBestFontPath(), // 设置最佳字体路径
-		FontSize: DefaultFontSize,   // 设置默认字体大小
-		DPI:      DefaultDPI,        // 设置默认DPI
-		Device:   DefaultDevice,     // 设置默认设备路径
+		FontPath:    GetBestFontPath(),  // 设置最佳字体路径
+		FontSize:    DefaultFontSize,    // 设置默认字体大小
+		DPI:         DefaultDPI,         // 设置默认DPI
+		Device:      DefaultDevice,      // 设置默认设备路径
+		TabWidth:    DefaultTabWidth,    // 设置默认制表位宽度
+		FontIndex:   DefaultFontIndex,   // 设置默认字体序号
+		RepeatDelay: DefaultRepeatDelay, // 设置默认自动重复延迟
+		RepeatRate:  DefaultRepeatRate,  // 设置默认自动重复速率
+		Keymap:      DefaultKeymap,      // 设置默认键盘布局
+		IdleTimeout: DefaultIdleTimeout, // 设置默认空闲时间
+		Screensaver: DefaultScreensaver, // 设置默认屏幕保护时间
+		ExitKeys:    DefaultExitKeys,    // 设置默认退出热键
+		HomeKey:     DefaultHomeKey,     // 设置默认返回首页热键
+		Language:    DefaultLanguage,    // 设置默认界面语言
+		Footer:      true,               // 默认显示按键提示
+		Header:      true,               // 默认显示状态栏
+		NICsPerPage: DefaultNICsPerPage, // 设置默认每页网卡数
+		MainRefresh: DefaultMainRefresh, // 设置默认首页刷新间隔
+		Marquee:     DefaultMarquee,     // 设置默认文字滚动速度
+		PinActions:  DefaultPinActions,  // 设置默认需要PIN的功能
+		KeyWindows: KeyWindows{ // 设置默认多键热键识别窗口
+			Sequence:    DefaultSequenceMs,
+			DoublePress: DefaultDoubleMs,
+			Chord:       DefaultChordMs,
+		},
+		Serial: SerialConfig{ // 设置默认串口参数
+			Enabled: true,
+			Device:  DefaultSerialPort,
+			Baud:    DefaultSerialBaud,
+			Mirror:  true,
+		},
+		Splash: SplashConfig{ // 设置默认启动画面
+			Enabled:  true,
+			Product:  DefaultProductName,
+			Duration: DefaultSplashTime,
+		},
+		QRCode: QRConfig{ // 设置默认二维码参数
+			Content:   DefaultQRContent,
+			QuietZone: DefaultQRQuietZone,
+			Level:     DefaultQRLevel,
+		},
+		MainLayout: LayoutConfig{ // 设置默认首页分栏
+			TwoColumnWidth: DefaultTwoColumns,
+			Split:          DefaultColumnSplit,
+		},
+		Dashboard: DashboardConfig{ // 设置默认仪表盘参数
+			Columns: DefaultTileColumns,
+		},
+		Services: ServiceConfig{ // 设置默认服务管理参数
+			Units:        DefaultServices,
+			JournalRows:  DefaultJournalRows,
+			NetworkUnit:  DefaultNetworkUnit,
+			SSHUnit:      DefaultSSHUnit,
+			FirewallUnit: DefaultFirewallUnit,
+		},
+		Logs: LogConfig{ // 设置默认日志页面参数
+			Backlog: DefaultLogBacklog,
+		},
+		SpeedTest: SpeedTestConfig{ // 设置默认测速参数
+			Duration: DefaultSpeedTime,
+		},
+		Alerts: AlertConfig{ // 设置默认告警阈值
+			Disk:        DefaultDiskAlert,
+			Memory:      DefaultMemoryAlert,
+			Temperature: DefaultTempAlert,
+			Mounts:      DefaultMountAlert,
+		},
+		Touch: TouchConfig{ // 设置默认触摸手势参数
+			Swipe:     DefaultSwipe,
+			LongPress: DefaultLongPress,
+		},
 	}
 }
 
+// RequiresPin 返回执行内置功能action前是否需要输入管理员PIN
+// 没有设置PIN时总是返回false
+// 参数action: 功能名称，如reboot
+func (c *Config) RequiresPin(action string) bool {
+	if c.Pin == "" {
+		return false
+	}
+	for _, a := range c.PinActions {
+		if strings.EqualFold(a, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListFontFiles 列出字体目录中可供切换的字体文件
+// 参数dir: 字体目录路径
+// 返回按文件名排序的.ttf/.ttc/.otf文件路径列表，目录不存在时返回空列表
+// .otf文件也会列出：TrueType轮廓的OTF可以正常使用，CFF轮廓的OTF在加载时报告不支持，而不是不声不响地不显示
+func ListFontFiles(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var fonts []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".ttf", ".ttc", ".otf":
+			fonts = append(fonts, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return fonts
+}
+
 // GetBestFontPath 获取最佳的字体文件路径
 // 优先选择TTF格式，如果不存在则尝试OTF格式
 func GetBestFontPath() string {
@@ -44,12 +411,12 @@ func GetBestFontPath() string {
 	if _, err := os.Stat(DefaultFontPath); err == nil {
 		return DefaultFontPath
 	}
-	
+
 	// 检查OTF文件是否存在
 	if _, err := os.Stat(BackupFontPath); err == nil {
 		return BackupFontPath
 	}
-	
+
 	// 都不存在时返回默认TTF路径（会在后续处理中给出错误提示）
 	return DefaultFontPath
-}
\ No newline at end of file
+}
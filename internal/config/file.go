@@ -0,0 +1,423 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultConfigPath 默认配置文件路径
+const DefaultConfigPath = "/etc/framebuffer-console.conf"
+
+// Section 配置文件中的一个段落
+// 形如[name]的段落头之后、下一个段落头之前的所有键值对都属于该段落，
+// 同名段落可以重复出现（例如多个网络测试目标），按出现顺序保存
+type Section struct {
+	Name   string            // 段落名称
+	Values map[string]string // 段落内的键值对
+}
+
+// File 解析后的配置文件内容
+// 格式为简单的INI风格：key=value，以#或;开头的行为注释
+type File struct {
+	Global   map[string]string // 第一个段落头之前的全局键值对
+	Sections []Section         // 按出现顺序排列的段落
+}
+
+// ParseFile 读取并解析配置文件
+func ParseFile(path string) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("无法打开配置文件 %s: %v", path, err)
+	}
+	defer f.Close()
+
+	file := &File{Global: make(map[string]string)}
+	current := file.Global
+
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		// 段落头
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if name == "" {
+				return nil, fmt.Errorf("%s:%d: 段落名称不能为空", path, lineNo)
+			}
+			file.Sections = append(file.Sections, Section{Name: name, Values: make(map[string]string)})
+			current = file.Sections[len(file.Sections)-1].Values
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: 无效的配置行: %s", path, lineNo, line)
+		}
+		current[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取配置文件 %s 失败: %v", path, err)
+	}
+
+	return file, nil
+}
+
+// SectionsNamed 返回指定名称的所有段落
+func (f *File) SectionsNamed(name string) []Section {
+	var result []Section
+	for _, s := range f.Sections {
+		if s.Name == name {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// String 读取字符串配置项，不存在时返回默认值
+func (s Section) String(key, def string) string {
+	if v, ok := s.Values[key]; ok && v != "" {
+		return v
+	}
+	return def
+}
+
+// Int 读取整数配置项，不存在或格式错误时返回默认值
+func (s Section) Int(key string, def int) int {
+	if v, ok := s.Values[key]; ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	}
+	return def
+}
+
+// Float 读取浮点数配置项，不存在或格式错误时返回默认值
+func (s Section) Float(key string, def float64) float64 {
+	if v, ok := s.Values[key]; ok {
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+	}
+	return def
+}
+
+// Bool 读取布尔配置项（true/false/yes/no/1/0/on/off），不存在或格式错误时返回默认值
+func (s Section) Bool(key string, def bool) bool {
+	switch strings.ToLower(s.Values[key]) {
+	case "true", "yes", "1", "on":
+		return true
+	case "false", "no", "0", "off":
+		return false
+	}
+	return def
+}
+
+// Color 读取#RRGGBB或#RGB格式的颜色配置项，不存在或格式错误时返回默认值
+func (s Section) Color(key string, def color.Color) color.Color {
+	if c, err := ParseColor(s.Values[key]); err == nil {
+		return c
+	}
+	return def
+}
+
+// layoutColumns 解析[layout]段落的columns：auto表示按屏幕宽度自动选择（0），否则为列数（1或2）
+// 无法解析或列数超出范围时记录日志并返回默认值
+// 参数value: 配置文件中的值，为空时返回默认值
+func layoutColumns(value string, def int) int {
+	if value == "" {
+		return def
+	}
+	if strings.EqualFold(value, "auto") {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 || n > 2 {
+		log.Printf("[layout] columns的值%q无效，应为1、2或auto，使用默认值", value)
+		return def
+	}
+	return n
+}
+
+// ParseColor 解析#RRGGBB或#RGB格式的颜色，#号可以省略
+func ParseColor(s string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return color.RGBA{}, fmt.Errorf("无效的颜色: %q", s)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("无效的颜色: %q", s)
+	}
+	return color.RGBA{uint8(v >> 16), uint8(v >> 8), uint8(v), 255}, nil
+}
+
+// List 读取以逗号分隔的列表配置项，自动去除空白和空元素
+func (s Section) List(key string) []string {
+	var result []string
+	for _, item := range strings.Split(s.Values[key], ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Section 返回全局键值对组成的匿名段落，便于使用统一的读取方法
+func (f *File) Section() Section {
+	return Section{Values: f.Global}
+}
+
+// Load 从配置文件加载配置
+// 配置文件中未出现的配置项保持默认值；文件不存在时直接返回默认配置
+func Load(path string) (*Config, error) {
+	cfg := NewConfig()
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return cfg, nil
+	}
+
+	file, err := ParseFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.apply(file)
+	return cfg, nil
+}
+
+// apply 将配置文件内容应用到配置对象上
+func (c *Config) apply(file *File) {
+	g := file.Section()
+	c.FontPath = g.String("font_path", c.FontPath)
+	c.FontIndex = g.Int("font_index", c.FontIndex)
+	c.FontSize = g.Float("font_size", c.FontSize)
+	c.DPI = g.Float("dpi", c.DPI)
+	c.Device = g.String("framebuffer_device", c.Device)
+	c.TabWidth = g.Int("tab_width", c.TabWidth)
+	c.RepeatDelay = g.Int("repeat_delay", c.RepeatDelay)
+	c.RepeatRate = g.Int("repeat_rate", c.RepeatRate)
+	c.Keymap = g.String("keymap", c.Keymap)
+	c.IdleTimeout = g.Int("idle_timeout", c.IdleTimeout)
+	c.Screensaver = g.Int("screensaver_timeout", c.Screensaver)
+	c.Language = g.String("language", c.Language)
+	c.Footer = g.Bool("footer", c.Footer)
+	c.Header = g.Bool("header", c.Header)
+	c.ErrorBeep = g.Bool("error_beep", c.ErrorBeep)
+	c.NICsPerPage = g.Int("interfaces_per_page", c.NICsPerPage)
+	c.MainRefresh = g.Int("main_refresh", c.MainRefresh)
+	c.NetRefresh = g.Int("network_refresh", c.NetRefresh)
+	c.Marquee = g.Int("marquee_speed", c.Marquee)
+	c.Transition = g.String("transition", c.Transition)
+	c.Pin = g.String("pin", c.Pin)
+	if devices := g.List("input_devices"); len(devices) > 0 {
+		c.InputDevices = devices
+	}
+	if keys := g.List("exit_keys"); len(keys) > 0 {
+		c.ExitKeys = keys
+	}
+	if commands := g.List("allowed_commands"); len(commands) > 0 {
+		c.Commands = commands
+	}
+	// pin_actions留空表示PIN不保护任何内置功能，只保护设置了require_pin的[menu_item]
+	if _, ok := g.Values["pin_actions"]; ok {
+		c.PinActions = g.List("pin_actions")
+	}
+	// home_key留空表示禁用，不能用String读取（空值会回退到默认值）
+	if v, ok := g.Values["home_key"]; ok {
+		c.HomeKey = v
+	}
+	c.KeyWindows.Sequence = g.Int("sequence_window", c.KeyWindows.Sequence)
+	c.KeyWindows.DoublePress = g.Int("double_press_window", c.KeyWindows.DoublePress)
+	c.KeyWindows.Chord = g.Int("chord_window", c.KeyWindows.Chord)
+
+	if serial := file.SectionsNamed("serial"); len(serial) > 0 {
+		sc := serial[0]
+		c.Serial.Enabled = sc.Bool("enabled", c.Serial.Enabled)
+		c.Serial.Device = sc.String("device", c.Serial.Device)
+		c.Serial.Baud = sc.Int("baud", c.Serial.Baud)
+		c.Serial.Mirror = sc.Bool("mirror", c.Serial.Mirror)
+	}
+
+	if scanner := file.SectionsNamed("scanner"); len(scanner) > 0 {
+		sc := scanner[0]
+		if devices := sc.List("devices"); len(devices) > 0 {
+			c.Scanner.Devices = devices
+		}
+		c.Scanner.SaveTo = sc.String("save_to", c.Scanner.SaveTo)
+	}
+
+	if splash := file.SectionsNamed("splash"); len(splash) > 0 {
+		sc := splash[0]
+		c.Splash.Enabled = sc.Bool("enabled", c.Splash.Enabled)
+		c.Splash.Logo = sc.String("logo", c.Splash.Logo)
+		c.Splash.Product = sc.String("product", c.Splash.Product)
+		c.Splash.Duration = sc.Int("duration", c.Splash.Duration)
+		c.Splash.Banner = sc.Bool("banner", c.Splash.Banner)
+		c.Splash.Hotkey = sc.String("banner_key", c.Splash.Hotkey)
+	}
+
+	if theme := file.SectionsNamed("theme"); len(theme) > 0 {
+		t := theme[0]
+		c.Theme.Name = t.String("name", c.Theme.Name)
+		c.Theme.Background = t.Color("background", c.Theme.Background)
+		c.Theme.Foreground = t.Color("foreground", c.Theme.Foreground)
+		c.Theme.Accent = t.Color("accent", c.Theme.Accent)
+		c.Theme.Line = t.Color("line", c.Theme.Line)
+		c.Theme.Success = t.Color("success", c.Theme.Success)
+		c.Theme.Warning = t.Color("warning", c.Theme.Warning)
+		c.Theme.Error = t.Color("error", c.Theme.Error)
+		c.Theme.Separator = t.String("separator", c.Theme.Separator)
+	}
+
+	if layout := file.SectionsNamed("layout"); len(layout) > 0 {
+		l := layout[0]
+		c.MainLayout.Columns = layoutColumns(l.String("columns", ""), c.MainLayout.Columns)
+		c.MainLayout.TwoColumnWidth = l.Int("two_column_width", c.MainLayout.TwoColumnWidth)
+		c.MainLayout.Split = l.Int("split", c.MainLayout.Split)
+		c.MainLayout.Template = l.String("template", c.MainLayout.Template)
+	}
+
+	if qrcode := file.SectionsNamed("qrcode"); len(qrcode) > 0 {
+		q := qrcode[0]
+		c.QRCode.Content = q.String("content", c.QRCode.Content)
+		c.QRCode.URL = q.String("url", c.QRCode.URL)
+		c.QRCode.Caption = q.String("caption", c.QRCode.Caption)
+		c.QRCode.ModuleSize = q.Int("module_size", c.QRCode.ModuleSize)
+		c.QRCode.QuietZone = q.Int("quiet_zone", c.QRCode.QuietZone)
+		c.QRCode.Level = q.String("level", c.QRCode.Level)
+		c.QRCode.AdminURL = q.String("admin_url", c.QRCode.AdminURL)
+		c.QRCode.WiFiSSID = q.String("wifi_ssid", c.QRCode.WiFiSSID)
+		c.QRCode.WiFiPassword = q.String("wifi_password", c.QRCode.WiFiPassword)
+		c.QRCode.WiFiSecurity = q.String("wifi_security", c.QRCode.WiFiSecurity)
+		c.QRCode.WiFiHidden = q.Bool("wifi_hidden", c.QRCode.WiFiHidden)
+	}
+
+	if dashboard := file.SectionsNamed("dashboard"); len(dashboard) > 0 {
+		c.Dashboard.Columns = dashboard[0].Int("columns", c.Dashboard.Columns)
+	}
+
+	if alerts := file.SectionsNamed("alerts"); len(alerts) > 0 {
+		a := alerts[0]
+		c.Alerts.Disk = a.Float("disk", c.Alerts.Disk)
+		c.Alerts.Memory = a.Float("memory", c.Alerts.Memory)
+		c.Alerts.Temperature = a.Float("temperature", c.Alerts.Temperature)
+		c.Alerts.Mounts = a.Float("mounts", c.Alerts.Mounts)
+	}
+
+	if services := file.SectionsNamed("services"); len(services) > 0 {
+		s := services[0]
+		if units := s.List("units"); len(units) > 0 {
+			c.Services.Units = units
+		}
+		c.Services.JournalRows = s.Int("journal_lines", c.Services.JournalRows)
+		// 重启网络、SSH和防火墙的服务名称留空表示不显示对应的选项
+		for key, unit := range map[string]*string{
+			"network_unit":  &c.Services.NetworkUnit,
+			"ssh_unit":      &c.Services.SSHUnit,
+			"firewall_unit": &c.Services.FirewallUnit,
+		} {
+			if v, ok := s.Values[key]; ok {
+				*unit = v
+			}
+		}
+	}
+
+	// 网络测试目标：每个[nettest_target]段落为一个目标，缺少host（http目标可以只写url）的段落被忽略
+	if sections := file.SectionsNamed("nettest_target"); len(sections) > 0 {
+		c.NetTargets = nil
+		for _, t := range sections {
+			target := NetTestTarget{
+				Name:        t.String("name", ""),
+				Host:        t.String("host", ""),
+				Type:        strings.ToLower(t.String("type", DefaultNetTestType)),
+				Count:       t.Int("count", 0),
+				Timeout:     t.Int("timeout", 0),
+				Port:        t.Int("port", 0),
+				Description: t.String("description", ""),
+				Enabled:     t.Bool("enabled", true),
+
+				URL:          t.String("url", ""),
+				ExpectStatus: t.Int("expect_status", 0),
+				VerifyTLS:    t.Bool("verify_tls", true),
+			}
+			if target.Host != "" || target.URL != "" {
+				c.NetTargets = append(c.NetTargets, target)
+			}
+		}
+	}
+
+	if containers := file.SectionsNamed("containers"); len(containers) > 0 {
+		c.Containers.Socket = containers[0].String("socket", "")
+	}
+
+	if speed := file.SectionsNamed("speedtest"); len(speed) > 0 {
+		c.SpeedTest.DownloadURL = speed[0].String("download_url", "")
+		c.SpeedTest.UploadURL = speed[0].String("upload_url", "")
+		c.SpeedTest.Duration = speed[0].Int("duration", c.SpeedTest.Duration)
+	}
+
+	if logs := file.SectionsNamed("logs"); len(logs) > 0 {
+		c.Logs.Units = logs[0].List("units")
+		c.Logs.Backlog = logs[0].Int("backlog", c.Logs.Backlog)
+	}
+
+	// 仪表盘磁贴：每个[tile]段落为一个磁贴，缺少type的段落被忽略
+	if sections := file.SectionsNamed("tile"); len(sections) > 0 {
+		c.Dashboard.Tiles = nil
+		for _, t := range sections {
+			tile := TileConfig{
+				Type:     strings.ToLower(t.String("type", "")),
+				Title:    t.String("title", ""),
+				Interval: t.Int("interval", DefaultTileRefresh),
+				Services: t.List("services"),
+				Limit:    t.Float("limit", 0),
+			}
+			if tile.Type != "" {
+				c.Dashboard.Tiles = append(c.Dashboard.Tiles, tile)
+			}
+		}
+	}
+
+	// 配置菜单：每个[menu_item]段落为一个选项，缺少action的段落被忽略
+	if sections := file.SectionsNamed("menu_item"); len(sections) > 0 {
+		c.Menu = nil
+		for _, m := range sections {
+			item := MenuItem{
+				Action:  strings.ToLower(m.String("action", "")),
+				Label:   m.String("label", ""),
+				Key:     m.String("key", ""),
+				Enabled: m.Bool("enabled", true),
+				Command: m.String("command", ""),
+			}
+			item.Pin = m.Bool("require_pin", c.RequiresPin(item.Action))
+			if item.Action != "" {
+				c.Menu = append(c.Menu, item)
+			}
+		}
+	}
+
+	if touch := file.SectionsNamed("touch"); len(touch) > 0 {
+		t := touch[0]
+		c.Touch.MinX = t.Int("min_x", c.Touch.MinX)
+		c.Touch.MaxX = t.Int("max_x", c.Touch.MaxX)
+		c.Touch.MinY = t.Int("min_y", c.Touch.MinY)
+		c.Touch.MaxY = t.Int("max_y", c.Touch.MaxY)
+		c.Touch.SwapXY = t.Bool("swap_xy", c.Touch.SwapXY)
+		c.Touch.InvertX = t.Bool("invert_x", c.Touch.InvertX)
+		c.Touch.InvertY = t.Bool("invert_y", c.Touch.InvertY)
+		c.Touch.Swipe = t.Int("swipe_distance", c.Touch.Swipe)
+		c.Touch.LongPress = t.Int("long_press", c.Touch.LongPress)
+	}
+}
@@ -0,0 +1,75 @@
+package font
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// CollectionFaceCount 返回字体数据中包含的字体数量
+// TTC字体集合返回其中的字体个数，普通TTF文件返回1，无法识别的数据返回0
+func CollectionFaceCount(fontData []byte) int {
+	if !bytes.HasPrefix(fontData, []byte("ttcf")) {
+		if len(fontData) >= 4 {
+			return 1
+		}
+		return 0
+	}
+	if len(fontData) < 12 {
+		return 0
+	}
+	return int(binary.BigEndian.Uint32(fontData[8:12]))
+}
+
+// FileFaceCount 返回字体文件中包含的字体数量，只读取文件头
+// 文件无法读取时返回0，其它情况与CollectionFaceCount相同
+func FileFaceCount(path string) int {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	header := make([]byte, 12)
+	n, _ := io.ReadFull(f, header)
+	return CollectionFaceCount(header[:n])
+}
+
+// selectCollectionFace 从TTC字体集合中选出指定序号的字体
+// freetype只会解析集合中的第一个字体，因此这里复制一份数据，
+// 把TTC偏移表的第一项改写为目标字体的偏移量。
+// TTC中各表的偏移均相对于文件开头，所以改写后无需移动任何表数据。
+// 普通TTF文件只接受序号0，并原样返回数据
+func selectCollectionFace(fontData []byte, faceIndex int) ([]byte, error) {
+	if faceIndex < 0 {
+		return nil, fmt.Errorf("字体序号无效: %d", faceIndex)
+	}
+
+	if !bytes.HasPrefix(fontData, []byte("ttcf")) {
+		if faceIndex != 0 {
+			return nil, fmt.Errorf("字体文件不是TTC字体集合，无法选择序号 %d", faceIndex)
+		}
+		return fontData, nil
+	}
+
+	count := CollectionFaceCount(fontData)
+	if faceIndex >= count {
+		return nil, fmt.Errorf("字体序号 %d 超出范围，字体集合中共有 %d 个字体", faceIndex, count)
+	}
+	if faceIndex == 0 {
+		return fontData, nil
+	}
+
+	// 偏移表从第12字节开始，每项4字节
+	entry := 12 + 4*faceIndex
+	if len(fontData) < entry+4 {
+		return nil, fmt.Errorf("TTC偏移表数据不完整")
+	}
+
+	data := make([]byte, len(fontData))
+	copy(data, fontData)
+	copy(data[12:16], fontData[entry:entry+4])
+	return data, nil
+}
@@ -0,0 +1,47 @@
+package font
+
+import (
+	_ "embed"
+	"fmt"
+)
+
+// embeddedFont 编译进二进制文件的后备字体（TTF格式）
+// 当外部字体文件缺失或无法解析时使用，保证控制台始终能够显示内容
+// 内置的是思源黑体的子集（转换为TrueType轮廓），包含ASCII、拉丁字母补充和界面文字用到的全部字符，
+// 中英文界面在后备模式下都能完整显示；界面文字有变化时执行go generate重新生成
+//
+//go:generate go run gen_fallback.go
+//go:embed fonts/fallback.ttf
+var embeddedFont []byte
+
+// NewEmbeddedRenderer 使用内置后备字体创建字体渲染器
+// 参数size: 字体大小（点）
+// 参数dpi: 分辨率（每英寸点数）
+func NewEmbeddedRenderer(size float64, dpi float64) (*Renderer, error) {
+	r, err := NewRendererFromBytes(embeddedFont, size, dpi)
+	if err != nil {
+		return nil, fmt.Errorf("内置字体: %v", err)
+	}
+	return r, nil
+}
+
+// NewRendererWithFallback 创建字体渲染器，外部字体优先
+// 参数faceIndex: 外部字体为TTC字体集合时使用的字体序号
+// 外部字体文件存在且可用时使用外部字体，否则退回到内置字体
+// 返回的fallback为true表示当前使用的是内置字体，loadErr记录外部字体加载失败的原因
+func NewRendererWithFallback(fontPath string, faceIndex int, size float64, dpi float64) (r *Renderer, fallback bool, loadErr error) {
+	if fontPath != "" {
+		r, loadErr = NewRendererWithIndex(fontPath, faceIndex, size, dpi)
+		if loadErr == nil {
+			return r, false, nil
+		}
+	} else {
+		loadErr = fmt.Errorf("字体文件路径不能为空")
+	}
+
+	r, err := NewEmbeddedRenderer(size, dpi)
+	if err != nil {
+		return nil, true, fmt.Errorf("%v; %v", loadErr, err)
+	}
+	return r, true, loadErr
+}
@@ -0,0 +1,608 @@
+//go:build ignore
+
+// gen_fallback 由仓库中的思源黑体OTF生成内置后备字体fonts/fallback.ttf
+// 只保留ASCII、拉丁字母补充以及程序字符串字面量中用到的字符（包括i18n表中的全部中文），
+// 并把CFF的三次曲线轮廓转换为freetype能够解析的TrueType二次曲线轮廓
+// 界面文字有变化时在pkg/font目录下执行 go generate 重新生成
+//
+// 思源黑体使用SIL OFL 1.1许可证并保留了"Source"字体名，修改后的字体不能使用该名称，
+// 因此生成的字体命名为"Console Fallback SC"
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	sourceFont = "../../fonts/SourceHanSansSC-Regular.otf"
+	outputFont = "fonts/fallback.ttf"
+	familyName = "Console Fallback SC"
+	psName     = "ConsoleFallbackSC-Regular"
+	// tolerance 三次曲线转换为二次曲线时允许的最大偏差（字体单位）
+	tolerance = 1.0
+)
+
+// sourceDirs 收集字符串字面量的目录
+var sourceDirs = []string{"../../cmd", "../../internal", "../../pkg"}
+
+func main() {
+	data, err := os.ReadFile(sourceFont)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := sfnt.Parse(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+	runes, err := collectRunes()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	b := &builder{src: src, raw: data, upem: int(src.UnitsPerEm())}
+	// 0号字形为.notdef
+	if err := b.addGlyph(0); err != nil {
+		log.Fatal(err)
+	}
+	var missing []rune
+	for _, r := range runes {
+		gi, err := src.GlyphIndex(&b.buf, r)
+		if err != nil || gi == 0 {
+			missing = append(missing, r)
+			continue
+		}
+		b.runes = append(b.runes, r)
+		if err := b.addGlyph(gi); err != nil {
+			log.Fatalf("U+%04X: %v", r, err)
+		}
+	}
+	if len(missing) > 0 {
+		log.Printf("源字体中没有以下字符: %q", string(missing))
+	}
+
+	out, err := b.build()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(outputFont, out, 0644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("已生成 %s：%d 个字形，%d 字节", outputFont, len(b.glyphs), len(out))
+}
+
+// collectRunes 返回需要保留的字符：ASCII可见字符、拉丁字母补充，以及源码字符串字面量中的所有非ASCII字符
+func collectRunes() ([]rune, error) {
+	set := make(map[rune]bool)
+	for r := rune(0x20); r < 0x7F; r++ {
+		set[r] = true
+	}
+	for r := rune(0xA0); r <= 0xFF; r++ {
+		set[r] = true
+	}
+
+	for _, dir := range sourceDirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return err
+			}
+			file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+			if err != nil {
+				return err
+			}
+			ast.Inspect(file, func(n ast.Node) bool {
+				if lit, ok := n.(*ast.BasicLit); ok && (lit.Kind == token.STRING || lit.Kind == token.CHAR) {
+					text := lit.Value
+					if s, err := strconv.Unquote(lit.Value); err == nil {
+						text = s
+					}
+					for _, r := range text {
+						if r >= 0x80 && r <= 0xFFFF {
+							set[r] = true
+						}
+					}
+				}
+				return true
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	runes := make([]rune, 0, len(set))
+	for r := range set {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+	return runes, nil
+}
+
+// point TrueType轮廓上的一个点
+type point struct {
+	x, y int
+	on   bool
+}
+
+// glyph 转换后的字形
+type glyph struct {
+	contours [][]point
+	advance  int
+	xMin     int
+	yMin     int
+	xMax     int
+	yMax     int
+}
+
+// builder 收集字形并写出TrueType字体
+type builder struct {
+	src    *sfnt.Font
+	raw    []byte
+	upem   int
+	buf    sfnt.Buffer
+	runes  []rune // 与glyphs[1:]一一对应
+	glyphs []glyph
+}
+
+// addGlyph 读取源字体中的字形，按1个像素等于1个字体单位的比例取出轮廓并转换为二次曲线
+func (b *builder) addGlyph(gi sfnt.GlyphIndex) error {
+	ppem := fixed.Int26_6(b.upem << 6)
+	segments, err := b.src.LoadGlyph(&b.buf, gi, ppem, nil)
+	if err != nil {
+		return err
+	}
+	advance, err := b.src.GlyphAdvance(&b.buf, gi, ppem, font.HintingNone)
+	if err != nil {
+		return err
+	}
+
+	g := glyph{advance: int(math.Round(float64(advance) / 64))}
+	var contour []point
+	var cur [2]float64
+	// sfnt的Y轴向下，TrueType的Y轴向上
+	pt := func(p fixed.Point26_6) [2]float64 {
+		return [2]float64{float64(p.X) / 64, -float64(p.Y) / 64}
+	}
+	add := func(p [2]float64, on bool) {
+		contour = append(contour, point{x: int(math.Round(p[0])), y: int(math.Round(p[1])), on: on})
+	}
+	flush := func() {
+		if c := cleanContour(contour); len(c) > 0 {
+			g.contours = append(g.contours, c)
+		}
+		contour = nil
+	}
+	for _, seg := range segments {
+		switch seg.Op {
+		case sfnt.SegmentOpMoveTo:
+			flush()
+			cur = pt(seg.Args[0])
+			add(cur, true)
+		case sfnt.SegmentOpLineTo:
+			cur = pt(seg.Args[0])
+			add(cur, true)
+		case sfnt.SegmentOpQuadTo:
+			add(pt(seg.Args[0]), false)
+			cur = pt(seg.Args[1])
+			add(cur, true)
+		case sfnt.SegmentOpCubeTo:
+			for _, q := range cubicToQuads(cur, pt(seg.Args[0]), pt(seg.Args[1]), pt(seg.Args[2])) {
+				add(q[0], false)
+				add(q[1], true)
+			}
+			cur = pt(seg.Args[2])
+		}
+	}
+	flush()
+
+	for i, c := range g.contours {
+		for j, p := range c {
+			if i == 0 && j == 0 {
+				g.xMin, g.xMax, g.yMin, g.yMax = p.x, p.x, p.y, p.y
+				continue
+			}
+			g.xMin, g.xMax = min(g.xMin, p.x), max(g.xMax, p.x)
+			g.yMin, g.yMax = min(g.yMin, p.y), max(g.yMax, p.y)
+		}
+	}
+	b.glyphs = append(b.glyphs, g)
+	return nil
+}
+
+// cubicToQuads 把三次贝塞尔曲线均匀分成若干段，每段用一条二次曲线近似，返回各段的控制点和终点
+// 段数由三次项的大小估计，使每段的偏差不超过tolerance
+func cubicToQuads(p0, c1, c2, p3 [2]float64) [][2][2]float64 {
+	d := math.Hypot(p3[0]-3*c2[0]+3*c1[0]-p0[0], p3[1]-3*c2[1]+3*c1[1]-p0[1])
+	n := int(math.Ceil(math.Cbrt(d * math.Sqrt(3) / 36 / tolerance)))
+	n = max(1, min(n, 16))
+
+	at := func(t float64) ([2]float64, [2]float64) {
+		u := 1 - t
+		var p, dp [2]float64
+		for k := 0; k < 2; k++ {
+			p[k] = u*u*u*p0[k] + 3*u*u*t*c1[k] + 3*u*t*t*c2[k] + t*t*t*p3[k]
+			dp[k] = 3*u*u*(c1[k]-p0[k]) + 6*u*t*(c2[k]-c1[k]) + 3*t*t*(p3[k]-c2[k])
+		}
+		return p, dp
+	}
+	quads := make([][2][2]float64, 0, n)
+	for i := 0; i < n; i++ {
+		t0, t1 := float64(i)/float64(n), float64(i+1)/float64(n)
+		q0, d0 := at(t0)
+		q3, d1 := at(t1)
+		h := (t1 - t0) / 3
+		var ctrl [2]float64
+		for k := 0; k < 2; k++ {
+			q1 := q0[k] + h*d0[k]
+			q2 := q3[k] - h*d1[k]
+			ctrl[k] = (3*(q1+q2) - (q0[k] + q3[k])) / 4
+		}
+		if i == n-1 {
+			q3 = p3
+		}
+		quads = append(quads, [2][2]float64{ctrl, q3})
+	}
+	return quads
+}
+
+// cleanContour 去除重复的点、与起点重合的终点，以及恰好位于两个控制点中点的曲线上的点（TrueType会自动补出）
+func cleanContour(c []point) []point {
+	var out []point
+	for _, p := range c {
+		if n := len(out); n > 0 && out[n-1] == p {
+			continue
+		}
+		out = append(out, p)
+	}
+	if n := len(out); n > 1 && out[n-1] == out[0] {
+		out = out[:n-1]
+	}
+	if len(out) < 3 {
+		return nil
+	}
+
+	result := []point{out[0]}
+	for i := 1; i < len(out); i++ {
+		p, prev, next := out[i], out[i-1], out[(i+1)%len(out)]
+		if p.on && !prev.on && !next.on && 2*p.x == prev.x+next.x && 2*p.y == prev.y+next.y {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// build 写出完整的TrueType字体文件
+func (b *builder) build() ([]byte, error) {
+	os2, err := b.table("OS/2")
+	if err != nil {
+		return nil, err
+	}
+	srcHead, err := b.table("head")
+	if err != nil {
+		return nil, err
+	}
+	if len(os2) < 96 {
+		return nil, fmt.Errorf("源字体的OS/2表版本过低")
+	}
+	typoAscender := int16(binary.BigEndian.Uint16(os2[68:]))
+	typoDescender := int16(binary.BigEndian.Uint16(os2[70:]))
+	typoLineGap := int16(binary.BigEndian.Uint16(os2[72:]))
+
+	glyf, loca := b.glyfLoca()
+	var xMin, yMin, xMax, yMax, maxPoints, maxContours, advanceMax, totalAdvance int
+	minLSB, minRSB, maxExtent := math.MaxInt, math.MaxInt, math.MinInt
+	for _, g := range b.glyphs {
+		advanceMax = max(advanceMax, g.advance)
+		totalAdvance += g.advance
+		if len(g.contours) == 0 {
+			continue
+		}
+		xMin, yMin, xMax, yMax = min(xMin, g.xMin), min(yMin, g.yMin), max(xMax, g.xMax), max(yMax, g.yMax)
+		points := 0
+		for _, c := range g.contours {
+			points += len(c)
+		}
+		maxPoints, maxContours = max(maxPoints, points), max(maxContours, len(g.contours))
+		minLSB, minRSB, maxExtent = min(minLSB, g.xMin), min(minRSB, g.advance-g.xMax), max(maxExtent, g.xMax)
+	}
+
+	// head：复制源字体的创建和修改时间，保证重新生成的结果不变
+	head := newWriter()
+	head.u32(0x00010000).u32(0x00010000).u32(0).u32(0x5F0F3CF5)
+	head.u16(0x0009).u16(uint16(b.upem))
+	head.bytes(srcHead[20:36])
+	head.i16(xMin).i16(yMin).i16(xMax).i16(yMax)
+	head.u16(0).u16(8).i16(2).i16(1).i16(0)
+
+	// hhea：使用OS/2的排版行高（思源黑体为880/-120，正好一个em），文字不会超出渲染器按字号计算的行高
+	hhea := newWriter()
+	hhea.u32(0x00010000).i16(int(typoAscender)).i16(int(typoDescender)).i16(int(typoLineGap))
+	hhea.u16(uint16(advanceMax)).i16(minLSB).i16(minRSB).i16(maxExtent)
+	hhea.i16(1).i16(0).i16(0).i16(0).i16(0).i16(0).i16(0).i16(0)
+	hhea.u16(uint16(len(b.glyphs)))
+
+	hmtx := newWriter()
+	for _, g := range b.glyphs {
+		lsb := 0
+		if len(g.contours) > 0 {
+			lsb = g.xMin
+		}
+		hmtx.u16(uint16(g.advance)).i16(lsb)
+	}
+
+	maxp := newWriter()
+	maxp.u32(0x00010000).u16(uint16(len(b.glyphs))).u16(uint16(maxPoints)).u16(uint16(maxContours))
+	maxp.u16(0).u16(0).u16(2)
+	for i := 0; i < 8; i++ {
+		maxp.u16(0)
+	}
+
+	// OS/2：复制源字体的表，修改平均字宽、厂商标识和字符范围，并要求使用排版行高
+	newOS2 := append([]byte(nil), os2[:96]...)
+	binary.BigEndian.PutUint16(newOS2[0:], 4)
+	binary.BigEndian.PutUint16(newOS2[2:], uint16(totalAdvance/len(b.glyphs)))
+	copy(newOS2[58:62], "NONE")
+	binary.BigEndian.PutUint16(newOS2[62:], binary.BigEndian.Uint16(newOS2[62:])|0x80)
+	binary.BigEndian.PutUint16(newOS2[64:], uint16(b.runes[0]))
+	binary.BigEndian.PutUint16(newOS2[66:], uint16(b.runes[len(b.runes)-1]))
+
+	post := newWriter()
+	post.u32(0x00030000).u32(0).i16(-125).i16(50)
+	for i := 0; i < 5; i++ {
+		post.u32(0)
+	}
+
+	name, err := b.nameTable()
+	if err != nil {
+		return nil, err
+	}
+
+	tables := map[string][]byte{
+		"OS/2": newOS2,
+		"cmap": b.cmapTable(),
+		"glyf": glyf,
+		"head": head.buf.Bytes(),
+		"hhea": hhea.buf.Bytes(),
+		"hmtx": hmtx.buf.Bytes(),
+		"loca": loca,
+		"maxp": maxp.buf.Bytes(),
+		"name": name,
+		"post": post.buf.Bytes(),
+	}
+	return assemble(tables), nil
+}
+
+// glyfLoca 编码所有字形，返回glyf表和长格式的loca表
+func (b *builder) glyfLoca() ([]byte, []byte) {
+	glyf := newWriter()
+	loca := newWriter()
+	for _, g := range b.glyphs {
+		loca.u32(uint32(glyf.buf.Len()))
+		if len(g.contours) == 0 {
+			continue // 空白字形没有轮廓数据
+		}
+		glyf.i16(len(g.contours)).i16(g.xMin).i16(g.yMin).i16(g.xMax).i16(g.yMax)
+		end := -1
+		for _, c := range g.contours {
+			end += len(c)
+			glyf.u16(uint16(end))
+		}
+		glyf.u16(0) // 没有指令
+
+		var flags []byte
+		xs, ys := newWriter(), newWriter()
+		px, py := 0, 0
+		for _, c := range g.contours {
+			for _, p := range c {
+				var flag byte
+				if p.on {
+					flag |= 0x01
+				}
+				flag |= encodeDelta(xs, p.x-px, 0x02, 0x10)
+				flag |= encodeDelta(ys, p.y-py, 0x04, 0x20)
+				px, py = p.x, p.y
+				flags = append(flags, flag)
+			}
+		}
+		// 连续相同的标志使用重复计数压缩
+		for i := 0; i < len(flags); {
+			j := i + 1
+			for j < len(flags) && flags[j] == flags[i] && j-i <= 255 {
+				j++
+			}
+			if j-i > 1 {
+				glyf.bytes([]byte{flags[i] | 0x08, byte(j - i - 1)})
+			} else {
+				glyf.bytes([]byte{flags[i]})
+			}
+			i = j
+		}
+		glyf.bytes(xs.buf.Bytes())
+		glyf.bytes(ys.buf.Bytes())
+		for glyf.buf.Len()%4 != 0 {
+			glyf.bytes([]byte{0})
+		}
+	}
+	loca.u32(uint32(glyf.buf.Len()))
+	return glyf.buf.Bytes(), loca.buf.Bytes()
+}
+
+// encodeDelta 写出坐标的增量并返回对应的标志位
+// 参数short: 1字节坐标的标志位；参数same: 坐标不变（长格式）或为正数（短格式）的标志位
+func encodeDelta(w *writer, delta int, short, same byte) byte {
+	switch {
+	case delta == 0:
+		return same
+	case delta > 0 && delta < 256:
+		w.bytes([]byte{byte(delta)})
+		return short | same
+	case delta < 0 && delta > -256:
+		w.bytes([]byte{byte(-delta)})
+		return short
+	}
+	w.i16(delta)
+	return 0
+}
+
+// cmapTable 返回格式12的字符映射表，Unicode和Windows两个平台共用同一个子表
+func (b *builder) cmapTable() []byte {
+	type group struct{ start, end, glyph uint32 }
+	var groups []group
+	for i, r := range b.runes {
+		gi := uint32(i + 1)
+		if n := len(groups); n > 0 && groups[n-1].end+1 == uint32(r) && groups[n-1].glyph+(groups[n-1].end-groups[n-1].start)+1 == gi {
+			groups[n-1].end = uint32(r)
+			continue
+		}
+		groups = append(groups, group{uint32(r), uint32(r), gi})
+	}
+
+	w := newWriter()
+	w.u16(0).u16(2)
+	w.u16(0).u16(4).u32(20)
+	w.u16(3).u16(10).u32(20)
+	w.u16(12).u16(0).u32(uint32(16 + 12*len(groups))).u32(0).u32(uint32(len(groups)))
+	for _, g := range groups {
+		w.u32(g.start).u32(g.end).u32(g.glyph)
+	}
+	return w.buf.Bytes()
+}
+
+// nameTable 返回字体名称表，版权和许可证信息取自源字体
+func (b *builder) nameTable() ([]byte, error) {
+	copyright, err := b.src.Name(&b.buf, sfnt.NameIDCopyright)
+	if err != nil {
+		return nil, err
+	}
+	license, _ := b.src.Name(&b.buf, sfnt.NameIDLicense)
+	licenseURL, _ := b.src.Name(&b.buf, sfnt.NameIDLicenseURL)
+	records := []struct {
+		id   uint16
+		text string
+	}{
+		{0, copyright},
+		{1, familyName},
+		{2, "Regular"},
+		{3, psName + ";subset of Source Han Sans SC"},
+		{4, familyName + " Regular"},
+		{5, "Version 1.000"},
+		{6, psName},
+		{13, license},
+		{14, licenseURL},
+	}
+
+	var strs bytes.Buffer
+	w := newWriter()
+	w.u16(0).u16(uint16(len(records))).u16(uint16(6 + 12*len(records)))
+	for _, rec := range records {
+		encoded := utf16.Encode([]rune(rec.text))
+		w.u16(3).u16(1).u16(0x0409).u16(rec.id).u16(uint16(2 * len(encoded))).u16(uint16(strs.Len()))
+		for _, u := range encoded {
+			strs.Write([]byte{byte(u >> 8), byte(u)})
+		}
+	}
+	w.bytes(strs.Bytes())
+	return w.buf.Bytes(), nil
+}
+
+// table 返回源字体中指定的表
+func (b *builder) table(tag string) ([]byte, error) {
+	n := int(binary.BigEndian.Uint16(b.raw[4:]))
+	for i := 0; i < n; i++ {
+		entry := b.raw[12+16*i:]
+		if string(entry[:4]) == tag {
+			offset, length := binary.BigEndian.Uint32(entry[8:]), binary.BigEndian.Uint32(entry[12:])
+			return b.raw[offset : offset+length], nil
+		}
+	}
+	return nil, fmt.Errorf("源字体中没有%s表", tag)
+}
+
+// assemble 按标签排序写出表目录和各表，并填写head表的校验和调整值
+func assemble(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	n := len(tags)
+	entrySelector := int(math.Floor(math.Log2(float64(n))))
+	searchRange := (1 << entrySelector) * 16
+	w := newWriter()
+	w.u32(0x00010000).u16(uint16(n)).u16(uint16(searchRange)).u16(uint16(entrySelector)).u16(uint16(n*16 - searchRange))
+
+	offset := 12 + 16*n
+	headOffset := 0
+	for _, tag := range tags {
+		data := tables[tag]
+		if tag == "head" {
+			headOffset = offset
+		}
+		w.bytes([]byte(tag))
+		w.u32(checksum(data)).u32(uint32(offset)).u32(uint32(len(data)))
+		offset += (len(data) + 3) &^ 3
+	}
+	for _, tag := range tags {
+		w.bytes(tables[tag])
+		for w.buf.Len()%4 != 0 {
+			w.bytes([]byte{0})
+		}
+	}
+
+	out := w.buf.Bytes()
+	binary.BigEndian.PutUint32(out[headOffset+8:], 0xB1B0AFBA-checksum(out))
+	return out
+}
+
+// checksum 计算TrueType表的校验和：按大端32位整数求和，不足4字节的部分补零
+func checksum(data []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(data); i += 4 {
+		var word [4]byte
+		copy(word[:], data[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
+
+// writer 按大端顺序写出字体数据
+type writer struct{ buf bytes.Buffer }
+
+func newWriter() *writer { return &writer{} }
+
+func (w *writer) u16(v uint16) *writer {
+	w.buf.Write([]byte{byte(v >> 8), byte(v)})
+	return w
+}
+
+func (w *writer) i16(v int) *writer { return w.u16(uint16(int16(v))) }
+
+func (w *writer) u32(v uint32) *writer {
+	w.buf.Write([]byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)})
+	return w
+}
+
+func (w *writer) bytes(p []byte) *writer {
+	w.buf.Write(p)
+	return w
+}
@@ -0,0 +1,78 @@
+package font
+
+import (
+	"image/color"
+	"image/draw"
+
+	"golang.org/x/image/font"
+	"golang.org/x/text/width"
+)
+
+// CellWidth 返回等宽排版时一个半角字符格的宽度（像素）
+// 取ASCII可见字符中最大的前进宽度，比例字体中最宽的字符也能放进格子
+func (r *Renderer) CellWidth() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cellWidth()
+}
+
+// cellWidth CellWidth的实现，调用者必须持有r.mu
+func (r *Renderer) cellWidth() int {
+	face := r.face(font.HintingFull)
+	cell := 0
+	for ch := rune(0x21); ch < 0x7F; ch++ {
+		if adv, ok := face.GlyphAdvance(ch); ok && adv.Ceil() > cell {
+			cell = adv.Ceil()
+		}
+	}
+	if cell == 0 {
+		cell = int(r.size / 2)
+	}
+	return cell
+}
+
+// cellsOf 返回字符在等宽排版中占的格数：全角字符（如汉字）占2格，其它字符占1格
+func cellsOf(ch rune) int {
+	switch width.LookupRune(ch).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// MeasureMonospace 返回等宽排版时单行文本的宽度（像素）
+// 参数text: 要测量的文本
+func (r *Renderer) MeasureMonospace(text string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cells := 0
+	for _, ch := range r.sanitizeLine(text) {
+		cells += cellsOf(ch)
+	}
+	return cells * r.cellWidth()
+}
+
+// RenderMonospaceInto 按固定的字符格逐字绘制单行文本，用于字符画等依赖列对齐的内容
+// 比例字体中各字符的宽度不同，按普通方式绘制时字符画会错位；此方法把每个字符放在自己的格子中水平居中，
+// 半角字符占1格，全角字符占2格，与终端中的显示一致
+// 参数dst: 目标图像
+// 参数x,y: 文本区域的左上角坐标
+// 参数text: 要绘制的文本
+// 参数textColor: 文本颜色
+func (r *Renderer) RenderMonospaceInto(dst draw.Image, x, y int, text string, textColor color.Color) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cell := r.cellWidth()
+	face := r.face(font.HintingFull)
+	for _, ch := range r.sanitizeLine(text) {
+		span := cellsOf(ch) * cell
+		if ch != ' ' {
+			adv, _ := face.GlyphAdvance(ch)
+			if err := r.renderTextInto(dst, x+(span-adv.Round())/2, y, string(ch), textColor); err != nil {
+				return err
+			}
+		}
+		x += span
+	}
+	return nil
+}
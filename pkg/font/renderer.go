@@ -9,6 +9,8 @@ import (
 	"image/color"
 	"image/draw"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/golang/freetype"
 	"github.com/golang/freetype/truetype"
@@ -17,11 +19,26 @@ import (
 
 // Renderer 字体渲染器结构体
 // 封装了字体文件、渲染上下文和相关参数
+// 忙碌动画、跑马灯和主循环会在不同的goroutine中使用同一个渲染器，
+// 导出的方法都持有mu，未导出的辅助方法假定调用者已经持有mu
 type Renderer struct {
-	font    *truetype.Font    // TrueType字体对象
-	context *freetype.Context // FreeType渲染上下文
-	dpi     float64           // 每英寸点数（分辨率）
-	size    float64           // 字体大小（点）
+	mu        sync.Mutex            // 保护以下所有字段，渲染时会修改渲染上下文和临时画布
+	font      *truetype.Font        // TrueType字体对象
+	context   *freetype.Context     // FreeType渲染上下文
+	dpi       float64               // 每英寸点数（分辨率）
+	size      float64               // 字体大小（点）
+	src       *image.Uniform        // 复用的文本颜色源，避免每次绘制分配
+	scratch   *image.RGBA           // 复用的临时画布，用于RenderTextInto
+	faces     map[faceKey]font.Face // 按字号、DPI和微调方式缓存的字体Face
+	tabWidth  int                   // 制表符展开的制表位宽度（字符数）
+	faceIndex int                   // 当前字体在TTC字体集合中的序号
+}
+
+// faceKey 字体Face缓存的键
+type faceKey struct {
+	size    float64
+	dpi     float64
+	hinting font.Hinting
 }
 
 // NewRenderer 创建新的字体渲染器
@@ -30,10 +47,45 @@ type Renderer struct {
 // 参数dpi: 分辨率（每英寸点数）
 // 返回初始化完成的渲染器或错误信息
 func NewRenderer(fontPath string, size float64, dpi float64) (*Renderer, error) {
+	return NewRendererWithIndex(fontPath, 0, size, dpi)
+}
+
+// NewRendererWithIndex 创建使用字体集合中指定字体的渲染器
+// 参数fontPath: 字体文件路径（支持.ttf/.ttc格式）
+// 参数faceIndex: TTC字体集合中的字体序号（从0开始），普通TTF文件只能为0
+// 参数size: 字体大小（点）
+// 参数dpi: 分辨率（每英寸点数）
+// 例如在同时包含简体和繁体字形的Noto TTC中选择简体中文字体
+func NewRendererWithIndex(fontPath string, faceIndex int, size float64, dpi float64) (*Renderer, error) {
 	// 验证参数
 	if fontPath == "" {
 		return nil, fmt.Errorf("字体文件路径不能为空")
 	}
+
+	// 读取字体文件内容 (使用新的API)
+	fontBytes, err := os.ReadFile(fontPath)
+	if err != nil {
+		return nil, fmt.Errorf("无法读取字体文件 %s: %v", fontPath, err)
+	}
+
+	r, err := newRendererFromBytes(fontBytes, faceIndex, size, dpi)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", fontPath, err)
+	}
+	return r, nil
+}
+
+// NewRendererFromBytes 使用内存中的字体数据创建字体渲染器
+// 参数fontBytes: 字体文件的完整内容
+// 参数size: 字体大小（点）
+// 参数dpi: 分辨率（每英寸点数）
+// 返回初始化完成的渲染器或错误信息
+func NewRendererFromBytes(fontBytes []byte, size float64, dpi float64) (*Renderer, error) {
+	return newRendererFromBytes(fontBytes, 0, size, dpi)
+}
+
+// newRendererFromBytes 解析字体数据中指定序号的字体并创建渲染器
+func newRendererFromBytes(fontBytes []byte, faceIndex int, size float64, dpi float64) (*Renderer, error) {
 	if size <= 0 || size > 200 {
 		return nil, fmt.Errorf("字体大小无效: %f", size)
 	}
@@ -41,15 +93,35 @@ func NewRenderer(fontPath string, size float64, dpi float64) (*Renderer, error)
 		return nil, fmt.Errorf("DPI值无效: %f", dpi)
 	}
 
-	// 读取字体文件内容 (使用新的API)
-	fontBytes, err := os.ReadFile(fontPath)
+	f, err := parseFont(fontBytes, faceIndex)
 	if err != nil {
-		return nil, fmt.Errorf("无法读取字体文件 %s: %v", fontPath, err)
+		return nil, err
 	}
 
+	// 创建FreeType渲染上下文
+	c := freetype.NewContext()
+	c.SetFont(f)        // 设置字体
+	c.SetFontSize(size) // 设置字体大小
+	c.SetDPI(dpi)       // 设置分辨率
+
+	return &Renderer{
+		font:      f,
+		context:   c,
+		dpi:       dpi,
+		size:      size,
+		src:       image.NewUniform(color.White),
+		faces:     make(map[faceKey]font.Face),
+		tabWidth:  DefaultTabWidth,
+		faceIndex: faceIndex,
+	}, nil
+}
+
+// parseFont 校验并解析字体数据
+// 参数faceIndex: TTC字体集合中的字体序号，普通TTF文件只能为0
+func parseFont(fontBytes []byte, faceIndex int) (*truetype.Font, error) {
 	// 验证文件大小
 	if len(fontBytes) == 0 {
-		return nil, fmt.Errorf("字体文件为空: %s", fontPath)
+		return nil, fmt.Errorf("字体文件为空")
 	}
 	if len(fontBytes) > 50*1024*1024 { // 限制50MB
 		return nil, fmt.Errorf("字体文件过大: %d bytes", len(fontBytes))
@@ -57,7 +129,13 @@ func NewRenderer(fontPath string, size float64, dpi float64) (*Renderer, error)
 
 	// 检查字体文件格式
 	if err := validateFontFormat(fontBytes); err != nil {
-		return nil, fmt.Errorf("不支持的字体格式 %s: %v", fontPath, err)
+		return nil, fmt.Errorf("不支持的字体格式: %v", err)
+	}
+
+	// 从字体集合中选出指定序号的字体
+	fontBytes, err := selectCollectionFace(fontBytes, faceIndex)
+	if err != nil {
+		return nil, err
 	}
 
 	// 解析字体文件
@@ -65,43 +143,113 @@ func NewRenderer(fontPath string, size float64, dpi float64) (*Renderer, error)
 	if err != nil {
 		// 如果是OTF文件，尝试转换或给出更友好的错误信息
 		if isOTFFont(fontBytes) {
-			return nil, fmt.Errorf("OTF字体格式支持有限，建议使用TTF格式的字体文件。当前文件: %s", fontPath)
+			return nil, fmt.Errorf("OTF字体格式支持有限，建议使用TTF格式的字体文件")
 		}
-		return nil, fmt.Errorf("无法解析字体文件 %s: %v", fontPath, err)
+		return nil, fmt.Errorf("无法解析字体文件: %v", err)
 	}
+	return f, nil
+}
 
-	// 创建FreeType渲染上下文
-	c := freetype.NewContext()
-	c.SetFont(f)        // 设置字体
-	c.SetFontSize(size) // 设置字体大小
-	c.SetDPI(dpi)       // 设置分辨率
+// LoadFont 在运行时切换字体文件
+// 参数fontPath: 新字体文件路径
+// 参数faceIndex: TTC字体集合中的字体序号（从0开始），普通TTF文件只能为0
+// 新字体完全解析成功后才会替换当前字体并清空Face缓存，
+// 任何一步失败都保持原字体不变，因此无需重启程序即可安全地切换字体
+func (r *Renderer) LoadFont(fontPath string, faceIndex int) error {
+	if fontPath == "" {
+		return fmt.Errorf("字体文件路径不能为空")
+	}
 
-	return &Renderer{
-		font:    f,
-		context: c,
-		dpi:     dpi,
-		size:    size,
-	}, nil
+	fontBytes, err := os.ReadFile(fontPath)
+	if err != nil {
+		return fmt.Errorf("无法读取字体文件 %s: %v", fontPath, err)
+	}
+
+	if err := r.swapFont(fontBytes, faceIndex); err != nil {
+		return fmt.Errorf("%s: %v", fontPath, err)
+	}
+	return nil
+}
+
+// LoadEmbeddedFont 在运行时切换回内置后备字体
+func (r *Renderer) LoadEmbeddedFont() error {
+	if err := r.swapFont(embeddedFont, 0); err != nil {
+		return fmt.Errorf("内置字体: %v", err)
+	}
+	return nil
+}
+
+// swapFont 解析字体数据并替换当前字体
+// 解析在锁外进行，替换时持有r.mu，其它goroutine中进行的渲染不会用到一半新一半旧的字体
+func (r *Renderer) swapFont(fontBytes []byte, faceIndex int) error {
+	f, err := parseFont(fontBytes, faceIndex)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.font = f
+	r.faceIndex = faceIndex
+	r.context.SetFont(f)
+	// 旧字体的Face已失效，清空缓存
+	r.faces = make(map[faceKey]font.Face)
+	return nil
+}
+
+// FaceIndex 返回当前使用的字体在TTC字体集合中的序号
+func (r *Renderer) FaceIndex() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.faceIndex
 }
 
 // SetSize 设置字体大小
 // 参数size: 新的字体大小（点）
 // 动态调整渲染器的字体大小，用于不同场景的文字显示
 func (r *Renderer) SetSize(size float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	r.size = size               // 更新内部字体大小记录
 	r.context.SetFontSize(size) // 更新FreeType上下文的字体大小
 }
 
+// face 返回当前字号对应的字体Face
+// 参数hinting: 字体微调方式
+// 同一组(字号, DPI, 微调)只创建一次Face，避免刷新循环中反复构造带来的CPU和GC开销
+// 调用者必须持有r.mu
+func (r *Renderer) face(hinting font.Hinting) font.Face {
+	key := faceKey{size: r.size, dpi: r.dpi, hinting: hinting}
+	if f, ok := r.faces[key]; ok {
+		return f
+	}
+
+	f := truetype.NewFace(r.font, &truetype.Options{
+		Size:    r.size,
+		DPI:     r.dpi,
+		Hinting: hinting,
+	})
+	r.faces[key] = f
+	return f
+}
+
 // GetTextBounds 使用现代的 `golang.org/x/image/font` 库来精确计算文本的边界尺寸
 // 参数text: 要测量的文本字符串
 // 返回文本的宽度和高度（像素）
 // 这个方法能正确处理kerning等高级字体特性，确保尺寸的精确性
 func (r *Renderer) GetTextBounds(text string) (int, int) {
-	face := truetype.NewFace(r.font, &truetype.Options{
-		Size:    r.size,
-		DPI:     r.dpi,
-		Hinting: font.HintingFull, // 使用完整的字体微调，以获得最精确的尺寸
-	})
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.textBounds(text)
+}
+
+// textBounds GetTextBounds的实现，调用者必须持有r.mu
+func (r *Renderer) textBounds(text string) (int, int) {
+	// 与绘制时使用相同的预处理，保证测量结果与实际显示一致
+	text = r.sanitizeLine(text)
+
+	// 使用完整的字体微调，以获得最精确的尺寸
+	face := r.face(font.HintingFull)
 
 	bounds, advance := font.BoundString(face, text)
 
@@ -115,22 +263,101 @@ func (r *Renderer) GetTextBounds(text string) (int, int) {
 	return width + 2, height + 2
 }
 
+// Ellipsis 文本被截断时追加的省略号
+const Ellipsis = "…"
+
+// TruncateToWidth 将文本截断到指定的像素宽度以内
+// 参数text: 要截断的文本字符串
+// 参数maxWidth: 允许的最大宽度（像素）
+// 文本宽度不超过maxWidth时原样返回，否则在末尾追加"…"，
+// 用于让过长的CPU型号、IPv6地址列表等内容保持在一行内显示
+func (r *Renderer) TruncateToWidth(text string, maxWidth int) string {
+	if maxWidth <= 0 {
+		return ""
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if w, _ := r.textBounds(text); w <= maxWidth {
+		return text
+	}
+
+	runes := []rune(text)
+	// 二分查找能容纳的最长前缀（含省略号）
+	lo, hi := 0, len(runes)
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if w, _ := r.textBounds(string(runes[:mid]) + Ellipsis); w <= maxWidth {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+
+	if lo == 0 {
+		// 连省略号本身都放不下时返回空串
+		if w, _ := r.textBounds(Ellipsis); w > maxWidth {
+			return ""
+		}
+	}
+	return strings.TrimRight(string(runes[:lo]), " ") + Ellipsis
+}
+
+// LineHeight 返回当前字号下的标准行高（像素）
+// 取自字体度量（ascent+descent+行距），与具体文本内容无关，
+// 因此中文、英文和数字混排的各行间距保持一致
+func (r *Renderer) LineHeight() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lineHeight()
+}
+
+// lineHeight LineHeight的实现，调用者必须持有r.mu
+func (r *Renderer) lineHeight() int {
+	return r.face(font.HintingNone).Metrics().Height.Ceil()
+}
+
+// Ascent 返回当前字号下基线以上的高度（像素）
+// 绘制单行文本时，基线位于文本区域顶部向下Ascent像素处
+func (r *Renderer) Ascent() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ascent()
+}
+
+// ascent Ascent的实现，调用者必须持有r.mu
+func (r *Renderer) ascent() int {
+	return r.face(font.HintingNone).Metrics().Ascent.Ceil()
+}
+
+// Descent 返回当前字号下基线以下的高度（像素）
+func (r *Renderer) Descent() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.face(font.HintingNone).Metrics().Descent.Ceil()
+}
+
 // RenderText 渲染单行文本为图像
 // 参数text: 要渲染的文本字符串
 // 参数textColor: 文本颜色
 // 返回包含渲染文本的图像或错误信息
 // 支持中文字符的完美渲染，包括复杂汉字
 func (r *Renderer) RenderText(text string, textColor color.Color) (image.Image, error) {
-	// 计算文本尺寸
-	width, height := r.GetTextBounds(text)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	text = r.sanitizeLine(text)
+	// 宽度按文本测量，高度使用统一的行高，保证混排文本高度一致
+	width, _ := r.textBounds(text)
+	height := r.lineHeight()
 	// 如果计算失败，使用默认尺寸
-	if width == 0 || height == 0 {
+	if width == 0 {
 		width = 100
+	}
+	if height == 0 {
 		height = int(r.size)
 	}
 
-	// 创建RGBA图像，额外添加10像素高度以防止裁剪
-	img := image.NewRGBA(image.Rect(0, 0, width, height+10))
+	// 创建RGBA图像
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
 	// 用透明色填充背景
 	draw.Draw(img, img.Bounds(), &image.Uniform{color.RGBA{0, 0, 0, 0}}, image.Point{}, draw.Src)
 
@@ -140,7 +367,7 @@ func (r *Renderer) RenderText(text string, textColor color.Color) (image.Image,
 	r.context.SetSrc(&image.Uniform{textColor}) // 设置文本颜色
 
 	// 计算文本基线位置
-	pt := freetype.Pt(0, int(r.context.PointToFixed(r.size)>>6))
+	pt := freetype.Pt(0, r.ascent())
 	// 绘制文本字符串
 	_, err := r.context.DrawString(text, pt)
 	if err != nil {
@@ -150,6 +377,12 @@ func (r *Renderer) RenderText(text string, textColor color.Color) (image.Image,
 	return img, nil
 }
 
+// LineStyle 单行文本的显示样式
+// 用于RenderStyledText为每一行指定不同的颜色
+type LineStyle struct {
+	Color color.Color // 文本颜色，为nil时使用默认颜色
+}
+
 // RenderMultilineText 渲染多行文本为图像
 // 参数lines: 文本行数组，每个元素为一行文本
 // 参数textColor: 文本颜色
@@ -157,19 +390,46 @@ func (r *Renderer) RenderText(text string, textColor color.Color) (image.Image,
 // 返回包含渲染文本的图像或错误信息
 // 支持多行中文文本的排版和渲染
 func (r *Renderer) RenderMultilineText(lines []string, textColor color.Color, lineSpacing int) (image.Image, error) {
+	return r.RenderStyledText(lines, nil, textColor, lineSpacing)
+}
+
+// RenderStyledText 渲染带有逐行样式的多行文本
+// 参数lines: 文本行数组，每个元素为一行文本
+// 参数styles: 与lines一一对应的行样式，长度不足或样式颜色为nil的行使用textColor
+// 参数textColor: 默认文本颜色
+// 参数lineSpacing: 行间距（像素）
+// 例如网络测试结果页可以把成功的目标显示为绿色、失败的显示为红色，
+// 而无需在MenuRenderer中逐行单独渲染
+func (r *Renderer) RenderStyledText(lines []string, styles []LineStyle, textColor color.Color, lineSpacing int) (image.Image, error) {
 	// 如果没有文本行，返回最小图像
 	if len(lines) == 0 {
 		return image.NewRGBA(image.Rect(0, 0, 1, 1)), nil
 	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// 展开制表符、统一换行符并去除无法显示的控制字符
+	// 行内换行拆分出的新行沿用原行的颜色
+	var colors []color.Color
+	var normalized []string
+	for i, line := range lines {
+		lineColor := textColor
+		if i < len(styles) && styles[i].Color != nil {
+			lineColor = styles[i].Color
+		}
+		for _, part := range r.normalizeLines([]string{line}) {
+			normalized = append(normalized, part)
+			colors = append(colors, lineColor)
+		}
+	}
+	lines = normalized
 
-	face := truetype.NewFace(r.font, &truetype.Options{Size: r.size, DPI: r.dpi})
-	metrics := face.Metrics()
 	// 使用字体文件中定义的标准行高，这是最可靠的方式
-	fontLineHeight := int(metrics.Height >> 6)
+	fontLineHeight := r.lineHeight()
 
 	maxWidth := 0
 	for _, line := range lines {
-		w, _ := r.GetTextBounds(line) // 只需要宽度用于计算画布最大宽度
+		w, _ := r.textBounds(line) // 只需要宽度用于计算画布最大宽度
 		if w > maxWidth {
 			maxWidth = w
 		}
@@ -193,12 +453,12 @@ func (r *Renderer) RenderMultilineText(lines []string, textColor color.Color, li
 	// 设置FreeType渲染参数
 	r.context.SetClip(img.Bounds())
 	r.context.SetDst(img)
-	r.context.SetSrc(&image.Uniform{textColor})
+	r.context.SetSrc(r.src)
 
 	// 逐行绘制文本
-	ascent := int(metrics.Ascent >> 6)
-	y := ascent // 第一行的基线位置
-	for _, line := range lines {
+	y := r.ascent() // 第一行的基线位置
+	for i, line := range lines {
+		r.src.C = colors[i]
 		pt := freetype.Pt(0, y) // 当前行的绘制位置
 		_, err := r.context.DrawString(line, pt)
 		if err != nil {
@@ -225,22 +485,92 @@ func (r *Renderer) MeasureString(text string) (width, height int) {
 // 参数text: 要绘制的文本字符串
 // 参数textColor: 文本颜色
 // 返回绘制过程中的错误信息
-// 先渲染文本为图像，再复制到目标位置，支持透明度混合
+// 等同于RenderTextInto，保留此名称以兼容已有调用
 func (r *Renderer) DrawTextAt(dst draw.Image, x, y int, text string, textColor color.Color) error {
-	// 渲染文本为图像
-	textImg, err := r.RenderText(text, textColor)
-	if err != nil {
-		return err
+	return r.RenderTextInto(dst, x, y, text, textColor)
+}
+
+// RenderTextInto 将单行文本直接渲染到目标图像的指定区域
+// 参数dst: 目标图像（如*image.RGBA或帧缓冲区）
+// 参数x,y: 文本区域的左上角坐标
+// 参数text: 要绘制的文本字符串
+// 参数textColor: 文本颜色
+// 与RenderText不同，此方法不会为每个字符串分配新图像：
+// 目标为*image.RGBA时直接光栅化到目标上，否则先光栅化到复用的临时画布，
+// 再使用Over模式合成到目标区域，稳定刷新时几乎不产生内存分配
+func (r *Renderer) RenderTextInto(dst draw.Image, x, y int, text string, textColor color.Color) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.renderTextInto(dst, x, y, text, textColor)
+}
+
+// renderTextInto RenderTextInto的实现，调用者必须持有r.mu
+func (r *Renderer) renderTextInto(dst draw.Image, x, y int, text string, textColor color.Color) error {
+	text = r.sanitizeLine(text)
+	if text == "" {
+		return nil
+	}
+
+	// 与RenderText保持一致，高度使用统一的行高
+	width, _ := r.textBounds(text)
+	height := r.lineHeight()
+	if width == 0 {
+		width = 100
+	}
+	if height == 0 {
+		height = int(r.size)
+	}
+	area := image.Rect(x, y, x+width, y+height).Intersect(dst.Bounds())
+	if area.Empty() {
+		return nil
+	}
+
+	r.src.C = textColor
+	ascent := r.ascent()
+
+	// 目标本身就是RGBA图像时直接绘制，省去中间拷贝
+	if rgba, ok := dst.(*image.RGBA); ok {
+		r.context.SetClip(area)
+		r.context.SetDst(rgba)
+		r.context.SetSrc(r.src)
+		if _, err := r.context.DrawString(text, freetype.Pt(x, y+ascent)); err != nil {
+			return fmt.Errorf("无法绘制文本: %v", err)
+		}
+		return nil
 	}
 
-	// 获取文本图像边界
-	bounds := textImg.Bounds()
-	// 使用Over混合模式将文本图像绘制到目标图像的指定位置
-	// Over模式支持透明度，可以在现有内容上叠加文本
-	draw.Draw(dst, image.Rect(x, y, x+bounds.Dx(), y+bounds.Dy()), textImg, bounds.Min, draw.Over)
+	// 其它目标（如帧缓冲区）先光栅化到复用的临时画布
+	canvas := r.scratchCanvas(width, height)
+	r.context.SetClip(canvas.Bounds())
+	r.context.SetDst(canvas)
+	r.context.SetSrc(r.src)
+	if _, err := r.context.DrawString(text, freetype.Pt(0, ascent)); err != nil {
+		return fmt.Errorf("无法绘制文本: %v", err)
+	}
+
+	draw.Draw(dst, area, canvas, area.Min.Sub(image.Pt(x, y)), draw.Over)
 	return nil
 }
 
+// scratchCanvas 返回至少为指定尺寸的透明临时画布
+// 画布在多次调用之间复用，仅在尺寸不足时重新分配
+func (r *Renderer) scratchCanvas(width, height int) *image.RGBA {
+	if r.scratch == nil || r.scratch.Rect.Dx() < width || r.scratch.Rect.Dy() < height {
+		// 按需扩容，并预留余量减少后续扩容次数
+		r.scratch = image.NewRGBA(image.Rect(0, 0, width+width/2, height+height/2))
+	}
+
+	canvas := r.scratch.SubImage(image.Rect(0, 0, width, height)).(*image.RGBA)
+	// 仅清除本次使用的区域
+	for py := 0; py < height; py++ {
+		row := canvas.Pix[py*canvas.Stride : py*canvas.Stride+width*4]
+		for i := range row {
+			row[i] = 0
+		}
+	}
+	return canvas
+}
+
 // validateFontFormat 检查字体文件格式是否支持
 func validateFontFormat(fontData []byte) error {
 	if len(fontData) < 4 {
@@ -257,9 +587,9 @@ func validateFontFormat(fontData []byte) error {
 		return nil // TTC格式
 	}
 
-	// 检查OTF签名
+	// 检查OTF签名，"OTTO"表示字形使用CFF轮廓，freetype只能解析TrueType轮廓
 	if bytes.HasPrefix(fontData, []byte("OTTO")) {
-		return fmt.Errorf("检测到OTF格式，freetype库对此格式支持有限")
+		return fmt.Errorf("CFF轮廓的OTF字体，请改用TTF版本的字体")
 	}
 
 	// 检查WOFF签名
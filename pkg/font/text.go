@@ -0,0 +1,139 @@
+package font
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// DefaultTabWidth 默认的制表位宽度（字符数）
+const DefaultTabWidth = 4
+
+// SetTabWidth 设置制表符展开时的制表位宽度
+// 参数width: 每个制表位包含的字符数，小于1时使用DefaultTabWidth
+func (r *Renderer) SetTabWidth(width int) {
+	if width < 1 {
+		width = DefaultTabWidth
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tabWidth = width
+}
+
+// HasGlyph 返回当前字体中是否有ch的字形，用于在可选的符号（如状态圆点）缺失时改用ASCII字符
+func (r *Renderer) HasGlyph(ch rune) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return ch < 0x80 || (r.font != nil && r.font.Index(ch) != 0)
+}
+
+// normalizeLines 规范化多行文本
+// 统一CR/LF换行符（\r\n和单独的\r都视为换行），将行内换行拆分为独立的行，
+// 并对每一行执行sanitizeLine处理
+func (r *Renderer) normalizeLines(lines []string) []string {
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.ReplaceAll(line, "\r\n", "\n")
+		line = strings.ReplaceAll(line, "\r", "\n")
+		for _, part := range strings.Split(line, "\n") {
+			result = append(result, r.sanitizeLine(part))
+		}
+	}
+	return result
+}
+
+// sanitizeLine 预处理单行文本，使测量和绘制结果一致
+// 1. 制表符按制表位展开为空格，其余控制字符直接去除
+// 2. 统一为NFC规范形式，使"e"+组合重音与预组合的"é"宽度相同
+// 3. 去除零宽字符以及字体中没有字形的组合标记，避免显示为方框并占用宽度
+// 4. 字体缺少全角字形时退回到对应的半角字符
+func (r *Renderer) sanitizeLine(line string) string {
+	line = r.expandControls(line)
+	if !norm.NFC.IsNormalString(line) {
+		line = norm.NFC.String(line)
+	}
+	return r.filterGlyphs(line)
+}
+
+// filterGlyphs 去除零宽字符和无法显示的组合标记，并替换缺失的全角字形
+func (r *Renderer) filterGlyphs(line string) string {
+	// 快速路径：所有字符都无需处理时直接返回，避免额外分配
+	needed := false
+	for _, ch := range line {
+		if _, changed := r.mapGlyph(ch); changed {
+			needed = true
+			break
+		}
+	}
+	if !needed {
+		return line
+	}
+
+	var builder strings.Builder
+	for _, ch := range line {
+		if mapped, _ := r.mapGlyph(ch); mapped >= 0 {
+			builder.WriteRune(mapped)
+		}
+	}
+	return builder.String()
+}
+
+// mapGlyph 返回字符实际应绘制的字符
+// 返回值为-1表示应去除该字符，changed表示结果与原字符不同
+func (r *Renderer) mapGlyph(ch rune) (mapped rune, changed bool) {
+	if ch < 0x80 {
+		return ch, false // ASCII字符无需处理
+	}
+
+	// 零宽空格、零宽连接符、BOM等格式字符没有可见字形
+	if unicode.Is(unicode.Cf, ch) {
+		return -1, true
+	}
+
+	if r.font == nil || r.font.Index(ch) != 0 {
+		return ch, false
+	}
+
+	// 字体中没有字形的组合标记直接去除，而不是显示为方框
+	if unicode.Is(unicode.Mn, ch) || unicode.Is(unicode.Me, ch) {
+		return -1, true
+	}
+
+	// 全角字符缺失时尝试使用对应的半角字符
+	if folded := []rune(width.Fold.String(string(ch))); len(folded) == 1 && folded[0] != ch && r.font.Index(folded[0]) != 0 {
+		return folded[0], true
+	}
+	return ch, false
+}
+
+// expandControls 展开制表符并去除其余控制字符
+func (r *Renderer) expandControls(line string) string {
+	// 快速路径：不含控制字符时直接返回，避免额外分配
+	if strings.IndexFunc(line, unicode.IsControl) < 0 {
+		return line
+	}
+
+	tabWidth := r.tabWidth
+	if tabWidth < 1 {
+		tabWidth = DefaultTabWidth
+	}
+
+	var builder strings.Builder
+	column := 0
+	for _, ch := range line {
+		switch {
+		case ch == '\t':
+			spaces := tabWidth - column%tabWidth
+			builder.WriteString(strings.Repeat(" ", spaces))
+			column += spaces
+		case unicode.IsControl(ch):
+			// 丢弃其它控制字符
+		default:
+			builder.WriteRune(ch)
+			column++
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,100 @@
+package font
+
+import (
+	"strings"
+	"unicode"
+)
+
+// noBreakBefore 不能出现在行首的标点，折行时与前面的字符放在同一行
+const noBreakBefore = "，。、；：！？）】》」』”’,.;:!?)]}%"
+
+// WrapText 把文本按像素宽度折成多行
+// 参数text: 要折行的文本，其中的换行符同样作为分行位置
+// 参数maxWidth: 每行允许的最大宽度（像素）
+// 英文单词、数字和路径等连续的非空白字符尽量整行移到下一行，中日韩文字之间可以任意折行，
+// 逗号句号等标点不会出现在行首；单个单词比整行还宽时按字符拆开。
+// 折行处的空格被去掉；maxWidth不大于0时只按换行符分行
+func (r *Renderer) WrapText(text string, maxWidth int) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		if maxWidth <= 0 {
+			lines = append(lines, paragraph)
+			continue
+		}
+		lines = append(lines, r.wrapLine(paragraph, maxWidth)...)
+	}
+	return lines
+}
+
+// wrapLine 把不含换行符的一行文本按宽度折成多行，空行返回一个空字符串
+func (r *Renderer) wrapLine(line string, maxWidth int) []string {
+	if w, _ := r.MeasureString(line); w <= maxWidth {
+		return []string{line}
+	}
+
+	var lines []string
+	current := ""
+	for _, token := range wrapTokens(line) {
+		if w, _ := r.MeasureString(current + token); w <= maxWidth {
+			current += token
+			continue
+		}
+		if strings.TrimSpace(token) == "" {
+			// 空格放不下时在此处折行，空格本身去掉
+			lines = append(lines, strings.TrimRight(current, " "))
+			current = ""
+			continue
+		}
+		if strings.TrimSpace(current) != "" {
+			lines = append(lines, strings.TrimRight(current, " "))
+		}
+		current = ""
+		// 比整行还宽的单词按字符拆开
+		for _, ch := range token {
+			if w, _ := r.MeasureString(current + string(ch)); w > maxWidth && current != "" {
+				lines = append(lines, current)
+				current = ""
+			}
+			current += string(ch)
+		}
+	}
+	if current != "" || len(lines) == 0 {
+		lines = append(lines, strings.TrimRight(current, " "))
+	}
+	return lines
+}
+
+// wrapTokens 把一行文本拆成折行时不可再分的片段：
+// 连续的非空白、非中日韩字符组成一个片段，每个空格和每个中日韩字符各为一个片段，
+// 不能出现在行首的标点并入前一个片段
+func wrapTokens(line string) []string {
+	var tokens []string
+	word := false // 最后一个片段是否为可以继续追加字符的单词
+	for _, ch := range line {
+		switch {
+		case strings.ContainsRune(noBreakBefore, ch) && len(tokens) > 0 && strings.TrimSpace(tokens[len(tokens)-1]) != "":
+			tokens[len(tokens)-1] += string(ch)
+			word = !isWideRune(ch)
+		case unicode.IsSpace(ch):
+			tokens = append(tokens, string(ch))
+			word = false
+		case isWideRune(ch):
+			tokens = append(tokens, string(ch))
+			word = false
+		case word:
+			tokens[len(tokens)-1] += string(ch)
+		default:
+			tokens = append(tokens, string(ch))
+			word = true
+		}
+	}
+	return tokens
+}
+
+// isWideRune 判断字符是否为可以在任意位置折行的中日韩文字或全角符号
+func isWideRune(ch rune) bool {
+	return unicode.In(ch, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		(ch >= 0x3000 && ch <= 0x303F) || // 中日韩标点
+		(ch >= 0xFF00 && ch <= 0xFFEF) // 全角字符
+}
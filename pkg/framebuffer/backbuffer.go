@@ -0,0 +1,138 @@
+package framebuffer
+
+import "image"
+
+// Effect 从上一帧过渡到新画面的效果
+type Effect int
+
+const (
+	EffectSlideLeft  Effect = iota // 新画面从右侧推入，上一帧向左移出
+	EffectSlideRight               // 新画面从左侧推入，上一帧向右移出
+	EffectFade                     // 上一帧逐渐变为新画面
+)
+
+// backBuffer 后备缓冲区：在屏幕以外绘制下一帧，再按过渡效果显示到屏幕
+type backBuffer struct {
+	screen []byte // 屏幕的内存映射，绘制到后备缓冲区期间fbData指向next
+	prev   []byte // 开始绘制时屏幕上的画面
+	next   []byte // 在后备缓冲区中绘制的新画面
+	active bool   // 是否正在后备缓冲区中绘制
+}
+
+// BeginBackBuffer 开始在后备缓冲区中绘制下一帧
+// 之后的所有绘制写入后备缓冲区，屏幕保持当前的画面，直到EndBackBuffer；
+// 后备缓冲区以当前画面为初始内容，因此只重绘部分区域的页面也能得到完整的下一帧。
+// 返回是否成功开始，已经在后备缓冲区中绘制或设备已关闭时返回false
+func (fb *FrameBuffer) BeginBackBuffer() bool {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+
+	if fb.closed || fb.fbData == nil {
+		return false
+	}
+	if fb.back == nil {
+		fb.back = &backBuffer{}
+	}
+	b := fb.back
+	if b.active {
+		return false
+	}
+	if len(b.prev) != len(fb.fbData) {
+		// 缓冲区只分配一次，之后的每次过渡重复使用
+		b.prev = make([]byte, len(fb.fbData))
+		b.next = make([]byte, len(fb.fbData))
+	}
+	copy(b.prev, fb.fbData)
+	copy(b.next, fb.fbData)
+	b.screen = fb.fbData
+	fb.fbData = b.next
+	b.active = true
+	return true
+}
+
+// EndBackBuffer 结束在后备缓冲区中的绘制，之后的绘制重新直接写入屏幕
+// 屏幕上仍是开始前的画面，新画面保存在后备缓冲区中，由Transition逐帧显示
+func (fb *FrameBuffer) EndBackBuffer() {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+
+	if fb.back == nil || !fb.back.active {
+		return
+	}
+	fb.fbData = fb.back.screen
+	fb.back.screen = nil
+	fb.back.active = false
+}
+
+// Transition 按过渡效果显示从上一帧到新画面之间的一帧
+// area以外的部分（如页眉、页脚）直接显示新画面
+// 参数effect: 过渡效果
+// 参数area: 参与过渡的区域
+// 参数progress: 过渡的进度，0为上一帧，1为完全显示新画面
+func (fb *FrameBuffer) Transition(effect Effect, area image.Rectangle, progress float64) {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+
+	b := fb.back
+	if fb.closed || fb.fbData == nil || b == nil || b.active || len(b.next) != len(fb.fbData) {
+		return
+	}
+	if progress < 0 {
+		progress = 0
+	} else if progress > 1 {
+		progress = 1
+	}
+
+	bytesPerPixel := fb.bpp / 8
+	lineLength := int(fb.screenInfo.LineLength)
+	rowBytes := fb.width * bytesPerPixel
+	if bytesPerPixel == 0 || rowBytes > lineLength || (fb.height-1)*lineLength+rowBytes > len(fb.fbData) {
+		return
+	}
+	area = area.Intersect(fb.Bounds())
+	shift := int(float64(fb.width)*progress) * bytesPerPixel // 滑动时移动的字节数
+
+	for y := 0; y < fb.height; y++ {
+		start := y * lineLength
+		dst := fb.fbData[start : start+rowBytes]
+		prev := b.prev[start : start+rowBytes]
+		next := b.next[start : start+rowBytes]
+		if y < area.Min.Y || y >= area.Max.Y || progress == 1 {
+			copy(dst, next)
+			continue
+		}
+		switch effect {
+		case EffectSlideLeft:
+			copy(dst, prev[shift:])
+			copy(dst[rowBytes-shift:], next[:shift])
+		case EffectSlideRight:
+			copy(dst, next[rowBytes-shift:])
+			copy(dst[shift:], prev[:rowBytes-shift])
+		case EffectFade:
+			fb.blendRow(dst, prev, next, progress)
+		}
+	}
+}
+
+// blendRow 按比例混合一行的两帧像素，16位色深时先拆分为各颜色分量
+func (fb *FrameBuffer) blendRow(dst, prev, next []byte, progress float64) {
+	mix := func(a, b uint16) uint16 {
+		return uint16(float64(a) + (float64(b)-float64(a))*progress)
+	}
+	if fb.bpp != 16 {
+		for i := range dst {
+			dst[i] = byte(mix(uint16(prev[i]), uint16(next[i])))
+		}
+		return
+	}
+	for i := 0; i+1 < len(dst); i += 2 {
+		p := uint16(prev[i]) | uint16(prev[i+1])<<8
+		n := uint16(next[i]) | uint16(next[i+1])<<8
+		r := mix(p>>11, n>>11)
+		g := mix(p>>5&0x3F, n>>5&0x3F)
+		bl := mix(p&0x1F, n&0x1F)
+		pixel := r<<11 | g<<5 | bl
+		dst[i] = byte(pixel)
+		dst[i+1] = byte(pixel >> 8)
+	}
+}
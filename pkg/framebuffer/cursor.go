@@ -0,0 +1,160 @@
+package framebuffer
+
+import (
+	"image/color"
+	"sync"
+)
+
+// cursorSprite 鼠标指针图案（箭头）
+// '#'为黑色描边，'.'为白色填充，空格为透明
+var cursorSprite = []string{
+	"#",
+	"##",
+	"#.#",
+	"#..#",
+	"#...#",
+	"#....#",
+	"#.....#",
+	"#......#",
+	"#.......#",
+	"#........#",
+	"#.....#####",
+	"#..#..#",
+	"#.# #..#",
+	"##  #..#",
+	"#    #..#",
+	"     #..#",
+	"      ##",
+}
+
+// 鼠标指针颜色
+var (
+	cursorOutline = color.RGBA{0, 0, 0, 255}
+	cursorFill    = color.RGBA{255, 255, 255, 255}
+)
+
+// Cursor 在帧缓冲区上绘制的鼠标指针
+// 绘制前保存指针下方的像素，移动或隐藏时恢复，避免在屏幕上留下痕迹
+type Cursor struct {
+	fb      *FrameBuffer
+	mu      sync.Mutex
+	x, y    int           // 指针热点（左上角）坐标
+	visible bool          // 是否正在显示
+	saved   []color.Color // 指针覆盖区域的原始像素，按cursorSprite的顺序保存
+	drawn   []color.Color // 绘制后读回的指针像素，用于判断指针是否已被重绘覆盖
+}
+
+// NewCursor 创建鼠标指针，初始位于屏幕中央且不显示
+// 参数fb: 绘制指针的帧缓冲区
+func NewCursor(fb *FrameBuffer) *Cursor {
+	width, height := fb.GetDimensions()
+	return &Cursor{fb: fb, x: width / 2, y: height / 2}
+}
+
+// Position 返回指针当前坐标
+func (c *Cursor) Position() (int, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.x, c.y
+}
+
+// MoveTo 将指针移动到指定位置并显示
+// 参数x,y: 新的指针坐标
+func (c *Cursor) MoveTo(x, y int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.visible {
+		c.restore()
+	}
+	c.x, c.y = x, y
+	c.draw()
+}
+
+// Hide 隐藏指针并恢复其下方的像素
+func (c *Cursor) Hide() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.visible {
+		c.restore()
+	}
+}
+
+// Redraw 在屏幕内容可能被重绘后重新绘制指针
+// 重绘已覆盖了旧指针，此时不能恢复保存的像素，只需按新的屏幕内容重新保存并绘制；
+// 指针区域未被覆盖时保持不变，避免把指针本身当作背景保存
+func (c *Cursor) Redraw() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.visible && !c.intact() {
+		c.draw()
+	}
+}
+
+// Show 在当前位置显示指针
+func (c *Cursor) Show() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.visible {
+		c.draw()
+	}
+}
+
+// draw 保存指针区域的像素后绘制指针，调用方需持有c.mu
+func (c *Cursor) draw() {
+	c.saved = c.saved[:0]
+	c.drawn = c.drawn[:0]
+	for dy, row := range cursorSprite {
+		for dx, ch := range row {
+			if ch == ' ' {
+				continue
+			}
+			px, py := c.x+dx, c.y+dy
+			c.saved = append(c.saved, c.fb.At(px, py))
+			if ch == '#' {
+				c.fb.SetPixel(px, py, cursorOutline)
+			} else {
+				c.fb.SetPixel(px, py, cursorFill)
+			}
+			c.drawn = append(c.drawn, c.fb.At(px, py))
+		}
+	}
+	c.visible = true
+}
+
+// intact 判断屏幕上的指针是否仍完整存在，调用方需持有c.mu
+func (c *Cursor) intact() bool {
+	i := 0
+	for dy, row := range cursorSprite {
+		for dx, ch := range row {
+			if ch == ' ' {
+				continue
+			}
+			if i >= len(c.drawn) || c.fb.At(c.x+dx, c.y+dy) != c.drawn[i] {
+				return false
+			}
+			i++
+		}
+	}
+	return true
+}
+
+// restore 恢复指针覆盖的像素，调用方需持有c.mu
+func (c *Cursor) restore() {
+	i := 0
+	for dy, row := range cursorSprite {
+		for dx, ch := range row {
+			if ch == ' ' {
+				continue
+			}
+			if i < len(c.saved) {
+				c.fb.SetPixel(c.x+dx, c.y+dy, c.saved[i])
+			}
+			i++
+		}
+	}
+	c.visible = false
+}
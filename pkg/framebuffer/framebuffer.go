@@ -26,6 +26,7 @@ type FrameBuffer struct {
 	bpp        int             // 每像素位数（bits per pixel）
 	mu         sync.RWMutex    // 读写锁，保护并发访问
 	closed     bool            // 关闭状态标志
+	back       *backBuffer     // 后备缓冲区，用于页面切换的过渡效果，第一次使用时创建
 }
 
 // FixedScreenInfo 固定屏幕信息结构体
@@ -207,6 +208,36 @@ func (fb *FrameBuffer) Clear() {
 	}
 }
 
+// Fill 用指定颜色填充整个屏幕
+// 先按色深格式写入第一个像素，再逐段复制到整行和其余各行，比逐像素设置快得多
+func (fb *FrameBuffer) Fill(c color.Color) {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+
+	if fb.closed || fb.fbData == nil || fb.width == 0 || fb.height == 0 {
+		return
+	}
+
+	bytesPerPixel := fb.bpp / 8
+	lineLength := int(fb.screenInfo.LineLength)
+	rowBytes := fb.width * bytesPerPixel
+	if bytesPerPixel == 0 || rowBytes > lineLength || (fb.height-1)*lineLength+rowBytes > len(fb.fbData) {
+		return
+	}
+
+	// 第一行：写入一个像素后成倍复制
+	fb.setPixelUnsafe(0, 0, c)
+	row := fb.fbData[:rowBytes]
+	for n := bytesPerPixel; n < rowBytes; n *= 2 {
+		copy(row[n:], row[:n])
+	}
+
+	// 其余各行复制第一行
+	for y := 1; y < fb.height; y++ {
+		copy(fb.fbData[y*lineLength:y*lineLength+rowBytes], row)
+	}
+}
+
 // SetPixel 在指定位置设置像素颜色
 // 参数x,y: 像素坐标  参数c: 颜色值
 // 根据不同的色深格式写入相应的像素数据
@@ -290,8 +321,62 @@ func (fb *FrameBuffer) DrawImage(img image.Image, x, y int) {
 	}
 }
 
-// Close 关闭帧缓冲区并释放资源
-// 取消内存映射并关闭设备文件
+// ColorModel 返回帧缓冲区的颜色模型
+// 与Bounds、At、Set一起实现draw.Image接口，使帧缓冲区可以作为绘制目标
+func (fb *FrameBuffer) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+// Bounds 返回帧缓冲区的可见区域
+func (fb *FrameBuffer) Bounds() image.Rectangle {
+	return image.Rect(0, 0, fb.width, fb.height)
+}
+
+// At 读取指定位置的像素颜色
+// 超出屏幕范围或设备已关闭时返回黑色
+func (fb *FrameBuffer) At(x, y int) color.Color {
+	fb.mu.RLock()
+	defer fb.mu.RUnlock()
+
+	if fb.closed || fb.fbData == nil {
+		return color.RGBA{0, 0, 0, 255}
+	}
+	return fb.getPixelUnsafe(x, y)
+}
+
+// Set 设置指定位置的像素颜色，等同于SetPixel
+func (fb *FrameBuffer) Set(x, y int, c color.Color) {
+	fb.SetPixel(x, y, c)
+}
+
+// getPixelUnsafe 不安全的像素读取方法，调用前需要确保已加锁
+func (fb *FrameBuffer) getPixelUnsafe(x, y int) color.RGBA {
+	black := color.RGBA{0, 0, 0, 255}
+	if x < 0 || x >= fb.width || y < 0 || y >= fb.height {
+		return black
+	}
+
+	// 计算像素在帧缓冲区中的字节偏移量
+	offset := y*int(fb.screenInfo.LineLength) + x*(fb.bpp/8)
+	bytesPerPixel := fb.bpp / 8
+	if offset < 0 || offset+bytesPerPixel > len(fb.fbData) {
+		return black
+	}
+
+	// 根据不同的色深格式解析像素数据
+	switch fb.bpp {
+	case 16: // 16位色深（RGB565格式）
+		pixel := uint16(fb.fbData[offset]) | uint16(fb.fbData[offset+1])<<8
+		r := uint8((pixel >> 11) & 0x1F)
+		g := uint8((pixel >> 5) & 0x3F)
+		b := uint8(pixel & 0x1F)
+		return color.RGBA{r<<3 | r>>2, g<<2 | g>>4, b<<3 | b>>2, 255}
+	case 24, 32: // 24/32位色深（BGR顺序）
+		return color.RGBA{fb.fbData[offset+2], fb.fbData[offset+1], fb.fbData[offset], 255}
+	}
+	return black
+}
+
 // setPixelUnsafe 不安全的像素设置方法，调用前需要确保已加锁
 func (fb *FrameBuffer) setPixelUnsafe(x, y int, c color.Color) {
 	// 边界检查，超出屏幕范围则直接返回
@@ -348,6 +433,8 @@ func max(a, b int) int {
 	return b
 }
 
+// Close 关闭帧缓冲区并释放资源
+// 取消内存映射并关闭设备文件
 func (fb *FrameBuffer) Close() error {
 	fb.mu.Lock()
 	defer fb.mu.Unlock()
@@ -358,13 +445,19 @@ func (fb *FrameBuffer) Close() error {
 	
 	var err error
 	
-	// 取消内存映射
-	if fb.fbData != nil {
+	// 正在后备缓冲区中绘制时fbData指向后备缓冲区，取消映射的应是屏幕
+	if fb.back != nil && fb.back.active {
+		fb.fbData = fb.back.screen
+		fb.back.active = false
+	}
+	
+	// 取消内存映射（内存帧缓冲区没有设备文件，无需取消映射）
+	if fb.fbData != nil && fb.device != nil {
 		if munmapErr := syscall.Munmap(fb.fbData); munmapErr != nil {
 			err = fmt.Errorf("取消内存映射失败: %v", munmapErr)
 		}
-		fb.fbData = nil
 	}
+	fb.fbData = nil
 	
 	// 关闭设备文件
 	if fb.device != nil {
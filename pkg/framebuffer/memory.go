@@ -0,0 +1,45 @@
+package framebuffer
+
+import (
+	"fmt"
+	"image"
+)
+
+// NewMemoryFrameBuffer 创建不对应任何显示设备的内存帧缓冲区
+// 用于没有显示器的设备（如仅通过串口操作时），界面照常绘制到内存中，
+// 所有绘制方法与真实设备一致，像素格式固定为32位
+// 参数width,height: 虚拟屏幕的分辨率
+func NewMemoryFrameBuffer(width, height int) (*FrameBuffer, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("无效的分辨率: %dx%d", width, height)
+	}
+	fb := &FrameBuffer{
+		width:  width,
+		height: height,
+		bpp:    32,
+		fbData: make([]byte, width*height*4),
+	}
+	fb.screenInfo.LineLength = uint32(width * 4)
+	fb.varInfo.XRes = uint32(width)
+	fb.varInfo.YRes = uint32(height)
+	fb.varInfo.BitsPerPixel = 32
+	return fb, nil
+}
+
+// Snapshot 返回屏幕当前内容的副本，之后的绘制不影响返回的图像
+// 内存帧缓冲区用于在测试中检查页面的画面，真实设备上可用于截图
+func (fb *FrameBuffer) Snapshot() *image.RGBA {
+	fb.mu.RLock()
+	defer fb.mu.RUnlock()
+
+	img := image.NewRGBA(image.Rect(0, 0, fb.width, fb.height))
+	if fb.closed || fb.fbData == nil {
+		return img
+	}
+	for y := 0; y < fb.height; y++ {
+		for x := 0; x < fb.width; x++ {
+			img.SetRGBA(x, y, fb.getPixelUnsafe(x, y))
+		}
+	}
+	return img
+}
@@ -0,0 +1,502 @@
+package i18n
+
+// enUS 美式英文消息目录
+// 键为界面上的简体中文原文，格式字符串的译文必须保留原文中的格式动词及其顺序
+var enUS = Catalog{
+	// 通用
+	"确定":       "OK",
+	"取消":       "Cancel",
+	"按任意键继续":   "Press any key to continue",
+	"按任意键返回":   "Press any key to return",
+	"操作失败: %v": "Operation failed: %v",
+	"未知":       "Unknown",
+	"(未配置)":    "(not configured)",
+
+	// 页脚的按键提示
+	"方向键":  "Arrows",
+	"任意键":  "Any key",
+	"选择":   "Select",
+	"返回":   "Back",
+	"滚动":   "Scroll",
+	"翻页":   "Page",
+	"左右键":  "Left/Right",
+	"上下键":  "Up/Down",
+	"切换":   "Switch",
+	"确认":   "Confirm",
+	"菜单":   "Menu",
+	"退出程序": "Quit",
+	"返回首页": "Home",
+	"空格":   "Space",
+	"暂停":   "Pause",
+	"继续":   "Resume",
+	"跟踪":   "Follow",
+	"刷新":   "Refresh",
+
+	// 状态栏
+	"告警 %d": "Alerts %d",
+
+	// 首页
+	"系统信息":                "System Information",
+	"主机名：%s":              "Hostname: %s",
+	"操作系统：%s（内核 %s，%s）":   "OS: %s (kernel %s, %s)",
+	"操作系统运行时间：%s":         "System uptime: %s",
+	"处理器型号：%s *%d 核":      "Processor: %s x%d cores",
+	"CPU使用率：":             "CPU usage:",
+	"内存使用状态：":             "Memory usage:",
+	"根分区使用状态：":            "Root filesystem:",
+	"系统安装磁盘大小：%s（共%d个磁盘）": "System disk size: %s (%d disks)",
+	"当前系统时间：%s":           "System time: %s",
+	"（时间未同步）":             " (clock not synchronized)",
+	"（已同步，偏差 %s）":         " (synchronized, offset %s)",
+	"（已同步）":               " (synchronized)",
+	"设备IP地址：%s":           "IP address: %s",
+	"设备ID：%s":             "Device ID: %s",
+	"未获取到":                "Not available",
+	"此处为二维码展示，二维码的值为设备ID": "The QR code below encodes the device ID",
+	"二维码生成失败: %v":         "Failed to generate QR code: %v",
+	"二维码生成失败：%v":          "Failed to generate QR code: %v",
+	"首页模板执行失败: %v":        "Main page template failed: %v",
+	"无法获取乾坤云设备ID":         "device ID is not available",
+	"无法获取设备IP地址":          "IP address is not available",
+	"无法获取主机名":             "hostname is not available",
+	"无法获取设备厂商":            "device vendor is not available",
+	"无法获取设备型号":            "device model is not available",
+	"无法获取设备序列号，需要root权限":  "serial number is not available (requires root)",
+	"没有配置二维码的url":         "no QR code url is configured",
+	"扫描二维码":               "Scan the QR code",
+	"扫码":                  "QR codes",
+	"9. 扫码":               "9. QR codes",
+	"设备二维码":               "Device QR code",
+	"管理页面":                "Web admin",
+	"连接无线网络":              "Join Wi-Fi",
+	"网络名称：%s":             "Network: %s",
+	"没有可以显示的二维码":          "No QR codes to show",
+	"如有问题请咨询技术客服：微信：your-service-wechat": "For help, contact technical support on WeChat: your-service-wechat",
+	"CPU使用率（最近5分钟）":                      "CPU usage (last 5 min)",
+	"按回车键进入配置菜单":                         "Press Enter to open the configuration menu",
+	"根分区使用率 %.1f%%，超过%.0f%%":             "Root filesystem usage %.1f%% exceeds %.0f%%",
+	"内存使用率 %.1f%%，超过%.0f%%":              "Memory usage %.1f%% exceeds %.0f%%",
+	"温度 %.1f°C（%s），超过%.0f°C":             "Temperature %.1f°C (%s) exceeds %.0f°C",
+	"版本 %s":                              "Version %s",
+	"扫码结果：\n%s":                          "Scanned code:\n%s",
+	"已保存扫码内容：\n%s":                       "Scanned code saved:\n%s",
+
+	// 系统信息的取值
+	"%d天 %d小时 %d分钟":            "%dd %dh %dm",
+	"%d天 %d小时":                 "%dd %dh",
+	"%d小时 %d分钟":                "%dh %dm",
+	"%d分钟 %d秒":                 "%dm %ds",
+	"%d秒":                      "%ds",
+	"%.1f%% (已用: %s / 总计: %s)": "%.1f%% (used: %s / total: %s)",
+	"，交换 %dM/%dMB":             ", swap %dM/%dMB",
+	"，交换 %s / %s":              ", swap %s / %s",
+	"未知处理器":                    "Unknown processor",
+	"过大":                       "Too large",
+	"未获取到IP":                   "No IP address",
+
+	// 配置菜单
+	"配置菜单":      "Configuration Menu",
+	"1. 查看网卡信息": "1. Network interfaces",
+	"2. 系统服务管理": "2. System services",
+	"3. 检测设备网络": "3. Network connectivity test",
+	"4. 重启设备":   "4. Reboot device",
+	"5. 关机":     "5. Shut down",
+	"6. 切换字体":   "6. Switch font",
+	"7. 仪表盘":    "7. Dashboard",
+	"8. 查看日志":   "8. Logs",
+	"方向键选择，回车确认，或按快捷键；按q返回首页": "Arrows to select, Enter to confirm, or press a shortcut; q to return home",
+	"查看网卡信息":         "Network interfaces",
+	"系统服务管理":         "System Services",
+	"检测设备网络":         "Network connectivity test",
+	"命令执行失败: %v":     "Command failed: %v",
+	"打开页面失败: %v":     "Failed to open page: %v",
+	"正在执行: %s":       "Running: %s",
+	"（命令没有输出）":       "(no output)",
+	"命令执行超时（%v），已结束": "Command timed out after %v and was stopped",
+	"退出状态: %d（耗时%v）": "Exit status: %d (took %v)",
+	"第 %d/%d 页":      "Page %d/%d",
+
+	// 仪表盘
+	"仪表盘":              "Dashboard",
+	"仪表盘中没有配置磁贴":       "No tiles are configured for the dashboard",
+	"内存":               "Memory",
+	"根分区":              "Root filesystem",
+	"网络":               "Network",
+	"服务":               "Services",
+	"温度":               "Temperature",
+	"时间":               "Time",
+	"接收 %s":            "RX %s",
+	"发送 %s":            "TX %s",
+	"已连接网卡 %d/%d":      "Links up %d/%d",
+	"没有配置服务":           "No services configured",
+	"失败的单元: %d":        "Failed units: %d",
+	"告警温度 %.0f°C":      "Alert at %.0f°C",
+	"磁盘读写":             "Disk I/O",
+	"读取 %s（%.0f IOPS）": "Read %s (%.0f IOPS)",
+	"写入 %s（%.0f IOPS）": "Write %s (%.0f IOPS)",
+	"最忙的磁盘 %s":         "Busiest disk %s",
+	"正在采样":             "Sampling",
+
+	// 硬件传感器
+	"硬件传感器":        "Sensors",
+	"温度传感器":        "Temperature sensor",
+	"风扇":           "Fan",
+	"转速":           "Speed",
+	"%d 转/分":       "%d RPM",
+	"没有找到温度传感器":    "No temperature sensors found",
+	"没有找到温度传感器和风扇": "No temperature sensors or fans found",
+	"/sys/class/thermal中的温度区域和/sys/class/hwmon中各芯片的温度": "Thermal zones in /sys/class/thermal and chip temperatures in /sys/class/hwmon",
+	"达到告警温度显示错误色，低于告警温度10°C以内显示警告色":                    "Error color at the alert temperature, warning color within 10°C below it",
+	"/sys/class/hwmon中各芯片的风扇转速，转速为0（停转或未接风扇）显示警告色":     "Fan speeds from /sys/class/hwmon; warning color at 0 RPM (stopped or not connected)",
+
+	// 设备信息
+	"设备信息":       "Device info",
+	"项目":         "Item",
+	"内容":         "Value",
+	"厂商":         "Vendor",
+	"型号":         "Model",
+	"序列号":        "Serial number",
+	"主板厂商":       "Board vendor",
+	"主板型号":       "Board model",
+	"BIOS厂商":     "BIOS vendor",
+	"BIOS版本":     "BIOS version",
+	"BIOS日期":     "BIOS date",
+	"(需要root权限)": "(requires root)",
+	"(未填写)":      "(not set)",
+	"主板BIOS中记录的DMI信息，来自/sys/class/dmi/id，与dmidecode显示的相同":         "DMI information recorded in the BIOS, from /sys/class/dmi/id, as shown by dmidecode",
+	"整机序列号只有root可以读取；厂商未填写或为占位内容时显示为(未填写)":                        "Only root can read the serial number; empty or placeholder values are shown as (not set)",
+	"在[qrcode]的content中使用{vendor}、{model}、{serial}可以把设备信息编入首页二维码": "Use {vendor}, {model} and {serial} in content of [qrcode] to encode device info in the main page QR code",
+
+	// 磁盘使用情况
+	"磁盘使用情况":    "Disk usage",
+	"没有找到挂载的磁盘": "No mounted disks found",
+	"挂载点":       "Mount point",
+	"设备":        "Device",
+	"类型":        "Type",
+	"容量":        "Size",
+	"已用":        "Used",
+	"可用":        "Avail",
+	"使用率":       "Use%",
+	"%d 个挂载点的使用率超过%.0f%%": "%d mount points above %.0f%% usage",
+	"所有挂载点的使用率均未超过%.0f%%": "All mount points are at or below %.0f%% usage",
+	"占用存储空间的文件系统，不含proc、tmpfs等虚拟文件系统；同一设备只列出第一个挂载点": "Filesystems that use storage, excluding virtual ones such as proc and tmpfs; each device is listed once",
+	"普通用户可用的空间，不含为root保留的空间":                        "Space available to regular users, excluding space reserved for root",
+	"与df相同，超过[alerts]中mounts的挂载点以错误色显示":             "Same as df; mount points above mounts in [alerts] are shown in the error color",
+	"磁盘读写（最近5分钟）:":                                  "Disk I/O (last 5 minutes):",
+	"%s 读取":                                         "%s read",
+	"%s 写入":                                         "%s write",
+	"各磁盘每5秒采样一次的平均读写速率和IOPS，不含分区、loop和ram设备": "Average read/write throughput and IOPS of each disk, sampled every 5 seconds; partitions, loop and ram devices are excluded",
+
+	// 日志
+	"查看日志":         "Logs",
+	"程序日志":         "Application log",
+	"服务日志：%s":      "Service log: %s",
+	"已暂停，%d 行新内容":  "Paused, %d new lines",
+	"跟踪最新内容":       "Following",
+	"已停止跟踪，按End恢复": "Not following, press End to resume",
+
+	// 网卡信息
+	"物理网卡信息:":       "Physical network interfaces:",
+	"未找到任何物理网络接口。":  "No physical network interfaces found.",
+	"获取网卡信息失败: %v":  "Failed to get network interfaces: %v",
+	"接口":            "Interface",
+	"状态":            "State",
+	"MAC地址":         "MAC address",
+	"IPv4地址":        "IPv4 address",
+	"驱动":            "Driver",
+	"链路":            "Link",
+	"已接收":           "RX total",
+	"已发送":           "TX total",
+	"IPv6地址:":       "IPv6 addresses:",
+	"网卡流量（最近5分钟）:":  "Traffic (last 5 min):",
+	"%s 接收":         "%s RX",
+	"%s 发送":         "%s TX",
+	"路由:":           "Routes:",
+	"默认网关: (未配置)":   "Default gateway: (not configured)",
+	"默认网关: %s（%s）":  "Default gateway: %s (%s)",
+	"DNS服务器: (未配置)": "DNS servers: (not configured)",
+	"DNS服务器: %s":    "DNS servers: %s",
+
+	// 系统服务
+	"没有配置可以管理的服务": "No services are configured",
+	"最近日志：%s":     "Recent log: %s",
+	"（没有日志）":      "(no log entries)",
+	"重启":          "Restart",
+	"启动":          "Start",
+	"停止":          "Stop",
+	"%s服务":        "%s Service",
+	"确认要%s服务 %s 吗？\n\n按y确认，按n或ESC取消": "%s service %s?\n\nPress y to confirm, n or ESC to cancel",
+	"正在%s服务 %s...":   "%s service %s...",
+	"%s服务 %s 失败: %v": "%s service %s failed: %v",
+	"已%s服务 %s":       "%s service %s: done",
+
+	// 重启系统服务
+	"重启系统服务":     "Restart services",
+	"重启网络服务":     "Restart network",
+	"重启SSH服务":    "Restart SSH",
+	"重启防火墙":      "Restart firewall",
+	"没有配置要重启的服务": "No services to restart are configured",
+	"方向键选择，回车确认，或按快捷键；按q返回":          "Arrows to select, Enter to confirm, or press a shortcut; q to go back",
+	"确认要重启服务 %s 吗？\n\n按y确认，按n或ESC取消": "Restart service %s?\n\nPress y to confirm, n or ESC to cancel",
+	"正在重启服务 %s...":                   "Restarting service %s...",
+	"重启服务 %s 失败: %v":                 "Failed to restart service %s: %v",
+	"最近日志：":                          "Recent log:",
+	"已重启服务 %s":                       "Service %s restarted",
+	"当前状态：%s":                        "Current state: %s",
+
+	// 进程列表
+	"进程列表":       "Processes",
+	"进程（按CPU排序）": "Processes (by CPU)",
+	"进程（按内存排序）":  "Processes (by memory)",
+	"名称":         "Name",
+	"线程":         "Threads",
+	"按CPU排序":     "Sort by CPU",
+	"按内存排序":      "Sort by memory",
+	"其他键":        "Other keys",
+	"上次刷新以来占用所有CPU时间的百分比，所有进程合计不超过100%":    "Share of total CPU time since the last refresh; all processes together stay within 100%",
+	"进程的常驻内存（RSS），内核线程为0":                  "Resident memory (RSS) of the process; 0 for kernel threads",
+	"R运行、S睡眠、D不可中断（通常在等待磁盘）、Z僵尸；D和Z以警告色显示": "R running, S sleeping, D uninterruptible (usually waiting for disk), Z zombie; D and Z are shown in the warning color",
+
+	// 容器
+	"容器": "Containers",
+	"没有找到容器运行时（docker或podman）": "No container runtime (docker or podman) found",
+	"，已自动重启 %d 次":              ", restarted %d times",
+	"没有容器":                     "No containers",
+	"运行时：%s":                   "Runtime: %s",
+	"运行时":                      "Runtime",
+	"容器反复退出、正在按重启策略重启，以错误色显示":                        "Container keeps exiting and is being restarted by its restart policy; shown in the error color",
+	"已停止、已暂停或尚未启动，以警告色显示":                            "Stopped, paused or not started yet; shown in the warning color",
+	"通过docker或podman的套接字查询，可在[containers]的socket中指定": "Queried through the docker or podman socket, which can be set with socket in [containers]",
+	"重启容器": "Restart container",
+	"确认要重启容器 %s 吗？\n\n按y确认，按n或ESC取消": "Restart container %s?\n\nPress y to confirm, n or ESC to cancel",
+	"正在重启容器 %s...": "Restarting container %s...",
+	"已重启容器 %s":     "Container %s restarted",
+
+	// 网络测试目标
+	"网络测试目标":      "Network test targets",
+	"没有测试目标，按a添加": "No test targets, press a to add one",
+	"选中":          "Select",
+	"编辑":          "Edit",
+	"添加":          "Add",
+	"开始测试":        "Run test",
+	"参与测试的目标，\"检测设备网络\"只测试选中的目标": "Targets included in the test; \"Network test\" only tests the selected targets",
+	"ping：发送ICMP回显请求；http：请求URL，检查状态码和证书有效期，URL为空时请求http://主机/；tcp：连接主机的端口，检查防火墙是否放行": "ping: send ICMP echo requests; http: request a URL and check the status code and certificate expiry, http://host/ when the URL is empty; tcp: connect to a port on the host to check that firewalls allow it",
+	"保存": "Saving",
+	"修改只在本次运行中有效，需要长期使用时写入配置文件的[nettest_target]段落": "Changes last until the program exits; add [nettest_target] sections to the configuration to keep them",
+	"最多%d个测试目标":     "At most %d test targets",
+	"已删除测试目标 %s":    "Test target %s removed",
+	"添加测试目标":        "Add test target",
+	"编辑测试目标":        "Edit test target",
+	"主机":            "Host",
+	"次数":            "Count",
+	"超时（秒）":         "Timeout (s)",
+	"已保存测试目标 %s":    "Test target %s saved",
+	"请输入主机名或IP地址":   "Enter a host name or IP address",
+	"%s 不是有效的主机名":   "%s is not a valid host name",
+	"测试类型应为 %s":     "Test type must be %s",
+	"请输入%d到%d之间的整数": "Enter an integer from %d to %d",
+	"期望状态码":         "Expected status",
+	"校验证书(y/n)":     "Verify certificate (y/n)",
+	"请输入y或n":        "Enter y or n",
+	"端口":            "Port",
+	"tcp测试需要指定端口":   "A tcp test needs a port",
+	"%s 不是有效的URL，应以http://或https://开头": "%s is not a valid URL; it must start with http:// or https://",
+	"没有选中的测试目标，请在\"网络测试目标\"中选择":        "No test targets selected; choose them under \"Network test targets\"",
+	"不支持的测试类型: %s":                     "Unsupported test type: %s",
+
+	// 网络连通性测试
+	"网络连通性测试":                          "Network Connectivity Test",
+	"正在初始化网络连通性测试...\n\n请稍候...":        "Preparing the network connectivity test...\n\nPlease wait...",
+	"网络连通性测试进度: %d/%d\n\n当前测试: %s\n%s": "Network connectivity test: %d/%d\n\nTesting: %s\n%s",
+	"网络测试执行失败: %v":                     "Network test failed: %v",
+	"正在测试 %s...":                       "Testing %s...",
+	"%s 测试成功":                          "%s: passed",
+	"%s 测试失败":                          "%s: failed",
+	"测试超时":                             "Test timed out",
+	"ping失败: %v":                       "ping failed: %v",
+	"所有数据包丢失":                          "All packets lost",
+	"%.1f%% 数据包丢失":                     "%.1f%% packet loss",
+	"请求失败: %v":                         "Request failed: %v",
+	"状态码 %d 不符合要求":                     "Unexpected status code %d",
+	"证书已过期":                            "Certificate has expired",
+	"证书将在 %d 天后过期":                     "Certificate expires in %d days",
+	"没有指定端口":                           "No port specified",
+	"%d次连接失败: %s":                      "%d connections failed: %s",
+	"连接被拒绝，端口没有开放":                     "Connection refused, the port is not open",
+	"连接超时，可能被防火墙拦截":                    "Connection timed out, possibly blocked by a firewall",
+	"无法到达主机，请检查路由":                     "Host unreachable, check the routing",
+	"连接失败: %v":                         "Connection failed: %v",
+	"网络连通性测试结果":                        "Network Connectivity Test Results",
+	"  状态: %s":                         "  Status: %s",
+	"  数据包: 发送%d 接收%d 丢失%.1f%%":        "  Packets: sent %d, received %d, lost %.1f%%",
+	"  平均延迟: %s":                       "  Average latency: %s",
+	"  最小/最大延迟: %s / %s，抖动: %s":        "  Min/max latency: %s / %s, jitter: %s",
+	"  各包往返时间: %s":                     "  Round trips: %s",
+	"  URL: %s":                        "  URL: %s",
+	"  状态码: %s":                        "  Status code: %s",
+	"  响应时间: %s":                       "  Response time: %s",
+	"  证书到期: %s（剩余%d天）":                "  Certificate expires: %s (%d days left)",
+	"  连接: 尝试%d 成功%d 失败%.1f%%":         "  Connections: attempted %d, succeeded %d, failed %.1f%%",
+	"  连接耗时: 平均%s，最小/最大 %s / %s":       "  Connect time: average %s, min/max %s / %s",
+	"超时":                     "timeout",
+	"  详情: %s":               "  Details: %s",
+	"正常":                     "OK",
+	"部分正常":                   "Degraded",
+	"异常":                     "Failed",
+	"✓ 网络连接状态: 良好":           "✓ Network status: good",
+	"所有测试目标均可正常访问":           "All test targets are reachable",
+	"⚠ 网络连接状态: 部分异常":         "⚠ Network status: degraded",
+	"可访问 %d/%d 个测试目标":        "%d/%d test targets are reachable",
+	"✗ 网络连接状态: 异常":           "✗ Network status: down",
+	"正在测试DNS解析...\n\n请稍候...": "Testing DNS resolution...\n\nPlease wait...",
+	"DNS解析:":                 "DNS resolution:",
+	"DNS解析: %v":              "DNS resolution: %v",
+	"%s 服务器 %s: 成功 %d/%d":    "%s Server %s: %d/%d resolved",
+	"  %s: 失败: %v":           "  %s: failed: %v",
+	"无法测试DNS解析: %v":          "Could not test DNS resolution: %v",
+	"DNS解析全部失败，请检查DNS服务器设置":      "DNS resolution failed everywhere; check the DNS server settings",
+	"DNS解析正常，无法访问的目标可能被防火墙或路由阻断": "DNS works; unreachable targets may be blocked by a firewall or routing",
+	"没有配置DNS服务器":                 "No DNS servers configured",
+	"查询超时":                       "Query timed out",
+	"连接被拒绝，服务器上没有DNS服务":          "Connection refused; no DNS service on the server",
+	"主机名无效: %s":                  "Invalid host name: %s",
+	"查询格式错误":                     "Format error in query",
+	"服务器内部错误":                    "Server failure",
+	"域名不存在":                      "Domain does not exist",
+	"服务器不支持该查询":                  "Query not supported by the server",
+	"服务器拒绝查询":                    "Query refused by the server",
+	"服务器返回错误码 %d":                "Server returned error code %d",
+	"回复被截断":                      "Reply truncated",
+	"回复格式错误":                     "Malformed reply",
+	"没有IPv4地址":                   "No IPv4 address",
+	"所有测试目标均无法访问":                "No test target is reachable",
+
+	// 路由追踪
+	"路由追踪": "Traceroute",
+	"正在追踪到 %s 的路由...\n\n请稍候...":   "Tracing the route to %s...\n\nPlease wait...",
+	"正在追踪到 %s 的路由...\n\n第%d跳: %s": "Tracing the route to %s...\n\nHop %d: %s",
+	"路由追踪失败: %v":                  "Traceroute failed: %v",
+	"✓ 已到达目标，共%d跳":                "✓ Reached the target in %d hops",
+	"✗ 第%d跳 %s 报告目标不可达":           "✗ Hop %d (%s) reported the target unreachable",
+	"⚠ 追踪中断: %v":                  "⚠ Trace interrupted: %v",
+	"⚠ %d跳内没有到达目标，连接可能在最后一个有回复的路由器之后中断": "⚠ Target not reached within %d hops; the path probably breaks after the last router that replied",
+	"逐跳显示到选中目标的路径和各跳的往返时间，需要root权限":     "Show the path to the selected target hop by hop with round-trip times; requires root",
+
+	// 测速
+	"测速": "Speed test",
+	"没有配置测速地址，请在配置文件的[speedtest]段落中设置download_url或upload_url": "No speed test endpoints configured; set download_url or upload_url in the [speedtest] section of the configuration",
+	"正在准备测速...\n\n请稍候...":     "Preparing the speed test...\n\nPlease wait...",
+	"正在测试下载速率...\n\n当前: %s":   "Testing download speed...\n\nCurrent: %s",
+	"正在测试上传速率...\n\n当前: %s":   "Testing upload speed...\n\nCurrent: %s",
+	"测速失败: %v":                "Speed test failed: %v",
+	"测速结果":                    "Speed Test Results",
+	"下载":                      "Download",
+	"上传":                      "Upload",
+	"%s: %s（传输 %s，用时 %.1f 秒）": "%s: %s (%s in %.1f s)",
+	"  失败: %v":                "  Failed: %v",
+
+	// 重启和关机
+	"重启设备": "Reboot Device",
+	"确认要重启设备吗？\n\n按y确认重启，按n或ESC取消": "Reboot the device?\n\nPress y to reboot, n or ESC to cancel",
+	"正在重启设备...": "Rebooting...",
+	"关机":        "Shut Down",
+	"确认要关机吗？\n\n按y确认关机，按n或ESC取消": "Shut down the device?\n\nPress y to shut down, n or ESC to cancel",
+	"正在关机...":      "Shutting down...",
+	"将在 %d 秒后重启设备": "Rebooting in %d seconds",
+	"将在 %d 秒后关机":   "Shutting down in %d seconds",
+	"按任意键取消":       "Press any key to cancel",
+
+	// 切换字体
+	"切换字体": "Switch Font",
+	"方向键选择，回车确认；按q返回": "Arrows to select, Enter to confirm; q to go back",
+	"0. 内置字体":    "0. Built-in font",
+	"切换字体失败: %v": "Failed to switch font: %v",
+	"字体切换成功":     "Font switched",
+
+	// 表单输入检查
+	"请输入IPv4地址":        "Please enter an IPv4 address",
+	"%s 不是有效的IPv4地址":   "%s is not a valid IPv4 address",
+	"请输入子网掩码":          "Please enter a netmask",
+	"前缀长度 %d 超出范围0-32": "Prefix length %d is out of range 0-32",
+	"%s 不是有效的子网掩码":     "%s is not a valid netmask",
+
+	// 帮助页面
+	"帮助":        "Help",
+	"按键":        "Keys",
+	"显示内容":      "Fields",
+	"此页面没有帮助信息": "No help is available for this page",
+	"物理网卡的名称，不包括虚拟网卡": "Physical interface name; virtual interfaces are not listed",
+	"绿色的Up, Running表示已启用并接通，黄色的Up表示已启用但未接网线，红色的Down表示未启用": "Green Up, Running: enabled and connected; yellow Up: enabled but cable unplugged; red Down: disabled",
+	"网卡的硬件地址":                    "Hardware address of the interface",
+	"网卡的第一个IPv4地址":               "First IPv4 address of the interface",
+	"网卡使用的内核驱动，与ethtool -i显示的相同": "Kernel driver of the interface, as shown by ethtool -i",
+	"协商的速率、双工模式和是否接通网线；半双工显示为警告色，未接通显示为错误色": "Negotiated speed, duplex and carrier; half duplex is shown in the warning color and no carrier in the error color",
+	"流量曲线": "Traffic graphs",
+	"最近5分钟的接收和发送速率，每5秒采样一次，右侧为最近一次采样的速率": "RX and TX rates over the last 5 minutes, sampled every 5 seconds; the latest rate is shown on the right",
+	"已接收/已发送": "RX/TX total",
+	"网卡启用以来累计收发的字节数，重新加载驱动后从0开始": "Bytes received and sent since the interface came up; reset when the driver is reloaded",
+	"默认网关": "Default gateway",
+	"跃点数最小的默认路由的网关和出口网卡，没有默认路由时显示为错误色": "Gateway and interface of the default route with the lowest metric; shown in the error color when there is no default route",
+	"DNS服务器": "DNS servers",
+	"/etc/resolv.conf中的nameserver，使用systemd-resolved时显示其上游服务器": "Nameservers in /etc/resolv.conf; with systemd-resolved, its upstream servers are shown",
+	"路由": "Routes",
+	"/proc/net/route中的全部IPv4路由，格式与ip route相同": "All IPv4 routes in /proc/net/route, in the same format as ip route",
+	"IPv6地址": "IPv6 addresses",
+	"各网卡的全部IPv6地址，较多时可以滚动查看": "All IPv6 addresses of each interface; scroll when there are many",
+	"只在进入页面时刷新":              "Refreshed only when the page is opened",
+	"每 %s 刷新一次":              "Refreshed every %s",
+	"有新内容时自动滚动到最后一行":         "Scrolls to the last line when new content arrives",
+	"向上滚动后停在当前位置，方便查看之前的内容":  "Scrolling up stays in place so earlier content can be read",
+	"已暂停": "Paused",
+	"画面保持不变，新内容暂存起来，继续后一并显示": "The screen is frozen; new lines are kept and shown when resumed",
+	"主机名": "Hostname",
+	"设备的主机名，联系技术客服时可据此确认设备": "Hostname of the device, which identifies it to technical support",
+	"操作系统": "OS",
+	"/etc/os-release中的发行版名称、内核版本（uname -r）和硬件架构（uname -m）": "Distribution name from /etc/os-release, kernel version (uname -r) and architecture (uname -m)",
+	"操作系统运行时间":    "System uptime",
+	"系统启动以来经过的时间": "Time since the system booted",
+	"处理器型号":       "Processor",
+	"CPU型号和逻辑核数":  "CPU model and number of logical cores",
+	"CPU使用率":      "CPU usage",
+	"最近一次采样（每5秒）的CPU使用率，即使用率曲线的最后一个点，颜色规则与内存相同": "CPU usage from the latest sample (every 5 seconds), the last point of the usage chart, colored like memory usage",
+	"内存使用状态": "Memory usage",
+	"已用和总内存，有交换空间时附上交换空间的使用量；进度条只按内存计算，达到70%显示警告色，达到90%显示错误色": "Used and total memory, followed by swap usage when swap is configured; the bar counts memory only, warning color at 70%, error color at 90%",
+	"根分区使用状态": "Root partition usage",
+	"根分区已用和总容量，颜色规则与内存相同": "Used and total space of /, colored like memory usage",
+	"系统安装磁盘大小":            "System disk size",
+	"系统所在磁盘的容量和磁盘个数":      "Capacity of the system disk and number of disks",
+	"当前系统时间":              "System time",
+	"括号中为chrony、ntpd或timedatectl报告的同步状态和偏差，时钟未同步时显示警告色": "In parentheses: sync status and offset reported by chrony, ntpd or timedatectl; warning color when the clock is not synchronized",
+	"设备IP地址":          "Device IP address",
+	"默认路由所在网卡的IPv4地址": "IPv4 address of the interface with the default route",
+	"设备ID":            "Device ID",
+	"联系技术客服时提供的设备标识":  "Device identifier to give to technical support",
+	"告警数":             "Alert count",
+	"状态栏中超过[alerts]阈值的指标数，与告警横幅的条数相同": "Number of metrics over the [alerts] thresholds, shown in the status bar; equals the number of banner lines",
+	"告警横幅": "Alert banner",
+	"根分区、内存或温度超过[alerts]中的阈值时显示": "Shown when root partition, memory or temperature exceeds the [alerts] thresholds",
+	"二维码": "QR code",
+	"内容由[qrcode]中的模板决定，默认为设备ID": "Content comes from the [qrcode] template; the device ID by default",
+	"正在运行，以正常色显示":               "Running, shown in the success color",
+	"服务不存在":                     "not found",
+	"%s，已运行 %s":                 "%s, up %s",
+	"括号中的状态":                    "State in parentheses",
+	"systemd的子状态，如running（正在运行）、exited（已执行完毕）；后面是进入当前状态以来的时间": "systemd sub-state, such as running or exited, followed by the time since the service became active",
+	"运行失败，以错误色显示": "Failed, shown in the error color",
+	"其它状态":        "Other states",
+	"未运行、正在启动或停止等，以警告色显示": "Inactive, starting, stopping etc., shown in the warning color",
+	"最近日志": "Recent log",
+	"选中服务最近的journal日志，执行操作后刷新": "Latest journal lines of the selected service, reloaded after each action",
+
+	// 管理员PIN
+	"请输入管理员PIN": "Enter the admin PIN",
+	"删除":        "Del",
+	"输入":        "Enter digits",
+	"管理员PIN配置无效，请检查配置文件": "The admin PIN in the configuration is invalid",
+	"输错次数过多，请%d秒后再试":     "Too many wrong attempts, try again in %d seconds",
+	"PIN错误，还可以再试%d次":     "Wrong PIN, %d attempts left",
+
+	// 拼音输入法
+	"[英] Ctrl+空格切换中文": "[EN] Ctrl+Space for Chinese",
+	"[中] Ctrl+空格切换英文": "[中] Ctrl+Space for English",
+}
@@ -0,0 +1,95 @@
+// i18n包提供界面文字的多语言支持
+// 界面文字以简体中文原文作为消息键，其它语言的消息目录把原文映射为译文，
+// 目录中没有收录的文字原样显示，因此新增的界面文字即使尚未翻译也能正常显示
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 支持的语言
+const (
+	ZhCN            = "zh-CN" // 简体中文，界面文字的原文
+	EnUS            = "en-US" // 美式英文
+	DefaultLanguage = ZhCN    // 默认语言
+)
+
+// Catalog 一种语言的消息目录：原文 -> 译文
+type Catalog map[string]string
+
+// catalogs 各语言的消息目录，简体中文为原文，不需要目录
+var catalogs = map[string]Catalog{
+	EnUS: enUS,
+}
+
+var (
+	mu      sync.RWMutex
+	current = DefaultLanguage
+)
+
+// Normalize 把语言名称规范化为"语言-地区"的形式
+// 大小写和分隔符不敏感，只写语言时选择该语言的默认地区，如"en"、"en_us"都为"en-US"
+// 参数lang: 语言名称，可以带编码后缀，如"zh_CN.UTF-8"
+// 返回规范化的名称，以及该语言是否受支持
+func Normalize(lang string) (string, bool) {
+	lang, _, _ = strings.Cut(strings.TrimSpace(lang), ".")
+	lang = strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+	switch lang {
+	case "zh", "zh-cn", "zh-hans":
+		return ZhCN, true
+	case "en", "en-us":
+		return EnUS, true
+	}
+	return lang, false
+}
+
+// SetLanguage 切换界面语言，之后翻译的文字使用新语言
+// 参数lang: 语言名称，如"zh-CN"、"en-US"、"en"
+func SetLanguage(lang string) error {
+	name, ok := Normalize(lang)
+	if !ok {
+		return fmt.Errorf("不支持的语言: %s（可选: %s）", lang, strings.Join(Languages(), ", "))
+	}
+	mu.Lock()
+	current = name
+	mu.Unlock()
+	return nil
+}
+
+// Language 返回当前的界面语言
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Languages 返回所有支持的语言
+func Languages() []string {
+	langs := []string{ZhCN}
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs[1:])
+	return langs
+}
+
+// Translate 把界面文字翻译为当前语言
+// 参数msg: 简体中文原文，当前语言的目录中没有收录时原样返回
+func Translate(msg string) string {
+	mu.RLock()
+	catalog := catalogs[current]
+	mu.RUnlock()
+	if text, ok := catalog[msg]; ok {
+		return text
+	}
+	return msg
+}
+
+// Translatef 翻译格式字符串后再格式化
+// 参数format: 简体中文原文的格式字符串，译文中的格式动词与原文一一对应
+func Translatef(format string, args ...interface{}) string {
+	return fmt.Sprintf(Translate(format), args...)
+}
@@ -0,0 +1,503 @@
+package input
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+	"unicode"
+	"unsafe"
+)
+
+// evdev相关的扫描码常量（见linux/input-event-codes.h）
+const (
+	KEY_BACKSPACE  = 14  // 退格键
+	KEY_TAB        = 15  // 制表键
+	KEY_LEFTCTRL   = 29  // 左Ctrl键
+	KEY_LEFTSHIFT  = 42  // 左Shift键
+	KEY_RIGHTSHIFT = 54  // 右Shift键
+	KEY_LEFTALT    = 56  // 左Alt键
+	KEY_SPACE      = 57  // 空格键
+	KEY_CAPSLOCK   = 58  // 大写锁定键
+	KEY_KPENTER    = 96  // 小键盘回车键
+	KEY_RIGHTCTRL  = 97  // 右Ctrl键
+	KEY_RIGHTALT   = 100 // 右Alt键
+	KEY_A          = 30  // 字母A键，用于识别键盘设备
+	KEY_Z          = 44  // 字母Z键，用于识别键盘设备
+)
+
+// evdev按键事件的值
+const (
+	keyValueRelease = 0 // 抬起
+	keyValuePress   = 1 // 按下
+	keyValueRepeat  = 2 // 按住自动重复
+)
+
+// evdevDeviceGlob 输入子系统事件设备的路径模式
+const evdevDeviceGlob = "/dev/input/event*"
+
+// inputEventSize 内核input_event结构的大小（与平台的timeval大小相关）
+const inputEventSize = int(unsafe.Sizeof(InputEvent{}))
+
+// evdevCharKeys 扫描码到字符的映射（美式键盘布局），依次为未按Shift和按住Shift时的字符
+// 作为内置默认布局，其它布局在此基础上覆盖
+var evdevCharKeys = map[uint16][2]rune{
+	2: {'1', '!'}, 3: {'2', '@'}, 4: {'3', '#'}, 5: {'4', '$'}, 6: {'5', '%'},
+	7: {'6', '^'}, 8: {'7', '&'}, 9: {'8', '*'}, 10: {'9', '('}, 11: {'0', ')'},
+	12: {'-', '_'}, 13: {'=', '+'},
+	16: {'q', 'Q'}, 17: {'w', 'W'}, 18: {'e', 'E'}, 19: {'r', 'R'}, 20: {'t', 'T'},
+	21: {'y', 'Y'}, 22: {'u', 'U'}, 23: {'i', 'I'}, 24: {'o', 'O'}, 25: {'p', 'P'},
+	26: {'[', '{'}, 27: {']', '}'},
+	30: {'a', 'A'}, 31: {'s', 'S'}, 32: {'d', 'D'}, 33: {'f', 'F'}, 34: {'g', 'G'},
+	35: {'h', 'H'}, 36: {'j', 'J'}, 37: {'k', 'K'}, 38: {'l', 'L'},
+	39: {';', ':'}, 40: {'\'', '"'}, 41: {'`', '~'}, 43: {'\\', '|'},
+	44: {'z', 'Z'}, 45: {'x', 'X'}, 46: {'c', 'C'}, 47: {'v', 'V'}, 48: {'b', 'B'},
+	49: {'n', 'N'}, 50: {'m', 'M'}, 51: {',', '<'}, 52: {'.', '>'}, 53: {'/', '?'},
+	KEY_SPACE: {' ', ' '},
+	// 小键盘
+	55: {'*', '*'}, 71: {'7', '7'}, 72: {'8', '8'}, 73: {'9', '9'}, 74: {'-', '-'},
+	75: {'4', '4'}, 76: {'5', '5'}, 77: {'6', '6'}, 78: {'+', '+'},
+	79: {'1', '1'}, 80: {'2', '2'}, 81: {'3', '3'}, 82: {'0', '0'}, 83: {'.', '.'},
+	98: {'/', '/'},
+}
+
+// evdevSpecialKeys 扫描码到非字符按键的映射
+var evdevSpecialKeys = map[uint16]Key{
+	KEY_ESC: KeyEscape, KEY_ENTER: KeyEnter, KEY_KPENTER: KeyEnter,
+	KEY_BACKSPACE: KeyBackspace, KEY_TAB: KeyTab,
+	59: KeyF1, 60: KeyF2, 61: KeyF3, 62: KeyF4, 63: KeyF5,
+	64: KeyF6, 65: KeyF7, 66: KeyF8, 67: KeyF9, 68: KeyF10,
+	87: KeyF11, 88: KeyF12,
+	102: KeyHome, 103: KeyUp, 104: KeyPageUp, 105: KeyLeft, 106: KeyRight,
+	107: KeyEnd, 108: KeyDown, 109: KeyPageDown, 110: KeyInsert, 111: KeyDelete,
+}
+
+// evdevReader 直接读取/dev/input/event*的键盘后端
+// 在没有控制终端（如由systemd启动）时代替标准输入读取按键
+type evdevReader struct {
+	mu         sync.Mutex
+	devices    []*os.File               // 已打开的键盘设备
+	pending    []KeyEvent               // 已解码但尚未取走的事件
+	mods       Modifiers                // 当前按住的修饰键
+	held       map[uint16]bool          // 当前按住的修饰键扫描码
+	capsLock   bool                     // 大写锁定状态
+	numLockOff bool                     // 数字锁定是否关闭，关闭时小键盘作为方向键使用
+	pads       map[string]*gamepadState // 各设备的手柄方向轴状态
+	watcher    *hotplugWatcher          // 设备热插拔监听器，不可用时为nil
+	closed     bool                     // 关闭状态标志
+	keymap     *Keymap                  // 键盘布局，nil时使用美式布局
+
+	// 自动重复：内核产生的重复事件被忽略，改为按repeat参数自行合成
+	repeat     RepeatConfig // 自动重复参数
+	repeatKey  *KeyEvent    // 当前按住、需要重复的按键，未按住时为nil
+	repeatCode uint16       // 按住按键的扫描码，用于识别其抬起
+	nextRepeat time.Time    // 下一次合成重复事件的时间
+}
+
+// newEvdevReader 打开系统中所有的键盘事件设备，并监听之后接入的键盘
+// 返回初始化完成的读取器；既没有键盘又无法监听热插拔时返回错误
+func newEvdevReader() (*evdevReader, error) {
+	devices, err := openEvdevDevices(isKeyInputDevice)
+	if err != nil {
+		return nil, err
+	}
+	er := &evdevReader{devices: devices, held: make(map[uint16]bool)}
+
+	watcher, err := newHotplugWatcher()
+	if err != nil {
+		if len(er.devices) == 0 {
+			return nil, fmt.Errorf("未找到可用的键盘输入设备，且无法监听键盘接入: %v", err)
+		}
+		log.Printf("无法监听键盘热插拔，仅使用当前已接入的键盘: %v", err)
+	}
+	er.watcher = watcher
+	return er, nil
+}
+
+// isKeyboardDevice 通过EVIOCGBIT查询设备支持的按键，判断是否为键盘
+// 同时支持字母键和回车键的设备才视为键盘，以排除电源键、鼠标等设备
+func isKeyboardDevice(f *os.File) bool {
+	return hasEventCodes(f, EV_KEY, KEY_A, KEY_Z, KEY_ENTER)
+}
+
+// hasEventCodes 通过EVIOCGBIT查询设备是否支持指定类型下的全部事件代码
+// 参数ev: 事件类型，如EV_KEY、EV_REL
+// 参数codes: 需要支持的事件代码
+func hasEventCodes(f *os.File, ev int, codes ...int) bool {
+	var bits [96]byte // 覆盖KEY_MAX(0x2ff)范围的位图
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL,
+		f.Fd(),
+		eviocgbit(ev, len(bits)),
+		uintptr(unsafe.Pointer(&bits[0])))
+	if errno != 0 {
+		return false
+	}
+
+	for _, code := range codes {
+		if bits[code/8]&(1<<(uint(code)%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// openEvdevDevices 打开所有满足条件的输入事件设备
+// 参数match: 判断设备是否需要的函数，不满足的设备会被关闭
+func openEvdevDevices(match func(f *os.File) bool) ([]*os.File, error) {
+	paths, err := filepath.Glob(evdevDeviceGlob)
+	if err != nil {
+		return nil, fmt.Errorf("查找输入设备失败: %v", err)
+	}
+	sort.Strings(paths)
+
+	var devices []*os.File
+	for _, path := range paths {
+		f, err := os.OpenFile(path, os.O_RDONLY, 0)
+		if err != nil {
+			continue
+		}
+		if !match(f) {
+			f.Close()
+			continue
+		}
+		devices = append(devices, f)
+	}
+	return devices, nil
+}
+
+// waitReadable 等待一组文件描述符中的任意一个可读
+// 参数fds: 文件描述符列表
+// 参数timeout: 最长等待时间
+// 返回与fds一一对应的可读标志，超时或被信号中断时全部为false
+func waitReadable(fds []int, timeout time.Duration) ([]bool, error) {
+	ready := make([]bool, len(fds))
+
+	var readfds syscall.FdSet
+	maxFd := 0
+	for _, fd := range fds {
+		readfds.Bits[fd/64] |= 1 << (uint(fd) % 64)
+		if fd > maxFd {
+			maxFd = fd
+		}
+	}
+
+	tv := syscall.NsecToTimeval(timeout.Nanoseconds())
+	n, err := syscall.Select(maxFd+1, &readfds, nil, nil, &tv)
+	if err != nil {
+		// EINTR 表示系统调用被信号中断，视为本次超时
+		if errno, ok := err.(syscall.Errno); ok && errno == syscall.EINTR {
+			return ready, nil
+		}
+		return nil, fmt.Errorf("select调用失败: %v", err)
+	}
+	if n == 0 {
+		return ready, nil
+	}
+
+	for i, fd := range fds {
+		ready[i] = readfds.Bits[fd/64]&(1<<(uint(fd)%64)) != 0
+	}
+	return ready, nil
+}
+
+// eviocgbit 构造EVIOCGBIT(ev, length)的ioctl命令号
+func eviocgbit(ev, length int) uintptr {
+	const iocRead = 2
+	return uintptr(iocRead<<30 | length<<16 | 'E'<<8 | (0x20 + ev))
+}
+
+// Devices 返回当前已打开的键盘设备路径
+func (er *evdevReader) Devices() []string {
+	er.mu.Lock()
+	defer er.mu.Unlock()
+	names := make([]string, 0, len(er.devices))
+	for _, f := range er.devices {
+		names = append(names, f.Name())
+	}
+	return names
+}
+
+// readEvent 读取一个按键事件
+// 参数timeout: 等待输入的超时时间
+// 返回按键事件以及是否在超时前读到按键
+func (er *evdevReader) readEvent(timeout time.Duration) (KeyEvent, bool, error) {
+	er.mu.Lock()
+	defer er.mu.Unlock()
+
+	deadline := time.Now().Add(timeout)
+	for len(er.pending) == 0 {
+		now := time.Now()
+		if er.repeatDue(now) {
+			er.emitRepeat(now)
+			break
+		}
+
+		// 超时为0时也至少检查一次设备，实现非阻塞读取；按住按键时最多等到下一次重复
+		remaining := deadline.Sub(now)
+		if remaining < 0 {
+			remaining = 0
+		}
+		if er.repeatKey != nil {
+			if d := er.nextRepeat.Sub(now); d < remaining {
+				remaining = d
+			}
+		}
+		if err := er.poll(remaining); err != nil {
+			return KeyEvent{}, false, err
+		}
+		now = time.Now()
+		if len(er.pending) == 0 && !er.repeatDue(now) && !now.Before(deadline) {
+			return KeyEvent{}, false, nil
+		}
+	}
+
+	ev := er.pending[0]
+	er.pending = er.pending[1:]
+	return ev, true, nil
+}
+
+// poll 等待任一键盘设备或热插拔监听器可读，并处理读到的事件
+func (er *evdevReader) poll(timeout time.Duration) error {
+	if er.closed {
+		return fmt.Errorf("键盘设备已关闭")
+	}
+	if len(er.devices) == 0 && er.watcher == nil {
+		return fmt.Errorf("没有可用的键盘设备")
+	}
+
+	// 读取过程中可能移除设备，遍历副本
+	devices := append([]*os.File(nil), er.devices...)
+	fds := make([]int, 0, len(devices)+1)
+	for _, f := range devices {
+		fds = append(fds, int(f.Fd()))
+	}
+	if er.watcher != nil {
+		fds = append(fds, er.watcher.fd)
+	}
+	ready, err := waitReadable(fds, timeout)
+	if err != nil {
+		return err
+	}
+
+	for i, f := range devices {
+		if !ready[i] {
+			continue
+		}
+		if err := er.readDevice(f); err != nil {
+			// 读取失败通常是键盘已被拔出（ENODEV），移除该设备后继续使用其它键盘
+			log.Printf("%v", err)
+			er.detach(f.Name())
+		}
+	}
+
+	if er.watcher != nil && ready[len(ready)-1] {
+		return er.handleHotplug()
+	}
+	return nil
+}
+
+// readDevice 从单个设备读取一批原始事件并解码
+func (er *evdevReader) readDevice(f *os.File) error {
+	buf := make([]byte, inputEventSize*64)
+	n, err := f.Read(buf)
+	if err != nil {
+		return fmt.Errorf("读取输入设备%s失败: %v", f.Name(), err)
+	}
+
+	for off := 0; off+inputEventSize <= n; off += inputEventSize {
+		raw := *(*InputEvent)(unsafe.Pointer(&buf[off]))
+		if raw.Type == EV_ABS {
+			if ev, ok := er.translateAxis(f, raw); ok {
+				er.pending = append(er.pending, ev)
+			}
+			continue
+		}
+		if raw.Type != EV_KEY {
+			continue
+		}
+		if ev, ok := er.translate(raw); ok {
+			ev.Device = f.Name()
+			er.pending = append(er.pending, ev)
+			er.startRepeat(raw.Code, ev)
+		}
+	}
+	return nil
+}
+
+// translate 把内核按键事件转换为KeyEvent，同时跟踪修饰键状态
+// 修饰键本身、按键抬起以及内核的自动重复不产生事件
+func (er *evdevReader) translate(raw InputEvent) (KeyEvent, bool) {
+	pressed := raw.Value != keyValueRelease
+
+	if _, ok := modifierForCode(raw.Code); ok {
+		er.held[raw.Code] = pressed
+		er.mods = 0
+		for code, down := range er.held {
+			if m, _ := modifierForCode(code); down {
+				er.mods |= m
+			}
+		}
+		return KeyEvent{}, false
+	}
+	if raw.Code == KEY_CAPSLOCK {
+		if raw.Value == keyValuePress {
+			er.capsLock = !er.capsLock
+		}
+		return KeyEvent{}, false
+	}
+	if raw.Code == KEY_NUMLOCK {
+		if raw.Value == keyValuePress {
+			er.numLockOff = !er.numLockOff
+		}
+		return KeyEvent{}, false
+	}
+	if !pressed {
+		if er.repeatKey != nil && raw.Code == er.repeatCode {
+			er.repeatKey = nil // 按住的按键已抬起，停止重复
+		}
+		return KeyEvent{}, false
+	}
+	if raw.Value == keyValueRepeat {
+		return KeyEvent{}, false
+	}
+
+	ev := KeyEvent{
+		Modifiers: er.mods,
+		Pressed:   true,
+		Time:      time.Unix(int64(raw.Time.Sec), int64(raw.Time.Usec)*1000),
+	}
+
+	if key, ok := evdevSpecialKeys[raw.Code]; ok {
+		ev.Code = key
+		return ev, true
+	}
+	if key, ok := er.translateNavigation(raw.Code); ok {
+		ev.Code = key
+		return ev, true
+	}
+
+	km := er.keymap
+	if km == nil {
+		km = DefaultKeymap()
+		er.keymap = km
+	}
+	shift := er.mods&ModShift != 0
+	altGr := er.held[KEY_RIGHTALT]
+	letter := km.isLetter(raw.Code)
+
+	var ch rune
+	var ok bool
+	switch {
+	case er.mods&ModCtrl != 0:
+		// Ctrl组合键统一使用小写字母，与终端后端保持一致
+		ch, ok = km.lookup(raw.Code, false, false)
+	case letter:
+		// 字母受Shift和大写锁定共同影响
+		ch, ok = km.lookup(raw.Code, shift != er.capsLock, altGr)
+	default:
+		ch, ok = km.lookup(raw.Code, shift, altGr)
+	}
+	if !ok {
+		return KeyEvent{}, false
+	}
+
+	ev.Code = KeyRune
+	ev.Rune = ch
+	ev.Modifiers &^= ModShift
+	// 大写字母带Shift修饰，与终端后端保持一致；AltGr产生的字符不再视为Alt组合键
+	if unicode.IsUpper(ch) {
+		ev.Modifiers |= ModShift
+	}
+	if altGr && er.mods&ModCtrl == 0 {
+		if normal, _ := km.lookup(raw.Code, shift, false); normal != ch {
+			ev.Modifiers &^= ModAlt
+		}
+	}
+	return ev, true
+}
+
+// setRepeat 设置自动重复参数
+func (er *evdevReader) setRepeat(cfg RepeatConfig) {
+	er.mu.Lock()
+	defer er.mu.Unlock()
+	er.repeat = cfg
+	er.repeatKey = nil
+}
+
+// startRepeat 记录刚按下的按键，经过延迟后开始合成重复事件
+// 调用方需持有er.mu
+func (er *evdevReader) startRepeat(code uint16, ev KeyEvent) {
+	if er.repeat.Rate <= 0 {
+		er.repeatKey = nil
+		return
+	}
+	held := ev
+	er.repeatKey = &held
+	er.repeatCode = code
+	er.nextRepeat = time.Now().Add(er.repeat.Delay)
+}
+
+// repeatDue 判断是否到了合成下一次重复事件的时间，调用方需持有er.mu
+func (er *evdevReader) repeatDue(now time.Time) bool {
+	return er.repeatKey != nil && !now.Before(er.nextRepeat)
+}
+
+// emitRepeat 合成一次重复事件并安排下一次，调用方需持有er.mu
+func (er *evdevReader) emitRepeat(now time.Time) {
+	ev := *er.repeatKey
+	ev.Repeat = true
+	ev.Time = now
+	er.pending = append(er.pending, ev)
+
+	er.nextRepeat = er.nextRepeat.Add(er.repeat.interval())
+	if er.nextRepeat.Before(now) {
+		er.nextRepeat = now.Add(er.repeat.interval())
+	}
+}
+
+// modifierForCode 返回修饰键扫描码对应的修饰键
+func modifierForCode(code uint16) (Modifiers, bool) {
+	switch code {
+	case KEY_LEFTSHIFT, KEY_RIGHTSHIFT:
+		return ModShift, true
+	case KEY_LEFTCTRL, KEY_RIGHTCTRL:
+		return ModCtrl, true
+	case KEY_LEFTALT, KEY_RIGHTALT:
+		return ModAlt, true
+	}
+	return 0, false
+}
+
+// close 关闭所有已打开的键盘设备
+func (er *evdevReader) close() error {
+	er.mu.Lock()
+	defer er.mu.Unlock()
+
+	var err error
+	for _, f := range er.devices {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("关闭输入设备%s失败: %v", f.Name(), closeErr)
+		}
+	}
+	er.devices = nil
+	if er.watcher != nil {
+		er.watcher.close()
+		er.watcher = nil
+	}
+	er.closed = true
+	return err
+}
+
+// isTerminal 判断文件是否为终端设备
+func isTerminal(f *os.File) bool {
+	var termios syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL,
+		f.Fd(),
+		TCGETS,
+		uintptr(unsafe.Pointer(&termios)))
+	return errno == 0
+}
@@ -0,0 +1,246 @@
+package input
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Modifiers 按键事件的修饰键状态（可组合）
+type Modifiers uint8
+
+// 修饰键常量
+const (
+	ModShift Modifiers = 1 << iota // Shift键
+	ModAlt                         // Alt键
+	ModCtrl                        // Ctrl键
+)
+
+// String 返回修饰键的可读形式，如"Ctrl+Alt"
+func (m Modifiers) String() string {
+	var parts []string
+	if m&ModCtrl != 0 {
+		parts = append(parts, "Ctrl")
+	}
+	if m&ModAlt != 0 {
+		parts = append(parts, "Alt")
+	}
+	if m&ModShift != 0 {
+		parts = append(parts, "Shift")
+	}
+	return strings.Join(parts, "+")
+}
+
+// KeyEvent 结构化的按键事件
+// 取代原先的单字节按键，能够表达方向键、功能键以及Shift/Ctrl/Alt等修饰键
+type KeyEvent struct {
+	Code      Key       // 按键代码，普通字符为KeyRune
+	Rune      rune      // 按键对应的字符（Code为KeyRune时有效）
+	Modifiers Modifiers // 修饰键状态
+	Pressed   bool      // true表示按下，false表示抬起（TTY后端只能检测到按下）
+	Repeat    bool      // 按住不放产生的自动重复事件
+	Time      time.Time // 事件发生时间
+	Device    string    // 产生事件的设备：终端为"tty"，evdev为设备路径
+}
+
+// IsCtrl 判断事件是否为Ctrl加指定字母，如IsCtrl('c')表示Ctrl+C
+func (e KeyEvent) IsCtrl(letter rune) bool {
+	return e.Code == KeyRune && e.Modifiers&ModCtrl != 0 && e.Rune == letter
+}
+
+// Byte 将事件转换为传统终端的单字节表示
+// 用于兼容仍按字节处理按键的代码：Ctrl+C转换为3，回车为'\r'，ESC为27；
+// 方向键、功能键等无单字节表示的按键返回0
+func (e KeyEvent) Byte() byte {
+	switch e.Code {
+	case KeyEnter:
+		return '\r'
+	case KeyEscape:
+		return 27
+	case KeyBackspace:
+		return 0x7F
+	case KeyTab:
+		return '\t'
+	case KeyRune:
+		if e.Modifiers&ModCtrl != 0 {
+			if b, ok := ctrlByte(e.Rune); ok {
+				return b
+			}
+		}
+		if e.Rune < 0x80 {
+			return byte(e.Rune)
+		}
+	}
+	return 0
+}
+
+// String 返回事件的可读形式，如"Ctrl+c"、"Up"、"Shift+F5"
+func (e KeyEvent) String() string {
+	name := e.Code.String()
+	if e.Code == KeyRune {
+		name = string(e.Rune)
+	}
+	if mods := e.Modifiers.String(); mods != "" {
+		name = mods + "+" + name
+	}
+	if !e.Pressed {
+		name += " (released)"
+	}
+	if e.Repeat {
+		name += " (repeat)"
+	}
+	return name
+}
+
+// ctrlRunes 终端中Ctrl组合键产生的控制字节（28-31、0）与字符的对应关系
+var ctrlRunes = map[byte]rune{0: '@', 28: '\\', 29: ']', 30: '^', 31: '_'}
+
+// ctrlByte 返回Ctrl加指定字符对应的控制字节
+func ctrlByte(r rune) (byte, bool) {
+	if r >= 'a' && r <= 'z' {
+		return byte(r-'a') + 1, true
+	}
+	for b, cr := range ctrlRunes {
+		if cr == r {
+			return b, true
+		}
+	}
+	return 0, false
+}
+
+// eventFromByte 将终端的单字节输入转换为按键事件
+func eventFromByte(b byte) KeyEvent {
+	ev := KeyEvent{Code: KeyRune, Rune: rune(b), Pressed: true, Time: time.Now()}
+	if key, ok := decodeControlByte(b); ok {
+		ev.Code = key
+		return ev
+	}
+
+	switch {
+	case b >= 1 && b <= 26: // Ctrl+A ~ Ctrl+Z
+		ev.Rune = rune('a' + b - 1)
+		ev.Modifiers = ModCtrl
+	case ctrlRunes[b] != 0 || b == 0:
+		ev.Rune = ctrlRunes[b]
+		ev.Modifiers = ModCtrl
+	case b >= 'A' && b <= 'Z':
+		ev.Modifiers = ModShift
+	}
+	return ev
+}
+
+// csiModifiers 解析CSI序列中的修饰键参数（xterm格式：1 + Shift(1)|Alt(2)|Ctrl(4)）
+func csiModifiers(param int) Modifiers {
+	if param < 2 {
+		return 0
+	}
+	bits := param - 1
+	var mods Modifiers
+	if bits&1 != 0 {
+		mods |= ModShift
+	}
+	if bits&2 != 0 {
+		mods |= ModAlt
+	}
+	if bits&4 != 0 {
+		mods |= ModCtrl
+	}
+	return mods
+}
+
+// eventsBufferSize 按键事件通道的缓冲大小
+const eventsBufferSize = 16
+
+// Events 返回按键事件通道
+// 首次调用时启动后台读取goroutine，持续把终端输入解码为KeyEvent发送到通道中，
+// 通过AddDevices并入的设备也由各自的goroutine读取并汇入同一通道，
+// 所有来源结束（键盘关闭）后通道随之关闭。启动后ReadKey也改为从该通道读取，避免两处同时读取设备
+func (ki *KeyboardInput) Events() <-chan KeyEvent {
+	ki.pumpOnce.Do(func() {
+		events := make(chan KeyEvent, eventsBufferSize)
+		ki.mu.Lock()
+		ki.events = events
+		extra := ki.extra
+		ki.mu.Unlock()
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer RecoverTerminal()
+			defer wg.Done()
+			ki.pumpEvents(events)
+		}()
+		if extra != nil {
+			wg.Add(1)
+			go func() {
+				defer RecoverTerminal()
+				defer wg.Done()
+				ki.pumpReader(extra, events)
+			}()
+		}
+		go func() {
+			wg.Wait()
+			close(events)
+		}()
+	})
+
+	ki.mu.Lock()
+	defer ki.mu.Unlock()
+	return ki.events
+}
+
+// pumpEvents 后台读取并解码按键，直到键盘关闭
+func (ki *KeyboardInput) pumpEvents(events chan<- KeyEvent) {
+	var repeats repeatFilter
+	for {
+		ev, ok, err := ki.ReadKeyEvent(100 * time.Millisecond)
+		if err != nil {
+			if ki.isClosed() {
+				return
+			}
+			continue
+		}
+		// evdev后端自行合成重复事件，回放的事件已带有重复标记，只有终端后端需要过滤终端产生的重复按键
+		if ok && !ki.hasEventBackend() {
+			ev, ok = repeats.filter(ev, ki.repeatConfig())
+		}
+		if ok {
+			ki.record(ev)
+			select {
+			case events <- ev:
+			case <-ki.done:
+				return
+			}
+		}
+	}
+}
+
+// ReadKeyContext 阻塞读取一个按键事件，直到读到按键或ctx被取消
+// 参数ctx: 控制等待的上下文，通常为应用程序的上下文，退出时立即返回
+// 返回读到的按键事件；ctx取消时返回ctx.Err()，键盘关闭时返回错误
+func (ki *KeyboardInput) ReadKeyContext(ctx context.Context) (KeyEvent, error) {
+	return readKeyFrom(ctx, ki.Events())
+}
+
+// ReadKeyEvent 读取一个完整的按键事件
+// 参数timeout: 等待首个字节的超时时间
+// 返回按键事件以及是否在超时前读到按键
+func (ki *KeyboardInput) ReadKeyEvent(timeout time.Duration) (KeyEvent, bool, error) {
+	if ki.evdev != nil {
+		return ki.evdev.readEvent(timeout)
+	}
+	if ki.replay != nil {
+		return ki.replay.readEvent(timeout)
+	}
+	first, ok, err := ki.ReadKeyNonBlockingWithTimeout(timeout)
+	if err != nil || !ok {
+		return KeyEvent{}, false, err
+	}
+	ev, err := ki.decodeFrom(first)
+	if err != nil {
+		return KeyEvent{}, false, err
+	}
+	ev.Device = ki.ttyName()
+	return ev, true, nil
+}
@@ -0,0 +1,180 @@
+package input
+
+import (
+	"os"
+	"time"
+)
+
+// 小键盘和游戏手柄相关的事件代码（见linux/input-event-codes.h）
+const (
+	KEY_NUMLOCK    = 69    // 数字锁定键
+	KEY_KP0        = 82    // 小键盘数字0键
+	KEY_KP1        = 79    // 小键盘数字1键
+	KEY_KP5        = 76    // 小键盘数字5键
+	KEY_KP9        = 73    // 小键盘数字9键
+	BTN_JOYSTICK   = 0x120 // 通用摇杆的第一个按钮（扳机）
+	BTN_THUMB      = 0x121 // 通用摇杆的第二个按钮
+	BTN_SOUTH      = 0x130 // 手柄下方动作键（A键）
+	BTN_EAST       = 0x131 // 手柄右侧动作键（B键）
+	BTN_TL         = 0x136 // 左肩键
+	BTN_TR         = 0x137 // 右肩键
+	BTN_SELECT     = 0x13a // 选择键
+	BTN_START      = 0x13b // 开始键
+	BTN_DPAD_UP    = 0x220 // 方向键上
+	BTN_DPAD_DOWN  = 0x221 // 方向键下
+	BTN_DPAD_LEFT  = 0x222 // 方向键左
+	BTN_DPAD_RIGHT = 0x223 // 方向键右
+	ABS_HAT0X      = 0x10  // 十字键X轴（-1、0、1）
+	ABS_HAT0Y      = 0x11  // 十字键Y轴（-1、0、1）
+)
+
+// axisRepeatCode 方向轴产生的按键在自动重复中使用的虚拟扫描码，与真实按键扫描码错开
+const axisRepeatCode = 0x1000
+
+// gamepadButtons 手柄按钮到菜单操作按键的映射：
+// A键和开始键确认，B键和选择键返回，肩键翻页
+var gamepadButtons = map[uint16]Key{
+	BTN_SOUTH: KeyEnter, BTN_START: KeyEnter, BTN_JOYSTICK: KeyEnter,
+	BTN_EAST: KeyEscape, BTN_SELECT: KeyEscape, BTN_THUMB: KeyEscape,
+	BTN_TL: KeyPageUp, BTN_TR: KeyPageDown,
+	BTN_DPAD_UP: KeyUp, BTN_DPAD_DOWN: KeyDown, BTN_DPAD_LEFT: KeyLeft, BTN_DPAD_RIGHT: KeyRight,
+}
+
+// keypadNavigationKeys 关闭数字锁定后小键盘按键到导航键的映射，与PC键盘小键盘的印字一致
+var keypadNavigationKeys = map[uint16]Key{
+	71: KeyHome, 72: KeyUp, 73: KeyPageUp,
+	75: KeyLeft, 77: KeyRight,
+	79: KeyEnd, 80: KeyDown, 81: KeyPageDown,
+	82: KeyInsert, 83: KeyDelete,
+}
+
+// gamepadState 单个手柄设备的方向轴状态
+type gamepadState struct {
+	ranges map[uint16]absInfo // 各轴的取值范围，首次收到该轴事件时查询
+	dirs   map[uint16]int     // 各轴当前的方向：-1、0或1
+}
+
+// isKeypadDevice 判断设备是否为数字小键盘：支持小键盘数字键和小键盘回车键
+func isKeypadDevice(f *os.File) bool {
+	return hasEventCodes(f, EV_KEY, KEY_KP0, KEY_KP1, KEY_KP5, KEY_KP9, KEY_KPENTER)
+}
+
+// isGamepadDevice 判断设备是否为游戏手柄或摇杆
+func isGamepadDevice(f *os.File) bool {
+	return hasEventCodes(f, EV_KEY, BTN_SOUTH) || hasEventCodes(f, EV_KEY, BTN_JOYSTICK)
+}
+
+// isKeyInputDevice 判断设备能否用于操作菜单：键盘、数字小键盘或游戏手柄
+// 工业面板上往往只有小键盘或手柄，插入后同样自动识别
+func isKeyInputDevice(f *os.File) bool {
+	return isKeyboardDevice(f) || isKeypadDevice(f) || isGamepadDevice(f)
+}
+
+// translateNavigation 处理手柄按钮和关闭数字锁定后的小键盘按键
+// 返回对应的导航按键，不属于这两类的按键返回false
+// 调用方需持有er.mu
+func (er *evdevReader) translateNavigation(code uint16) (Key, bool) {
+	if key, ok := gamepadButtons[code]; ok {
+		return key, true
+	}
+	if er.numLockOff {
+		if key, ok := keypadNavigationKeys[code]; ok {
+			return key, true
+		}
+	}
+	return KeyNone, false
+}
+
+// translateAxis 把手柄十字键和摇杆的方向变化转换为方向键
+// 推向某个方向时产生一次按下事件并开始自动重复，回到中间时停止重复
+// 调用方需持有er.mu
+func (er *evdevReader) translateAxis(f *os.File, raw InputEvent) (KeyEvent, bool) {
+	if raw.Code != ABS_X && raw.Code != ABS_Y && raw.Code != ABS_HAT0X && raw.Code != ABS_HAT0Y {
+		return KeyEvent{}, false
+	}
+
+	pad := er.gamepad(f)
+	if pad == nil {
+		return KeyEvent{}, false
+	}
+	info, ok := pad.ranges[raw.Code]
+	if !ok {
+		var err error
+		if info, err = readAbsInfo(f, int(raw.Code)); err != nil {
+			return KeyEvent{}, false
+		}
+		pad.ranges[raw.Code] = info
+	}
+
+	dir := axisDirection(raw.Value, info)
+	if dir == pad.dirs[raw.Code] {
+		return KeyEvent{}, false
+	}
+	pad.dirs[raw.Code] = dir
+
+	repeatCode := axisRepeatCode + raw.Code
+	if dir == 0 {
+		if er.repeatKey != nil && er.repeatCode == repeatCode {
+			er.repeatKey = nil
+		}
+		return KeyEvent{}, false
+	}
+
+	ev := KeyEvent{
+		Code:      axisKey(raw.Code, dir),
+		Modifiers: er.mods,
+		Pressed:   true,
+		Time:      time.Unix(int64(raw.Time.Sec), int64(raw.Time.Usec)*1000),
+		Device:    f.Name(),
+	}
+	er.startRepeat(repeatCode, ev)
+	return ev, true
+}
+
+// gamepad 返回设备的方向轴状态，首次调用时创建
+// 只有手柄设备的方向轴会被处理，触摸屏等其它绝对坐标设备返回nil
+// 调用方需持有er.mu
+func (er *evdevReader) gamepad(f *os.File) *gamepadState {
+	if pad, ok := er.pads[f.Name()]; ok {
+		return pad
+	}
+	var pad *gamepadState
+	if isGamepadDevice(f) {
+		pad = &gamepadState{ranges: make(map[uint16]absInfo), dirs: make(map[uint16]int)}
+	}
+	if er.pads == nil {
+		er.pads = make(map[string]*gamepadState)
+	}
+	er.pads[f.Name()] = pad
+	return pad
+}
+
+// axisDirection 按轴的取值范围判断方向，靠近两端各四分之一的区域视为推到底
+// 十字键的范围为-1到1，廉价手柄的摇杆通常为0到255、中间为127
+func axisDirection(value int32, info absInfo) int {
+	span := info.Maximum - info.Minimum
+	if span <= 0 {
+		return 0
+	}
+	switch {
+	case value <= info.Minimum+span/4:
+		return -1
+	case value >= info.Maximum-span/4:
+		return 1
+	}
+	return 0
+}
+
+// axisKey 返回方向轴在指定方向上对应的方向键
+func axisKey(code uint16, dir int) Key {
+	horizontal := code == ABS_X || code == ABS_HAT0X
+	switch {
+	case horizontal && dir < 0:
+		return KeyLeft
+	case horizontal:
+		return KeyRight
+	case dir < 0:
+		return KeyUp
+	}
+	return KeyDown
+}
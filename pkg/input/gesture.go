@@ -0,0 +1,136 @@
+package input
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// 触摸手势的默认参数
+const (
+	DefaultSwipeDistance = 80                     // 判定为滑动的最小水平移动距离（像素）
+	DefaultLongPress     = 800 * time.Millisecond // 判定为长按的按住时间
+)
+
+// GestureConfig 触摸手势参数
+// 水平滑动翻页：向左滑为下一页（PageDown），向右滑为上一页（PageUp）；
+// 长按相当于回车键。距离或时间为0表示禁用对应的手势
+type GestureConfig struct {
+	SwipeDistance int           // 判定为滑动的最小水平移动距离（像素）
+	LongPress     time.Duration // 判定为长按的按住时间
+}
+
+// DefaultGestureConfig 返回默认的手势参数
+func DefaultGestureConfig() GestureConfig {
+	return GestureConfig{SwipeDistance: DefaultSwipeDistance, LongPress: DefaultLongPress}
+}
+
+// SetGestures 设置触摸手势参数
+func (ti *TouchInput) SetGestures(cfg GestureConfig) {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+	ti.gestures = cfg
+}
+
+// Gestures 返回手势产生的按键事件通道
+// 滑动和长按被转换为与键盘相同的导航按键，可以通过WithKeyEvents并入键盘输入源；
+// 手势在Events启动的后台读取中识别，接收不及时时多余的手势被丢弃
+func (ti *TouchInput) Gestures() <-chan KeyEvent {
+	return ti.gestureKeys
+}
+
+// swipeKey 根据抬起时相对按下位置的位移判断是否为水平滑动
+// 调用方需持有ti.mu
+func (ti *TouchInput) swipeKey(dx, dy int) (Key, bool) {
+	dist := ti.gestures.SwipeDistance
+	if dist <= 0 || absInt(dx) < dist || absInt(dx) < 2*absInt(dy) {
+		return KeyNone, false
+	}
+	if dx < 0 {
+		return KeyPageDown, true
+	}
+	return KeyPageUp, true
+}
+
+// checkLongPress 按住时间达到长按阈值且基本没有移动时产生一次回车
+// 每次读取后调用，因此即使手指不动、没有新的事件也能及时识别
+// 调用方需持有ti.mu
+func (ti *TouchInput) checkLongPress(now time.Time) {
+	if !ti.touching || ti.longPressed || ti.gestures.LongPress <= 0 {
+		return
+	}
+	if absInt(ti.curX-ti.startX) > defaultTapSlop || absInt(ti.curY-ti.startY) > defaultTapSlop {
+		return
+	}
+	if now.Sub(ti.touchStart) < ti.gestures.LongPress {
+		return
+	}
+	ti.longPressed = true
+	ti.emitGesture(KeyEnter, now)
+}
+
+// emitGesture 送出一个手势按键，通道已满时丢弃
+// 调用方需持有ti.mu
+func (ti *TouchInput) emitGesture(key Key, now time.Time) {
+	ev := KeyEvent{Code: key, Pressed: true, Time: now, Device: touchDeviceName}
+	select {
+	case ti.gestureKeys <- ev:
+	default:
+	}
+}
+
+// touchDeviceName 手势产生的按键事件的设备标识
+const touchDeviceName = "touch"
+
+// keySource 并入了额外按键事件的输入源
+type keySource struct {
+	Source
+	extra  <-chan KeyEvent
+	once   sync.Once
+	events chan KeyEvent
+}
+
+// WithKeyEvents 包装输入源，把额外的按键事件（如触摸手势）并入其事件流
+// 返回的输入源在被包装的输入源关闭后关闭
+// 参数src: 被包装的输入源
+// 参数extra: 额外的按键事件通道，为nil时直接返回src
+func WithKeyEvents(src Source, extra <-chan KeyEvent) Source {
+	if extra == nil {
+		return src
+	}
+	return &keySource{Source: src, extra: extra}
+}
+
+// Events 返回合并后的按键事件通道
+func (s *keySource) Events() <-chan KeyEvent {
+	s.once.Do(func() {
+		s.events = make(chan KeyEvent, eventsBufferSize)
+		go func() {
+			defer RecoverTerminal()
+			defer close(s.events)
+			src := s.Source.Events()
+			extra := s.extra
+			for {
+				select {
+				case ev, ok := <-src:
+					if !ok {
+						return
+					}
+					s.events <- ev
+				case ev, ok := <-extra:
+					if !ok {
+						extra = nil // 额外的通道关闭后只转发主输入源
+						continue
+					}
+					s.events <- ev
+				}
+			}
+		}()
+	})
+	return s.events
+}
+
+// ReadKeyContext 阻塞读取一个按键事件，直到读到按键或ctx被取消
+func (s *keySource) ReadKeyContext(ctx context.Context) (KeyEvent, error) {
+	return readKeyFrom(ctx, s.Events())
+}
@@ -0,0 +1,485 @@
+package input
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Hotkey 热键，由按键代码、字符和修饰键组成
+// 字母统一以小写保存，大写字母表示为Shift加小写字母
+type Hotkey struct {
+	Code      Key       // 按键代码
+	Rune      rune      // 字符（Code为KeyRune时有效）
+	Modifiers Modifiers // 修饰键
+}
+
+// HotkeyFor 返回按键事件对应的热键
+func HotkeyFor(ev KeyEvent) Hotkey {
+	hk := Hotkey{Code: ev.Code, Modifiers: ev.Modifiers}
+	if ev.Code == KeyRune {
+		hk.Rune = ev.Rune
+		if unicode.IsUpper(ev.Rune) {
+			hk.Rune = unicode.ToLower(ev.Rune)
+			hk.Modifiers |= ModShift
+		}
+	}
+	return hk
+}
+
+// String 返回热键的可读形式，如"Ctrl+c"、"F5"
+func (hk Hotkey) String() string {
+	return KeyEvent{Code: hk.Code, Rune: hk.Rune, Modifiers: hk.Modifiers, Pressed: true}.String()
+}
+
+// ParseHotkey 解析热键描述
+// 参数s: 形如"F5"、"s"、"Ctrl+C"、"Ctrl+Alt+q"、"Shift+Up"的描述，大小写不敏感
+// 返回解析出的热键，无法识别时返回错误
+func ParseHotkey(s string) (Hotkey, error) {
+	parts := strings.Split(strings.TrimSpace(s), "+")
+	// 热键本身为"+"时，拆分结果末尾是两个空串
+	if strings.HasSuffix(s, "++") || s == "+" {
+		parts = append(parts[:len(parts)-2], "+")
+	}
+
+	var hk Hotkey
+	for _, mod := range parts[:len(parts)-1] {
+		m, ok := parseModifier(mod)
+		if !ok {
+			return Hotkey{}, fmt.Errorf("无法识别的修饰键: %s", mod)
+		}
+		hk.Modifiers |= m
+	}
+
+	name := strings.TrimSpace(parts[len(parts)-1])
+	if name == "" {
+		return Hotkey{}, fmt.Errorf("热键描述为空: %q", s)
+	}
+	if code, ok := parseKeyName(name); ok {
+		hk.Code = code
+		return hk, nil
+	}
+
+	if utf8.RuneCountInString(name) != 1 {
+		return Hotkey{}, fmt.Errorf("无法识别的按键: %s", name)
+	}
+	r, _ := utf8.DecodeRuneInString(name)
+	hk.Code = KeyRune
+	hk.Rune = unicode.ToLower(r)
+	// Ctrl组合键在终端中不区分大小写，只有单独的大写字母才表示Shift
+	if unicode.IsUpper(r) && hk.Modifiers&ModCtrl == 0 {
+		hk.Modifiers |= ModShift
+	}
+	return hk, nil
+}
+
+// parseModifier 解析修饰键名称（Ctrl/Control、Alt、Shift），大小写不敏感
+func parseModifier(name string) (Modifiers, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "ctrl", "control":
+		return ModCtrl, true
+	case "alt":
+		return ModAlt, true
+	case "shift":
+		return ModShift, true
+	}
+	return 0, false
+}
+
+// parseKeyName 解析非字符按键的名称，如"Up"、"Esc"、"F5"，大小写不敏感
+func parseKeyName(name string) (Key, bool) {
+	for code, keyName := range keyNames {
+		if code > KeyRune && strings.EqualFold(name, keyName) {
+			return code, true
+		}
+	}
+	for code := KeyF1; code <= KeyF12; code++ {
+		if strings.EqualFold(name, code.String()) {
+			return code, true
+		}
+	}
+	return KeyNone, false
+}
+
+// HotkeyHandler 热键回调
+// 返回true表示按键已被处理，不再交给当前页面；返回false时按键继续按普通按键处理
+type HotkeyHandler func(ev KeyEvent) bool
+
+// ParseHotkeySequence 解析以空格分隔的按键序列，如"Esc Esc Esc"
+// 只有一个按键时等同于ParseHotkey
+func ParseHotkeySequence(s string) ([]Hotkey, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("热键描述为空: %q", s)
+	}
+	seq := make([]Hotkey, 0, len(fields))
+	for _, field := range fields {
+		hk, err := ParseHotkey(field)
+		if err != nil {
+			return nil, err
+		}
+		seq = append(seq, hk)
+	}
+	return seq, nil
+}
+
+// ParseHotkeyBinding 解析任意一种热键描述，返回其类型和按键
+// 支持单个热键（"F5"）、按键序列（"Esc Esc Esc"）、双击（"Esc*2"）和组合按键（"1&3"）
+func ParseHotkeyBinding(s string) (BindingKind, []Hotkey, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case len(strings.Fields(s)) > 1:
+		seq, err := ParseHotkeySequence(s)
+		return BindSequence, seq, err
+	case strings.HasSuffix(s, doublePressSuffix) && len(s) > len(doublePressSuffix):
+		hk, err := ParseHotkey(strings.TrimSuffix(s, doublePressSuffix))
+		if err != nil {
+			return 0, nil, err
+		}
+		return BindDoublePress, []Hotkey{hk, hk}, nil
+	case strings.Contains(s, chordSeparator) && s != chordSeparator:
+		parts := strings.Split(s, chordSeparator)
+		keys := make([]Hotkey, 0, len(parts))
+		for _, part := range parts {
+			hk, err := ParseHotkey(part)
+			if err != nil {
+				return 0, nil, err
+			}
+			keys = append(keys, hk)
+		}
+		return BindChord, keys, nil
+	}
+	hk, err := ParseHotkey(s)
+	if err != nil {
+		return 0, nil, err
+	}
+	return BindSingle, []Hotkey{hk}, nil
+}
+
+// 双击和组合按键在热键描述中的写法
+const (
+	doublePressSuffix = "*2" // 双击，如"Esc*2"
+	chordSeparator    = "&"  // 组合按键，如"1&3"
+)
+
+// BindingKind 热键绑定的类型
+type BindingKind int
+
+// 热键绑定类型常量
+const (
+	BindSingle      BindingKind = iota // 单个热键
+	BindSequence                       // 按键序列：依次按下，相邻按键间隔不超过序列窗口
+	BindDoublePress                    // 双击：同一按键连按两次，间隔不超过双击窗口
+	BindChord                          // 组合按键：几个按键以任意顺序在组合窗口内先后按下
+)
+
+// HotkeyWindows 按键序列、双击和组合按键的识别时间窗口
+type HotkeyWindows struct {
+	Sequence    time.Duration // 按键序列中相邻两次按键的最大间隔
+	DoublePress time.Duration // 双击两次按下的最大间隔
+	Chord       time.Duration // 组合按键从第一个到最后一个按键的最大间隔
+}
+
+// 时间窗口的默认值
+const (
+	DefaultSequenceWindow    = time.Second
+	DefaultDoublePressWindow = 400 * time.Millisecond
+	DefaultChordWindow       = 150 * time.Millisecond
+)
+
+// DefaultHotkeyWindows 返回默认的识别时间窗口
+func DefaultHotkeyWindows() HotkeyWindows {
+	return HotkeyWindows{
+		Sequence:    DefaultSequenceWindow,
+		DoublePress: DefaultDoublePressWindow,
+		Chord:       DefaultChordWindow,
+	}
+}
+
+// HotkeyBinding 一条热键注册信息
+type HotkeyBinding struct {
+	Kind        BindingKind   // 绑定类型
+	Hotkey      Hotkey        // 热键，多个按键时为最后一个按键
+	Sequence    []Hotkey      // 按键序列、双击或组合按键包含的按键，单个热键时为nil
+	Description string        // 功能说明，用于帮助信息
+	Handler     HotkeyHandler // 回调
+}
+
+// String 返回热键的可读形式，与ParseHotkeyBinding的写法一致，
+// 如"F5"、"Esc Esc Esc"、"Esc*2"、"1&3"
+func (b HotkeyBinding) String() string {
+	switch b.Kind {
+	case BindDoublePress:
+		return b.Hotkey.String() + doublePressSuffix
+	case BindSequence, BindChord:
+		names := make([]string, len(b.Sequence))
+		for i, hk := range b.Sequence {
+			names[i] = hk.String()
+		}
+		if b.Kind == BindChord {
+			return strings.Join(names, chordSeparator)
+		}
+		return strings.Join(names, " ")
+	}
+	return b.Hotkey.String()
+}
+
+// keyPress 分发器记录的一次按键
+type keyPress struct {
+	hotkey Hotkey
+	time   time.Time
+	serial uint64 // 按键的序号，用于避免同一组按键重复触发同一绑定
+}
+
+// HotkeyDispatcher 全局热键分发器
+// 各模块注册热键及回调，读取按键的地方先交给分发器处理，未被处理的按键再由页面自行处理。
+// 除单个热键外还识别按键序列、双击和组合按键这类由多次按键组成的高层事件，
+// 它们的最后一个按键到达时调用回调；前面的按键照常交给页面处理
+type HotkeyDispatcher struct {
+	mu       sync.RWMutex
+	bindings map[Hotkey]HotkeyBinding
+	combos   []HotkeyBinding   // 已注册的按键序列、双击和组合按键
+	windows  HotkeyWindows     // 识别时间窗口
+	recent   []keyPress        // 最近的按键，用于匹配多键绑定
+	presses  uint64            // 已记录的按键数
+	lastUsed map[string]uint64 // 各多键绑定上次触发时最后一个按键的序号
+}
+
+// NewHotkeyDispatcher 创建空的热键分发器
+func NewHotkeyDispatcher() *HotkeyDispatcher {
+	return &HotkeyDispatcher{
+		bindings: make(map[Hotkey]HotkeyBinding),
+		windows:  DefaultHotkeyWindows(),
+		lastUsed: make(map[string]uint64),
+	}
+}
+
+// SetWindows 设置识别时间窗口，为0的窗口保持原值
+func (d *HotkeyDispatcher) SetWindows(w HotkeyWindows) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if w.Sequence > 0 {
+		d.windows.Sequence = w.Sequence
+	}
+	if w.DoublePress > 0 {
+		d.windows.DoublePress = w.DoublePress
+	}
+	if w.Chord > 0 {
+		d.windows.Chord = w.Chord
+	}
+}
+
+// Register 注册热键，同一热键重复注册时后注册的回调生效
+// 参数hk: 热键
+// 参数description: 功能说明
+// 参数handler: 按下热键时调用的回调
+func (d *HotkeyDispatcher) Register(hk Hotkey, description string, handler HotkeyHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.bindings[hk] = HotkeyBinding{Kind: BindSingle, Hotkey: hk, Description: description, Handler: handler}
+}
+
+// RegisterSequence 注册按键序列，序列的最后一个按键按下时调用回调
+// 序列中前面的按键照常交给页面处理，只有最后一个按键由回调决定是否拦截
+// 参数seq: 按键序列，如连按三次ESC
+func (d *HotkeyDispatcher) RegisterSequence(seq []Hotkey, description string, handler HotkeyHandler) {
+	if len(seq) == 1 {
+		d.Register(seq[0], description, handler)
+		return
+	}
+	d.registerCombo(BindSequence, seq, description, handler)
+}
+
+// RegisterDoublePress 注册双击：同一按键在双击窗口内连按两次时调用回调
+// 第一次按下照常交给页面处理
+func (d *HotkeyDispatcher) RegisterDoublePress(hk Hotkey, description string, handler HotkeyHandler) {
+	d.registerCombo(BindDoublePress, []Hotkey{hk, hk}, description, handler)
+}
+
+// RegisterChord 注册组合按键：几个按键以任意顺序在组合窗口内先后按下时调用回调
+// 终端无法报告按键抬起，因此只按按下的时间判断；先到达的按键照常交给页面处理，
+// 宜选用在页面中没有含义的按键（如功能键）组合
+func (d *HotkeyDispatcher) RegisterChord(keys []Hotkey, description string, handler HotkeyHandler) {
+	if len(keys) == 1 {
+		d.Register(keys[0], description, handler)
+		return
+	}
+	d.registerCombo(BindChord, keys, description, handler)
+}
+
+// registerCombo 注册多键绑定，写法相同的绑定重复注册时后注册的回调生效
+func (d *HotkeyDispatcher) registerCombo(kind BindingKind, keys []Hotkey, description string, handler HotkeyHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	binding := HotkeyBinding{
+		Kind:        kind,
+		Hotkey:      keys[len(keys)-1],
+		Sequence:    keys,
+		Description: description,
+		Handler:     handler,
+	}
+	for i, existing := range d.combos {
+		if existing.String() == binding.String() {
+			d.combos[i] = binding
+			return
+		}
+	}
+	d.combos = append(d.combos, binding)
+}
+
+// RegisterString 按描述字符串注册热键，写法见ParseHotkeyBinding，如RegisterString("F5", "刷新", fn)、
+// RegisterString("Esc Esc Esc", "退出", fn)、RegisterString("Esc*2", "返回首页", fn)
+func (d *HotkeyDispatcher) RegisterString(key, description string, handler HotkeyHandler) error {
+	kind, keys, err := ParseHotkeyBinding(key)
+	if err != nil {
+		return err
+	}
+	switch kind {
+	case BindSingle:
+		d.Register(keys[0], description, handler)
+	case BindDoublePress:
+		d.RegisterDoublePress(keys[0], description, handler)
+	default:
+		d.registerCombo(kind, keys, description, handler)
+	}
+	return nil
+}
+
+// Unregister 取消热键注册
+func (d *HotkeyDispatcher) Unregister(hk Hotkey) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.bindings, hk)
+}
+
+// Dispatch 把按键事件交给对应的热键回调
+// 刚好完成的多键绑定优先，按键数多的先调用；返回true表示事件已被热键处理
+func (d *HotkeyDispatcher) Dispatch(ev KeyEvent) bool {
+	if !ev.Pressed {
+		return false
+	}
+
+	hk := HotkeyFor(ev)
+	for _, combo := range d.matchCombos(hk, ev) {
+		if combo.Handler(ev) {
+			return true
+		}
+	}
+
+	d.mu.RLock()
+	binding, ok := d.bindings[hk]
+	d.mu.RUnlock()
+	if !ok {
+		return false
+	}
+	return binding.Handler(ev)
+}
+
+// matchCombos 记录按键并返回刚好完成的多键绑定，按键数多的在前
+// 自动重复产生的按键不计入
+func (d *HotkeyDispatcher) matchCombos(hk Hotkey, ev KeyEvent) []HotkeyBinding {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.combos) == 0 || ev.Repeat {
+		return nil
+	}
+	now := ev.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	d.presses++
+	d.recent = append(d.recent, keyPress{hotkey: hk, time: now, serial: d.presses})
+
+	var matched []HotkeyBinding
+	maxLen := 0
+	for _, combo := range d.combos {
+		n := len(combo.Sequence)
+		if n > maxLen {
+			maxLen = n
+		}
+		if n > len(d.recent) {
+			continue
+		}
+		tail := d.recent[len(d.recent)-n:]
+		// 触发过的按键不再参与同一绑定的匹配，如连按四次ESC只触发两次双击
+		if tail[0].serial <= d.lastUsed[combo.String()] {
+			continue
+		}
+		if d.comboMatches(combo, tail) {
+			d.lastUsed[combo.String()] = d.presses
+			matched = append(matched, combo)
+		}
+	}
+	if len(d.recent) > maxLen {
+		d.recent = append(d.recent[:0], d.recent[len(d.recent)-maxLen:]...)
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return len(matched[i].Sequence) > len(matched[j].Sequence)
+	})
+	return matched
+}
+
+// comboMatches 判断最近的按键是否构成指定的多键绑定，调用方需持有d.mu
+// 参数tail: 最近的按键，长度与绑定的按键数相同
+func (d *HotkeyDispatcher) comboMatches(combo HotkeyBinding, tail []keyPress) bool {
+	if combo.Kind == BindChord {
+		if tail[len(tail)-1].time.Sub(tail[0].time) > d.windows.Chord {
+			return false
+		}
+		return sameHotkeys(tail, combo.Sequence)
+	}
+
+	window := d.windows.Sequence
+	if combo.Kind == BindDoublePress {
+		window = d.windows.DoublePress
+	}
+	for i, press := range tail {
+		if press.hotkey != combo.Sequence[i] {
+			return false
+		}
+		if i > 0 && press.time.Sub(tail[i-1].time) > window {
+			return false
+		}
+	}
+	return true
+}
+
+// sameHotkeys 判断按键与热键列表是否包含相同的热键（不考虑顺序）
+func sameHotkeys(presses []keyPress, keys []Hotkey) bool {
+	used := make([]bool, len(keys))
+	for _, press := range presses {
+		found := false
+		for i, hk := range keys {
+			if !used[i] && hk == press.hotkey {
+				used[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Bindings 返回已注册的热键，按热键名称排序
+func (d *HotkeyDispatcher) Bindings() []HotkeyBinding {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	list := make([]HotkeyBinding, 0, len(d.bindings)+len(d.combos))
+	for _, b := range d.bindings {
+		list = append(list, b)
+	}
+	list = append(list, d.combos...)
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].String() < list[j].String()
+	})
+	return list
+}
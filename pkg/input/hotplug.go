@@ -0,0 +1,137 @@
+package input
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// evdevDeviceDir 输入设备节点所在目录，监听其变化以支持键盘热插拔
+const evdevDeviceDir = "/dev/input"
+
+// hotplugWatchMask 需要监听的inotify事件
+// 设备节点创建后udev才会调整权限，因此同时监听IN_ATTRIB以便在权限就绪后重试打开
+const hotplugWatchMask = syscall.IN_CREATE | syscall.IN_DELETE | syscall.IN_ATTRIB
+
+// hotplugWatcher 基于inotify的输入设备目录监听器
+type hotplugWatcher struct {
+	fd int // inotify文件描述符
+}
+
+// newHotplugWatcher 创建对/dev/input的inotify监听
+func newHotplugWatcher() (*hotplugWatcher, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("初始化inotify失败: %v", err)
+	}
+	if _, err := syscall.InotifyAddWatch(fd, evdevDeviceDir, hotplugWatchMask); err != nil {
+		syscall.Close(fd)
+		return nil, fmt.Errorf("监听%s失败: %v", evdevDeviceDir, err)
+	}
+	return &hotplugWatcher{fd: fd}, nil
+}
+
+// hotplugChange 一次设备节点变化
+type hotplugChange struct {
+	path    string // 设备节点路径
+	removed bool   // true表示设备节点被删除
+}
+
+// readChanges 读取并解析已到达的inotify事件，只保留event*设备节点
+func (hw *hotplugWatcher) readChanges() ([]hotplugChange, error) {
+	buf := make([]byte, 4096)
+	n, err := syscall.Read(hw.fd, buf)
+	if err != nil {
+		if err == syscall.EAGAIN || err == syscall.EINTR {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("读取inotify事件失败: %v", err)
+	}
+
+	var changes []hotplugChange
+	for off := 0; off+syscall.SizeofInotifyEvent <= n; {
+		raw := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[off]))
+		nameStart := off + syscall.SizeofInotifyEvent
+		nameEnd := nameStart + int(raw.Len)
+		if nameEnd > n {
+			break
+		}
+		name := strings.TrimRight(string(buf[nameStart:nameEnd]), "\x00")
+		off = nameEnd
+
+		if !strings.HasPrefix(name, "event") {
+			continue
+		}
+		changes = append(changes, hotplugChange{
+			path:    filepath.Join(evdevDeviceDir, name),
+			removed: raw.Mask&syscall.IN_DELETE != 0,
+		})
+	}
+	return changes, nil
+}
+
+// close 关闭inotify监听
+func (hw *hotplugWatcher) close() error {
+	return syscall.Close(hw.fd)
+}
+
+// handleHotplug 处理设备节点变化：新出现的键盘加入读取列表，被拔出的键盘移除
+// 调用方需持有er.mu
+func (er *evdevReader) handleHotplug() error {
+	changes, err := er.watcher.readChanges()
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		if change.removed {
+			er.detach(change.path)
+			continue
+		}
+		er.attach(change.path)
+	}
+	return nil
+}
+
+// attach 尝试打开设备并在其为键盘、小键盘或手柄时加入读取列表，已打开的设备会被忽略
+// 调用方需持有er.mu
+func (er *evdevReader) attach(path string) {
+	for _, f := range er.devices {
+		if f.Name() == path {
+			return
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return // 权限可能尚未就绪，等待后续IN_ATTRIB事件重试
+	}
+	if !isKeyInputDevice(f) {
+		f.Close()
+		return
+	}
+	er.devices = append(er.devices, f)
+	log.Printf("检测到键盘接入: %s", path)
+}
+
+// detach 关闭并移除指定的设备
+// 调用方需持有er.mu
+func (er *evdevReader) detach(path string) {
+	for i, f := range er.devices {
+		if f.Name() != path {
+			continue
+		}
+		f.Close()
+		er.devices = append(er.devices[:i], er.devices[i+1:]...)
+		// 拔出键盘时丢弃其未松开的修饰键，避免Shift/Ctrl一直处于按下状态
+		er.held = make(map[uint16]bool)
+		er.mods = 0
+		er.repeatKey = nil
+		delete(er.pads, path)
+		log.Printf("键盘已移除: %s", path)
+		return
+	}
+}
@@ -0,0 +1,137 @@
+package input
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// IdleNotifier 空闲检测器
+// 超过设定时间没有任何输入时通过Changes通知进入空闲，之后再有输入时通知恢复活动，
+// 供应用程序统一实现屏幕保护、关闭背光、返回首页等行为
+type IdleNotifier struct {
+	mu      sync.Mutex
+	timeout time.Duration
+	timer   *time.Timer
+	last    time.Time // 最近一次输入的时间
+	idle    bool      // 当前是否处于空闲状态
+	stopped bool
+	changes chan bool // true表示进入空闲，false表示恢复活动
+}
+
+// NewIdleNotifier 创建空闲检测器，从创建时开始计时
+// 参数timeout: 无输入多久后视为空闲
+func NewIdleNotifier(timeout time.Duration) *IdleNotifier {
+	n := &IdleNotifier{
+		timeout: timeout,
+		last:    time.Now(),
+		changes: make(chan bool, 4),
+	}
+	n.timer = time.AfterFunc(timeout, n.expire)
+	return n
+}
+
+// Changes 返回空闲状态变化的通道：true表示进入空闲，false表示恢复活动
+// 接收方处理不及时时变化会被丢弃，需要准确状态时以Idle为准
+func (n *IdleNotifier) Changes() <-chan bool {
+	return n.changes
+}
+
+// Idle 返回当前是否处于空闲状态
+func (n *IdleNotifier) Idle() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.idle
+}
+
+// LastActivity 返回最近一次输入的时间
+func (n *IdleNotifier) LastActivity() time.Time {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.last
+}
+
+// Activity 记录一次输入，重新开始计时；处于空闲状态时通知恢复活动
+// 按键由WithIdle包装的输入源自动记录，鼠标、触摸等其它输入由调用方记录
+func (n *IdleNotifier) Activity() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.stopped {
+		return
+	}
+	n.last = time.Now()
+	if n.idle {
+		n.idle = false
+		n.notify(false)
+	}
+	n.timer.Reset(n.timeout)
+}
+
+// Stop 停止空闲检测
+func (n *IdleNotifier) Stop() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.stopped = true
+	n.timer.Stop()
+}
+
+// expire 计时到期：确认期间没有新的输入后进入空闲状态
+func (n *IdleNotifier) expire() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.stopped || n.idle {
+		return
+	}
+	// Activity与到期回调可能同时发生，按最近输入时间重新核对
+	if remaining := n.timeout - time.Since(n.last); remaining > 0 {
+		n.timer.Reset(remaining)
+		return
+	}
+	n.idle = true
+	n.notify(true)
+}
+
+// notify 发送状态变化，通道已满时丢弃
+// 调用方需持有n.mu
+func (n *IdleNotifier) notify(idle bool) {
+	select {
+	case n.changes <- idle:
+	default:
+	}
+}
+
+// idleSource 每个按键都通知空闲检测器的输入源
+type idleSource struct {
+	Source
+	notifier *IdleNotifier
+	once     sync.Once
+	events   chan KeyEvent
+}
+
+// WithIdle 包装输入源，经过它读取的每个按键事件都会重置空闲检测器
+// 参数src: 被包装的输入源
+// 参数n: 空闲检测器
+func WithIdle(src Source, n *IdleNotifier) Source {
+	return &idleSource{Source: src, notifier: n}
+}
+
+// Events 返回转发后的按键事件通道，被包装的通道关闭后随之关闭
+func (s *idleSource) Events() <-chan KeyEvent {
+	s.once.Do(func() {
+		s.events = make(chan KeyEvent, eventsBufferSize)
+		go func() {
+			defer RecoverTerminal()
+			defer close(s.events)
+			for ev := range s.Source.Events() {
+				s.notifier.Activity()
+				s.events <- ev
+			}
+		}()
+	})
+	return s.events
+}
+
+// ReadKeyContext 阻塞读取一个按键事件，直到读到按键或ctx被取消
+func (s *idleSource) ReadKeyContext(ctx context.Context) (KeyEvent, error) {
+	return readKeyFrom(ctx, s.Events())
+}
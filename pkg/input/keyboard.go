@@ -21,6 +21,15 @@ type KeyboardInput struct {
 	mu         sync.Mutex      // 保护并发访问
 	closed     bool            // 关闭状态标志
 	restored   bool            // 终端状态恢复标志
+	events     chan KeyEvent   // 按键事件通道，调用Events后创建
+	pumpOnce   sync.Once       // 保证事件读取goroutine只启动一次
+	done       chan struct{}   // 键盘关闭时关闭，通知后台goroutine退出
+	evdev      *evdevReader    // evdev后端，标准输入不是终端时启用
+	repeat     RepeatConfig    // 按键自动重复参数
+	extra      *evdevReader    // 通过AddDevices并入的其它输入设备，未添加时为nil
+	serial     string          // 串口设备路径，串口输入时device和ttyDevice均为该串口
+	replay     *replayReader   // 回放录制按键的后端，仅回放时不为nil
+	recorder   *Recorder       // 按键录制器，未录制时为nil
 }
 
 // InputEvent 输入事件结构体
@@ -57,13 +66,18 @@ const (
 // 初始化终端设备并设置为原始模式，实现无缓冲的字符输入
 // 返回初始化完成的键盘输入器或错误信息
 func NewKeyboardInput() (*KeyboardInput, error) {
-	ki := &KeyboardInput{} // 创建键盘输入器实例
+	ki := &KeyboardInput{done: make(chan struct{}), repeat: DefaultRepeatConfig()} // 创建键盘输入器实例
 
 	var err error
 	// 打开标准输入设备（终端）
 	ki.device, err = os.OpenFile("/dev/stdin", os.O_RDONLY, 0)
-	if err != nil {
-		return nil, fmt.Errorf("无法打开标准输入设备: %v", err)
+	if err != nil || !isTerminal(ki.device) {
+		// 没有控制终端（如由systemd启动）时改为直接读取输入子系统
+		if ki.device != nil {
+			ki.device.Close()
+			ki.device = nil
+		}
+		return newEvdevKeyboardInput(ki)
 	}
 
 	// 打开TTY设备用于写入控制序列
@@ -86,6 +100,52 @@ func NewKeyboardInput() (*KeyboardInput, error) {
 	return ki, nil
 }
 
+// newEvdevKeyboardInput 使用evdev后端初始化键盘输入处理器
+func newEvdevKeyboardInput(ki *KeyboardInput) (*KeyboardInput, error) {
+	er, err := newEvdevReader()
+	if err != nil {
+		return nil, fmt.Errorf("标准输入不是终端，且无法使用evdev键盘: %v", err)
+	}
+	er.setRepeat(ki.repeat)
+	ki.evdev = er
+	ki.restored = true // 未修改终端属性，无需恢复
+	return ki, nil
+}
+
+// Backend 返回当前使用的输入后端名称："tty"、"evdev"或"serial"
+func (ki *KeyboardInput) Backend() string {
+	if ki.evdev != nil {
+		return "evdev"
+	}
+	if ki.serial != "" {
+		return "serial"
+	}
+	if ki.replay != nil {
+		return "replay"
+	}
+	return "tty"
+}
+
+// hasEventBackend 判断是否使用直接产生按键事件的后端（evdev或回放），而非读取终端字节
+func (ki *KeyboardInput) hasEventBackend() bool {
+	return ki.evdev != nil || ki.replay != nil
+}
+
+// readEventByte 从evdev或回放后端读取一个按键并转换为单字节表示
+// 没有单字节表示的按键（方向键等）会被跳过
+func (ki *KeyboardInput) readEventByte(timeout time.Duration) (byte, bool, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		ev, ok, err := ki.ReadKeyEvent(time.Until(deadline))
+		if err != nil || !ok {
+			return 0, false, err
+		}
+		if b := ev.Byte(); b != 0 || ev.IsCtrl('@') {
+			return b, true, nil
+		}
+	}
+}
+
 // setRawMode 设置终端为原始模式
 // 禁用行编辑、回显和特殊字符处理，实现字符级的实时输入
 func (ki *KeyboardInput) setRawMode() error {
@@ -104,6 +164,8 @@ func (ki *KeyboardInput) setRawMode() error {
 	newTermios := ki.oldTermios
 	// 禁用本地模式标志：行编辑、回显等
 	newTermios.Lflag &^= syscall.ICANON | syscall.ECHO | syscall.ECHOE | syscall.ECHOK | syscall.ECHONL | syscall.ECHOPRT | syscall.ECHOKE | syscall.ICRNL
+	// 禁用信号字符：Ctrl+C、Ctrl+Z、Ctrl+\作为普通按键送达，由程序的热键统一处理退出
+	newTermios.Lflag &^= syscall.ISIG
 	// 禁用输入模式标志：流控制等
 	newTermios.Iflag &^= syscall.IXON | syscall.IXOFF | syscall.IXANY
 	// 设置特殊字符：最少读取1个字符，无超时
@@ -118,16 +180,44 @@ func (ki *KeyboardInput) setRawMode() error {
 	if errno != 0 {
 		return fmt.Errorf("无法设置终端属性: %v", errno)
 	}
+	// 登记终端，程序在Close之前panic或退出时也能恢复
+	registerTerminal(ki)
 
 	// 隐藏光标
 	if err := ki.hideCursor(); err != nil {
+		ki.restoreTerminalUnsafe()
 		return fmt.Errorf("隐藏光标失败: %v", err)
 	}
 
 	return nil
 }
 
+// ReadKey 阻塞读取一个按键，返回其单字节表示
+// 若已通过Events启动了事件读取goroutine，则从事件通道中读取，避免与其争抢输入
 func (ki *KeyboardInput) ReadKey() (byte, error) {
+	ki.mu.Lock()
+	events := ki.events
+	ki.mu.Unlock()
+	if events != nil {
+		ev, ok := <-events
+		if !ok {
+			return 0, fmt.Errorf("键盘设备已关闭")
+		}
+		return ev.Byte(), nil
+	}
+
+	if ki.hasEventBackend() {
+		for {
+			b, ok, err := ki.readEventByte(time.Second)
+			if err != nil {
+				return 0, err
+			}
+			if ok {
+				return b, nil
+			}
+		}
+	}
+
 	ki.mu.Lock()
 	defer ki.mu.Unlock()
 
@@ -147,6 +237,10 @@ func (ki *KeyboardInput) ReadKey() (byte, error) {
 }
 
 func (ki *KeyboardInput) ReadKeyNonBlocking() (byte, bool, error) {
+	if ki.hasEventBackend() {
+		return ki.readEventByte(0)
+	}
+
 	ki.mu.Lock()
 	defer ki.mu.Unlock()
 
@@ -188,6 +282,10 @@ func (ki *KeyboardInput) ReadKeyNonBlocking() (byte, bool, error) {
 }
 
 func (ki *KeyboardInput) ReadKeyNonBlockingWithTimeout(timeout time.Duration) (byte, bool, error) {
+	if ki.hasEventBackend() {
+		return ki.readEventByte(timeout)
+	}
+
 	ki.mu.Lock()
 	defer ki.mu.Unlock()
 
@@ -355,8 +453,8 @@ func (ki *KeyboardInput) Close() error {
 		ki.device = nil
 	}
 
-	// 关闭TTY设备
-	if ki.ttyDevice != nil && ki.ttyDevice != os.Stdout {
+	// 关闭TTY设备（串口与输入设备为同一文件，已在上面关闭）
+	if ki.ttyDevice != nil && ki.ttyDevice != os.Stdout && ki.serial == "" {
 		if closeErr := ki.ttyDevice.Close(); closeErr != nil {
 			if err != nil {
 				err = fmt.Errorf("%v; 关闭TTY设备失败: %v", err, closeErr)
@@ -364,13 +462,35 @@ func (ki *KeyboardInput) Close() error {
 				err = fmt.Errorf("关闭TTY设备失败: %v", closeErr)
 			}
 		}
-		ki.ttyDevice = nil
+	}
+	ki.ttyDevice = nil
+
+	// 关闭并入的其它输入设备
+	if ki.extra != nil {
+		if closeErr := ki.extra.close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+
+	// 关闭evdev设备
+	if ki.evdev != nil {
+		if closeErr := ki.evdev.close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
 	}
 
 	ki.closed = true
+	close(ki.done)
 	return err
 }
 
+// isClosed 判断键盘是否已关闭
+func (ki *KeyboardInput) isClosed() bool {
+	ki.mu.Lock()
+	defer ki.mu.Unlock()
+	return ki.closed
+}
+
 func (ki *KeyboardInput) RestoreTerminal() error {
 	ki.mu.Lock()
 	defer ki.mu.Unlock()
@@ -399,6 +519,7 @@ func (ki *KeyboardInput) restoreTerminalUnsafe() error {
 	}
 
 	ki.restored = true
+	unregisterTerminal(ki)
 	return nil
 }
 
@@ -0,0 +1,185 @@
+package input
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultKeymapName 内置的默认键盘布局（美式）
+const DefaultKeymapName = "us"
+
+// builtinKeymaps 随程序一起发布的键盘布局文件
+//
+//go:embed keymaps/*.map
+var builtinKeymaps embed.FS
+
+// keymapEntry 一个扫描码在不同修饰键下对应的字符，0表示没有字符
+type keymapEntry struct {
+	normal rune // 未按修饰键
+	shift  rune // 按住Shift
+	altGr  rune // 按住右Alt（AltGr）
+}
+
+// Keymap 键盘布局：evdev扫描码加修饰键到字符的映射表
+type Keymap struct {
+	Name    string
+	entries map[uint16]keymapEntry
+}
+
+// DefaultKeymap 返回内置的美式键盘布局
+func DefaultKeymap() *Keymap {
+	km := &Keymap{Name: DefaultKeymapName, entries: make(map[uint16]keymapEntry, len(evdevCharKeys))}
+	for code, chars := range evdevCharKeys {
+		km.entries[code] = keymapEntry{normal: chars[0], shift: chars[1]}
+	}
+	return km
+}
+
+// LoadKeymap 加载键盘布局
+// 参数name: 内置布局名称（如"us"、"de"）或布局文件路径
+// 布局文件只需列出与美式布局不同的按键，每行格式为"扫描码 普通字符 Shift字符 [AltGr字符]"，
+// 字符可直接书写或使用U+XXXX表示，"-"表示没有字符，以#开头的行为注释
+func LoadKeymap(name string) (*Keymap, error) {
+	if name == "" || name == DefaultKeymapName {
+		return DefaultKeymap(), nil
+	}
+
+	var r io.Reader
+	if f, err := builtinKeymaps.Open("keymaps/" + name + ".map"); err == nil {
+		defer f.Close()
+		r = f
+	} else {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, fmt.Errorf("找不到键盘布局%s: %v", name, err)
+		}
+		defer f.Close()
+		r = f
+		name = strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	}
+
+	km := DefaultKeymap()
+	km.Name = name
+	if err := km.parse(r); err != nil {
+		return nil, fmt.Errorf("解析键盘布局%s失败: %v", name, err)
+	}
+	return km, nil
+}
+
+// BuiltinKeymaps 返回内置键盘布局的名称
+func BuiltinKeymaps() []string {
+	names := []string{DefaultKeymapName}
+	entries, _ := builtinKeymaps.ReadDir("keymaps")
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".map"))
+	}
+	return names
+}
+
+// parse 读取布局文件内容，覆盖对应扫描码的映射
+func (km *Keymap) parse(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 3 || len(fields) > 4 {
+			return fmt.Errorf("第%d行格式错误: %s", lineNo, line)
+		}
+		code, err := strconv.ParseUint(fields[0], 10, 16)
+		if err != nil {
+			return fmt.Errorf("第%d行扫描码无效: %s", lineNo, fields[0])
+		}
+
+		var chars [3]rune
+		for i, field := range fields[1:] {
+			ch, err := parseKeymapRune(field)
+			if err != nil {
+				return fmt.Errorf("第%d行: %v", lineNo, err)
+			}
+			chars[i] = ch
+		}
+		km.entries[uint16(code)] = keymapEntry{normal: chars[0], shift: chars[1], altGr: chars[2]}
+	}
+	return scanner.Err()
+}
+
+// parseKeymapRune 解析布局文件中的字符字段
+func parseKeymapRune(field string) (rune, error) {
+	if field == "-" {
+		return 0, nil
+	}
+	if strings.HasPrefix(field, "U+") || strings.HasPrefix(field, "u+") {
+		v, err := strconv.ParseUint(field[2:], 16, 32)
+		if err != nil {
+			return 0, fmt.Errorf("无效的字符编码: %s", field)
+		}
+		return rune(v), nil
+	}
+	if utf8.RuneCountInString(field) != 1 {
+		return 0, fmt.Errorf("字符字段只能包含一个字符: %s", field)
+	}
+	ch, _ := utf8.DecodeRuneInString(field)
+	return ch, nil
+}
+
+// lookup 查找扫描码对应的字符
+// 参数shift: 是否按住Shift（已与大写锁定合并）
+// 参数altGr: 是否按住AltGr
+// 返回字符以及该扫描码是否有映射
+func (km *Keymap) lookup(code uint16, shift, altGr bool) (rune, bool) {
+	entry, ok := km.entries[code]
+	if !ok {
+		return 0, false
+	}
+	switch {
+	case altGr && entry.altGr != 0:
+		return entry.altGr, true
+	case shift && entry.shift != 0:
+		return entry.shift, true
+	case entry.normal != 0:
+		return entry.normal, true
+	}
+	return 0, false
+}
+
+// isLetter 判断扫描码是否为字母键（受大写锁定影响）
+func (km *Keymap) isLetter(code uint16) bool {
+	entry := km.entries[code]
+	return unicode.IsLower(entry.normal) && entry.shift == unicode.ToUpper(entry.normal)
+}
+
+// SetKeymap 设置evdev后端使用的键盘布局
+// 终端后端的字符由内核控制台布局决定，不受影响
+func (ki *KeyboardInput) SetKeymap(km *Keymap) {
+	ki.mu.Lock()
+	extra := ki.extra
+	ki.mu.Unlock()
+
+	if ki.evdev != nil {
+		ki.evdev.setKeymap(km)
+	}
+	if extra != nil {
+		extra.setKeymap(km)
+	}
+}
+
+// setKeymap 设置键盘布局
+func (er *evdevReader) setKeymap(km *Keymap) {
+	er.mu.Lock()
+	defer er.mu.Unlock()
+	er.keymap = km
+}
@@ -0,0 +1,237 @@
+package input
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Key 解码后的按键代码
+// 普通字符统一为KeyRune，具体字符另见返回的rune；
+// 方向键、功能键等由多字节转义序列组成的按键解码为对应的常量
+type Key int
+
+// 按键代码常量
+const (
+	KeyNone      Key = iota // 无按键/无法识别的序列
+	KeyRune                 // 普通可打印字符
+	KeyEnter                // 回车键
+	KeyEscape               // 单独按下的ESC键
+	KeyBackspace            // 退格键
+	KeyTab                  // 制表键
+	KeyUp                   // 上方向键
+	KeyDown                 // 下方向键
+	KeyLeft                 // 左方向键
+	KeyRight                // 右方向键
+	KeyHome                 // Home键
+	KeyEnd                  // End键
+	KeyInsert               // Insert键
+	KeyDelete               // Delete键
+	KeyPageUp               // PageUp键
+	KeyPageDown             // PageDown键
+	KeyF1                   // 功能键F1
+	KeyF2                   // 功能键F2
+	KeyF3                   // 功能键F3
+	KeyF4                   // 功能键F4
+	KeyF5                   // 功能键F5
+	KeyF6                   // 功能键F6
+	KeyF7                   // 功能键F7
+	KeyF8                   // 功能键F8
+	KeyF9                   // 功能键F9
+	KeyF10                  // 功能键F10
+	KeyF11                  // 功能键F11
+	KeyF12                  // 功能键F12
+)
+
+// keyNames 按键代码对应的名称，用于日志和帮助信息
+var keyNames = map[Key]string{
+	KeyNone:      "None",
+	KeyRune:      "Rune",
+	KeyEnter:     "Enter",
+	KeyEscape:    "Esc",
+	KeyBackspace: "Backspace",
+	KeyTab:       "Tab",
+	KeyUp:        "Up",
+	KeyDown:      "Down",
+	KeyLeft:      "Left",
+	KeyRight:     "Right",
+	KeyHome:      "Home",
+	KeyEnd:       "End",
+	KeyInsert:    "Insert",
+	KeyDelete:    "Delete",
+	KeyPageUp:    "PageUp",
+	KeyPageDown:  "PageDown",
+}
+
+// String 返回按键名称，如"Up"、"F5"
+func (k Key) String() string {
+	if k >= KeyF1 && k <= KeyF12 {
+		return fmt.Sprintf("F%d", int(k-KeyF1)+1)
+	}
+	if name, ok := keyNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("Key(%d)", int(k))
+}
+
+// escapeTimeout 读取转义序列后续字节的等待时间
+// 终端发送的转义序列各字节几乎同时到达，超过该时间仍无后续字节则认为是单独的ESC键
+const escapeTimeout = 50 * time.Millisecond
+
+// csiTildeKeys "ESC [ n ~" 形式序列中数字参数对应的按键
+var csiTildeKeys = map[int]Key{
+	1: KeyHome, 2: KeyInsert, 3: KeyDelete, 4: KeyEnd,
+	5: KeyPageUp, 6: KeyPageDown, 7: KeyHome, 8: KeyEnd,
+	11: KeyF1, 12: KeyF2, 13: KeyF3, 14: KeyF4, 15: KeyF5,
+	17: KeyF6, 18: KeyF7, 19: KeyF8, 20: KeyF9, 21: KeyF10,
+	23: KeyF11, 24: KeyF12,
+}
+
+// csiFinalKeys "ESC [ X" 和 "ESC O X" 形式序列中结束字符对应的按键
+var csiFinalKeys = map[byte]Key{
+	'A': KeyUp, 'B': KeyDown, 'C': KeyRight, 'D': KeyLeft,
+	'H': KeyHome, 'F': KeyEnd,
+	'P': KeyF1, 'Q': KeyF2, 'R': KeyF3, 'S': KeyF4,
+}
+
+// linuxConsoleFKeys Linux控制台"ESC [ [ X"形式的F1-F5序列
+var linuxConsoleFKeys = map[byte]Key{
+	'A': KeyF1, 'B': KeyF2, 'C': KeyF3, 'D': KeyF4, 'E': KeyF5,
+}
+
+// parseEscapeSequence 解析ESC之后的转义序列
+// 参数seq: 不含开头ESC的序列字节，如"[A"、"[15~"、"OP"、"[1;5C"
+// 返回解析出的按键和修饰键，无法识别时返回KeyNone
+func parseEscapeSequence(seq []byte) (Key, Modifiers) {
+	if len(seq) < 2 {
+		return KeyNone, 0
+	}
+
+	switch seq[0] {
+	case 'O': // SS3序列：ESC O A / ESC O P 等
+		return csiFinalKeys[seq[1]], 0
+	case '[': // CSI序列
+		body := seq[1:]
+		// Linux控制台的功能键：ESC [ [ A
+		if body[0] == '[' && len(body) == 2 {
+			return linuxConsoleFKeys[body[1]], 0
+		}
+
+		final := body[len(body)-1]
+		params := strings.Split(string(body[:len(body)-1]), ";")
+		// 第二个参数为修饰键，如ESC [ 1 ; 5 C 表示Ctrl+Right
+		var mods Modifiers
+		if len(params) >= 2 {
+			if m, err := strconv.Atoi(params[1]); err == nil {
+				mods = csiModifiers(m)
+			}
+		}
+		if final == '~' {
+			n, err := strconv.Atoi(params[0])
+			if err != nil {
+				return KeyNone, 0
+			}
+			return csiTildeKeys[n], mods
+		}
+		return csiFinalKeys[final], mods
+	}
+	return KeyNone, 0
+}
+
+// isCSIFinal 判断字节是否为CSI序列的结束字符
+func isCSIFinal(b byte) bool {
+	return b >= 0x40 && b <= 0x7E
+}
+
+// decodeControlByte 将单字节控制字符转换为按键代码
+func decodeControlByte(b byte) (Key, bool) {
+	switch b {
+	case '\r', '\n':
+		return KeyEnter, true
+	case '\t':
+		return KeyTab, true
+	case 0x7F, 0x08:
+		return KeyBackspace, true
+	}
+	return KeyNone, false
+}
+
+// ReadKeyCode 读取一个完整的按键并解码
+// 参数timeout: 等待首个字节的超时时间
+// 返回按键代码、对应的字符以及是否读到按键；
+// 多字节的转义序列（方向键、功能键、Home/End等）和UTF-8字符会被组装为单个按键
+func (ki *KeyboardInput) ReadKeyCode(timeout time.Duration) (Key, rune, bool, error) {
+	ev, ok, err := ki.ReadKeyEvent(timeout)
+	return ev.Code, ev.Rune, ok, err
+}
+
+// decodeFrom 以已读取的首字节开始，读取并解码一个完整的按键事件
+func (ki *KeyboardInput) decodeFrom(first byte) (KeyEvent, error) {
+	// 转义序列：ESC之后在短时间内到达的字节属于同一个按键
+	if first == 27 {
+		return ki.readEscapeSequence()
+	}
+
+	// UTF-8多字节字符：根据首字节读取剩余的后续字节
+	if first >= 0x80 {
+		buf := []byte{first}
+		for !utf8.FullRune(buf) && len(buf) < utf8.UTFMax {
+			b, ok, err := ki.ReadKeyNonBlockingWithTimeout(escapeTimeout)
+			if err != nil || !ok {
+				break
+			}
+			buf = append(buf, b)
+		}
+		ch, _ := utf8.DecodeRune(buf)
+		return KeyEvent{Code: KeyRune, Rune: ch, Pressed: true, Time: time.Now()}, nil
+	}
+
+	return eventFromByte(first), nil
+}
+
+// readEscapeSequence 读取ESC之后的转义序列并解码
+func (ki *KeyboardInput) readEscapeSequence() (KeyEvent, error) {
+	escape := KeyEvent{Code: KeyEscape, Rune: 27, Pressed: true, Time: time.Now()}
+
+	var seq []byte
+	for len(seq) < 16 {
+		b, ok, err := ki.ReadKeyNonBlockingWithTimeout(escapeTimeout)
+		if err != nil {
+			return KeyEvent{}, err
+		}
+		if !ok {
+			break
+		}
+		seq = append(seq, b)
+
+		// 判断序列是否已经完整
+		if len(seq) == 1 && seq[0] != '[' && seq[0] != 'O' {
+			break // ESC后跟普通字符（Alt组合键），不再继续读取
+		}
+		if len(seq) == 2 && seq[0] == 'O' {
+			break
+		}
+		if len(seq) >= 2 && seq[0] == '[' && isCSIFinal(b) && !(len(seq) == 2 && b == '[') {
+			break
+		}
+	}
+
+	if len(seq) == 0 {
+		return escape, nil // 单独按下ESC
+	}
+
+	// ESC后跟单个普通字符：Alt组合键
+	if len(seq) == 1 {
+		ev := eventFromByte(seq[0])
+		ev.Modifiers |= ModAlt
+		return ev, nil
+	}
+
+	if key, mods := parseEscapeSequence(seq); key != KeyNone {
+		return KeyEvent{Code: key, Modifiers: mods, Pressed: true, Time: escape.Time}, nil
+	}
+	// 无法识别的序列作为ESC处理，避免把序列残余当作普通字符
+	return escape, nil
+}
@@ -0,0 +1,182 @@
+package input
+
+import (
+	"context"
+	"errors"
+	"unicode"
+)
+
+// ErrInputCancelled 用户按ESC取消了输入
+var ErrInputCancelled = errors.New("输入已取消")
+
+// EditResult 行编辑器处理按键后的状态
+type EditResult int
+
+// 行编辑器状态常量
+const (
+	EditContinue EditResult = iota // 继续编辑
+	EditAccept                     // 按下回车，输入完成
+	EditCancel                     // 按下ESC，取消输入
+)
+
+// LineRenderFunc 行编辑器的显示回调
+// 参数text: 当前输入的文本
+// 参数cursor: 光标位置（字符下标，0表示在第一个字符之前）
+type LineRenderFunc func(text string, cursor int) error
+
+// LineEditor 单行文本编辑器
+// 支持UTF-8字符、退格/删除、左右移动光标、Home/End以及插入/改写模式切换，
+// 本身不读取按键也不负责显示，由调用方逐个传入按键事件
+type LineEditor struct {
+	buf       []rune            // 当前文本
+	cursor    int               // 光标位置
+	overwrite bool              // 改写模式（按Insert键切换）
+	MaxLength int               // 最大字符数，0表示不限制
+	Accept    func(r rune) bool // 允许输入的字符，nil表示允许所有可打印字符
+}
+
+// NewLineEditor 创建行编辑器
+// 参数initial: 初始文本，光标位于末尾
+func NewLineEditor(initial string) *LineEditor {
+	buf := []rune(initial)
+	return &LineEditor{buf: buf, cursor: len(buf)}
+}
+
+// Text 返回当前文本
+func (le *LineEditor) Text() string {
+	return string(le.buf)
+}
+
+// Cursor 返回光标位置（字符下标）
+func (le *LineEditor) Cursor() int {
+	return le.cursor
+}
+
+// Overwrite 返回是否处于改写模式
+func (le *LineEditor) Overwrite() bool {
+	return le.overwrite
+}
+
+// SetText 替换全部文本，光标移到末尾
+func (le *LineEditor) SetText(text string) {
+	le.buf = []rune(text)
+	le.cursor = len(le.buf)
+}
+
+// Insert 在光标处插入文本（改写模式下覆盖光标后的字符）
+// 超出最大长度或不被允许的字符会被忽略
+func (le *LineEditor) Insert(text string) {
+	for _, r := range text {
+		le.insertRune(r)
+	}
+}
+
+// insertRune 在光标处插入一个字符
+func (le *LineEditor) insertRune(r rune) {
+	if !unicode.IsPrint(r) || (le.Accept != nil && !le.Accept(r)) {
+		return
+	}
+	if le.overwrite && le.cursor < len(le.buf) {
+		le.buf[le.cursor] = r
+		le.cursor++
+		return
+	}
+	if le.MaxLength > 0 && len(le.buf) >= le.MaxLength {
+		return
+	}
+	le.buf = append(le.buf, 0)
+	copy(le.buf[le.cursor+1:], le.buf[le.cursor:])
+	le.buf[le.cursor] = r
+	le.cursor++
+}
+
+// Backspace 删除光标前的一个字符
+func (le *LineEditor) Backspace() {
+	if le.cursor == 0 {
+		return
+	}
+	le.buf = append(le.buf[:le.cursor-1], le.buf[le.cursor:]...)
+	le.cursor--
+}
+
+// HandleKey 处理一个按键事件
+// 返回处理后的编辑状态；无法处理的按键被忽略
+func (le *LineEditor) HandleKey(ev KeyEvent) EditResult {
+	if !ev.Pressed {
+		return EditContinue
+	}
+
+	switch ev.Code {
+	case KeyEnter:
+		return EditAccept
+	case KeyEscape:
+		return EditCancel
+	case KeyBackspace:
+		le.Backspace()
+	case KeyDelete:
+		if le.cursor < len(le.buf) {
+			le.buf = append(le.buf[:le.cursor], le.buf[le.cursor+1:]...)
+		}
+	case KeyLeft:
+		if le.cursor > 0 {
+			le.cursor--
+		}
+	case KeyRight:
+		if le.cursor < len(le.buf) {
+			le.cursor++
+		}
+	case KeyHome:
+		le.cursor = 0
+	case KeyEnd:
+		le.cursor = len(le.buf)
+	case KeyInsert:
+		le.overwrite = !le.overwrite
+	case KeyRune:
+		switch {
+		case ev.IsCtrl('u'): // 清空整行
+			le.buf = le.buf[:0]
+			le.cursor = 0
+		case ev.IsCtrl('a'):
+			le.cursor = 0
+		case ev.IsCtrl('e'):
+			le.cursor = len(le.buf)
+		case ev.Modifiers&(ModCtrl|ModAlt) == 0:
+			le.insertRune(ev.Rune)
+		}
+	}
+	return EditContinue
+}
+
+// ReadLine 读取一行文本输入
+// 参数ctx: 控制等待的上下文，取消时返回ctx.Err()
+// 参数initial: 初始文本
+// 参数render: 显示回调，开始时以及每次按键后调用，用于在屏幕上显示输入内容和光标
+// 返回输入的文本；按ESC时返回ErrInputCancelled
+func (ki *KeyboardInput) ReadLine(ctx context.Context, initial string, render LineRenderFunc) (string, error) {
+	return NewLineEditor(initial).Run(ctx, ki.ReadKeyContext, render)
+}
+
+// Run 循环读取按键并编辑，直到回车确认或ESC取消
+// 参数ctx: 控制等待的上下文
+// 参数readKey: 读取按键的函数，如KeyboardInput.ReadKeyContext；调用方可在其中先处理全局热键
+// 参数render: 显示回调，可以为nil
+func (le *LineEditor) Run(ctx context.Context, readKey func(context.Context) (KeyEvent, error), render LineRenderFunc) (string, error) {
+	for {
+		if render != nil {
+			if err := render(le.Text(), le.cursor); err != nil {
+				return "", err
+			}
+		}
+
+		ev, err := readKey(ctx)
+		if err != nil {
+			return "", err
+		}
+		switch le.HandleKey(ev) {
+		case EditAccept:
+			return le.Text(), nil
+		case EditCancel:
+			return "", ErrInputCancelled
+		}
+	}
+}
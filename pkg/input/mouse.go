@@ -0,0 +1,304 @@
+package input
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// 鼠标相关的evdev常量（见linux/input-event-codes.h）
+const (
+	EV_SYN     = 0x00  // 同步事件类型
+	EV_REL     = 0x02  // 相对坐标事件类型
+	SYN_REPORT = 0     // 一组事件结束
+	REL_X      = 0x00  // X方向相对移动
+	REL_Y      = 0x01  // Y方向相对移动
+	BTN_LEFT   = 0x110 // 鼠标左键
+	BTN_RIGHT  = 0x111 // 鼠标右键
+	BTN_MIDDLE = 0x112 // 鼠标中键
+)
+
+// MouseEventType 鼠标事件类型
+type MouseEventType int
+
+// 鼠标事件类型常量
+const (
+	MouseMove MouseEventType = iota // 指针移动
+	MouseDown                       // 按键按下
+	MouseUp                         // 按键抬起
+)
+
+// MouseButton 鼠标按键
+type MouseButton int
+
+// 鼠标按键常量
+const (
+	MouseNoButton MouseButton = iota // 无按键（移动事件）
+	MouseLeft                        // 左键
+	MouseRight                       // 右键
+	MouseMiddle                      // 中键
+)
+
+// MouseEvent 鼠标事件，坐标为限制在屏幕范围内的绝对像素位置
+type MouseEvent struct {
+	Type   MouseEventType // 事件类型
+	Button MouseButton    // 按下或抬起的按键
+	X, Y   int            // 事件发生时的指针坐标
+	Time   time.Time      // 事件发生时间
+	Touch  bool           // 事件来自触摸屏（没有指针需要移动）
+}
+
+// IsClick 判断事件是否为左键按下，菜单以此作为点击
+func (e MouseEvent) IsClick() bool {
+	return e.Type == MouseDown && e.Button == MouseLeft
+}
+
+// mouseButtons 按键扫描码与鼠标按键的对应关系
+var mouseButtons = map[uint16]MouseButton{
+	BTN_LEFT:   MouseLeft,
+	BTN_RIGHT:  MouseRight,
+	BTN_MIDDLE: MouseMiddle,
+}
+
+// MouseInput 鼠标输入处理器
+// 读取evdev相对坐标设备（鼠标、触摸板），累计位移得到屏幕上的指针位置
+type MouseInput struct {
+	mu            sync.Mutex
+	devices       []*os.File      // 已打开的鼠标设备
+	width, height int             // 屏幕尺寸，用于限制指针范围
+	x, y          int             // 当前指针位置
+	moved         bool            // 自上次同步后是否有位移
+	events        chan MouseEvent // 鼠标事件通道，调用Events后创建
+	pumpOnce      sync.Once       // 保证事件读取goroutine只启动一次
+	done          chan struct{}   // 关闭时关闭，通知后台goroutine退出
+	closed        bool            // 关闭状态标志
+}
+
+// NewMouseInput 打开系统中所有的鼠标设备
+// 参数width,height: 屏幕尺寸，指针初始位于屏幕中央
+// 返回初始化完成的鼠标输入器，找不到鼠标时返回错误
+func NewMouseInput(width, height int) (*MouseInput, error) {
+	devices, err := openEvdevDevices(isMouseDevice)
+	if err != nil {
+		return nil, err
+	}
+	if len(devices) == 0 {
+		return nil, fmt.Errorf("未找到可用的鼠标输入设备")
+	}
+	return &MouseInput{
+		devices: devices,
+		width:   width,
+		height:  height,
+		x:       width / 2,
+		y:       height / 2,
+		done:    make(chan struct{}),
+	}, nil
+}
+
+// isMouseDevice 判断设备是否为鼠标：支持X/Y相对移动且带有左键
+func isMouseDevice(f *os.File) bool {
+	return hasEventCodes(f, EV_REL, REL_X, REL_Y) && hasEventCodes(f, EV_KEY, BTN_LEFT)
+}
+
+// Position 返回当前指针位置
+func (mi *MouseInput) Position() (int, int) {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+	return mi.x, mi.y
+}
+
+// Events 返回鼠标事件通道
+// 首次调用时启动后台读取goroutine，关闭后通道随之关闭。
+// 通道已满时丢弃移动事件，保证按键事件不会因指针移动过多而积压
+func (mi *MouseInput) Events() <-chan MouseEvent {
+	mi.pumpOnce.Do(func() {
+		events := make(chan MouseEvent, eventsBufferSize)
+		mi.mu.Lock()
+		mi.events = events
+		mi.mu.Unlock()
+		go mi.pumpEvents(events)
+	})
+
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+	return mi.events
+}
+
+// pumpEvents 后台读取鼠标事件，直到关闭
+func (mi *MouseInput) pumpEvents(events chan<- MouseEvent) {
+	defer RecoverTerminal()
+	defer close(events)
+	for {
+		batch, err := mi.readEvents(100 * time.Millisecond)
+		if err != nil {
+			if mi.isClosed() {
+				return
+			}
+			continue
+		}
+		for _, ev := range batch {
+			if ev.Type == MouseMove {
+				select {
+				case events <- ev:
+				default: // 通道已满，丢弃移动事件
+				}
+				continue
+			}
+			select {
+			case events <- ev:
+			case <-mi.done:
+				return
+			}
+		}
+	}
+}
+
+// readEvents 等待并读取一批鼠标事件
+// 参数timeout: 等待输入的超时时间
+func (mi *MouseInput) readEvents(timeout time.Duration) ([]MouseEvent, error) {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+
+	if mi.closed || len(mi.devices) == 0 {
+		return nil, fmt.Errorf("鼠标设备已关闭")
+	}
+
+	fds := make([]int, len(mi.devices))
+	for i, f := range mi.devices {
+		fds[i] = int(f.Fd())
+	}
+	ready, err := waitReadable(fds, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var batch []MouseEvent
+	buf := make([]byte, inputEventSize*64)
+	for i, f := range mi.devices {
+		if !ready[i] {
+			continue
+		}
+		n, err := f.Read(buf)
+		if err != nil {
+			return batch, fmt.Errorf("读取鼠标设备%s失败: %v", f.Name(), err)
+		}
+		for off := 0; off+inputEventSize <= n; off += inputEventSize {
+			raw := *(*InputEvent)(unsafe.Pointer(&buf[off]))
+			if ev, ok := mi.translate(raw); ok {
+				batch = append(batch, ev)
+			}
+		}
+	}
+	return batch, nil
+}
+
+// translate 处理一个原始输入事件，更新指针位置
+// 位移在SYN_REPORT时合并为一次移动事件，按键直接产生按下/抬起事件
+// 调用方需持有mi.mu
+func (mi *MouseInput) translate(raw InputEvent) (MouseEvent, bool) {
+	now := time.Unix(int64(raw.Time.Sec), int64(raw.Time.Usec)*1000)
+
+	switch raw.Type {
+	case EV_REL:
+		switch raw.Code {
+		case REL_X:
+			mi.x = clampInt(mi.x+int(raw.Value), 0, mi.width-1)
+			mi.moved = true
+		case REL_Y:
+			mi.y = clampInt(mi.y+int(raw.Value), 0, mi.height-1)
+			mi.moved = true
+		}
+	case EV_KEY:
+		button, ok := mouseButtons[raw.Code]
+		if !ok {
+			return MouseEvent{}, false
+		}
+		ev := MouseEvent{Type: MouseUp, Button: button, X: mi.x, Y: mi.y, Time: now}
+		if raw.Value != keyValueRelease {
+			ev.Type = MouseDown
+		}
+		return ev, true
+	case EV_SYN:
+		if raw.Code == SYN_REPORT && mi.moved {
+			mi.moved = false
+			return MouseEvent{Type: MouseMove, X: mi.x, Y: mi.y, Time: now}, true
+		}
+	}
+	return MouseEvent{}, false
+}
+
+// isClosed 判断鼠标是否已关闭
+func (mi *MouseInput) isClosed() bool {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+	return mi.closed
+}
+
+// Close 关闭所有鼠标设备并停止后台读取
+func (mi *MouseInput) Close() error {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+
+	if mi.closed {
+		return nil
+	}
+
+	var err error
+	for _, f := range mi.devices {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("关闭鼠标设备%s失败: %v", f.Name(), closeErr)
+		}
+	}
+	mi.devices = nil
+	mi.closed = true
+	close(mi.done)
+	return err
+}
+
+// MergeMouseEvents 把多个鼠标/触摸事件通道合并为一个
+// nil通道会被忽略，全部为nil时返回nil；所有来源关闭后合并通道随之关闭
+func MergeMouseEvents(sources ...<-chan MouseEvent) <-chan MouseEvent {
+	var active []<-chan MouseEvent
+	for _, src := range sources {
+		if src != nil {
+			active = append(active, src)
+		}
+	}
+	switch len(active) {
+	case 0:
+		return nil
+	case 1:
+		return active[0]
+	}
+
+	merged := make(chan MouseEvent, eventsBufferSize)
+	var wg sync.WaitGroup
+	for _, src := range active {
+		wg.Add(1)
+		go func(src <-chan MouseEvent) {
+			defer RecoverTerminal()
+			defer wg.Done()
+			for ev := range src {
+				merged <- ev
+			}
+		}(src)
+	}
+	go func() {
+		wg.Wait()
+		close(merged)
+	}()
+	return merged
+}
+
+// clampInt 将v限制在[lo, hi]范围内
+func clampInt(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
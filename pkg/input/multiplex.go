@@ -0,0 +1,131 @@
+package input
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// ttyDeviceName 终端后端产生的按键事件的设备标识
+const ttyDeviceName = "tty"
+
+// AddDevices 把指定的evdev输入设备并入按键事件流
+// 用于同时带有前面板小键盘和USB键盘等多种输入设备的设备，任一设备都可以操作菜单。
+// 指定的设备不做键盘能力检查，因此只有数字键的小键盘也可以使用；
+// 事件的Device字段标明产生事件的设备。需要在首次调用Events之前调用
+// 参数paths: 设备路径，如/dev/input/by-path/platform-keypad-event
+func (ki *KeyboardInput) AddDevices(paths []string) error {
+	ki.mu.Lock()
+	started := ki.events != nil
+	ki.mu.Unlock()
+	if started {
+		return fmt.Errorf("按键事件已开始读取，无法再添加输入设备")
+	}
+
+	var opened []*os.File
+	for _, path := range paths {
+		f, err := os.OpenFile(path, os.O_RDONLY, 0)
+		if err != nil {
+			for _, o := range opened {
+				o.Close()
+			}
+			return fmt.Errorf("打开输入设备%s失败: %v", path, err)
+		}
+		log.Printf("已添加输入设备: %s (%s)", path, deviceName(f))
+		opened = append(opened, f)
+	}
+
+	// evdev后端直接把设备加入已有的读取器；终端后端另建读取器与终端输入合并
+	if ki.evdev != nil {
+		ki.evdev.addDevices(opened)
+		return nil
+	}
+
+	ki.mu.Lock()
+	defer ki.mu.Unlock()
+	if ki.extra == nil {
+		ki.extra = &evdevReader{held: make(map[uint16]bool), repeat: ki.repeat}
+	}
+	ki.extra.addDevices(opened)
+	return nil
+}
+
+// addDevices 把已打开的设备加入读取列表，已存在的设备会被关闭并忽略
+func (er *evdevReader) addDevices(files []*os.File) {
+	er.mu.Lock()
+	defer er.mu.Unlock()
+
+	for _, f := range files {
+		duplicate := false
+		for _, existing := range er.devices {
+			if existing.Name() == f.Name() {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			f.Close()
+			continue
+		}
+		er.devices = append(er.devices, f)
+	}
+}
+
+// pumpReader 后台读取并入的evdev设备，把按键事件汇入events，直到键盘关闭
+func (ki *KeyboardInput) pumpReader(er *evdevReader, events chan<- KeyEvent) {
+	for {
+		ev, ok, err := er.readEvent(100 * time.Millisecond)
+		if err != nil {
+			if ki.isClosed() {
+				return
+			}
+			time.Sleep(100 * time.Millisecond) // 设备全部移除后避免空转
+			continue
+		}
+		if !ok {
+			continue
+		}
+		ki.record(ev)
+		select {
+		case events <- ev:
+		case <-ki.done:
+			return
+		}
+	}
+}
+
+// Devices 返回当前提供按键的所有设备标识
+func (ki *KeyboardInput) Devices() []string {
+	var devices []string
+	if ki.evdev != nil {
+		devices = append(devices, ki.evdev.Devices()...)
+	} else if ki.replay != nil {
+		devices = append(devices, replayDeviceName)
+	} else {
+		devices = append(devices, ki.ttyName())
+	}
+
+	ki.mu.Lock()
+	extra := ki.extra
+	ki.mu.Unlock()
+	if extra != nil {
+		devices = append(devices, extra.Devices()...)
+	}
+	return devices
+}
+
+// deviceName 通过EVIOCGNAME读取设备名称，失败时返回空字符串
+func deviceName(f *os.File) string {
+	var buf [256]byte
+	const iocRead = 2
+	cmd := uintptr(iocRead<<30 | len(buf)<<16 | 'E'<<8 | 0x06)
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), cmd, uintptr(unsafe.Pointer(&buf[0])))
+	if errno != 0 {
+		return ""
+	}
+	return strings.TrimRight(string(buf[:]), "\x00")
+}
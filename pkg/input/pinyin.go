@@ -0,0 +1,327 @@
+package input
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"go-framebuffer-console/pkg/i18n"
+)
+
+// PinyinPageSize 拼音输入法每页显示的候选数，按数字键1~5选择
+const PinyinPageSize = 5
+
+// maxPinyinLength 拼音串的最大长度，超出部分的字母被忽略
+const maxPinyinLength = 32
+
+// builtinPinyinDict 随程序一起发布的拼音词库
+//
+//go:embed dict/pinyin.txt
+var builtinPinyinDict string
+
+// PinyinDict 拼音词库：拼音到按频率排序的候选字词的映射
+type PinyinDict struct {
+	entries map[string][]string
+	keys    []string // 按字母序排列的全部拼音，用于前缀补全
+}
+
+var (
+	defaultPinyinDict     *PinyinDict
+	defaultPinyinDictOnce sync.Once
+)
+
+// DefaultPinyinDict 返回内置的拼音词库
+// 词库只在首次使用时解析一次
+func DefaultPinyinDict() *PinyinDict {
+	defaultPinyinDictOnce.Do(func() {
+		dict, err := parsePinyinDict(strings.NewReader(builtinPinyinDict))
+		if err != nil {
+			// 内置词库随代码一起发布，解析失败属于编程错误
+			panic(fmt.Sprintf("解析内置拼音词库失败: %v", err))
+		}
+		defaultPinyinDict = dict
+	})
+	return defaultPinyinDict
+}
+
+// parsePinyinDict 解析词库，每行格式为"拼音 候选1 候选2 ..."，以#开头的行为注释
+func parsePinyinDict(r io.Reader) (*PinyinDict, error) {
+	dict := &PinyinDict{entries: make(map[string][]string)}
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("第%d行缺少候选字: %s", lineNo, line)
+		}
+		key := fields[0]
+		if _, exists := dict.entries[key]; !exists {
+			dict.keys = append(dict.keys, key)
+		}
+		dict.entries[key] = append(dict.entries[key], fields[1:]...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.Strings(dict.keys)
+	return dict, nil
+}
+
+// pinyinCandidate 一个候选字词
+type pinyinCandidate struct {
+	text    string // 候选文本
+	consume int    // 选择后从拼音串开头消耗的字母数
+}
+
+// lookup 查找拼音串对应的候选
+// 依次为：完整匹配的字词、以拼音串为前缀的词组（如"nih"补全为"你好"）、
+// 拼音串开头最长音节的单字（选择后剩余字母继续输入，用于逐字输入多音节拼音）
+func (d *PinyinDict) lookup(composing string) []pinyinCandidate {
+	var result []pinyinCandidate
+	seen := make(map[string]bool)
+	add := func(texts []string, consume int) {
+		for _, text := range texts {
+			if !seen[text] {
+				seen[text] = true
+				result = append(result, pinyinCandidate{text: text, consume: consume})
+			}
+		}
+	}
+
+	add(d.entries[composing], len(composing))
+
+	// 前缀补全：只取每个拼音的首选候选，避免候选列表过长
+	i := sort.SearchStrings(d.keys, composing)
+	for ; i < len(d.keys) && strings.HasPrefix(d.keys[i], composing); i++ {
+		if key := d.keys[i]; key != composing {
+			add(d.entries[key][:1], len(composing))
+		}
+	}
+
+	for n := len(composing) - 1; n > 0; n-- {
+		if texts, ok := d.entries[composing[:n]]; ok {
+			add(texts, n)
+			break
+		}
+	}
+	return result
+}
+
+// PinyinIME 叠加在行编辑器之上的简易拼音输入法
+// 输入小写字母组成拼音串，空格选择首个候选，数字键选择当前页的候选，
+// -/=或PageUp/PageDown翻页，回车上屏原始字母，ESC清除拼音串；
+// 没有拼音串时按键交给行编辑器处理。Ctrl+空格切换中英文输入
+type PinyinIME struct {
+	editor     *LineEditor
+	dict       *PinyinDict
+	enabled    bool              // 是否处于中文输入状态
+	composing  string            // 正在输入的拼音串
+	candidates []pinyinCandidate // 当前拼音串的全部候选
+	page       int               // 当前候选页
+}
+
+// NewPinyinIME 创建拼音输入法，初始处于中文输入状态
+// 参数editor: 接收上屏文字的行编辑器
+// 参数dict: 拼音词库，nil表示使用内置词库
+func NewPinyinIME(editor *LineEditor, dict *PinyinDict) *PinyinIME {
+	if dict == nil {
+		dict = DefaultPinyinDict()
+	}
+	return &PinyinIME{editor: editor, dict: dict, enabled: true}
+}
+
+// Editor 返回输入法所在的行编辑器
+func (ime *PinyinIME) Editor() *LineEditor {
+	return ime.editor
+}
+
+// Enabled 返回是否处于中文输入状态
+func (ime *PinyinIME) Enabled() bool {
+	return ime.enabled
+}
+
+// SetEnabled 切换中英文输入状态，未完成的拼音串按原始字母上屏
+func (ime *PinyinIME) SetEnabled(enabled bool) {
+	if !enabled && ime.composing != "" {
+		ime.editor.Insert(ime.composing)
+		ime.setComposing("")
+	}
+	ime.enabled = enabled
+}
+
+// Composing 返回正在输入的拼音串
+func (ime *PinyinIME) Composing() string {
+	return ime.composing
+}
+
+// Candidates 返回当前页的候选
+func (ime *PinyinIME) Candidates() []string {
+	start := ime.page * PinyinPageSize
+	end := start + PinyinPageSize
+	if end > len(ime.candidates) {
+		end = len(ime.candidates)
+	}
+	var texts []string
+	for _, c := range ime.candidates[start:end] {
+		texts = append(texts, c.text)
+	}
+	return texts
+}
+
+// Status 返回输入法状态行，如"[中] nihao  1.你好 2.你 3.尼 (1/3)"，用于显示在输入框下方
+func (ime *PinyinIME) Status() string {
+	if !ime.enabled {
+		return i18n.Translate("[英] Ctrl+空格切换中文")
+	}
+	if ime.composing == "" {
+		return i18n.Translate("[中] Ctrl+空格切换英文")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("[中] ")
+	sb.WriteString(ime.composing)
+	sb.WriteString(" ")
+	for i, text := range ime.Candidates() {
+		fmt.Fprintf(&sb, " %d.%s", i+1, text)
+	}
+	if pages := ime.pageCount(); pages > 1 {
+		fmt.Fprintf(&sb, " (%d/%d)", ime.page+1, pages)
+	}
+	return sb.String()
+}
+
+// pageCount 返回候选的总页数
+func (ime *PinyinIME) pageCount() int {
+	return (len(ime.candidates) + PinyinPageSize - 1) / PinyinPageSize
+}
+
+// setComposing 更新拼音串并重新查找候选
+func (ime *PinyinIME) setComposing(composing string) {
+	ime.composing = composing
+	ime.page = 0
+	ime.candidates = nil
+	if composing != "" {
+		ime.candidates = ime.dict.lookup(composing)
+	}
+}
+
+// choose 选择当前页的第index个候选上屏，剩余的拼音字母继续输入
+func (ime *PinyinIME) choose(index int) {
+	i := ime.page*PinyinPageSize + index
+	if index < 0 || index >= PinyinPageSize || i >= len(ime.candidates) {
+		return
+	}
+	c := ime.candidates[i]
+	ime.editor.Insert(c.text)
+	ime.setComposing(ime.composing[c.consume:])
+}
+
+// isIMEToggle 判断是否为切换中英文的按键
+// evdev后端报告为Ctrl+空格，终端中Ctrl+空格产生NUL字节，报告为Ctrl+@
+func isIMEToggle(ev KeyEvent) bool {
+	return ev.IsCtrl(' ') || ev.IsCtrl('@')
+}
+
+// isPinyinLetter 判断按键是否为拼音字母（不带修饰键的小写字母）
+func isPinyinLetter(ev KeyEvent) bool {
+	return ev.Code == KeyRune && ev.Modifiers&(ModCtrl|ModAlt) == 0 && ev.Rune >= 'a' && ev.Rune <= 'z'
+}
+
+// HandleKey 处理一个按键事件
+// 返回处理后的编辑状态；输入拼音期间回车和ESC只作用于拼音串，不会结束编辑
+func (ime *PinyinIME) HandleKey(ev KeyEvent) EditResult {
+	if !ev.Pressed {
+		return EditContinue
+	}
+	if isIMEToggle(ev) {
+		ime.SetEnabled(!ime.enabled)
+		return EditContinue
+	}
+	if !ime.enabled {
+		return ime.editor.HandleKey(ev)
+	}
+	if isPinyinLetter(ev) {
+		if len(ime.composing) < maxPinyinLength {
+			ime.setComposing(ime.composing + string(ev.Rune))
+		}
+		return EditContinue
+	}
+	if ime.composing == "" {
+		return ime.editor.HandleKey(ev)
+	}
+
+	switch ev.Code {
+	case KeyBackspace:
+		ime.setComposing(ime.composing[:len(ime.composing)-1])
+	case KeyEscape:
+		ime.setComposing("")
+	case KeyEnter:
+		ime.editor.Insert(ime.composing)
+		ime.setComposing("")
+	case KeyPageDown:
+		ime.nextPage(1)
+	case KeyPageUp:
+		ime.nextPage(-1)
+	case KeyRune:
+		switch r := ev.Rune; {
+		case r == ' ':
+			if len(ime.candidates) == 0 {
+				ime.editor.Insert(ime.composing)
+				ime.setComposing("")
+			} else {
+				ime.choose(0)
+			}
+		case r >= '1' && r <= '9':
+			ime.choose(int(r - '1'))
+		case r == '=' || r == '.':
+			ime.nextPage(1)
+		case r == '-' || r == ',':
+			ime.nextPage(-1)
+		}
+	}
+	return EditContinue
+}
+
+// nextPage 向前或向后翻页，到达首页或末页时保持不变
+func (ime *PinyinIME) nextPage(delta int) {
+	page := ime.page + delta
+	if page >= 0 && page < ime.pageCount() {
+		ime.page = page
+	}
+}
+
+// Run 循环读取按键并输入，直到回车确认或ESC取消
+// 参数ctx: 控制等待的上下文
+// 参数readKey: 读取按键的函数，如KeyboardInput.ReadKeyContext
+// 参数render: 显示回调，可以为nil；需要显示拼音串和候选时可在回调中调用Status
+func (ime *PinyinIME) Run(ctx context.Context, readKey func(context.Context) (KeyEvent, error), render LineRenderFunc) (string, error) {
+	for {
+		if render != nil {
+			if err := render(ime.editor.Text(), ime.editor.Cursor()); err != nil {
+				return "", err
+			}
+		}
+
+		ev, err := readKey(ctx)
+		if err != nil {
+			return "", err
+		}
+		switch ime.HandleKey(ev) {
+		case EditAccept:
+			return ime.editor.Text(), nil
+		case EditCancel:
+			return "", ErrInputCancelled
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package input
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// replayDeviceName 回放产生的按键事件的设备标识
+const replayDeviceName = "replay"
+
+// RecordedKey 录制文件中的一个按键
+type RecordedKey struct {
+	Offset time.Duration // 相对第一个按键的时间偏移
+	Event  KeyEvent      // 按键事件（不含时间和设备）
+}
+
+// Recorder 按键录制器，把按键事件逐行写入录制文件
+// 文件每行格式为"毫秒偏移 按键 [up] [repeat]"，例如"1500 Ctrl+c"、"2300 Down repeat"，
+// 按键的写法与热键描述相同，空格写作Space，不可打印字符写作U+XXXX；
+// 以#开头的行为注释，因此录制文件也可以手工编写，用作菜单操作流程的回归测试脚本
+type Recorder struct {
+	mu    sync.Mutex
+	w     io.Writer
+	start time.Time // 第一个按键的时间
+}
+
+// NewRecorder 创建写入w的按键录制器
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w}
+}
+
+// Record 录制一个按键事件，无法识别的按键被忽略
+func (r *Recorder) Record(ev KeyEvent) error {
+	if ev.Code == KeyNone {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.start.IsZero() {
+		r.start = ev.Time
+	}
+	line := fmt.Sprintf("%d %s", ev.Time.Sub(r.start).Milliseconds(), formatRecordedKey(ev))
+	if !ev.Pressed {
+		line += " up"
+	}
+	if ev.Repeat {
+		line += " repeat"
+	}
+	if _, err := fmt.Fprintln(r.w, line); err != nil {
+		return fmt.Errorf("写入按键录制失败: %v", err)
+	}
+	return nil
+}
+
+// formatRecordedKey 返回按键在录制文件中的写法
+func formatRecordedKey(ev KeyEvent) string {
+	name := ev.Code.String()
+	if ev.Code == KeyRune {
+		switch {
+		case ev.Rune == ' ':
+			name = "Space"
+		case !unicode.IsPrint(ev.Rune) || unicode.IsSpace(ev.Rune):
+			name = fmt.Sprintf("U+%04X", ev.Rune)
+		default:
+			name = string(ev.Rune)
+		}
+	}
+	if mods := ev.Modifiers.String(); mods != "" {
+		name = mods + "+" + name
+	}
+	return name
+}
+
+// parseRecordedKey 解析录制文件中的按键写法，与formatRecordedKey互逆
+// 与ParseHotkey不同，字母保留原本的大小写，Shift只在写明时才加入修饰键
+func parseRecordedKey(spec string) (KeyEvent, error) {
+	// 拆分修饰键和按键名，按键本身可能是"+"或以"U+"开头
+	modsPart, name := "", spec
+	if i := strings.LastIndex(spec, "U+"); i == 0 || (i > 0 && spec[i-1] == '+') {
+		name = spec[i:]
+		if i > 0 {
+			modsPart = spec[:i-1]
+		}
+	} else if i := strings.LastIndex(spec[:len(spec)-1], "+"); i >= 0 {
+		modsPart, name = spec[:i], spec[i+1:]
+	}
+
+	ev := KeyEvent{Pressed: true}
+	if modsPart != "" {
+		for _, mod := range strings.Split(modsPart, "+") {
+			m, ok := parseModifier(mod)
+			if !ok {
+				return KeyEvent{}, fmt.Errorf("无法识别的修饰键: %s", mod)
+			}
+			ev.Modifiers |= m
+		}
+	}
+
+	if code, ok := parseKeyName(name); ok {
+		ev.Code = code
+		return ev, nil
+	}
+	ev.Code = KeyRune
+	switch {
+	case strings.EqualFold(name, "Space"):
+		ev.Rune = ' '
+	case strings.HasPrefix(name, "U+"):
+		v, err := strconv.ParseUint(name[2:], 16, 32)
+		if err != nil {
+			return KeyEvent{}, fmt.Errorf("无效的字符编码: %s", name)
+		}
+		ev.Rune = rune(v)
+	default:
+		runes := []rune(name)
+		if len(runes) != 1 {
+			return KeyEvent{}, fmt.Errorf("无法识别的按键: %s", name)
+		}
+		ev.Rune = runes[0]
+	}
+	return ev, nil
+}
+
+// ParseRecording 解析录制文件内容
+func ParseRecording(r io.Reader) ([]RecordedKey, error) {
+	var keys []RecordedKey
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("第%d行格式错误: %s", lineNo, line)
+		}
+		ms, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil || ms < 0 {
+			return nil, fmt.Errorf("第%d行时间偏移无效: %s", lineNo, fields[0])
+		}
+		ev, err := parseRecordedKey(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("第%d行: %v", lineNo, err)
+		}
+		for _, flag := range fields[2:] {
+			switch flag {
+			case "up":
+				ev.Pressed = false
+			case "repeat":
+				ev.Repeat = true
+			default:
+				return nil, fmt.Errorf("第%d行标记无效: %s", lineNo, flag)
+			}
+		}
+		keys = append(keys, RecordedKey{Offset: time.Duration(ms) * time.Millisecond, Event: ev})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// LoadRecording 读取录制文件
+func LoadRecording(path string) ([]RecordedKey, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("打开录制文件失败: %v", err)
+	}
+	defer f.Close()
+
+	keys, err := ParseRecording(f)
+	if err != nil {
+		return nil, fmt.Errorf("解析录制文件%s失败: %v", path, err)
+	}
+	return keys, nil
+}
+
+// SetRecorder 设置按键录制器，之后经Events送出的每个按键事件都会被录制
+// 参数rec: 录制器，nil表示停止录制
+func (ki *KeyboardInput) SetRecorder(rec *Recorder) {
+	ki.mu.Lock()
+	defer ki.mu.Unlock()
+	ki.recorder = rec
+}
+
+// record 录制一个按键事件，录制失败只影响录制文件，不影响按键处理
+func (ki *KeyboardInput) record(ev KeyEvent) {
+	ki.mu.Lock()
+	rec := ki.recorder
+	ki.mu.Unlock()
+	if rec != nil {
+		rec.Record(ev)
+	}
+}
+
+// replayReader 按录制的时间间隔依次产生按键事件
+type replayReader struct {
+	mu       sync.Mutex
+	keys     []RecordedKey
+	speed    float64       // 回放速度倍数，0表示不等待
+	next     int           // 下一个要产生的按键
+	start    time.Time     // 回放开始时间
+	finished chan struct{} // 所有按键产生完毕后关闭
+	once     sync.Once
+}
+
+// readEvent 等待并返回下一个到期的按键
+// 参数timeout: 最长等待时间
+// 返回按键事件以及是否在超时前产生了按键
+func (rr *replayReader) readEvent(timeout time.Duration) (KeyEvent, bool, error) {
+	rr.mu.Lock()
+	if rr.start.IsZero() {
+		rr.start = time.Now()
+	}
+	if rr.next >= len(rr.keys) {
+		rr.mu.Unlock()
+		rr.once.Do(func() { close(rr.finished) })
+		time.Sleep(timeout)
+		return KeyEvent{}, false, nil
+	}
+
+	key := rr.keys[rr.next]
+	var wait time.Duration
+	if rr.speed > 0 {
+		wait = time.Until(rr.start.Add(time.Duration(float64(key.Offset) / rr.speed)))
+	}
+	if wait > timeout {
+		rr.mu.Unlock()
+		time.Sleep(timeout)
+		return KeyEvent{}, false, nil
+	}
+	rr.next++
+	rr.mu.Unlock()
+
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+	ev := key.Event
+	ev.Time = time.Now()
+	ev.Device = replayDeviceName
+	return ev, true, nil
+}
+
+// NewReplayKeyboardInput 创建回放录制按键的输入处理器
+// 与内存帧缓冲区一起使用时，可以在没有键盘和显示器的CI环境中自动执行菜单操作流程
+// 参数keys: 录制的按键，通常来自LoadRecording
+// 参数speed: 回放速度倍数，1表示按录制时的节奏，0表示不等待、尽快送出
+func NewReplayKeyboardInput(keys []RecordedKey, speed float64) *KeyboardInput {
+	return &KeyboardInput{
+		done:     make(chan struct{}),
+		repeat:   DefaultRepeatConfig(),
+		restored: true, // 未修改终端属性，无需恢复
+		replay:   &replayReader{keys: keys, speed: speed, finished: make(chan struct{})},
+	}
+}
+
+// ReplayDone 返回回放结束时关闭的通道，不是回放输入时返回nil（永远不会就绪）
+func (ki *KeyboardInput) ReplayDone() <-chan struct{} {
+	if ki.replay == nil {
+		return nil
+	}
+	return ki.replay.finished
+}
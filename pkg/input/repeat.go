@@ -0,0 +1,96 @@
+package input
+
+import "time"
+
+// 按键自动重复的默认参数
+const (
+	DefaultRepeatDelay = 400 * time.Millisecond // 按住后开始重复前的等待时间
+	DefaultRepeatRate  = 20                     // 每秒重复次数
+)
+
+// ttyRepeatGap 终端后端识别自动重复的最大间隔
+// 终端无法报告按键抬起，只能把短时间内连续到达的相同按键视为按住不放
+const ttyRepeatGap = 100 * time.Millisecond
+
+// RepeatConfig 按键自动重复参数
+type RepeatConfig struct {
+	Delay time.Duration // 按住后开始重复前的等待时间
+	Rate  int           // 每秒重复次数，0表示禁用自动重复
+}
+
+// DefaultRepeatConfig 返回默认的自动重复参数
+func DefaultRepeatConfig() RepeatConfig {
+	return RepeatConfig{Delay: DefaultRepeatDelay, Rate: DefaultRepeatRate}
+}
+
+// interval 返回两次重复之间的间隔
+func (rc RepeatConfig) interval() time.Duration {
+	if rc.Rate <= 0 {
+		return 0
+	}
+	return time.Second / time.Duration(rc.Rate)
+}
+
+// sameKey 判断两个事件是否为同一个按键
+func sameKey(a, b KeyEvent) bool {
+	return a.Code == b.Code && a.Rune == b.Rune && a.Modifiers == b.Modifiers
+}
+
+// repeatFilter 终端后端的自动重复过滤器
+// 终端按自身的速率不断发送按住的按键，过滤器把它们标记为重复事件，
+// 并按配置的延迟和速率丢弃多余的事件，避免事件通道被淹没
+type repeatFilter struct {
+	last      KeyEvent  // 上一个到达的按键
+	lastSeen  time.Time // 上一个按键到达的时间
+	pressedAt time.Time // 本次连续按住的开始时间
+	lastEmit  time.Time // 上一次放行事件的时间
+}
+
+// filter 处理一个按键事件
+// 参数cfg: 自动重复参数
+// 返回处理后的事件以及是否应当放行
+func (f *repeatFilter) filter(ev KeyEvent, cfg RepeatConfig) (KeyEvent, bool) {
+	now := ev.Time
+	repeated := !f.lastSeen.IsZero() && sameKey(ev, f.last) && now.Sub(f.lastSeen) <= ttyRepeatGap
+	f.lastSeen = now
+	f.last = ev
+
+	if !repeated {
+		f.pressedAt = now
+		f.lastEmit = now
+		return ev, true
+	}
+
+	if cfg.Rate <= 0 {
+		return ev, false // 自动重复已禁用
+	}
+	if now.Sub(f.pressedAt) < cfg.Delay || now.Sub(f.lastEmit) < cfg.interval() {
+		return ev, false
+	}
+	f.lastEmit = now
+	ev.Repeat = true
+	return ev, true
+}
+
+// SetRepeat 设置按键自动重复参数
+// evdev后端能检测按键抬起，按该参数合成重复事件；终端后端据此过滤终端自身产生的重复按键
+func (ki *KeyboardInput) SetRepeat(cfg RepeatConfig) {
+	ki.mu.Lock()
+	ki.repeat = cfg
+	extra := ki.extra
+	ki.mu.Unlock()
+
+	if ki.evdev != nil {
+		ki.evdev.setRepeat(cfg)
+	}
+	if extra != nil {
+		extra.setRepeat(cfg)
+	}
+}
+
+// repeatConfig 返回当前的自动重复参数
+func (ki *KeyboardInput) repeatConfig() RepeatConfig {
+	ki.mu.Lock()
+	defer ki.mu.Unlock()
+	return ki.repeat
+}
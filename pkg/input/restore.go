@@ -0,0 +1,74 @@
+package input
+
+import (
+	"io"
+	"os"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// savedTerminal 已被修改属性的终端，记录恢复所需的全部信息
+// 不引用KeyboardInput的锁，因此即使在持有锁时发生panic也可以恢复
+type savedTerminal struct {
+	fd      uintptr         // 终端的文件描述符
+	termios syscall.Termios // 修改前的终端属性
+	tty     io.Writer       // 写入显示光标控制序列的终端，可以为nil
+}
+
+// 全局终端恢复登记表：设置原始模式时登记，正常恢复时注销
+var (
+	terminalsMu sync.Mutex
+	terminals   = make(map[*KeyboardInput]savedTerminal)
+)
+
+// registerTerminal 登记已修改属性的终端，供RestoreTerminals在异常退出时恢复
+func registerTerminal(ki *KeyboardInput) {
+	st := savedTerminal{fd: ki.device.Fd(), termios: ki.oldTermios}
+	if ki.ttyDevice != nil {
+		st.tty = ki.ttyDevice
+	}
+
+	terminalsMu.Lock()
+	defer terminalsMu.Unlock()
+	terminals[ki] = st
+}
+
+// unregisterTerminal 终端已正常恢复，从登记表中移除
+func unregisterTerminal(ki *KeyboardInput) {
+	terminalsMu.Lock()
+	defer terminalsMu.Unlock()
+	delete(terminals, ki)
+}
+
+// RestoreTerminals 恢复所有被修改过的终端：显示光标并还原终端属性
+// 只依赖登记时保存的信息，不获取键盘的锁，可以在panic和退出路径上安全调用；
+// 多次调用时只有第一次生效
+func RestoreTerminals() {
+	terminalsMu.Lock()
+	defer terminalsMu.Unlock()
+	for ki, st := range terminals {
+		if st.tty != nil {
+			st.tty.Write([]byte("\033[?25h"))
+		}
+		syscall.Syscall(syscall.SYS_IOCTL, st.fd, TCSETS, uintptr(unsafe.Pointer(&st.termios)))
+		delete(terminals, ki)
+	}
+}
+
+// RecoverTerminal 在panic时恢复终端后继续panic
+// 用法为在main和每个后台goroutine的开头写defer input.RecoverTerminal()；
+// 继续panic保留了原有的崩溃行为和调用栈，只是终端不会停留在无回显的原始模式
+func RecoverTerminal() {
+	if r := recover(); r != nil {
+		RestoreTerminals()
+		panic(r)
+	}
+}
+
+// Exit 恢复所有终端后以指定状态码退出进程
+// os.Exit和log.Fatal不会执行defer，程序在终端被修改后需要退出时应使用Exit
+func Exit(code int) {
+	RestoreTerminals()
+	os.Exit(code)
+}
@@ -0,0 +1,167 @@
+package input
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultScanTimeout 扫码枪两个字符之间的最长间隔，超过后丢弃未结束的扫码
+// 扫码枪逐字符模拟键盘输入，整个条码通常在几十毫秒内送完
+const DefaultScanTimeout = 500 * time.Millisecond
+
+// ScanCapture 扫码捕获器
+// 扫码枪以键盘的方式工作，扫描结果是一串字符加回车。捕获器缓存整次扫描的字符，
+// 收到回车（部分扫码枪为Tab）后把完整的字符串交给回调，这些按键不再传给页面，
+// 避免条码中的字符被当作菜单选项。来自指定扫码枪设备的按键总是被捕获；
+// 开启扫码模式后，其它键盘输入的字符也被捕获，便于在需要输入激活码等内容的页面手工输入
+type ScanCapture struct {
+	mu       sync.Mutex
+	onScan   func(code string)
+	devices  map[string]bool // 扫码枪设备，已解析符号链接
+	resolved map[string]string
+	active   bool          // 是否处于扫码模式
+	timeout  time.Duration // 扫码枪字符之间的最长间隔
+	buf      []rune        // 当前扫描已收到的字符
+	last     time.Time     // 最近一个字符的时间
+}
+
+// NewScanCapture 创建扫码捕获器
+// 参数onScan: 完成一次扫描时调用，参数为条码内容；在读取按键的goroutine中调用，不应长时间阻塞
+func NewScanCapture(onScan func(code string)) *ScanCapture {
+	return &ScanCapture{
+		onScan:   onScan,
+		devices:  make(map[string]bool),
+		resolved: make(map[string]string),
+		timeout:  DefaultScanTimeout,
+	}
+}
+
+// SetDevices 设置扫码枪设备，来自这些设备的按键总是作为扫码处理
+// 参数paths: 设备路径，可以是/dev/input/by-id下的符号链接
+func (sc *ScanCapture) SetDevices(paths []string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.devices = make(map[string]bool)
+	sc.resolved = make(map[string]string)
+	for _, path := range paths {
+		sc.devices[resolveDevicePath(path)] = true
+	}
+}
+
+// SetActive 开启或关闭扫码模式，切换时丢弃未完成的扫描
+func (sc *ScanCapture) SetActive(active bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.active = active
+	sc.buf = nil
+}
+
+// Active 返回是否处于扫码模式
+func (sc *ScanCapture) Active() bool {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.active
+}
+
+// Feed 处理一个按键事件，返回true表示按键已被扫码捕获，不应再传给页面
+func (sc *ScanCapture) Feed(ev KeyEvent) bool {
+	sc.mu.Lock()
+	fromScanner := sc.isScanner(ev.Device)
+	if !fromScanner && !sc.active {
+		sc.mu.Unlock()
+		return false
+	}
+	if !ev.Pressed {
+		sc.mu.Unlock()
+		return fromScanner
+	}
+
+	// 扫码枪的一次扫描中途中断时丢弃已收到的字符，手工输入不受间隔限制
+	if fromScanner && len(sc.buf) > 0 && ev.Time.Sub(sc.last) > sc.timeout {
+		sc.buf = nil
+	}
+
+	switch {
+	case ev.Code == KeyRune && ev.Modifiers&(ModCtrl|ModAlt) == 0:
+		sc.buf = append(sc.buf, ev.Rune)
+		sc.last = ev.Time
+		sc.mu.Unlock()
+		return true
+	case ev.Code == KeyBackspace && !fromScanner && len(sc.buf) > 0:
+		sc.buf = sc.buf[:len(sc.buf)-1]
+		sc.mu.Unlock()
+		return true
+	case (ev.Code == KeyEnter || ev.Code == KeyTab) && len(sc.buf) > 0:
+		code := string(sc.buf)
+		sc.buf = nil
+		sc.mu.Unlock()
+		if sc.onScan != nil {
+			sc.onScan(code)
+		}
+		return true
+	}
+	sc.mu.Unlock()
+	// 扫码枪送出的其它按键（如空扫描的回车）一律丢弃，键盘的其它按键照常传给页面
+	return fromScanner
+}
+
+// isScanner 判断设备是否为扫码枪，调用方需持有sc.mu
+func (sc *ScanCapture) isScanner(device string) bool {
+	if len(sc.devices) == 0 || device == "" {
+		return false
+	}
+	path, ok := sc.resolved[device]
+	if !ok {
+		path = resolveDevicePath(device)
+		sc.resolved[device] = path
+	}
+	return sc.devices[path]
+}
+
+// resolveDevicePath 解析设备路径的符号链接，失败时返回原路径
+// evdev后端以/dev/input/eventN打开设备，配置中通常写by-id路径，比较前统一解析
+func resolveDevicePath(path string) string {
+	if real, err := filepath.EvalSymlinks(path); err == nil {
+		return real
+	}
+	return path
+}
+
+// scanSource 经过扫码捕获器过滤的输入源
+type scanSource struct {
+	Source
+	capture *ScanCapture
+	once    sync.Once
+	events  chan KeyEvent
+}
+
+// WithScanCapture 包装输入源，被扫码捕获的按键不再出现在返回的输入源中
+// 参数src: 被包装的输入源
+// 参数sc: 扫码捕获器
+func WithScanCapture(src Source, sc *ScanCapture) Source {
+	return &scanSource{Source: src, capture: sc}
+}
+
+// Events 返回过滤后的按键事件通道，被包装的通道关闭后随之关闭
+func (s *scanSource) Events() <-chan KeyEvent {
+	s.once.Do(func() {
+		s.events = make(chan KeyEvent, eventsBufferSize)
+		go func() {
+			defer RecoverTerminal()
+			defer close(s.events)
+			for ev := range s.Source.Events() {
+				if !s.capture.Feed(ev) {
+					s.events <- ev
+				}
+			}
+		}()
+	})
+	return s.events
+}
+
+// ReadKeyContext 阻塞读取一个按键事件，直到读到按键或ctx被取消
+func (s *scanSource) ReadKeyContext(ctx context.Context) (KeyEvent, error) {
+	return readKeyFrom(ctx, s.Events())
+}
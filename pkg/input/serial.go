@@ -0,0 +1,112 @@
+package input
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// DefaultSerialBaud 串口的默认波特率
+const DefaultSerialBaud = 115200
+
+// termiosCBAUD termios中波特率位的掩码，syscall包未导出该常量
+const termiosCBAUD = 0x100f
+
+// serialBaudRates 支持的波特率与termios速率常量的对应关系
+var serialBaudRates = map[int]uint32{
+	1200:   syscall.B1200,
+	2400:   syscall.B2400,
+	4800:   syscall.B4800,
+	9600:   syscall.B9600,
+	19200:  syscall.B19200,
+	38400:  syscall.B38400,
+	57600:  syscall.B57600,
+	115200: syscall.B115200,
+	230400: syscall.B230400,
+	460800: syscall.B460800,
+	921600: syscall.B921600,
+}
+
+// NewSerialKeyboardInput 创建从串口读取按键的输入处理器
+// 用于既没有键盘也没有显示器的设备，通过串口终端（如minicom、screen）操作菜单。
+// 串口被设置为原始模式，终端发送的字节和转义序列按终端后端的方式解码，
+// Ctrl+C等控制字符作为普通按键传入，由程序的热键处理
+// 参数path: 串口设备路径，如/dev/ttyS0
+// 参数baud: 波特率，0表示使用DefaultSerialBaud
+func NewSerialKeyboardInput(path string, baud int) (*KeyboardInput, error) {
+	if baud == 0 {
+		baud = DefaultSerialBaud
+	}
+	speed, ok := serialBaudRates[baud]
+	if !ok {
+		return nil, fmt.Errorf("不支持的波特率: %d", baud)
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		return nil, fmt.Errorf("打开串口%s失败: %v", path, err)
+	}
+
+	ki := &KeyboardInput{
+		device:    f,
+		ttyDevice: f, // 串口同时用于输出控制序列和文本镜像
+		serial:    path,
+		done:      make(chan struct{}),
+		repeat:    DefaultRepeatConfig(),
+	}
+	if err := ki.setSerialMode(speed); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return ki, nil
+}
+
+// setSerialMode 把串口设置为8N1原始模式并设置波特率
+func (ki *KeyboardInput) setSerialMode(speed uint32) error {
+	fd := ki.device.Fd()
+
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, TCGETS, uintptr(unsafe.Pointer(&ki.oldTermios)))
+	if errno != 0 {
+		return fmt.Errorf("无法获取串口属性: %v", errno)
+	}
+
+	t := ki.oldTermios
+	t.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON | syscall.IXOFF | syscall.IXANY
+	t.Oflag &^= syscall.OPOST
+	t.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	t.Cflag &^= syscall.CSIZE | syscall.PARENB | syscall.CSTOPB | termiosCBAUD
+	t.Cflag |= syscall.CS8 | syscall.CREAD | syscall.CLOCAL | speed
+	t.Ispeed = speed
+	t.Ospeed = speed
+	t.Cc[syscall.VMIN] = 1
+	t.Cc[syscall.VTIME] = 0
+
+	_, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd, TCSETS, uintptr(unsafe.Pointer(&t)))
+	if errno != 0 {
+		return fmt.Errorf("无法设置串口属性: %v", errno)
+	}
+	registerTerminal(ki)
+	return nil
+}
+
+// SerialPort 返回串口的写入端，用于把屏幕内容以文本形式镜像到串口终端
+// 不是串口输入时返回nil
+func (ki *KeyboardInput) SerialPort() io.Writer {
+	ki.mu.Lock()
+	defer ki.mu.Unlock()
+	if ki.serial == "" || ki.ttyDevice == nil {
+		return nil
+	}
+	return ki.ttyDevice
+}
+
+// ttyName 返回终端后端按键事件的设备标识：串口为设备路径，否则为"tty"
+func (ki *KeyboardInput) ttyName() string {
+	if ki.serial != "" {
+		return ki.serial
+	}
+	return ttyDeviceName
+}
@@ -0,0 +1,123 @@
+package input
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Source 按键输入源
+// 终端、evdev、串口和回放后端都由KeyboardInput实现，FakeSource用于测试；
+// 应用程序只依赖该接口，因此可以在没有键盘的环境中测试菜单逻辑
+type Source interface {
+	// ReadKeyContext 阻塞读取一个按键事件，直到读到按键或ctx被取消
+	ReadKeyContext(ctx context.Context) (KeyEvent, error)
+	// Events 返回按键事件通道，输入源关闭后通道随之关闭
+	Events() <-chan KeyEvent
+	// Close 关闭输入源并释放设备
+	Close() error
+}
+
+// 确保各实现满足Source接口
+var (
+	_ Source = (*KeyboardInput)(nil)
+	_ Source = (*FakeSource)(nil)
+)
+
+// readKeyFrom 从事件通道读取一个按键事件，直到读到按键、通道关闭或ctx被取消
+func readKeyFrom(ctx context.Context, events <-chan KeyEvent) (KeyEvent, error) {
+	select {
+	case ev, ok := <-events:
+		if !ok {
+			return KeyEvent{}, fmt.Errorf("键盘设备已关闭")
+		}
+		return ev, nil
+	case <-ctx.Done():
+		return KeyEvent{}, ctx.Err()
+	}
+}
+
+// fakeDeviceName FakeSource产生的按键事件的设备标识
+const fakeDeviceName = "fake"
+
+// FakeSource 用于测试的按键输入源
+// 测试代码通过Send或Press注入按键，被测代码像读取真实键盘一样从Events或ReadKeyContext读取
+type FakeSource struct {
+	mu      sync.Mutex
+	events  chan KeyEvent
+	done    chan struct{}  // Close时关闭，使阻塞在Send中的发送放弃
+	sending sync.WaitGroup // 正在进行的Send，Close等它们结束后才关闭events
+	closed  bool
+}
+
+// NewFakeSource 创建测试用的按键输入源
+func NewFakeSource() *FakeSource {
+	return &FakeSource{events: make(chan KeyEvent, eventsBufferSize), done: make(chan struct{})}
+}
+
+// Send 注入一个按键事件，未设置的时间和设备会自动补全
+// 通道已满时阻塞，直到被测代码读走按键或输入源被关闭；输入源关闭后注入的按键被丢弃
+func (fs *FakeSource) Send(ev KeyEvent) {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	if ev.Device == "" {
+		ev.Device = fakeDeviceName
+	}
+
+	// 发送时不持有锁，否则通道已满时Close会一直等待
+	fs.mu.Lock()
+	if fs.closed {
+		fs.mu.Unlock()
+		return
+	}
+	fs.sending.Add(1)
+	fs.mu.Unlock()
+	defer fs.sending.Done()
+
+	select {
+	case fs.events <- ev:
+	case <-fs.done:
+	}
+}
+
+// Press 按顺序注入若干按下事件
+// 参数keys: 按键写法与录制文件相同，如"Enter"、"1"、"Ctrl+c"、"Space"
+func (fs *FakeSource) Press(keys ...string) error {
+	for _, key := range keys {
+		ev, err := parseRecordedKey(key)
+		if err != nil {
+			return err
+		}
+		fs.Send(ev)
+	}
+	return nil
+}
+
+// ReadKeyContext 阻塞读取一个按键事件，直到读到按键或ctx被取消
+func (fs *FakeSource) ReadKeyContext(ctx context.Context) (KeyEvent, error) {
+	return readKeyFrom(ctx, fs.events)
+}
+
+// Events 返回按键事件通道
+func (fs *FakeSource) Events() <-chan KeyEvent {
+	return fs.events
+}
+
+// Close 关闭输入源，已注入但未读取的按键仍可从通道中读出
+// 阻塞在Send中的按键被丢弃，这些Send返回后才关闭事件通道
+func (fs *FakeSource) Close() error {
+	fs.mu.Lock()
+	if fs.closed {
+		fs.mu.Unlock()
+		return nil
+	}
+	fs.closed = true
+	close(fs.done)
+	fs.mu.Unlock()
+
+	fs.sending.Wait()
+	close(fs.events)
+	return nil
+}
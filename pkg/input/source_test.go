@@ -0,0 +1,41 @@
+package input
+
+import (
+	"testing"
+	"time"
+)
+
+// TestFakeSourceCloseWhileFull 通道已满、Send阻塞时Close不会一直等待，阻塞的按键被丢弃
+func TestFakeSourceCloseWhileFull(t *testing.T) {
+	fs := NewFakeSource()
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		for i := 0; i < eventsBufferSize+4; i++ {
+			fs.Send(KeyEvent{Code: KeyRune, Rune: 'a', Pressed: true})
+		}
+	}()
+	for len(fs.Events()) < eventsBufferSize {
+		time.Sleep(time.Millisecond)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		fs.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("通道已满时Close没有返回")
+	}
+	<-sent
+
+	n := 0
+	for range fs.Events() {
+		n++
+	}
+	if n != eventsBufferSize {
+		t.Fatalf("关闭后读出%d个按键，期望为%d", n, eventsBufferSize)
+	}
+}
@@ -0,0 +1,322 @@
+package input
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// 触摸屏相关的evdev常量（见linux/input-event-codes.h）
+const (
+	EV_ABS             = 0x03  // 绝对坐标事件类型
+	ABS_X              = 0x00  // X方向绝对坐标
+	ABS_Y              = 0x01  // Y方向绝对坐标
+	ABS_MT_POSITION_X  = 0x35  // 多点触控X坐标
+	ABS_MT_POSITION_Y  = 0x36  // 多点触控Y坐标
+	BTN_TOUCH          = 0x14a // 触摸按下/抬起
+	defaultTapSlop     = 20    // 判定为点击的最大移动距离（像素）
+	absInfoStructBytes = 24    // 内核input_absinfo结构的大小
+)
+
+// TouchCalibration 触摸屏校准参数
+// 原始坐标范围为0（Min与Max相等）时使用设备上报的坐标范围
+type TouchCalibration struct {
+	MinX, MaxX int  // X方向原始坐标范围
+	MinY, MaxY int  // Y方向原始坐标范围
+	SwapXY     bool // 交换X/Y轴（屏幕旋转90度时使用）
+	InvertX    bool // 翻转X轴
+	InvertY    bool // 翻转Y轴
+}
+
+// absInfo 对应内核input_absinfo结构
+type absInfo struct {
+	Value, Minimum, Maximum, Fuzz, Flat, Resolution int32
+}
+
+// touchDevice 已打开的触摸屏设备及其坐标范围
+type touchDevice struct {
+	file       *os.File
+	minX, maxX int
+	minY, maxY int
+}
+
+// TouchInput 触摸屏输入处理器
+// 读取evdev绝对坐标设备，按校准参数换算为屏幕坐标，并把轻触转换为点击事件
+type TouchInput struct {
+	mu            sync.Mutex
+	devices       []*touchDevice
+	calibration   TouchCalibration
+	width, height int             // 屏幕尺寸
+	rawX, rawY    int             // 最近一次上报的原始坐标
+	touching      bool            // 当前是否处于按下状态
+	touchChanged  bool            // 自上次同步后按下状态是否变化
+	startX        int             // 本次触摸起点（屏幕坐标）
+	startY        int             //
+	curX, curY    int             // 按下期间的当前位置（屏幕坐标）
+	touchStart    time.Time       // 本次触摸按下的时间
+	longPressed   bool            // 本次触摸是否已识别为长按
+	gestures      GestureConfig   // 手势参数
+	gestureKeys   chan KeyEvent   // 手势产生的按键事件
+	events        chan MouseEvent // 点击事件通道，调用Events后创建
+	pumpOnce      sync.Once       // 保证事件读取goroutine只启动一次
+	done          chan struct{}   // 关闭时关闭，通知后台goroutine退出
+	closed        bool            // 关闭状态标志
+}
+
+// NewTouchInput 打开系统中所有的触摸屏设备
+// 参数width,height: 屏幕尺寸
+// 参数calibration: 校准参数
+// 返回初始化完成的触摸输入器，找不到触摸屏时返回错误
+func NewTouchInput(width, height int, calibration TouchCalibration) (*TouchInput, error) {
+	files, err := openEvdevDevices(isTouchDevice)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("未找到可用的触摸屏设备")
+	}
+
+	ti := &TouchInput{
+		calibration: calibration,
+		width:       width,
+		height:      height,
+		gestures:    DefaultGestureConfig(),
+		gestureKeys: make(chan KeyEvent, eventsBufferSize),
+		done:        make(chan struct{}),
+	}
+	for _, f := range files {
+		td := &touchDevice{file: f}
+		if info, err := readAbsInfo(f, ABS_X); err == nil {
+			td.minX, td.maxX = int(info.Minimum), int(info.Maximum)
+		}
+		if info, err := readAbsInfo(f, ABS_Y); err == nil {
+			td.minY, td.maxY = int(info.Minimum), int(info.Maximum)
+		}
+		ti.devices = append(ti.devices, td)
+	}
+	return ti, nil
+}
+
+// isTouchDevice 判断设备是否为触摸屏：支持X/Y绝对坐标且带有触摸按键
+func isTouchDevice(f *os.File) bool {
+	return hasEventCodes(f, EV_ABS, ABS_X, ABS_Y) && hasEventCodes(f, EV_KEY, BTN_TOUCH)
+}
+
+// readAbsInfo 通过EVIOCGABS读取指定绝对坐标轴的范围
+func readAbsInfo(f *os.File, abs int) (absInfo, error) {
+	var info absInfo
+	const iocRead = 2
+	cmd := uintptr(iocRead<<30 | absInfoStructBytes<<16 | 'E'<<8 | (0x40 + abs))
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), cmd, uintptr(unsafe.Pointer(&info)))
+	if errno != 0 {
+		return info, fmt.Errorf("读取坐标范围失败: %v", errno)
+	}
+	return info, nil
+}
+
+// Events 返回触摸事件通道
+// 轻触（按下到抬起的移动距离很小）产生一对左键按下/抬起事件，与鼠标点击的处理方式一致；
+// 滑动和长按不产生点击，而是从Gestures送出对应的按键
+func (ti *TouchInput) Events() <-chan MouseEvent {
+	ti.pumpOnce.Do(func() {
+		events := make(chan MouseEvent, eventsBufferSize)
+		ti.mu.Lock()
+		ti.events = events
+		ti.mu.Unlock()
+		go ti.pumpEvents(events)
+	})
+
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+	return ti.events
+}
+
+// pumpEvents 后台读取触摸事件，直到关闭
+func (ti *TouchInput) pumpEvents(events chan<- MouseEvent) {
+	defer RecoverTerminal()
+	defer close(events)
+	for {
+		batch, err := ti.readEvents(100 * time.Millisecond)
+		if err != nil {
+			if ti.isClosed() {
+				return
+			}
+			continue
+		}
+		for _, ev := range batch {
+			select {
+			case events <- ev:
+			case <-ti.done:
+				return
+			}
+		}
+	}
+}
+
+// readEvents 等待并读取一批触摸事件
+// 参数timeout: 等待输入的超时时间
+func (ti *TouchInput) readEvents(timeout time.Duration) ([]MouseEvent, error) {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+
+	if ti.closed || len(ti.devices) == 0 {
+		return nil, fmt.Errorf("触摸屏设备已关闭")
+	}
+
+	fds := make([]int, len(ti.devices))
+	for i, td := range ti.devices {
+		fds[i] = int(td.file.Fd())
+	}
+	ready, err := waitReadable(fds, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var batch []MouseEvent
+	buf := make([]byte, inputEventSize*64)
+	for i, td := range ti.devices {
+		if !ready[i] {
+			continue
+		}
+		n, err := td.file.Read(buf)
+		if err != nil {
+			return batch, fmt.Errorf("读取触摸屏设备%s失败: %v", td.file.Name(), err)
+		}
+		for off := 0; off+inputEventSize <= n; off += inputEventSize {
+			raw := *(*InputEvent)(unsafe.Pointer(&buf[off]))
+			batch = append(batch, ti.translate(td, raw)...)
+		}
+	}
+	ti.checkLongPress(time.Now())
+	return batch, nil
+}
+
+// translate 处理一个原始输入事件，在SYN_REPORT时根据按下状态的变化产生点击事件或识别滑动手势
+// 调用方需持有ti.mu
+func (ti *TouchInput) translate(td *touchDevice, raw InputEvent) []MouseEvent {
+	switch raw.Type {
+	case EV_ABS:
+		switch raw.Code {
+		case ABS_X, ABS_MT_POSITION_X:
+			ti.rawX = int(raw.Value)
+		case ABS_Y, ABS_MT_POSITION_Y:
+			ti.rawY = int(raw.Value)
+		}
+	case EV_KEY:
+		if raw.Code == BTN_TOUCH {
+			ti.touching = raw.Value != keyValueRelease
+			ti.touchChanged = true
+		}
+	case EV_SYN:
+		if raw.Code != SYN_REPORT {
+			return nil
+		}
+		if !ti.touchChanged {
+			// 按住期间跟踪当前位置，用于判断长按时手指是否移动
+			if ti.touching {
+				ti.curX, ti.curY = ti.toScreen(td, ti.rawX, ti.rawY)
+			}
+			return nil
+		}
+		ti.touchChanged = false
+		x, y := ti.toScreen(td, ti.rawX, ti.rawY)
+		if ti.touching {
+			ti.startX, ti.startY = x, y
+			ti.curX, ti.curY = x, y
+			ti.touchStart = time.Now()
+			ti.longPressed = false
+			return nil
+		}
+		now := time.Unix(int64(raw.Time.Sec), int64(raw.Time.Usec)*1000)
+		// 长按已经送出回车，抬起时不再产生点击
+		if ti.longPressed {
+			return nil
+		}
+		if key, ok := ti.swipeKey(x-ti.startX, y-ti.startY); ok {
+			ti.emitGesture(key, now)
+			return nil
+		}
+		// 抬起时移动距离很小才视为轻触，拖动不产生点击
+		if absInt(x-ti.startX) > defaultTapSlop || absInt(y-ti.startY) > defaultTapSlop {
+			return nil
+		}
+		return []MouseEvent{
+			{Type: MouseDown, Button: MouseLeft, X: ti.startX, Y: ti.startY, Time: now, Touch: true},
+			{Type: MouseUp, Button: MouseLeft, X: ti.startX, Y: ti.startY, Time: now, Touch: true},
+		}
+	}
+	return nil
+}
+
+// toScreen 按校准参数把原始坐标换算为屏幕坐标
+func (ti *TouchInput) toScreen(td *touchDevice, rawX, rawY int) (int, int) {
+	cal := ti.calibration
+	minX, maxX, minY, maxY := td.minX, td.maxX, td.minY, td.maxY
+	if cal.SwapXY {
+		rawX, rawY = rawY, rawX
+		minX, maxX, minY, maxY = minY, maxY, minX, maxX
+	}
+	if cal.MinX != cal.MaxX {
+		minX, maxX = cal.MinX, cal.MaxX
+	}
+	if cal.MinY != cal.MaxY {
+		minY, maxY = cal.MinY, cal.MaxY
+	}
+
+	x := scaleAxis(rawX, minX, maxX, ti.width)
+	y := scaleAxis(rawY, minY, maxY, ti.height)
+	if cal.InvertX {
+		x = ti.width - 1 - x
+	}
+	if cal.InvertY {
+		y = ti.height - 1 - y
+	}
+	return x, y
+}
+
+// scaleAxis 把[min, max]范围内的原始坐标线性映射到[0, size)
+func scaleAxis(v, min, max, size int) int {
+	if max <= min {
+		return clampInt(v, 0, size-1)
+	}
+	return clampInt((v-min)*(size-1)/(max-min), 0, size-1)
+}
+
+// absInt 返回整数的绝对值
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// isClosed 判断触摸屏是否已关闭
+func (ti *TouchInput) isClosed() bool {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+	return ti.closed
+}
+
+// Close 关闭所有触摸屏设备并停止后台读取
+func (ti *TouchInput) Close() error {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+
+	if ti.closed {
+		return nil
+	}
+
+	var err error
+	for _, td := range ti.devices {
+		if closeErr := td.file.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("关闭触摸屏设备%s失败: %v", td.file.Name(), closeErr)
+		}
+	}
+	ti.devices = nil
+	ti.closed = true
+	close(ti.done)
+	return err
+}
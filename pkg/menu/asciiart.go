@@ -0,0 +1,128 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+
+	"go-framebuffer-console/pkg/font"
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/input"
+)
+
+// 字符画可以使用的字号范围，放不下时从大到小逐级缩小
+const (
+	asciiArtMaxSize = 14
+	asciiArtMinSize = 6
+)
+
+// AsciiArt 字符画控件：按等宽方式逐字绘制多行文字，每个字符占固定宽度的格子
+// 比例字体中空格和各字符宽度不同，按普通文字绘制时字符画会错位；区域放不下时自动缩小字号
+type AsciiArt struct {
+	Lines     []string    // 字符画的各行
+	Color     color.Color // 文字颜色，为nil时使用主题的文字颜色
+	Align     Alignment   // 整块字符画在区域中的水平对齐方式，各行之间保持左对齐
+	MaxHeight int         // 最大高度（像素），0表示不限制
+}
+
+// NewAsciiArt 创建居中显示的字符画
+// 参数text: 字符画，首尾的空行被去掉
+func NewAsciiArt(text string) *AsciiArt {
+	text = strings.Trim(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	return &AsciiArt{Lines: strings.Split(text, "\n"), Align: AlignCenter}
+}
+
+// fit 返回宽度不超过width、高度不超过height时可以使用的最大字号和此时的尺寸
+// 最小字号仍放不下时返回最小字号，超出的部分被裁掉；调用后字号恢复为14
+func (a *AsciiArt) fit(r *font.Renderer, width, height int) (float64, image.Point) {
+	defer r.SetSize(14)
+	var size image.Point
+	for s := asciiArtMaxSize; s >= asciiArtMinSize; s-- {
+		r.SetSize(float64(s))
+		size = image.Pt(0, len(a.Lines)*r.LineHeight())
+		for _, line := range a.Lines {
+			if w := r.MeasureMonospace(line); w > size.X {
+				size.X = w
+			}
+		}
+		if size.X <= width && (height <= 0 || size.Y <= height) {
+			return float64(s), size
+		}
+	}
+	return asciiArtMinSize, size
+}
+
+// Measure 返回放得下时的尺寸，高度不超过MaxHeight
+func (a *AsciiArt) Measure(r *font.Renderer, width int) image.Point {
+	_, size := a.fit(r, width, a.MaxHeight)
+	return size
+}
+
+// Draw 以放得下的最大字号逐行绘制字符画
+func (a *AsciiArt) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	s, size := a.fit(r, bounds.Dx(), bounds.Dy())
+	r.SetSize(s)
+	defer r.SetSize(14)
+
+	x := alignX(a.Align, bounds, size.X)
+	y := bounds.Min.Y
+	col := colorOr(a.Color, theme.Foreground)
+	for _, line := range a.Lines {
+		if y+r.LineHeight() > bounds.Max.Y {
+			break
+		}
+		if err := r.RenderMonospaceInto(dst, x, y, line, col); err != nil {
+			return fmt.Errorf("绘制字符画失败: %v", err)
+		}
+		y += r.LineHeight()
+	}
+	return nil
+}
+
+// mirrorText 文本镜像中原样输出字符画
+func (a *AsciiArt) mirrorText() []string {
+	return a.Lines
+}
+
+// Banner 返回内置的"佛祖保佑 永不宕机"字符画，用于启动画面和隐藏的彩蛋页面
+func (mr *MenuRenderer) Banner() *AsciiArt {
+	return NewAsciiArt(mr.generateBuddha())
+}
+
+// RenderBannerSplash 清屏并绘制以字符画代替logo的启动画面：字符画、产品名称和版本号
+// 参数product: 产品名称
+// 参数version: 版本号，为空时不显示
+func (mr *MenuRenderer) RenderBannerSplash(product, version string) error {
+	art := mr.Banner()
+	art.Color = theme.Accent
+	art.MaxHeight = mr.height * 2 / 3
+	widgets := append([]Widget{art, &Spacer{Height: mr.renderer.LineHeight()}}, splashLabels(product, version)...)
+	if err := mr.RenderLayout(mr.centeredLayout(widgets...)); err != nil {
+		return fmt.Errorf("failed to render splash: %v", err)
+	}
+	return nil
+}
+
+// BannerPage 全屏显示字符画的彩蛋页面，由隐藏的热键打开，按任意键返回
+type BannerPage struct {
+	BasePage
+}
+
+// Render 在屏幕中央绘制字符画
+func (p *BannerPage) Render(mr *MenuRenderer) error {
+	art := mr.Banner()
+	art.Color = theme.Accent
+	return mr.RenderLayout(mr.centeredLayout(art))
+}
+
+// Hints 页脚的按键提示
+func (p *BannerPage) Hints() []Hint {
+	return []Hint{{Key: i18n.Translate("任意键"), Text: i18n.Translate("返回")}}
+}
+
+// HandleKey 按任意键返回
+func (p *BannerPage) HandleKey(nav *Navigator, ev input.KeyEvent) error {
+	return nav.Pop()
+}
@@ -0,0 +1,64 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+
+	"go-framebuffer-console/pkg/font"
+)
+
+// Banner 横跨页面的告警横幅，以错误色为底、背景色为字，每条消息占一行
+type Banner struct {
+	Messages []string    // 告警消息
+	Color    color.Color // 底色，为nil时使用主题的错误色
+}
+
+// Measure 占满可用宽度，高度为各行消息与上下留白之和
+func (b *Banner) Measure(r *font.Renderer, width int) image.Point {
+	return image.Pt(width, len(b.Messages)*lineStep(r)+2*defaultLineSpacing)
+}
+
+// Draw 填充底色后逐行绘制消息，过长的消息截断为一行
+func (b *Banner) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	draw.Draw(dst, bounds.Intersect(dst.Bounds()), &image.Uniform{colorOr(b.Color, theme.Error)}, image.Point{}, draw.Src)
+	x, width := bounds.Min.X+defaultPageMargin, bounds.Dx()-2*defaultPageMargin
+	y := bounds.Min.Y + defaultLineSpacing
+	for _, msg := range b.Messages {
+		if err := r.RenderTextInto(dst, x, y, r.TruncateToWidth(msg, width), theme.Background); err != nil {
+			return fmt.Errorf("绘制告警横幅失败: %v", err)
+		}
+		y += lineStep(r)
+	}
+	return nil
+}
+
+// mirrorText 文本镜像中每条消息前加"[!]"标记
+func (b *Banner) mirrorText() []string {
+	text := make([]string, len(b.Messages))
+	for i, msg := range b.Messages {
+		text[i] = "[!] " + msg
+	}
+	return text
+}
+
+// SetAlertBanner 设置首页顶部告警横幅的内容来源
+// 每次绘制首页时调用alerts，返回的消息不为空时在状态栏下方显示横幅，条件恢复正常后横幅随之消失
+// 参数alerts: 返回当前超过阈值的告警消息，nil表示不显示横幅
+func (mr *MenuRenderer) SetAlertBanner(alerts func() []string) {
+	mr.alertBanner = alerts
+	mr.InvalidateCache()
+}
+
+// banner 返回首页当前应显示的告警横幅，没有告警时返回nil
+func (mr *MenuRenderer) banner() *Banner {
+	if mr.alertBanner == nil {
+		return nil
+	}
+	messages := mr.alertBanner()
+	if len(messages) == 0 {
+		return nil
+	}
+	return &Banner{Messages: messages}
+}
@@ -0,0 +1,187 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+
+	"go-framebuffer-console/pkg/font"
+)
+
+// chartLines 未指定高度时绘图区占用的行数
+const chartLines = 3
+
+// Chart 折线图控件，显示最近一段时间的采样值，如最近5分钟的CPU使用率
+// 采样值保存在固定容量的滚动窗口中，最新的值画在最右侧，窗口未满时左侧留空
+type Chart struct {
+	Title    string      // 标题，显示在绘图区上方，后面跟最新的采样值
+	Unit     string      // 采样值的单位，如"%"
+	Min, Max float64     // 纵轴范围，超出范围的值画在边界上
+	Height   int         // 绘图区高度（像素），0表示3行高
+	Color    color.Color // 折线颜色，为nil时使用主题的强调色
+	Area     bool        // 是否填充折线下方的区域
+
+	capacity int       // 滚动窗口能容纳的采样数
+	samples  []float64 // 按时间先后排列的采样值
+}
+
+// NewChart 创建带面积填充的折线图
+// 参数title: 标题
+// 参数capacity: 滚动窗口的采样数，如每5秒采样一次、显示5分钟时为60
+// 参数min, max: 纵轴范围
+func NewChart(title string, capacity int, min, max float64) *Chart {
+	if capacity < 2 {
+		capacity = 2
+	}
+	return &Chart{Title: title, Min: min, Max: max, Area: true, capacity: capacity}
+}
+
+// Push 追加一个采样值，窗口已满时丢弃最早的采样
+func (c *Chart) Push(v float64) {
+	if len(c.samples) >= c.capacity {
+		c.samples = append(c.samples[:0], c.samples[len(c.samples)-c.capacity+1:]...)
+	}
+	c.samples = append(c.samples, v)
+}
+
+// Samples 返回窗口中的采样值，按时间先后排列
+func (c *Chart) Samples() []float64 {
+	return c.samples
+}
+
+// title 返回标题和最新的采样值，尚无采样时只返回标题
+func (c *Chart) title() string {
+	if len(c.samples) == 0 {
+		return c.Title
+	}
+	return fmt.Sprintf("%s %.1f%s", c.Title, c.samples[len(c.samples)-1], c.Unit)
+}
+
+// Measure 图表占满可用宽度，高度为标题行加绘图区
+func (c *Chart) Measure(r *font.Renderer, width int) image.Point {
+	return image.Pt(width, lineStep(r)+c.plotHeight(r))
+}
+
+// plotHeight 返回绘图区高度
+func (c *Chart) plotHeight(r *font.Renderer) int {
+	if c.Height > 0 {
+		return c.Height
+	}
+	return chartLines * r.LineHeight()
+}
+
+// Draw 绘制标题、绘图区边框、中线和折线
+func (c *Chart) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	title := r.TruncateToWidth(c.title(), bounds.Dx())
+	if err := r.RenderTextInto(dst, bounds.Min.X, bounds.Min.Y, title, theme.Foreground); err != nil {
+		return fmt.Errorf("绘制图表标题失败: %v", err)
+	}
+
+	plot := image.Rect(bounds.Min.X, bounds.Min.Y+lineStep(r), bounds.Max.X, bounds.Min.Y+lineStep(r)+c.plotHeight(r))
+	plot = plot.Intersect(bounds)
+	if plot.Dx() < 4 || plot.Dy() < 4 {
+		return nil
+	}
+	drawOutline(dst, plot, theme.Line)
+
+	// 中线用虚线表示纵轴范围的一半
+	inner := plot.Inset(1)
+	mid := inner.Min.Y + inner.Dy()/2
+	for x := inner.Min.X; x < inner.Max.X; x += dashLength + dashGap {
+		dash := image.Rect(x, mid, x+dashLength, mid+1).Intersect(inner)
+		draw.Draw(dst, dash.Intersect(dst.Bounds()), &image.Uniform{theme.Line}, image.Point{}, draw.Src)
+	}
+
+	if len(c.samples) == 0 {
+		return nil
+	}
+	lineColor := colorOr(c.Color, theme.Accent)
+	fill := &image.Uniform{blend(lineColor, theme.Background)}
+
+	// 第i个采样的横坐标：窗口的最后一个位置对齐绘图区右边
+	step := float64(inner.Dx()-1) / float64(c.capacity-1)
+	offset := c.capacity - len(c.samples)
+	point := func(i int) image.Point {
+		x := inner.Min.X + int(float64(offset+i)*step+0.5)
+		return image.Pt(x, c.valueY(c.samples[i], inner))
+	}
+
+	prev := point(0)
+	for i := 1; i < len(c.samples); i++ {
+		p := point(i)
+		if c.Area {
+			// 逐列填充两点之间折线下方的区域
+			for x := prev.X; x < p.X; x++ {
+				y := prev.Y + (p.Y-prev.Y)*(x-prev.X)/(p.X-prev.X)
+				col := image.Rect(x, y, x+1, inner.Max.Y)
+				draw.Draw(dst, col.Intersect(dst.Bounds()), fill, image.Point{}, draw.Src)
+			}
+		}
+		drawLine(dst, prev, p, lineColor)
+		prev = p
+	}
+	if len(c.samples) == 1 {
+		drawLine(dst, prev, prev, lineColor)
+	}
+	return nil
+}
+
+// valueY 返回采样值在绘图区中的纵坐标
+func (c *Chart) valueY(v float64, inner image.Rectangle) int {
+	span := c.Max - c.Min
+	if span <= 0 {
+		return inner.Max.Y - 1
+	}
+	ratio := (v - c.Min) / span
+	if ratio < 0 {
+		ratio = 0
+	} else if ratio > 1 {
+		ratio = 1
+	}
+	return inner.Max.Y - 1 - int(ratio*float64(inner.Dy()-1)+0.5)
+}
+
+// mirrorText 文本镜像中只输出标题和最新的采样值
+func (c *Chart) mirrorText() []string {
+	return []string{c.title()}
+}
+
+// drawLine 用Bresenham算法绘制两点之间宽度为1像素的线段
+func drawLine(dst draw.Image, from, to image.Point, col color.Color) {
+	dx, dy := abs(to.X-from.X), -abs(to.Y-from.Y)
+	sx, sy := 1, 1
+	if from.X > to.X {
+		sx = -1
+	}
+	if from.Y > to.Y {
+		sy = -1
+	}
+	clip := dst.Bounds()
+	err := dx + dy
+	for p := from; ; {
+		if p.In(clip) {
+			dst.Set(p.X, p.Y, col)
+		}
+		if p == to {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			p.X += sx
+		}
+		if e2 <= dx {
+			err += dx
+			p.Y += sy
+		}
+	}
+}
+
+// abs 返回整数的绝对值
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,198 @@
+package menu
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"time"
+
+	"go-framebuffer-console/pkg/font"
+)
+
+// 七段数码管各段的位：a上、b右上、c右下、d下、e左下、f左上、g中
+const (
+	segA = 1 << iota
+	segB
+	segC
+	segD
+	segE
+	segF
+	segG
+)
+
+// clockDigits 数字0-9点亮的段
+var clockDigits = [10]int{
+	segA | segB | segC | segD | segE | segF,
+	segB | segC,
+	segA | segB | segD | segE | segG,
+	segA | segB | segC | segD | segG,
+	segB | segC | segF | segG,
+	segA | segC | segD | segF | segG,
+	segA | segC | segD | segE | segF | segG,
+	segA | segB | segC,
+	segA | segB | segC | segD | segE | segF | segG,
+	segA | segB | segC | segD | segF | segG,
+}
+
+// Clock 七段数码管样式的大号时钟（HH:MM:SS）
+// 数字由矩形拼成，不依赖字体，任何字号下都清晰；绘制后由Tick每秒更新，只重绘变化的数字，不重绘整个页面
+type Clock struct {
+	Seconds bool        // 是否显示秒
+	Height  int         // 数字的高度（像素），0表示按可用区域自动选择
+	Color   color.Color // 数字颜色，为nil时使用主题的文字颜色
+	Align   Alignment   // 在区域中的水平对齐方式
+
+	dst    draw.Image
+	origin image.Point // 最近一次绘制时第一个字符的左上角
+	height int         // 最近一次绘制时数字的高度
+	shown  string      // 屏幕上显示的时间
+}
+
+// NewClock 创建居中显示的大号时钟
+// 参数seconds: 是否显示秒
+func NewClock(seconds bool) *Clock {
+	return &Clock{Seconds: seconds, Align: AlignCenter}
+}
+
+// format 返回now对应的时间文字
+func (c *Clock) format(now time.Time) string {
+	if c.Seconds {
+		return now.Format("15:04:05")
+	}
+	return now.Format("15:04")
+}
+
+// clockMetrics 返回数字高度为h时段的粗细和字符间距
+func clockMetrics(h int) (thickness, gap int) {
+	thickness = h / 8
+	if thickness < 2 {
+		thickness = 2
+	}
+	return thickness, thickness
+}
+
+// clockCharWidth 返回数字高度为h时字符ch的宽度
+func clockCharWidth(ch byte, h int) int {
+	t, _ := clockMetrics(h)
+	if ch == ':' {
+		return t
+	}
+	return h / 2
+}
+
+// textWidth 返回数字高度为h时整个时间的宽度
+func (c *Clock) textWidth(text string, h int) int {
+	_, gap := clockMetrics(h)
+	w := 0
+	for i := 0; i < len(text); i++ {
+		if i > 0 {
+			w += gap
+		}
+		w += clockCharWidth(text[i], h)
+	}
+	return w
+}
+
+// fitHeight 返回不超过maxHeight且宽度不超过width的最大数字高度
+func (c *Clock) fitHeight(width, maxHeight int) int {
+	text := c.format(time.Time{})
+	h := maxHeight
+	for h > 8 && c.textWidth(text, h) > width {
+		h--
+	}
+	return h
+}
+
+// Measure 高度为Height（未设置时为3行文字的高度），宽度放不下时按比例缩小
+func (c *Clock) Measure(r *font.Renderer, width int) image.Point {
+	h := c.Height
+	if h <= 0 {
+		h = 3 * lineStep(r)
+	}
+	h = c.fitHeight(width, h)
+	return image.Pt(c.textWidth(c.format(time.Time{}), h), h)
+}
+
+// Draw 在区域中绘制当前时间，数字高度不超过区域的高度，在区域中垂直居中
+func (c *Clock) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	h := c.Height
+	if h <= 0 || h > bounds.Dy() {
+		h = bounds.Dy()
+	}
+	h = c.fitHeight(bounds.Dx(), h)
+
+	c.dst = dst
+	c.height = h
+	c.shown = c.format(time.Now())
+	c.origin = image.Pt(alignX(c.Align, bounds, c.textWidth(c.shown, h)), bounds.Min.Y+(bounds.Dy()-h)/2)
+	for i := 0; i < len(c.shown); i++ {
+		c.drawChar(i)
+	}
+	return nil
+}
+
+// Tick 时间变化时只重绘变化的数字，返回是否重绘了屏幕上的内容；尚未绘制过时不做任何处理
+// 参数now: 当前时间
+func (c *Clock) Tick(now time.Time) bool {
+	text := c.format(now)
+	if c.dst == nil || text == c.shown {
+		return false
+	}
+	old := c.shown
+	c.shown = text
+	for i := 0; i < len(text); i++ {
+		if i >= len(old) || text[i] != old[i] {
+			c.drawChar(i)
+		}
+	}
+	return true
+}
+
+// drawChar 清除并重绘第i个字符
+func (c *Clock) drawChar(i int) {
+	h := c.height
+	t, gap := clockMetrics(h)
+	x := c.origin.X
+	for j := 0; j < i; j++ {
+		x += clockCharWidth(c.shown[j], h) + gap
+	}
+	y := c.origin.Y
+	ch := c.shown[i]
+	cell := image.Rect(x, y, x+clockCharWidth(ch, h), y+h)
+	draw.Draw(c.dst, cell.Intersect(c.dst.Bounds()), &image.Uniform{theme.Background}, image.Point{}, draw.Src)
+
+	fill := &image.Uniform{colorOr(c.Color, theme.Foreground)}
+	paint := func(rect image.Rectangle) {
+		draw.Draw(c.dst, rect.Intersect(c.dst.Bounds()), fill, image.Point{}, draw.Src)
+	}
+	if ch == ':' {
+		paint(image.Rect(x, y+h/3-t/2, x+t, y+h/3-t/2+t))
+		paint(image.Rect(x, y+2*h/3-t/2, x+t, y+2*h/3-t/2+t))
+		return
+	}
+	if ch < '0' || ch > '9' {
+		return
+	}
+
+	// 各段之间留出空隙，拐角处不相连，呈现数码管的样子
+	w, mid := h/2, h/2-t/2
+	segments := [...]image.Rectangle{
+		image.Rect(x+t, y, x+w-t, y+t),         // a
+		image.Rect(x+w-t, y+t, x+w, y+mid),     // b
+		image.Rect(x+w-t, y+mid+t, x+w, y+h-t), // c
+		image.Rect(x+t, y+h-t, x+w-t, y+h),     // d
+		image.Rect(x, y+mid+t, x+t, y+h-t),     // e
+		image.Rect(x, y+t, x+t, y+mid),         // f
+		image.Rect(x+t, y+mid, x+w-t, y+mid+t), // g
+	}
+	for s, rect := range segments {
+		if clockDigits[ch-'0']&(1<<s) != 0 {
+			paint(rect)
+		}
+	}
+}
+
+// mirrorText 文本镜像中输出最近一次绘制的时间
+func (c *Clock) mirrorText() []string {
+	return []string{c.shown}
+}
@@ -0,0 +1,87 @@
+package menu
+
+import (
+	"math"
+	"time"
+
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/input"
+)
+
+// CountdownPage 倒计时页面，用于重启、关机等确认后仍可反悔的操作
+// 每秒更新剩余秒数，倒计时结束后执行操作；期间按任意键取消，返回上一页
+type CountdownPage struct {
+	BasePage
+	Title   string // 标题，如"重启设备"
+	Message string // 消息格式，翻译后以剩余秒数替换其中的%d，如"将在 %d 秒后重启设备"
+	Seconds int    // 倒计时的秒数
+
+	// OnExpire 倒计时结束后调用，此时页面已经弹出
+	OnExpire func(nav *Navigator) error
+	// OnCancel 按键取消后调用，此时页面已经弹出，可以为nil
+	OnCancel func(nav *Navigator) error
+
+	deadline  time.Time
+	remaining int // 最近一次绘制的剩余秒数
+}
+
+// NewCountdownPage 创建倒计时页面
+// 参数title: 标题
+// 参数message: 消息格式，含一个%d
+// 参数seconds: 倒计时的秒数
+// 参数onExpire: 倒计时结束后调用
+func NewCountdownPage(title, message string, seconds int, onExpire func(nav *Navigator) error) *CountdownPage {
+	return &CountdownPage{Title: title, Message: message, Seconds: seconds, OnExpire: onExpire}
+}
+
+// OnEnter 开始倒计时
+func (p *CountdownPage) OnEnter(nav *Navigator) error {
+	p.deadline = time.Now().Add(time.Duration(p.Seconds) * time.Second)
+	p.remaining = p.Seconds
+	return nil
+}
+
+// Render 以警告级别的消息框显示剩余秒数和取消方法
+func (p *CountdownPage) Render(mr *MenuRenderer) error {
+	lines := []string{i18n.Translatef(p.Message, p.remaining), "", i18n.Translate("按任意键取消")}
+	return mr.RenderLayout(mr.NewLayout(
+		&Label{Text: p.Title, Color: theme.Accent},
+		NewSeparator(),
+		NewMessageBox(LevelWarning, lines, nil),
+	))
+}
+
+// Tick 剩余秒数变化时重绘，倒计时结束时弹出页面并执行操作
+func (p *CountdownPage) Tick(nav *Navigator, now time.Time) error {
+	remaining := int(math.Ceil(p.deadline.Sub(now).Seconds()))
+	if remaining > 0 {
+		if remaining != p.remaining {
+			p.remaining = remaining
+			nav.Invalidate()
+		}
+		return nil
+	}
+	if err := nav.Pop(); err != nil {
+		return err
+	}
+	if p.OnExpire == nil {
+		return nil
+	}
+	return p.OnExpire(nav)
+}
+
+// Hints 页脚的按键提示
+func (p *CountdownPage) Hints() []Hint {
+	return []Hint{{Key: i18n.Translate("任意键"), Text: i18n.Translate("取消")}}
+}
+
+// HandleKey 任意键取消倒计时，返回上一页
+func (p *CountdownPage) HandleKey(nav *Navigator, ev input.KeyEvent) error {
+	if err := nav.Pop(); err != nil {
+		return err
+	}
+	if p.OnCancel == nil {
+		return nil
+	}
+	return p.OnCancel(nav)
+}
@@ -0,0 +1,233 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"time"
+
+	"go-framebuffer-console/pkg/font"
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/input"
+)
+
+// 磁贴的外观
+const (
+	tilePadding     = 8  // 边框与内容之间的距离（像素）
+	tileValueSize   = 28 // 主要数值的字号
+	tileDetailLines = 3  // 说明文字的行数，各磁贴高度相同，内容变化时不必重新布局
+	tileRowGap      = 12 // 相邻两行磁贴之间的距离（像素）
+)
+
+// TileData 磁贴显示的内容，每次刷新时由数据源生成
+type TileData struct {
+	Value string      // 大号显示的主要数值，如"37.5%"
+	Color color.Color // 主要数值的颜色，为nil时使用主题的文字颜色
+	Gauge *Gauge      // 数值下方的进度条，为nil时不显示
+	Lines []string    // 说明文字，最多显示tileDetailLines行
+}
+
+// Tile 仪表盘中的一个磁贴
+// 标题、主要数值、进度条和几行说明显示在带边框的方块中；每个磁贴按自己的间隔刷新，
+// 刷新时只重绘这一个磁贴
+type Tile struct {
+	Title    string          // 标题，如"CPU"
+	Interval time.Duration   // 刷新间隔，不大于0时只在进入页面时刷新
+	Update   func() TileData // 获取最新内容，在主循环中调用
+	Clock    *Clock          // 不为nil时标题下方显示大号时钟，每秒只重绘变化的数字，不使用Update
+
+	data   TileData
+	next   time.Time       // 下次刷新的时间
+	bounds image.Rectangle // 最近一次绘制的区域
+}
+
+// refresh 到了刷新时间时获取最新内容，返回内容是否更新
+// 参数force: 不论是否到时都刷新，用于进入页面时
+func (t *Tile) refresh(now time.Time, force bool) bool {
+	if t.Update == nil || (!force && (t.Interval <= 0 || now.Before(t.next))) {
+		return false
+	}
+	t.data = t.Update()
+	t.next = now.Add(t.Interval)
+	return true
+}
+
+// valueHeight 返回主要数值一行的高度
+func valueHeight(r *font.Renderer) int {
+	r.SetSize(tileValueSize)
+	defer r.SetSize(14)
+	return r.LineHeight()
+}
+
+// Measure 磁贴占满可用宽度，高度固定：标题、数值、进度条和说明各占一行
+func (t *Tile) Measure(r *font.Renderer, width int) image.Point {
+	h := 2*tilePadding + lineStep(r) + valueHeight(r) + (1+tileDetailLines)*lineStep(r)
+	return image.Pt(width, h)
+}
+
+// Draw 绘制边框和内容
+func (t *Tile) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	t.bounds = bounds
+	drawFrame(dst, bounds, theme.Line)
+	inner := bounds.Inset(tilePadding)
+	x, y := inner.Min.X, inner.Min.Y
+
+	title := r.TruncateToWidth(t.Title, inner.Dx())
+	if err := r.RenderTextInto(dst, x, y, title, theme.Accent); err != nil {
+		return fmt.Errorf("绘制磁贴标题失败: %v", err)
+	}
+	y += lineStep(r)
+
+	if t.Clock != nil {
+		return t.Clock.Draw(r, dst, image.Rect(x, y, inner.Max.X, inner.Max.Y))
+	}
+
+	vh := valueHeight(r)
+	r.SetSize(tileValueSize)
+	value := r.TruncateToWidth(t.data.Value, inner.Dx())
+	err := r.RenderTextInto(dst, x, y, value, colorOr(t.data.Color, theme.Foreground))
+	r.SetSize(14)
+	if err != nil {
+		return fmt.Errorf("绘制磁贴数值失败: %v", err)
+	}
+	y += vh
+
+	if g := t.data.Gauge; g != nil {
+		if err := g.Draw(r, dst, image.Rect(x, y, inner.Max.X, y+lineStep(r)).Intersect(inner)); err != nil {
+			return err
+		}
+	}
+	y += lineStep(r)
+
+	for i, line := range t.data.Lines {
+		if i >= tileDetailLines || y >= inner.Max.Y {
+			break
+		}
+		if err := r.RenderTextInto(dst, x, y, r.TruncateToWidth(line, inner.Dx()), theme.Foreground); err != nil {
+			return fmt.Errorf("绘制磁贴说明失败: %v", err)
+		}
+		y += lineStep(r)
+	}
+	return nil
+}
+
+// redraw 清除磁贴上次绘制的区域并重新绘制，尚未绘制过时不做任何处理
+func (t *Tile) redraw(r *font.Renderer, dst draw.Image) error {
+	if t.bounds.Empty() {
+		return nil
+	}
+	draw.Draw(dst, t.bounds.Intersect(dst.Bounds()), &image.Uniform{theme.Background}, image.Point{}, draw.Src)
+	return t.Draw(r, dst, t.bounds)
+}
+
+// mirrorText 文本镜像中输出标题、数值和说明
+func (t *Tile) mirrorText() []string {
+	if t.Clock != nil {
+		return []string{fmt.Sprintf("[%s] %s", t.Title, t.Clock.shown)}
+	}
+	text := []string{fmt.Sprintf("[%s] %s", t.Title, t.data.Value)}
+	for _, line := range t.data.Lines {
+		text = append(text, "  "+line)
+	}
+	return text
+}
+
+// DashboardPage 由磁贴按网格排列组成的仪表盘页面
+// 进入页面时刷新所有磁贴，之后每个磁贴按自己的间隔刷新并单独重绘；任意键返回上一页
+type DashboardPage struct {
+	BasePage
+	Title   string  // 页面标题
+	Columns int     // 每行的磁贴数
+	Tiles   []*Tile // 从左到右、从上到下排列的磁贴
+
+	layout *Layout
+}
+
+// NewDashboardPage 创建仪表盘页面
+// 参数title: 页面标题
+// 参数columns: 每行的磁贴数，不大于0时为3
+// 参数tiles: 从左到右、从上到下排列的磁贴
+func NewDashboardPage(title string, columns int, tiles ...*Tile) *DashboardPage {
+	if columns <= 0 {
+		columns = 3
+	}
+	return &DashboardPage{Title: title, Columns: columns, Tiles: tiles}
+}
+
+// OnEnter 进入页面或从上层页面返回时刷新所有磁贴
+func (p *DashboardPage) OnEnter(nav *Navigator) error {
+	now := time.Now()
+	for _, t := range p.Tiles {
+		t.refresh(now, true)
+	}
+	return nil
+}
+
+// Render 按网格排列磁贴，最后一行不满时空出右侧的位置，各磁贴宽度保持一致
+func (p *DashboardPage) Render(mr *MenuRenderer) error {
+	if p.layout == nil {
+		p.layout = mr.NewLayout(&Label{Text: p.Title, Color: theme.Accent}, NewSeparator())
+		p.layout.Spacing = tileRowGap
+		for i := 0; i < len(p.Tiles); i += p.Columns {
+			row := NewColumns()
+			for j := i; j < i+p.Columns; j++ {
+				if j < len(p.Tiles) {
+					row.Cells = append(row.Cells, p.Tiles[j])
+				} else {
+					row.Cells = append(row.Cells, &Spacer{})
+				}
+			}
+			p.layout.Add(row)
+		}
+	}
+	if len(p.Tiles) == 0 {
+		return mr.RenderLayout(mr.NewLayout(NewMessageBox(LevelInfo, []string{i18n.Translate("仪表盘中没有配置磁贴")}, nil)))
+	}
+	return mr.RenderLayout(p.layout)
+}
+
+// Tick 刷新到时的磁贴，只重绘内容更新的磁贴；时钟磁贴只重绘变化的数字
+func (p *DashboardPage) Tick(nav *Navigator, now time.Time) error {
+	mr := nav.Renderer()
+	for _, t := range p.Tiles {
+		if t.Clock != nil {
+			t.Clock.Tick(now)
+			continue
+		}
+		if t.refresh(now, false) {
+			if err := t.redraw(mr.renderer, mr.fb); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Refresh 立即刷新所有磁贴，不论是否到了各自的刷新时间
+func (p *DashboardPage) Refresh(nav *Navigator) error {
+	return p.OnEnter(nav)
+}
+
+// Hints 页脚的按键提示
+func (p *DashboardPage) Hints() []Hint {
+	return []Hint{{Key: i18n.Translate("任意键"), Text: i18n.Translate("返回")}}
+}
+
+// Help 列出各磁贴及其刷新间隔
+func (p *DashboardPage) Help() []HelpItem {
+	items := make([]HelpItem, 0, len(p.Tiles))
+	for _, t := range p.Tiles {
+		text := i18n.Translate("只在进入页面时刷新")
+		if t.Interval > 0 {
+			text = i18n.Translatef("每 %s 刷新一次", t.Interval)
+		}
+		items = append(items, HelpItem{Name: t.Title, Text: text})
+	}
+	return items
+}
+
+// HandleKey 任意键返回上一页
+func (p *DashboardPage) HandleKey(nav *Navigator, ev input.KeyEvent) error {
+	return nav.Pop()
+}
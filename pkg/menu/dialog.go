@@ -0,0 +1,409 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"strings"
+
+	"go-framebuffer-console/pkg/font"
+	"go-framebuffer-console/pkg/i18n"
+)
+
+// 对话框的外观
+const (
+	dialogPadding   = 12 // 边框与内容之间的距离（像素）
+	dialogButtonGap = 12 // 按钮之间的距离（像素）
+)
+
+// DialogButton 对话框中的按钮
+type DialogButton struct {
+	Text string // 按钮文字
+	Key  byte   // 选择该按钮时返回的按键，也是该按钮的快捷键
+}
+
+// 常用的对话框按钮
+var (
+	ButtonOK     = DialogButton{Text: "确定", Key: 'y'}
+	ButtonCancel = DialogButton{Text: "取消", Key: 'n'}
+)
+
+// translateButtons 把按钮文字翻译为当前的界面语言
+func translateButtons(buttons ...DialogButton) []DialogButton {
+	for i := range buttons {
+		buttons[i].Text = i18n.Translate(buttons[i].Text)
+	}
+	return buttons
+}
+
+// Dialog 居中显示的模态对话框，由标题、正文、可选的输入框和一排按钮组成
+// 对话框只保存状态和负责绘制，按键由调用方读取后调用FocusNext等方法处理：
+// 方向键或Tab切换焦点，回车选择焦点所在的按钮，按钮的快捷键直接选择，ESC选择Cancel
+type Dialog struct {
+	Title   string         // 标题
+	Body    string         // 正文，换行符分隔多行
+	Buttons []DialogButton // 按钮，从左到右排列
+	Focus   int            // 获得焦点的按钮下标，带输入框时-1表示输入框获得焦点
+	Cancel  byte           // 按ESC时返回的按键
+
+	// 输入框，仅输入对话框使用
+	Input  bool   // 是否显示输入框
+	Text   string // 输入框中的文本
+	Cursor int    // 光标位置（字符下标）
+	Status string // 输入框下方的提示，如输入格式错误的原因
+}
+
+// NewConfirmDialog 创建确认对话框，带确定和取消两个按钮
+// 焦点默认在取消上，避免误按回车执行重启、关机等危险操作
+// 参数title: 标题
+// 参数body: 正文
+func NewConfirmDialog(title, body string) *Dialog {
+	return &Dialog{
+		Title:   title,
+		Body:    body,
+		Buttons: translateButtons(ButtonOK, ButtonCancel),
+		Focus:   1,
+		Cancel:  ButtonCancel.Key,
+	}
+}
+
+// NewAlertDialog 创建只有一个确定按钮的提示对话框
+// 参数title: 标题
+// 参数body: 正文
+func NewAlertDialog(title, body string) *Dialog {
+	return &Dialog{
+		Title:   title,
+		Body:    body,
+		Buttons: translateButtons(ButtonOK),
+		Cancel:  ButtonOK.Key,
+	}
+}
+
+// NewInputDialog 创建带输入框的对话框，用于输入IP地址等内容
+// 焦点默认在输入框上
+// 参数title: 标题
+// 参数prompt: 输入框上方的提示
+// 参数initial: 输入框的初始文本
+func NewInputDialog(title, prompt, initial string) *Dialog {
+	return &Dialog{
+		Title:   title,
+		Body:    prompt,
+		Buttons: translateButtons(ButtonOK, ButtonCancel),
+		Focus:   -1,
+		Cancel:  ButtonCancel.Key,
+		Input:   true,
+		Text:    initial,
+		Cursor:  len([]rune(initial)),
+	}
+}
+
+// firstFocus 返回焦点可以取到的最小下标
+func (d *Dialog) firstFocus() int {
+	if d.Input {
+		return -1
+	}
+	return 0
+}
+
+// FocusNext 焦点移到下一个按钮（带输入框时包括输入框），到达末尾后回到开头
+func (d *Dialog) FocusNext() {
+	d.Focus++
+	if d.Focus >= len(d.Buttons) {
+		d.Focus = d.firstFocus()
+	}
+}
+
+// FocusPrev 焦点移到上一个按钮，到达开头后回到末尾
+func (d *Dialog) FocusPrev() {
+	d.Focus--
+	if d.Focus < d.firstFocus() {
+		d.Focus = len(d.Buttons) - 1
+	}
+}
+
+// InputFocused 返回输入框是否获得焦点
+func (d *Dialog) InputFocused() bool {
+	return d.Input && d.Focus < 0
+}
+
+// FocusedKey 返回获得焦点的按钮的按键，焦点在输入框上时返回false
+func (d *Dialog) FocusedKey() (byte, bool) {
+	if d.Focus < 0 || d.Focus >= len(d.Buttons) {
+		return 0, false
+	}
+	return d.Buttons[d.Focus].Key, true
+}
+
+// ButtonKey 判断按键是否为某个按钮的快捷键（不区分大小写），返回按钮的按键
+func (d *Dialog) ButtonKey(key byte) (byte, bool) {
+	for _, b := range d.Buttons {
+		if lowerByte(b.Key) == lowerByte(key) {
+			return b.Key, true
+		}
+	}
+	return 0, false
+}
+
+// lowerByte 把ASCII大写字母转换为小写
+func lowerByte(b byte) byte {
+	if b >= 'A' && b <= 'Z' {
+		return b + 'a' - 'A'
+	}
+	return b
+}
+
+// bodyLines 返回正文的各行
+func (d *Dialog) bodyLines() []string {
+	if d.Body == "" {
+		return nil
+	}
+	return strings.Split(d.Body, "\n")
+}
+
+// bodyRows 返回正文在宽度为width的区域中折行后的各行
+func (d *Dialog) bodyRows(r *font.Renderer, width int) []string {
+	if d.Body == "" {
+		return nil
+	}
+	return r.WrapText(d.Body, width)
+}
+
+// buttonSize 返回按钮的尺寸
+func buttonSize(r *font.Renderer, b DialogButton) image.Point {
+	w, _ := r.MeasureString(b.Text)
+	return image.Pt(w+4*buttonPadding, r.LineHeight()+2*buttonPadding)
+}
+
+// inputHeight 返回输入框的高度
+func inputHeight(r *font.Renderer) int {
+	return r.LineHeight() + 2*buttonPadding
+}
+
+// Measure 返回对话框外框的尺寸，带输入框的对话框至少占可用宽度的三分之二
+func (d *Dialog) Measure(r *font.Renderer, width int) image.Point {
+	step := lineStep(r)
+	w, _ := r.MeasureString(d.Title)
+	for _, line := range d.bodyLines() {
+		if lw, _ := r.MeasureString(line); lw > w {
+			w = lw
+		}
+	}
+	buttons := 0
+	for i, b := range d.Buttons {
+		if i > 0 {
+			buttons += dialogButtonGap
+		}
+		buttons += buttonSize(r, b).X
+	}
+	if buttons > w {
+		w = buttons
+	}
+	if d.Status != "" {
+		if sw, _ := r.MeasureString(d.Status); sw > w {
+			w = sw
+		}
+	}
+	w += 2 * dialogPadding
+	if d.Input && w < width*2/3 {
+		w = width * 2 / 3
+	}
+	if w > width {
+		w = width
+	}
+
+	// 正文超出对话框宽度时折行，高度按折行后的行数计算
+	h := 2*dialogPadding + step + defaultLineSpacing + len(d.bodyRows(r, w-2*dialogPadding))*step
+	if d.Input {
+		h += inputHeight(r) + step/2
+		if d.Status != "" {
+			h += step
+		}
+	}
+	if len(d.Buttons) > 0 {
+		h += step/2 + r.LineHeight() + 2*buttonPadding
+	}
+	return image.Pt(w, h)
+}
+
+// Draw 在区域顶部水平居中绘制对话框
+func (d *Dialog) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	box := d.box(r, bounds)
+	draw.Draw(dst, box.Intersect(dst.Bounds()), &image.Uniform{theme.Background}, image.Point{}, draw.Src)
+	drawOutline(dst, box, theme.Foreground)
+
+	inner := box.Inset(dialogPadding)
+	step := lineStep(r)
+	y := inner.Min.Y
+
+	title := r.TruncateToWidth(d.Title, inner.Dx())
+	if err := r.RenderTextInto(dst, inner.Min.X, y, title, theme.Accent); err != nil {
+		return fmt.Errorf("绘制对话框失败: %v", err)
+	}
+	y += step
+	line := image.Rect(box.Min.X, y, box.Max.X, y+1)
+	draw.Draw(dst, line.Intersect(dst.Bounds()), &image.Uniform{theme.Line}, image.Point{}, draw.Src)
+	y += defaultLineSpacing
+
+	for _, text := range d.bodyRows(r, inner.Dx()) {
+		text = r.TruncateToWidth(text, inner.Dx())
+		if err := r.RenderTextInto(dst, inner.Min.X, y, text, theme.Foreground); err != nil {
+			return fmt.Errorf("绘制对话框失败: %v", err)
+		}
+		y += step
+	}
+
+	if d.Input {
+		y += step / 2
+		field := image.Rect(inner.Min.X, y, inner.Max.X, y+inputHeight(r))
+		if err := d.drawInput(r, dst, field); err != nil {
+			return err
+		}
+		y = field.Max.Y
+		if d.Status != "" {
+			status := r.TruncateToWidth(d.Status, inner.Dx())
+			if err := r.RenderTextInto(dst, inner.Min.X, y+defaultLineSpacing, status, theme.Accent); err != nil {
+				return fmt.Errorf("绘制对话框失败: %v", err)
+			}
+			y += step
+		}
+	}
+
+	y += step / 2
+	for i, rect := range d.buttonRects(r, inner, y) {
+		if err := drawButton(r, dst, rect, d.Buttons[i], i == d.Focus); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// box 返回对话框外框在区域中的位置
+func (d *Dialog) box(r *font.Renderer, bounds image.Rectangle) image.Rectangle {
+	size := d.Measure(r, bounds.Dx())
+	x := alignX(AlignCenter, bounds, size.X)
+	return image.Rect(x, bounds.Min.Y, x+size.X, bounds.Min.Y+size.Y)
+}
+
+// buttonRects 返回各按钮的位置，按钮在对话框底部居中排列
+func (d *Dialog) buttonRects(r *font.Renderer, inner image.Rectangle, y int) []image.Rectangle {
+	total := 0
+	for i, b := range d.Buttons {
+		if i > 0 {
+			total += dialogButtonGap
+		}
+		total += buttonSize(r, b).X
+	}
+	x := alignX(AlignCenter, inner, total)
+	rects := make([]image.Rectangle, len(d.Buttons))
+	for i, b := range d.Buttons {
+		size := buttonSize(r, b)
+		rects[i] = image.Rect(x, y, x+size.X, y+size.Y)
+		x += size.X + dialogButtonGap
+	}
+	return rects
+}
+
+// drawButton 绘制按钮，获得焦点的按钮反色显示
+func drawButton(r *font.Renderer, dst draw.Image, rect image.Rectangle, b DialogButton, focused bool) error {
+	col := theme.Foreground
+	if focused {
+		draw.Draw(dst, rect.Intersect(dst.Bounds()), &image.Uniform{theme.Foreground}, image.Point{}, draw.Src)
+		col = theme.Background
+	} else {
+		drawOutline(dst, rect, theme.Foreground)
+	}
+	w, _ := r.MeasureString(b.Text)
+	if err := r.RenderTextInto(dst, alignX(AlignCenter, rect, w), rect.Min.Y+buttonPadding, b.Text, col); err != nil {
+		return fmt.Errorf("绘制对话框按钮失败: %v", err)
+	}
+	return nil
+}
+
+// drawInput 绘制输入框和光标
+func (d *Dialog) drawInput(r *font.Renderer, dst draw.Image, field image.Rectangle) error {
+	return drawInputBox(r, dst, field, d.Text, d.Cursor, d.InputFocused())
+}
+
+// drawInputBox 绘制输入框，获得焦点时显示光标，文本超出输入框时向左滚动，保证光标始终可见
+// 参数text: 输入框中的文本
+// 参数cursor: 光标位置（字符下标）
+// 参数focused: 输入框是否获得焦点
+func drawInputBox(r *font.Renderer, dst draw.Image, field image.Rectangle, text string, cursor int, focused bool) error {
+	col := theme.Line
+	if focused {
+		col = theme.Foreground
+	}
+	drawOutline(dst, field, col)
+
+	runes := []rune(text)
+	if cursor < 0 {
+		cursor = 0
+	}
+	if cursor > len(runes) {
+		cursor = len(runes)
+	}
+
+	inner := field.Dx() - 2*buttonPadding
+	start := 0
+	for start < cursor {
+		if w, _ := r.MeasureString(string(runes[start:cursor])); w <= inner {
+			break
+		}
+		start++
+	}
+	visible := r.TruncateToWidth(string(runes[start:]), inner)
+	x, y := field.Min.X+buttonPadding, field.Min.Y+buttonPadding
+	if err := r.RenderTextInto(dst, x, y, visible, theme.Foreground); err != nil {
+		return fmt.Errorf("绘制输入框失败: %v", err)
+	}
+
+	if focused {
+		cursorX, _ := r.MeasureString(string(runes[start:cursor]))
+		bar := image.Rect(x+cursorX-1, y, x+cursorX+1, y+r.LineHeight())
+		draw.Draw(dst, bar.Intersect(dst.Bounds()), &image.Uniform{theme.Foreground}, image.Point{}, draw.Src)
+	}
+	return nil
+}
+
+// hitAreas 各按钮可以点击
+func (d *Dialog) hitAreas(r *font.Renderer, bounds image.Rectangle) []HitArea {
+	inner := d.box(r, bounds).Inset(dialogPadding)
+	y := inner.Max.Y - (r.LineHeight() + 2*buttonPadding) // 按钮位于对话框底部
+
+	var areas []HitArea
+	for i, rect := range d.buttonRects(r, inner, y) {
+		areas = append(areas, HitArea{Rect: rect, Key: d.Buttons[i].Key})
+	}
+	return areas
+}
+
+// mirrorText 文本镜像中输入框以"|"标出光标，获得焦点的按钮以<>标出
+func (d *Dialog) mirrorText() []string {
+	lines := []string{"[ " + d.Title + " ]"}
+	lines = append(lines, d.bodyLines()...)
+	if d.Input {
+		runes := []rune(d.Text)
+		cursor := d.Cursor
+		if cursor < 0 || cursor > len(runes) {
+			cursor = len(runes)
+		}
+		lines = append(lines, "> "+string(runes[:cursor])+"|"+string(runes[cursor:]))
+		if d.Status != "" {
+			lines = append(lines, d.Status)
+		}
+	}
+	return append(lines, mirrorButtons(d.Buttons, d.Focus))
+}
+
+// mirrorButtons 返回一排按钮的文本形式，获得焦点的按钮以<>标出，其余以[]标出
+func mirrorButtons(buttons []DialogButton, focus int) string {
+	var text []string
+	for i, b := range buttons {
+		if i == focus {
+			text = append(text, "<"+b.Text+">")
+		} else {
+			text = append(text, "["+b.Text+"]")
+		}
+	}
+	return strings.Join(text, " ")
+}
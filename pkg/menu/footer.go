@@ -0,0 +1,119 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"strings"
+)
+
+// footerGap 页脚中相邻两个按键提示之间的距离（像素）
+const footerGap = 16
+
+// Hint 页脚中的一条按键提示，显示为"按键:功能"
+type Hint struct {
+	Key  string // 按键名称，如"Enter"、"F5"
+	Text string // 功能说明，如"菜单"
+}
+
+// String 返回提示的文字形式，如"Enter:菜单"
+func (h Hint) String() string {
+	return h.Key + ":" + h.Text
+}
+
+// Hinter 在页脚列出自己的操作按键的页面
+// 导航栈绘制页面时先取得页面的按键提示，再追加全局热键的提示
+type Hinter interface {
+	Hints() []Hint
+}
+
+// FullScreen 嵌入后页面独占整个屏幕，不显示页眉和页脚，用于屏幕保护等页面
+type FullScreen struct{}
+
+func (FullScreen) fullScreen() {}
+
+// fullScreener 独占整个屏幕的页面
+type fullScreener interface {
+	fullScreen()
+}
+
+// clippedImage 把绘制目标的范围限制在bounds内，用于为页脚留出屏幕底部
+// 控件按Bounds()布局和裁剪，实际仍绘制到原来的目标上
+type clippedImage struct {
+	draw.Image
+	bounds image.Rectangle
+}
+
+// Bounds 返回限制后的范围
+func (c clippedImage) Bounds() image.Rectangle {
+	return c.bounds
+}
+
+// setFooter 设置下一次绘制页面时显示的按键提示，nil表示不显示页脚
+func (mr *MenuRenderer) setFooter(hints []Hint) {
+	mr.footer = hints
+}
+
+// footerHeight 返回页脚占用的高度：分隔线和一行提示，没有页脚时为0
+func (mr *MenuRenderer) footerHeight() int {
+	if len(mr.footer) == 0 {
+		return 0
+	}
+	return lineStep(mr.renderer) + 2*defaultLineSpacing
+}
+
+// content 返回去掉页眉和页脚后可供页面绘制的区域
+func (mr *MenuRenderer) content() draw.Image {
+	top, bottom := mr.headerHeight(), mr.footerHeight()
+	if top == 0 && bottom == 0 {
+		return mr.fb
+	}
+	bounds := mr.fb.Bounds()
+	bounds.Min.Y += top
+	bounds.Max.Y -= bottom
+	return clippedImage{Image: mr.fb, bounds: bounds}
+}
+
+// drawFooter 清除屏幕底部并绘制页脚：一条分隔线和一行按键提示
+// 按键名称使用主题的强调色，说明使用文字颜色，超出屏幕宽度的提示不再显示
+func (mr *MenuRenderer) drawFooter() error {
+	h := mr.footerHeight()
+	if h == 0 {
+		return nil
+	}
+	mr.renderer.SetSize(14)
+
+	screen := mr.fb.Bounds()
+	area := image.Rect(screen.Min.X, screen.Max.Y-h, screen.Max.X, screen.Max.Y)
+	draw.Draw(mr.fb, area, &image.Uniform{theme.Background}, image.Point{}, draw.Src)
+	line := image.Rect(area.Min.X+defaultPageMargin, area.Min.Y, area.Max.X-defaultPageMargin, area.Min.Y+1)
+	draw.Draw(mr.fb, line, &image.Uniform{theme.Line}, image.Point{}, draw.Src)
+
+	x := area.Min.X + defaultPageMargin
+	y := area.Min.Y + defaultLineSpacing + 1
+	for _, hint := range mr.footer {
+		key := hint.Key + ":"
+		keyWidth, _ := mr.renderer.MeasureString(key)
+		textWidth, _ := mr.renderer.MeasureString(hint.Text)
+		if x+keyWidth+textWidth > area.Max.X-defaultPageMargin {
+			break
+		}
+		if err := mr.renderer.RenderTextInto(mr.fb, x, y, key, theme.Accent); err != nil {
+			return fmt.Errorf("failed to render footer: %v", err)
+		}
+		if err := mr.renderer.RenderTextInto(mr.fb, x+keyWidth, y, hint.Text, theme.Foreground); err != nil {
+			return fmt.Errorf("failed to render footer: %v", err)
+		}
+		x += keyWidth + textWidth + footerGap
+	}
+	return nil
+}
+
+// footerText 返回页脚的文字形式，用于文本镜像，没有页脚时返回空字符串
+func (mr *MenuRenderer) footerText() string {
+	texts := make([]string, len(mr.footer))
+	for i, hint := range mr.footer {
+		texts[i] = hint.String()
+	}
+	return strings.Join(texts, "  ")
+}
@@ -0,0 +1,469 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"net"
+	"strconv"
+	"strings"
+
+	"go-framebuffer-console/pkg/font"
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/input"
+)
+
+// formInputMaxWidth 表单输入框的最大宽度（像素），IP地址等内容不需要占满整行
+const formInputMaxWidth = 480
+
+// FormField 表单中带标签的输入框
+type FormField struct {
+	Label    string             // 输入框左侧的标签，如"IP地址"
+	Validate func(string) error // 检查输入内容，不通过时返回显示在输入框下方的原因，可以为nil
+	Error    string             // 最近一次检查不通过的原因，为空表示没有错误
+
+	editor *input.LineEditor
+}
+
+// NewFormField 创建表单输入框
+// 参数label: 标签
+// 参数initial: 初始文本
+// 参数validate: 检查输入内容，可以为nil
+func NewFormField(label, initial string, validate func(string) error) *FormField {
+	return &FormField{Label: label, Validate: validate, editor: input.NewLineEditor(initial)}
+}
+
+// Text 返回输入框中的文本（去掉首尾空白）
+func (f *FormField) Text() string {
+	return strings.TrimSpace(f.editor.Text())
+}
+
+// Editor 返回输入框的行编辑器，可以用于限制长度和允许输入的字符
+func (f *FormField) Editor() *input.LineEditor {
+	return f.editor
+}
+
+// check 检查输入内容，记录并返回是否通过
+func (f *FormField) check() bool {
+	f.Error = ""
+	if f.Validate == nil {
+		return true
+	}
+	if err := f.Validate(f.Text()); err != nil {
+		f.Error = err.Error()
+		return false
+	}
+	return true
+}
+
+// Form 由多个带标签的输入框和一排按钮组成的表单
+// 表单只保存状态和负责绘制，按键由FormPage处理：Tab或上下方向键在输入框和按钮之间切换焦点，
+// 离开输入框时检查该项的内容，提交时检查全部内容
+type Form struct {
+	Fields  []*FormField   // 输入框，自上而下排列
+	Buttons []DialogButton // 按钮，位于输入框下方
+	Focus   int            // 获得焦点的位置：小于len(Fields)时为输入框下标，否则为按钮
+	Cancel  byte           // 按ESC时选择的按键
+	Status  string         // 按钮上方的提示，如各项之间不一致的原因
+}
+
+// NewForm 创建带确定和取消按钮的表单，焦点在第一个输入框上
+// 参数fields: 输入框
+func NewForm(fields ...*FormField) *Form {
+	return &Form{
+		Fields:  fields,
+		Buttons: translateButtons(ButtonOK, ButtonCancel),
+		Cancel:  ButtonCancel.Key,
+	}
+}
+
+// focusCount 返回可以获得焦点的位置数
+func (f *Form) focusCount() int {
+	return len(f.Fields) + len(f.Buttons)
+}
+
+// FocusedField 返回获得焦点的输入框，焦点在按钮上时返回nil
+func (f *Form) FocusedField() *FormField {
+	if f.Focus >= 0 && f.Focus < len(f.Fields) {
+		return f.Fields[f.Focus]
+	}
+	return nil
+}
+
+// FocusedKey 返回获得焦点的按钮的按键，焦点在输入框上时返回false
+func (f *Form) FocusedKey() (byte, bool) {
+	i := f.Focus - len(f.Fields)
+	if i < 0 || i >= len(f.Buttons) {
+		return 0, false
+	}
+	return f.Buttons[i].Key, true
+}
+
+// ButtonKey 判断按键是否为某个按钮的快捷键（不区分大小写），返回按钮的按键
+func (f *Form) ButtonKey(key byte) (byte, bool) {
+	for _, b := range f.Buttons {
+		if lowerByte(b.Key) == lowerByte(key) {
+			return b.Key, true
+		}
+	}
+	return 0, false
+}
+
+// SetFocus 把焦点移到指定位置，离开的输入框有内容时检查该项
+func (f *Form) SetFocus(focus int) {
+	if n := f.focusCount(); n > 0 {
+		focus = (focus%n + n) % n
+	}
+	if field := f.FocusedField(); field != nil && focus != f.Focus {
+		if field.Text() != "" {
+			field.check()
+		} else {
+			field.Error = "" // 还没有填写的项等到提交时再提示
+		}
+	}
+	f.Focus = focus
+}
+
+// FocusNext 焦点移到下一个输入框或按钮，到达末尾后回到开头
+func (f *Form) FocusNext() {
+	f.SetFocus(f.Focus + 1)
+}
+
+// FocusPrev 焦点移到上一个输入框或按钮，到达开头后回到末尾
+func (f *Form) FocusPrev() {
+	f.SetFocus(f.Focus - 1)
+}
+
+// Check 检查全部输入框，焦点移到第一个不通过的输入框上，返回是否全部通过
+func (f *Form) Check() bool {
+	first := -1
+	for i, field := range f.Fields {
+		if !field.check() && first < 0 {
+			first = i
+		}
+	}
+	if first >= 0 {
+		f.Focus = first
+		return false
+	}
+	return true
+}
+
+// Values 返回各输入框的文本，顺序与Fields一致
+func (f *Form) Values() []string {
+	values := make([]string, len(f.Fields))
+	for i, field := range f.Fields {
+		values[i] = field.Text()
+	}
+	return values
+}
+
+// labelWidth 返回标签列的宽度：最宽的标签加上与输入框之间的距离
+func (f *Form) labelWidth(r *font.Renderer) int {
+	w := 0
+	for _, field := range f.Fields {
+		if lw, _ := r.MeasureString(field.Label); lw > w {
+			w = lw
+		}
+	}
+	return w + dialogPadding
+}
+
+// inputWidth 返回可用宽度为width时输入框的宽度
+func (f *Form) inputWidth(r *font.Renderer, width int) int {
+	w := width - f.labelWidth(r)
+	if w > formInputMaxWidth {
+		w = formInputMaxWidth
+	}
+	return w
+}
+
+// Measure 占满可用宽度，高度为各输入框、错误提示和按钮之和
+func (f *Form) Measure(r *font.Renderer, width int) image.Point {
+	step := lineStep(r)
+	h := 0
+	for _, field := range f.Fields {
+		h += inputHeight(r) + step/2
+		if field.Error != "" {
+			h += step
+		}
+	}
+	if f.Status != "" {
+		h += step
+	}
+	if len(f.Buttons) > 0 {
+		h += r.LineHeight() + 2*buttonPadding
+	}
+	return image.Pt(width, h)
+}
+
+// Draw 逐行绘制标签和输入框，检查不通过的输入框下方以错误色显示原因，最后绘制按钮
+func (f *Form) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	step := lineStep(r)
+	labelW := f.labelWidth(r)
+	inputX := bounds.Min.X + labelW
+	inputW := f.inputWidth(r, bounds.Dx())
+	y := bounds.Min.Y
+
+	for i, field := range f.Fields {
+		label := r.TruncateToWidth(field.Label, labelW)
+		if err := r.RenderTextInto(dst, bounds.Min.X, y+buttonPadding, label, theme.Foreground); err != nil {
+			return fmt.Errorf("绘制表单失败: %v", err)
+		}
+		box := image.Rect(inputX, y, inputX+inputW, y+inputHeight(r))
+		if err := drawInputBox(r, dst, box, field.editor.Text(), field.editor.Cursor(), i == f.Focus); err != nil {
+			return err
+		}
+		y = box.Max.Y
+		if field.Error != "" {
+			msg := r.TruncateToWidth(field.Error, bounds.Max.X-inputX)
+			if err := r.RenderTextInto(dst, inputX, y+defaultLineSpacing, msg, theme.Error); err != nil {
+				return fmt.Errorf("绘制表单失败: %v", err)
+			}
+			y += step
+		}
+		y += step / 2
+	}
+
+	if f.Status != "" {
+		status := r.TruncateToWidth(f.Status, bounds.Dx())
+		if err := r.RenderTextInto(dst, bounds.Min.X, y, status, theme.Error); err != nil {
+			return fmt.Errorf("绘制表单失败: %v", err)
+		}
+		y += step
+	}
+
+	for i, rect := range f.buttonRects(r, inputX, y) {
+		if err := drawButton(r, dst, rect, f.Buttons[i], i == f.Focus-len(f.Fields)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buttonRects 返回各按钮的位置，按钮从输入框的左边界开始排列
+func (f *Form) buttonRects(r *font.Renderer, x, y int) []image.Rectangle {
+	rects := make([]image.Rectangle, len(f.Buttons))
+	for i, b := range f.Buttons {
+		size := buttonSize(r, b)
+		rects[i] = image.Rect(x, y, x+size.X, y+size.Y)
+		x += size.X + dialogButtonGap
+	}
+	return rects
+}
+
+// hitAreas 各按钮可以点击
+func (f *Form) hitAreas(r *font.Renderer, bounds image.Rectangle) []HitArea {
+	if len(f.Buttons) == 0 {
+		return nil
+	}
+	y := bounds.Min.Y + f.Measure(r, bounds.Dx()).Y - (r.LineHeight() + 2*buttonPadding) // 按钮位于表单底部
+	var areas []HitArea
+	for i, rect := range f.buttonRects(r, bounds.Min.X+f.labelWidth(r), y) {
+		areas = append(areas, HitArea{Rect: rect, Key: f.Buttons[i].Key})
+	}
+	return areas
+}
+
+// mirrorText 文本镜像中每个输入框占一行，获得焦点的输入框以">"开头并以"|"标出光标
+func (f *Form) mirrorText() []string {
+	var lines []string
+	for i, field := range f.Fields {
+		text := field.editor.Text()
+		prefix := "  "
+		if i == f.Focus {
+			runes := []rune(text)
+			cursor := field.editor.Cursor()
+			text = string(runes[:cursor]) + "|" + string(runes[cursor:])
+			prefix = "> "
+		}
+		lines = append(lines, prefix+field.Label+": "+text)
+		if field.Error != "" {
+			lines = append(lines, "  ! "+field.Error)
+		}
+	}
+	if f.Status != "" {
+		lines = append(lines, f.Status)
+	}
+	return append(lines, mirrorButtons(f.Buttons, f.Focus-len(f.Fields)))
+}
+
+// FormPage 显示表单并处理按键的页面
+// Tab、Shift+Tab或上下方向键切换焦点，焦点在输入框上时其余按键用于编辑，回车移到下一项，
+// 在最后一项上回车等同于确定；焦点在按钮上时左右方向键切换，回车选择；ESC取消
+type FormPage struct {
+	BasePage
+	Title string
+	Form  *Form
+
+	// Validate 各项检查通过后检查各项之间是否一致，不通过时在按钮上方显示原因，可以为nil
+	Validate func(values []string) error
+	// OnResult 选择按钮后调用，此时表单已经弹出；取消时key为Form.Cancel
+	OnResult func(nav *Navigator, key byte, values []string) error
+
+	layout *Layout
+}
+
+// NewFormPage 创建表单页面
+// 参数title: 页面标题
+// 参数form: 表单
+// 参数onResult: 选择按钮后调用，可以为nil
+func NewFormPage(title string, form *Form, onResult func(nav *Navigator, key byte, values []string) error) *FormPage {
+	return &FormPage{Title: title, Form: form, OnResult: onResult}
+}
+
+// Render 绘制标题和表单
+func (p *FormPage) Render(mr *MenuRenderer) error {
+	if p.layout == nil {
+		p.layout = mr.NewLayout(&Label{Text: p.Title, Color: theme.Accent}, NewSeparator(), p.Form)
+	}
+	return mr.RenderLayout(p.layout)
+}
+
+// Hints 页脚的按键提示
+func (p *FormPage) Hints() []Hint {
+	return []Hint{
+		{Key: "Tab", Text: i18n.Translate("切换")},
+		{Key: "Enter", Text: i18n.Translate("确认")},
+		{Key: "Esc", Text: i18n.Translate("取消")},
+	}
+}
+
+// HandleKey 切换焦点、编辑输入或选择按钮
+func (p *FormPage) HandleKey(nav *Navigator, ev input.KeyEvent) error {
+	f := p.Form
+	nav.Invalidate()
+
+	switch {
+	case ev.Code == input.KeyTab && ev.Modifiers&input.ModShift != 0, ev.Code == input.KeyUp:
+		f.FocusPrev()
+		return nil
+	case ev.Code == input.KeyTab, ev.Code == input.KeyDown:
+		f.FocusNext()
+		return nil
+	}
+
+	if field := f.FocusedField(); field != nil {
+		// 点击按钮产生的按键不作为输入内容
+		if key, ok := f.ButtonKey(ev.Byte()); ok && ev.Device == ClickDevice {
+			return p.finish(nav, key)
+		}
+		switch field.editor.HandleKey(ev) {
+		case input.EditAccept:
+			if f.Focus == len(f.Fields)-1 {
+				return p.finish(nav, ButtonOK.Key)
+			}
+			f.FocusNext()
+		case input.EditCancel:
+			return p.finish(nav, f.Cancel)
+		}
+		return nil
+	}
+
+	switch {
+	case ev.Code == input.KeyLeft:
+		f.FocusPrev()
+	case ev.Code == input.KeyRight:
+		f.FocusNext()
+	case ev.Code == input.KeyEscape || ev.Code == input.KeyBackspace:
+		return p.finish(nav, f.Cancel)
+	case ev.Code == input.KeyEnter:
+		if key, ok := f.FocusedKey(); ok {
+			return p.finish(nav, key)
+		}
+	default:
+		if key, ok := f.ButtonKey(ev.Byte()); ok {
+			return p.finish(nav, key)
+		}
+	}
+	return nil
+}
+
+// finish 选择了按钮：检查各项后弹出表单并通知调用方，取消时不检查
+func (p *FormPage) finish(nav *Navigator, key byte) error {
+	f := p.Form
+	values := f.Values()
+	if key != f.Cancel {
+		f.Status = ""
+		if !f.Check() {
+			return nil
+		}
+		if p.Validate != nil {
+			if err := p.Validate(values); err != nil {
+				f.Status = err.Error()
+				return nil
+			}
+		}
+	}
+	if err := nav.Pop(); err != nil {
+		return err
+	}
+	if p.OnResult == nil {
+		return nil
+	}
+	return p.OnResult(nav, key, values)
+}
+
+// ValidateIPv4 检查是否为点分十进制的IPv4地址，如"192.168.1.10"
+func ValidateIPv4(text string) error {
+	if text == "" {
+		return fmt.Errorf("%s", i18n.Translate("请输入IPv4地址"))
+	}
+	if strings.Count(text, ".") != 3 || net.ParseIP(text).To4() == nil {
+		return fmt.Errorf("%s", i18n.Translatef("%s 不是有效的IPv4地址", text))
+	}
+	return nil
+}
+
+// ValidateNetmask 检查是否为子网掩码，可以是点分十进制（如"255.255.255.0"）或前缀长度（如"24"）
+func ValidateNetmask(text string) error {
+	_, err := ParseNetmask(text)
+	return err
+}
+
+// ParseNetmask 解析子网掩码，返回前缀长度
+// 参数text: 点分十进制的子网掩码或0-32的前缀长度，前缀长度可以带"/"
+func ParseNetmask(text string) (int, error) {
+	if text == "" {
+		return 0, fmt.Errorf("%s", i18n.Translate("请输入子网掩码"))
+	}
+	if bits, err := strconv.Atoi(strings.TrimPrefix(text, "/")); err == nil {
+		if bits < 0 || bits > 32 {
+			return 0, fmt.Errorf("%s", i18n.Translatef("前缀长度 %d 超出范围0-32", bits))
+		}
+		return bits, nil
+	}
+	if ValidateIPv4(text) != nil {
+		return 0, fmt.Errorf("%s", i18n.Translatef("%s 不是有效的子网掩码", text))
+	}
+	ones, bits := net.IPMask(net.ParseIP(text).To4()).Size()
+	if bits == 0 {
+		// 1不连续的掩码，如255.0.255.0
+		return 0, fmt.Errorf("%s", i18n.Translatef("%s 不是有效的子网掩码", text))
+	}
+	return ones, nil
+}
+
+// ValidateIPv4List 检查以逗号或空格分隔的一个或多个IPv4地址，如DNS服务器
+func ValidateIPv4List(text string) error {
+	addrs := strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' })
+	if len(addrs) == 0 {
+		return fmt.Errorf("%s", i18n.Translate("请输入IPv4地址"))
+	}
+	for _, addr := range addrs {
+		if err := ValidateIPv4(addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Optional 允许留空的输入框：内容为空时通过，否则交给validate检查
+func Optional(validate func(string) error) func(string) error {
+	return func(text string) error {
+		if text == "" {
+			return nil
+		}
+		return validate(text)
+	}
+}
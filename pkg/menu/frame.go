@@ -0,0 +1,146 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+
+	"go-framebuffer-console/pkg/font"
+)
+
+// framePadding 边框与内容之间的距离（像素）
+const framePadding = 8
+
+// frameMirrorWidth 文本镜像中边框横线的字符数，与分隔线一致
+const frameMirrorWidth = 28
+
+// Frame 带标题的边框，把页面中的一组内容框在一起
+// 边框的样式与分隔线相同，由主题决定；标题嵌在上边框中
+type Frame struct {
+	Title string      // 嵌在上边框中的标题，为空时不显示
+	Child Widget      // 框内的控件
+	Color color.Color // 边框颜色，为nil时使用主题的线条颜色
+}
+
+// NewFrame 创建带标题的边框
+// 参数title: 标题
+// 参数child: 框内的控件
+func NewFrame(title string, child Widget) *Frame {
+	return &Frame{Title: title, Child: child}
+}
+
+// top 返回边框顶部到内容之间的高度，有标题时为标题所占的一行
+func (f *Frame) top(r *font.Renderer) int {
+	if f.Title != "" {
+		return lineStep(r)
+	}
+	return framePadding
+}
+
+// content 返回框内控件在bounds中的区域
+func (f *Frame) content(r *font.Renderer, bounds image.Rectangle) image.Rectangle {
+	return image.Rect(bounds.Min.X+framePadding, bounds.Min.Y+f.top(r), bounds.Max.X-framePadding, bounds.Max.Y-framePadding)
+}
+
+// Measure 占满可用宽度，高度为框内控件的高度加上标题和边距
+func (f *Frame) Measure(r *font.Renderer, width int) image.Point {
+	size := f.Child.Measure(r, width-2*framePadding)
+	return image.Pt(width, f.top(r)+size.Y+framePadding)
+}
+
+// Draw 绘制边框和标题，再在框内绘制控件；区域被页面底部裁掉时下边框随之上移
+func (f *Frame) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	border := bounds
+	if f.Title != "" {
+		border.Min.Y += r.LineHeight() / 2 // 上边框穿过标题的中间
+	}
+	drawFrame(dst, border, colorOr(f.Color, theme.Line))
+
+	if f.Title != "" {
+		title := r.TruncateToWidth(f.Title, bounds.Dx()-4*framePadding)
+		w, _ := r.MeasureString(title)
+		x := bounds.Min.X + 2*framePadding
+		// 擦掉标题背后的边框
+		gap := image.Rect(x-defaultLineSpacing, bounds.Min.Y, x+w+defaultLineSpacing, bounds.Min.Y+r.LineHeight())
+		draw.Draw(dst, gap.Intersect(dst.Bounds()), &image.Uniform{theme.Background}, image.Point{}, draw.Src)
+		if err := r.RenderTextInto(dst, x, bounds.Min.Y, title, theme.Accent); err != nil {
+			return fmt.Errorf("绘制边框标题失败: %v", err)
+		}
+	}
+
+	if content := f.content(r, bounds); !content.Empty() {
+		return f.Child.Draw(r, dst, content)
+	}
+	return nil
+}
+
+// hitAreas 框内的控件可以点击时，可点击区域与直接放在页面中相同
+func (f *Frame) hitAreas(r *font.Renderer, bounds image.Rectangle) []HitArea {
+	if c, ok := f.Child.(clickable); ok {
+		return c.hitAreas(r, f.content(r, bounds))
+	}
+	return nil
+}
+
+// mirrorText 文本镜像中以制表符画出边框的左侧和上下边
+func (f *Frame) mirrorText() []string {
+	box := mirrorBoxChars()
+	head := box.corner[0] + strings.Repeat(box.horizontal, frameMirrorWidth-1)
+	if f.Title != "" {
+		head = box.corner[0] + box.horizontal + " " + f.Title + " " + strings.Repeat(box.horizontal, frameMirrorWidth/2)
+	}
+	lines := []string{head}
+	if t, ok := f.Child.(textual); ok {
+		for _, line := range t.mirrorText() {
+			lines = append(lines, box.vertical+" "+line)
+		}
+	}
+	return append(lines, box.corner[1]+strings.Repeat(box.horizontal, frameMirrorWidth-1))
+}
+
+// boxChars 文本镜像中画边框和分隔线的字符
+type boxChars struct {
+	horizontal string
+	vertical   string
+	corner     [2]string // 左上角和左下角
+}
+
+// mirrorBoxChars 返回主题的分隔线样式对应的制表符，不画线时以空格代替
+func mirrorBoxChars() boxChars {
+	switch theme.Separator {
+	case SeparatorNone:
+		return boxChars{horizontal: " ", vertical: " ", corner: [2]string{" ", " "}}
+	case SeparatorDashed:
+		return boxChars{horizontal: "╌", vertical: "╎", corner: [2]string{"┌", "└"}}
+	case SeparatorDouble:
+		return boxChars{horizontal: "═", vertical: "║", corner: [2]string{"╔", "╚"}}
+	}
+	return boxChars{horizontal: "─", vertical: "│", corner: [2]string{"┌", "└"}}
+}
+
+// drawFrame 按主题的分隔线样式绘制矩形边框：实线、虚线、两条相距1像素的双线，不画线时什么也不画
+func drawFrame(dst draw.Image, rect image.Rectangle, col color.Color) {
+	switch theme.Separator {
+	case SeparatorNone:
+	case SeparatorDashed:
+		src := &image.Uniform{col}
+		fill := func(r image.Rectangle) {
+			draw.Draw(dst, r.Intersect(rect).Intersect(dst.Bounds()), src, image.Point{}, draw.Src)
+		}
+		for x := rect.Min.X; x < rect.Max.X; x += dashLength + dashGap {
+			fill(image.Rect(x, rect.Min.Y, x+dashLength, rect.Min.Y+1))
+			fill(image.Rect(x, rect.Max.Y-1, x+dashLength, rect.Max.Y))
+		}
+		for y := rect.Min.Y; y < rect.Max.Y; y += dashLength + dashGap {
+			fill(image.Rect(rect.Min.X, y, rect.Min.X+1, y+dashLength))
+			fill(image.Rect(rect.Max.X-1, y, rect.Max.X, y+dashLength))
+		}
+	case SeparatorDouble:
+		drawOutline(dst, rect, col)
+		drawOutline(dst, rect.Inset(2), col)
+	default:
+		drawOutline(dst, rect, col)
+	}
+}
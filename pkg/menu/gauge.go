@@ -0,0 +1,153 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+
+	"go-framebuffer-console/pkg/font"
+)
+
+// 进度条的默认阈值
+const (
+	gaugeWarning  = 0.7 // 使用比例达到70%时显示警告色
+	gaugeCritical = 0.9 // 使用比例达到90%时显示错误色
+	gaugeGap      = 8   // 标签、进度条和说明文字之间的距离（像素）
+	gaugeMirror   = 20  // 文本镜像中进度条的字符数
+)
+
+// Gauge 横向进度条控件，用于内存、磁盘等使用率
+// 一行中依次为标签、进度条和说明文字；填充颜色随使用比例变化：
+// 低于警告阈值为正常色，达到警告阈值为警告色，达到严重阈值为错误色
+type Gauge struct {
+	Label      string  // 左侧标签
+	Value      float64 // 当前值
+	Max        float64 // 最大值，不大于0时进度条为空
+	Text       string  // 进度条右侧的说明，如"444M/19995MB"，显示在百分比后面
+	Warning    float64 // 警告阈值（0-1），0表示70%
+	Critical   float64 // 严重阈值（0-1），0表示90%
+	LabelWidth int     // 标签列宽度（像素），多个进度条上下对齐时设为相同的值，0表示按标签文字宽度
+	BarWidth   int     // 进度条宽度（像素），0表示标签之后剩余宽度的一半
+}
+
+// NewGauge 创建使用默认阈值的进度条
+// 参数label: 标签
+// 参数value, max: 当前值和最大值
+// 参数text: 百分比后面的说明，可以为空
+func NewGauge(label string, value, max float64, text string) *Gauge {
+	return &Gauge{Label: label, Value: value, Max: max, Text: text}
+}
+
+// Ratio 返回当前值占最大值的比例，范围0-1
+func (g *Gauge) Ratio() float64 {
+	if g.Max <= 0 {
+		return 0
+	}
+	return clampRatio(g.Value / g.Max)
+}
+
+// Color 返回当前比例对应的填充颜色
+func (g *Gauge) Color() color.Color {
+	warning, critical := g.Warning, g.Critical
+	if warning <= 0 {
+		warning = gaugeWarning
+	}
+	if critical <= 0 {
+		critical = gaugeCritical
+	}
+	switch ratio := g.Ratio(); {
+	case ratio >= critical:
+		return theme.Error
+	case ratio >= warning:
+		return theme.Warning
+	}
+	return theme.Success
+}
+
+// text 返回进度条右侧的文字：百分比和说明
+func (g *Gauge) text() string {
+	text := fmt.Sprintf("%.1f%%", g.Ratio()*100)
+	if g.Max <= 0 {
+		text = "--"
+	}
+	if g.Text != "" {
+		text += "  " + g.Text
+	}
+	return text
+}
+
+// Measure 进度条占满可用宽度，高度为一行
+func (g *Gauge) Measure(r *font.Renderer, width int) image.Point {
+	return image.Pt(width, lineStep(r))
+}
+
+// Draw 绘制标签、进度条和说明文字
+func (g *Gauge) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	labelWidth := g.LabelWidth
+	if labelWidth <= 0 {
+		labelWidth, _ = r.MeasureString(g.Label)
+	}
+	if err := r.RenderTextInto(dst, bounds.Min.X, bounds.Min.Y, r.TruncateToWidth(g.Label, labelWidth), theme.Foreground); err != nil {
+		return fmt.Errorf("绘制进度条标签失败: %v", err)
+	}
+
+	x := bounds.Min.X + labelWidth + gaugeGap
+	barWidth := g.BarWidth
+	if barWidth <= 0 {
+		barWidth = (bounds.Max.X - x) / 2
+	}
+	if barWidth < 4 {
+		return nil
+	}
+	// 进度条与文字等高，上下各留出1/6行高
+	inset := r.LineHeight() / 6
+	bar := image.Rect(x, bounds.Min.Y+inset, x+barWidth, bounds.Min.Y+r.LineHeight()-inset)
+	drawBar(dst, bar, g.Ratio(), g.Color())
+
+	x = bar.Max.X + gaugeGap
+	text := r.TruncateToWidth(g.text(), bounds.Max.X-x)
+	if err := r.RenderTextInto(dst, x, bounds.Min.Y, text, theme.Foreground); err != nil {
+		return fmt.Errorf("绘制进度条说明失败: %v", err)
+	}
+	return nil
+}
+
+// mirrorText 文本镜像中用#和-画出进度条
+func (g *Gauge) mirrorText() []string {
+	return []string{fmt.Sprintf("%s %s %s", g.Label, textBar(g.Ratio(), gaugeMirror), g.text())}
+}
+
+// drawBar 绘制进度条：文字颜色的边框，内部按比例填充
+// 参数rect: 进度条的区域（含边框）
+// 参数ratio: 填充比例，范围0-1
+// 参数fill: 填充颜色
+func drawBar(dst draw.Image, rect image.Rectangle, ratio float64, fill color.Color) {
+	if rect.Dx() < 4 || rect.Dy() < 4 {
+		return
+	}
+	drawOutline(dst, rect, theme.Foreground)
+
+	inner := rect.Inset(2)
+	inner.Max.X = inner.Min.X + int(float64(inner.Dx())*clampRatio(ratio))
+	if inner.Dx() > 0 {
+		draw.Draw(dst, inner.Intersect(dst.Bounds()), &image.Uniform{fill}, image.Point{}, draw.Src)
+	}
+}
+
+// textBar 返回文本形式的进度条，如"[#####-----]"
+func textBar(ratio float64, width int) string {
+	filled := int(clampRatio(ratio) * float64(width))
+	return "[" + strings.Repeat("#", filled) + strings.Repeat("-", width-filled) + "]"
+}
+
+// clampRatio 把比例限制在0-1之间
+func clampRatio(ratio float64) float64 {
+	if ratio < 0 {
+		return 0
+	} else if ratio > 1 {
+		return 1
+	}
+	return ratio
+}
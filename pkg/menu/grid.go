@@ -0,0 +1,238 @@
+package menu
+
+import (
+	"image"
+	"image/draw"
+
+	"go-framebuffer-console/pkg/font"
+)
+
+// defaultCellGap 多列控件中相邻两列之间的默认距离（像素）
+const defaultCellGap = 20
+
+// splitColumns 把区域按权重横向分为若干列，列之间留出gap像素
+// 权重为nil或长度不足时缺少的列按1计算；最后一列吸收除不尽的像素，保证各列正好占满区域
+// 参数area: 要分割的区域
+// 参数n: 列数
+// 参数weights: 各列宽度的相对权重
+// 参数gap: 相邻两列之间的距离（像素）
+func splitColumns(area image.Rectangle, n int, weights []int, gap int) []image.Rectangle {
+	if n < 1 {
+		return nil
+	}
+	weight := func(i int) int {
+		if i < len(weights) && weights[i] > 0 {
+			return weights[i]
+		}
+		return 1
+	}
+	total := 0
+	for i := 0; i < n; i++ {
+		total += weight(i)
+	}
+
+	usable := area.Dx() - gap*(n-1)
+	if usable < 0 {
+		usable = 0
+	}
+	cols := make([]image.Rectangle, n)
+	x := area.Min.X
+	for i := 0; i < n; i++ {
+		w := usable * weight(i) / total
+		if i == n-1 {
+			w = area.Max.X - x
+		}
+		cols[i] = image.Rect(x, area.Min.Y, x+w, area.Max.Y)
+		x += w + gap
+	}
+	return cols
+}
+
+// Stack 把若干控件自上而下排列为一个控件，用作Columns中的一列
+type Stack struct {
+	Widgets []Widget // 自上而下排列的控件
+	Spacing int      // 控件之间的间距（像素）
+}
+
+// NewStack 创建使用默认间距的控件组
+func NewStack(widgets ...Widget) *Stack {
+	return &Stack{Widgets: widgets, Spacing: defaultLineSpacing}
+}
+
+// Measure 宽度取最宽的控件，高度为各控件高度与间距之和
+func (s *Stack) Measure(r *font.Renderer, width int) image.Point {
+	var size image.Point
+	for i, w := range s.Widgets {
+		ws := w.Measure(r, width)
+		if ws.X > size.X {
+			size.X = ws.X
+		}
+		size.Y += ws.Y
+		if i > 0 {
+			size.Y += s.Spacing
+		}
+	}
+	return size
+}
+
+// Draw 在区域内依次绘制各控件，超出底部的控件只得到剩余的高度
+func (s *Stack) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	for _, child := range s.children(r, bounds) {
+		if err := child.w.Draw(r, dst, child.bounds); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// placed 已经确定位置的子控件
+type placed struct {
+	w      Widget
+	bounds image.Rectangle
+}
+
+// children 返回各控件绘制在bounds区域时所在的位置，完全超出底部的控件不包括在内
+func (s *Stack) children(r *font.Renderer, bounds image.Rectangle) []placed {
+	var result []placed
+	y := bounds.Min.Y
+	for _, w := range s.Widgets {
+		if y >= bounds.Max.Y {
+			break
+		}
+		size := w.Measure(r, bounds.Dx())
+		result = append(result, placed{w, image.Rect(bounds.Min.X, y, bounds.Max.X, y+size.Y).Intersect(bounds)})
+		y += size.Y + s.Spacing
+	}
+	return result
+}
+
+// hitAreas 汇总各控件的可点击区域
+func (s *Stack) hitAreas(r *font.Renderer, bounds image.Rectangle) []HitArea {
+	var areas []HitArea
+	for _, child := range s.children(r, bounds) {
+		if c, ok := child.w.(clickable); ok {
+			areas = append(areas, c.hitAreas(r, child.bounds)...)
+		}
+	}
+	return areas
+}
+
+// mirrorText 依次输出各控件的文本
+func (s *Stack) mirrorText() []string {
+	return mirrorAll(s.Widgets)
+}
+
+// Columns 把若干控件并排放在一行中，每个控件占一列
+// 列宽按Weights的比例分配，高度取最高的一列；一列需要放多个控件时使用Stack
+type Columns struct {
+	Cells   []Widget // 从左到右各列的控件
+	Weights []int    // 各列宽度的相对权重，为nil时等宽
+	Gap     int      // 相邻两列之间的距离（像素）
+	Divider bool     // 是否在相邻两列之间画竖线
+}
+
+// NewColumns 创建等宽、使用默认列间距的多列控件
+func NewColumns(cells ...Widget) *Columns {
+	return &Columns{Cells: cells, Gap: defaultCellGap}
+}
+
+// columns 返回各列在bounds区域中的位置
+func (c *Columns) columns(bounds image.Rectangle) []image.Rectangle {
+	return splitColumns(bounds, len(c.Cells), c.Weights, c.Gap)
+}
+
+// Measure 占满可用宽度，高度取最高的一列
+func (c *Columns) Measure(r *font.Renderer, width int) image.Point {
+	h := 0
+	for i, col := range c.columns(image.Rect(0, 0, width, 0)) {
+		if size := c.Cells[i].Measure(r, col.Dx()); size.Y > h {
+			h = size.Y
+		}
+	}
+	return image.Pt(width, h)
+}
+
+// Draw 在各列的区域内绘制对应的控件，需要时在列之间画竖线
+func (c *Columns) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	for i, col := range c.columns(bounds) {
+		size := c.Cells[i].Measure(r, col.Dx())
+		cell := image.Rect(col.Min.X, col.Min.Y, col.Max.X, col.Min.Y+size.Y).Intersect(bounds)
+		if err := c.Cells[i].Draw(r, dst, cell); err != nil {
+			return err
+		}
+		if c.Divider && i > 0 {
+			x := col.Min.X - c.Gap/2
+			line := image.Rect(x, bounds.Min.Y, x+1, bounds.Max.Y)
+			draw.Draw(dst, line.Intersect(dst.Bounds()), &image.Uniform{theme.Line}, image.Point{}, draw.Src)
+		}
+	}
+	return nil
+}
+
+// hitAreas 汇总各列的可点击区域
+func (c *Columns) hitAreas(r *font.Renderer, bounds image.Rectangle) []HitArea {
+	var areas []HitArea
+	for i, col := range c.columns(bounds) {
+		if cl, ok := c.Cells[i].(clickable); ok {
+			size := c.Cells[i].Measure(r, col.Dx())
+			cell := image.Rect(col.Min.X, col.Min.Y, col.Max.X, col.Min.Y+size.Y).Intersect(bounds)
+			areas = append(areas, cl.hitAreas(r, cell)...)
+		}
+	}
+	return areas
+}
+
+// mirrorText 从左到右依次输出各列的文本
+func (c *Columns) mirrorText() []string {
+	return mirrorAll(c.Cells)
+}
+
+// mirrorAll 依次汇总各控件的文本镜像
+func mirrorAll(widgets []Widget) []string {
+	var text []string
+	for _, w := range widgets {
+		if t, ok := w.(textual); ok {
+			text = append(text, t.mirrorText()...)
+		}
+	}
+	return text
+}
+
+// 首页分栏的默认参数
+const (
+	DefaultTwoColumnWidth = 1280 // 自动分栏时使用两列的最小屏幕宽度（像素）
+	DefaultColumnSplit    = 55   // 两列时左列占的宽度百分比
+)
+
+// MainLayout 首页的分栏方式
+// 宽屏上单列显示时右侧大片空白，分为两列后系统信息在左列，二维码和客服信息在右列
+type MainLayout struct {
+	Columns  int // 列数：1为单列，2为两列，0表示按屏幕宽度自动选择
+	MinWidth int // 自动选择时使用两列的最小屏幕宽度（像素），0表示使用默认值
+	Split    int // 两列时左列占的宽度百分比，0表示使用默认值
+}
+
+// SetMainLayout 设置首页的分栏方式
+func (mr *MenuRenderer) SetMainLayout(l MainLayout) {
+	mr.mainLayout = l
+	mr.InvalidateCache()
+}
+
+// mainColumns 返回首页各列占据的区域（高度为整个屏幕），单列时只有一列
+func (mr *MenuRenderer) mainColumns() []image.Rectangle {
+	l := mr.mainLayout
+	minWidth := l.MinWidth
+	if minWidth <= 0 {
+		minWidth = DefaultTwoColumnWidth
+	}
+	split := l.Split
+	if split <= 0 || split >= 100 {
+		split = DefaultColumnSplit
+	}
+
+	screen := image.Rect(0, 0, mr.width, mr.height)
+	if l.Columns == 1 || (l.Columns == 0 && mr.width < minWidth) {
+		return []image.Rectangle{screen}
+	}
+	return splitColumns(screen, 2, []int{split, 100 - split}, 0)
+}
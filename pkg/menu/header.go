@@ -0,0 +1,99 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"time"
+
+	"go-framebuffer-console/pkg/i18n"
+)
+
+// headerBadgePadding 告警标记中文字左右的留白（像素）
+const headerBadgePadding = 6
+
+// Header 页面顶部的状态栏：左侧为主机名，右侧为时钟和告警标记
+// 状态栏与页面内容分开绘制，时钟每秒更新时只重绘状态栏
+type Header struct {
+	Host   string     // 主机名
+	Alerts func() int // 返回当前的告警数，为nil或返回0时不显示告警标记
+}
+
+// SetHeader 设置页面顶部的状态栏，下次由导航栈绘制页面时生效
+// 启动画面等不经过导航栈的画面不显示状态栏
+// 参数h: 状态栏，nil表示不显示
+func (mr *MenuRenderer) SetHeader(h *Header) {
+	mr.header = h
+	mr.showHeader = mr.showHeader && h != nil
+	mr.InvalidateCache()
+}
+
+// setHeaderVisible 设置当前页面是否显示状态栏，全屏页面不显示
+func (mr *MenuRenderer) setHeaderVisible(visible bool) {
+	mr.showHeader = visible && mr.header != nil
+}
+
+// headerHeight 返回状态栏占用的高度：一行文字和下方的分隔线，不显示时为0
+func (mr *MenuRenderer) headerHeight() int {
+	if !mr.showHeader {
+		return 0
+	}
+	return lineStep(mr.renderer) + 2*defaultLineSpacing
+}
+
+// RefreshHeader 只重绘状态栏，用于每秒更新时钟，页面其它部分保持不变
+// 当前页面不显示状态栏时不做任何处理
+func (mr *MenuRenderer) RefreshHeader() error {
+	if !mr.showHeader {
+		return nil
+	}
+	return mr.drawHeader()
+}
+
+// drawHeader 清除屏幕顶部并绘制状态栏
+// 告警标记以主题的错误色为底、背景色为字；状态栏不输出到文本镜像，避免每秒刷新串口终端
+func (mr *MenuRenderer) drawHeader() error {
+	mr.renderer.SetSize(14)
+	h := mr.headerHeight()
+	if h == 0 {
+		return nil
+	}
+
+	screen := mr.fb.Bounds()
+	area := image.Rect(screen.Min.X, screen.Min.Y, screen.Max.X, screen.Min.Y+h)
+	draw.Draw(mr.fb, area, &image.Uniform{theme.Background}, image.Point{}, draw.Src)
+	line := image.Rect(area.Min.X+defaultPageMargin, area.Max.Y-1, area.Max.X-defaultPageMargin, area.Max.Y)
+	draw.Draw(mr.fb, line, &image.Uniform{theme.Line}, image.Point{}, draw.Src)
+
+	y := area.Min.Y + defaultLineSpacing
+	left, right := area.Min.X+defaultPageMargin, area.Max.X-defaultPageMargin
+
+	// 右侧：时钟
+	clock := time.Now().Format("2006-01-02 15:04:05")
+	clockWidth, _ := mr.renderer.MeasureString(clock)
+	right -= clockWidth
+	if err := mr.renderer.RenderTextInto(mr.fb, right, y, clock, theme.Foreground); err != nil {
+		return fmt.Errorf("failed to render header: %v", err)
+	}
+
+	// 时钟左侧：告警标记
+	if mr.header.Alerts != nil {
+		if n := mr.header.Alerts(); n > 0 {
+			badge := i18n.Translatef("告警 %d", n)
+			badgeWidth, _ := mr.renderer.MeasureString(badge)
+			right -= badgeWidth + 2*headerBadgePadding + footerGap
+			rect := image.Rect(right, y-1, right+badgeWidth+2*headerBadgePadding, y+mr.renderer.LineHeight()+1)
+			draw.Draw(mr.fb, rect, &image.Uniform{theme.Error}, image.Point{}, draw.Src)
+			if err := mr.renderer.RenderTextInto(mr.fb, right+headerBadgePadding, y, badge, theme.Background); err != nil {
+				return fmt.Errorf("failed to render header: %v", err)
+			}
+		}
+	}
+
+	// 左侧：主机名，过长时截断，不与右侧重叠
+	host := mr.renderer.TruncateToWidth(mr.header.Host, right-footerGap-left)
+	if err := mr.renderer.RenderTextInto(mr.fb, left, y, host, theme.Accent); err != nil {
+		return fmt.Errorf("failed to render header: %v", err)
+	}
+	return nil
+}
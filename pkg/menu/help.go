@@ -0,0 +1,138 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/input"
+)
+
+// HelpItem 帮助页面中的一条说明
+type HelpItem struct {
+	Name string // 按键或显示内容的名称，如"内存使用状态"
+	Text string // 说明
+}
+
+// Helper 可以在帮助页面中说明显示内容的页面
+type Helper interface {
+	// Help 返回页面上各项显示内容的含义
+	Help() []HelpItem
+}
+
+// textEditor 可能正在编辑文本的页面，编辑时可打印字符作为输入内容，不应被当作热键
+type textEditor interface {
+	editingText() bool
+}
+
+// HelpPage 帮助页面，列出上一页的按键和显示内容的含义，任意键返回
+type HelpPage struct {
+	BasePage
+	Keys   []Hint     // 上一页可用的按键，包括全局热键
+	Fields []HelpItem // 上一页显示内容的说明
+
+	layout *Layout
+}
+
+// NewHelpPage 创建帮助页面
+// 参数keys: 可用的按键
+// 参数fields: 显示内容的说明，可以为nil
+func NewHelpPage(keys []Hint, fields []HelpItem) *HelpPage {
+	return &HelpPage{Keys: keys, Fields: fields}
+}
+
+// helpTable 创建没有表头的两列表格：名称和说明
+func helpTable(rows [][2]string) *Table {
+	t := &Table{Columns: []Column{{}, {}}}
+	for _, row := range rows {
+		t.AddRow(row[0], row[1])
+	}
+	return t
+}
+
+// Render 在带标题的边框中分别列出按键和显示内容
+func (p *HelpPage) Render(mr *MenuRenderer) error {
+	if p.layout == nil {
+		p.layout = mr.NewLayout(&Label{Text: i18n.Translate("帮助"), Color: theme.Accent}, NewSeparator())
+		var keys [][2]string
+		for _, h := range p.Keys {
+			keys = append(keys, [2]string{h.Key, h.Text})
+		}
+		if len(keys) > 0 {
+			p.layout.Add(NewFrame(i18n.Translate("按键"), helpTable(keys)))
+		}
+		var fields [][2]string
+		for _, f := range p.Fields {
+			fields = append(fields, [2]string{f.Name, f.Text})
+		}
+		if len(fields) > 0 {
+			p.layout.Add(NewFrame(i18n.Translate("显示内容"), helpTable(fields)))
+		}
+		if len(keys) == 0 && len(fields) == 0 {
+			p.layout.Add(NewLabel(i18n.Translate("此页面没有帮助信息")))
+		}
+	}
+	return mr.RenderLayout(p.layout)
+}
+
+// Hints 页脚的按键提示
+func (p *HelpPage) Hints() []Hint {
+	return []Hint{{Key: i18n.Translate("任意键"), Text: i18n.Translate("返回")}}
+}
+
+// HandleKey 任意键关闭帮助，返回上一页
+func (p *HelpPage) HandleKey(nav *Navigator, ev input.KeyEvent) error {
+	return nav.Pop()
+}
+
+// ShowHelp 显示当前页面的帮助：页面自己和全局热键的按键提示，页面实现了Helper时还说明各项显示内容；
+// 当前页面已经是帮助页面时关闭帮助
+func (n *Navigator) ShowHelp() error {
+	top := n.Top()
+	if _, ok := top.(*HelpPage); ok {
+		return n.Pop()
+	}
+	var keys []Hint
+	if h, ok := top.(Hinter); ok {
+		keys = append(keys, h.Hints()...)
+	}
+	if n.GlobalHints != nil {
+		keys = append(keys, n.GlobalHints()...)
+	}
+	var fields []HelpItem
+	if h, ok := top.(Helper); ok {
+		fields = h.Help()
+	}
+	return n.Push(NewHelpPage(keys, fields))
+}
+
+// EditingText 返回当前页面是否正在编辑文本，如输入框获得焦点的对话框和表单
+// 此时可打印字符属于输入内容，由这类字符组成的全局热键应当让给页面处理
+func (n *Navigator) EditingText() bool {
+	e, ok := n.Top().(textEditor)
+	return ok && e.editingText()
+}
+
+// editingText 输入框获得焦点时正在编辑文本
+func (p *DialogPage) editingText() bool {
+	return p.Dialog.InputFocused()
+}
+
+// editingText 焦点在输入框上时正在编辑文本
+func (p *FormPage) editingText() bool {
+	return p.Form.FocusedField() != nil
+}
+
+// Help 列出各选项的快捷键
+func (p *MenuPage) Help() []HelpItem {
+	var items []HelpItem
+	for _, item := range p.Items {
+		if item.Key == 0 {
+			continue
+		}
+		// 选项文字中已有的编号不再重复
+		text := strings.TrimPrefix(item.Text, fmt.Sprintf("%c. ", item.Key))
+		items = append(items, HelpItem{Name: string(item.Key), Text: text})
+	}
+	return items
+}
@@ -0,0 +1,68 @@
+package menu
+
+import (
+	"image"
+	"image/draw"
+
+	xdraw "golang.org/x/image/draw"
+
+	"go-framebuffer-console/pkg/font"
+)
+
+// ImageView 图片控件，如启动画面上的logo
+// 图片超出可用宽度或最大高度时按原比例缩小，不会放大
+type ImageView struct {
+	Image     image.Image // 要显示的图片
+	Align     Alignment   // 水平对齐方式
+	MaxHeight int         // 最大高度（像素），0表示不限制
+
+	scaled *image.RGBA // 按上次绘制尺寸缩放后的图片
+}
+
+// NewImageView 创建水平居中的图片控件
+func NewImageView(img image.Image) *ImageView {
+	return &ImageView{Image: img, Align: AlignCenter}
+}
+
+// Measure 返回图片缩放后的尺寸
+func (v *ImageView) Measure(r *font.Renderer, width int) image.Point {
+	return v.size(width)
+}
+
+// size 计算图片在给定宽度下按比例缩小后的尺寸
+func (v *ImageView) size(width int) image.Point {
+	if v.Image == nil {
+		return image.Point{}
+	}
+	size := v.Image.Bounds().Size()
+	if size.X > width && width > 0 {
+		size = image.Pt(width, size.Y*width/size.X)
+	}
+	if v.MaxHeight > 0 && size.Y > v.MaxHeight {
+		size = image.Pt(size.X*v.MaxHeight/size.Y, v.MaxHeight)
+	}
+	return size
+}
+
+// Draw 按对齐方式绘制图片，透明部分露出背景
+func (v *ImageView) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	size := v.size(bounds.Dx())
+	if size.X <= 0 || size.Y <= 0 {
+		return nil
+	}
+
+	src := v.Image
+	if size != v.Image.Bounds().Size() {
+		if v.scaled == nil || v.scaled.Bounds().Size() != size {
+			v.scaled = image.NewRGBA(image.Rectangle{Max: size})
+			xdraw.ApproxBiLinear.Scale(v.scaled, v.scaled.Bounds(), v.Image, v.Image.Bounds(), draw.Src, nil)
+		}
+		src = v.scaled
+	}
+
+	x := alignX(v.Align, bounds, size.X)
+	rect := image.Rect(x, bounds.Min.Y, x+size.X, bounds.Min.Y+size.Y)
+	clip := rect.Intersect(bounds)
+	draw.Draw(dst, clip, src, src.Bounds().Min.Add(clip.Min.Sub(rect.Min)), draw.Over)
+	return nil
+}
@@ -0,0 +1,187 @@
+package menu
+
+import (
+	"math"
+	"time"
+
+	"go-framebuffer-console/pkg/font"
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/input"
+)
+
+// logKeepLines 日志页面最多保留的行数，超过时丢弃最早的行
+const logKeepLines = 5000
+
+// LogSource 日志页面的内容来源，如system.FollowFile或system.GetJournal返回的跟踪器
+type LogSource interface {
+	Drain() []string // 取走上次调用以来的新行
+	Err() error      // 读取停止的原因，仍在跟踪时为nil
+	Stop()           // 停止跟踪
+}
+
+// LogPage 实时跟踪日志的页面
+// 跟踪模式下新内容出现时自动滚动到最后一行；向上滚动后停止跟踪，可以查看之前的内容，
+// 按End或f恢复跟踪。暂停时画面保持不变，新内容暂存起来，恢复后一并显示。
+// 进入页面时打开日志来源，离开页面时停止跟踪
+type LogPage struct {
+	BasePage
+	Title string                    // 页面标题，如日志文件名
+	Open  func() (LogSource, error) // 打开日志来源，每次进入页面时调用
+
+	source  LogSource
+	view    *ScrollView
+	status  *Label
+	layout  *Layout
+	follow  bool     // 是否跟踪最后一行
+	paused  bool     // 是否暂停显示新内容
+	pending []string // 暂停期间读到的新行
+}
+
+// NewLogPage 创建日志页面
+// 参数title: 页面标题
+// 参数open: 打开日志来源
+func NewLogPage(title string, open func() (LogSource, error)) *LogPage {
+	return &LogPage{Title: title, Open: open}
+}
+
+// OnEnter 打开日志来源，从跟踪模式开始
+func (p *LogPage) OnEnter(nav *Navigator) error {
+	p.view = NewScrollView(nil, nil)
+	p.view.Wrap = true
+	p.status = &Label{Color: theme.Accent}
+	p.layout = nil
+	p.follow, p.paused, p.pending = true, false, nil
+
+	source, err := p.Open()
+	if err != nil {
+		p.source = nil
+		p.view.Lines = []string{err.Error()}
+		p.view.Styles = []font.LineStyle{{Color: theme.Error}}
+		return nil
+	}
+	p.source = source
+	p.append(source.Drain())
+	return nil
+}
+
+// OnExit 停止跟踪
+func (p *LogPage) OnExit(nav *Navigator) {
+	if p.source != nil {
+		p.source.Stop()
+		p.source = nil
+	}
+}
+
+// append 追加新的行，超出保留行数时丢弃最早的行
+func (p *LogPage) append(lines []string) {
+	view := p.view
+	view.Lines = append(view.Lines, lines...)
+	if n := len(view.Lines) - logKeepLines; n > 0 {
+		view.Lines = append(view.Lines[:0], view.Lines[n:]...)
+		if !p.follow {
+			// 保持正在查看的内容不动
+			view.Offset -= n
+		}
+	}
+}
+
+// statusText 返回标题下方的状态说明
+func (p *LogPage) statusText() string {
+	if p.source != nil {
+		if err := p.source.Err(); err != nil {
+			return err.Error()
+		}
+	}
+	switch {
+	case p.paused:
+		return i18n.Translatef("已暂停，%d 行新内容", len(p.pending))
+	case p.follow:
+		return i18n.Translate("跟踪最新内容")
+	}
+	return i18n.Translate("已停止跟踪，按End恢复")
+}
+
+// Render 绘制标题、状态和日志内容，跟踪模式下显示最后一屏
+func (p *LogPage) Render(mr *MenuRenderer) error {
+	if p.layout == nil {
+		p.layout = mr.NewLayout(&Label{Text: p.Title, Color: theme.Accent}, p.status, NewSeparator(), p.view)
+	}
+	p.status.Text = p.statusText()
+	if p.follow {
+		p.view.Offset = math.MaxInt32 // 由ScrollView绘制时限制到最大值
+	}
+	return mr.RenderLayout(p.layout)
+}
+
+// Tick 取走新读到的行，有新内容或状态变化时重绘
+func (p *LogPage) Tick(nav *Navigator, now time.Time) error {
+	if p.source == nil {
+		return nil
+	}
+	lines := p.source.Drain()
+	if p.paused {
+		p.pending = append(p.pending, lines...)
+	} else {
+		p.append(lines)
+	}
+	if len(lines) > 0 || p.statusText() != p.status.Text {
+		nav.Invalidate()
+	}
+	return nil
+}
+
+// Hints 页脚的按键提示
+func (p *LogPage) Hints() []Hint {
+	pause := Hint{Key: i18n.Translate("空格"), Text: i18n.Translate("暂停")}
+	if p.paused {
+		pause.Text = i18n.Translate("继续")
+	}
+	return []Hint{
+		{Key: i18n.Translate("方向键"), Text: i18n.Translate("滚动")},
+		{Key: "End", Text: i18n.Translate("跟踪")},
+		pause,
+		{Key: "q", Text: i18n.Translate("返回")},
+	}
+}
+
+// Help 说明标题下方的状态
+func (p *LogPage) Help() []HelpItem {
+	return []HelpItem{
+		{Name: i18n.Translate("跟踪最新内容"), Text: i18n.Translate("有新内容时自动滚动到最后一行")},
+		{Name: i18n.Translate("已停止跟踪，按End恢复"), Text: i18n.Translate("向上滚动后停在当前位置，方便查看之前的内容")},
+		{Name: i18n.Translate("已暂停"), Text: i18n.Translate("画面保持不变，新内容暂存起来，继续后一并显示")},
+	}
+}
+
+// HandleKey 方向键和翻页键滚动，End或f恢复跟踪，空格或p暂停和继续，q、ESC或退格返回上一页
+func (p *LogPage) HandleKey(nav *Navigator, ev input.KeyEvent) error {
+	if moved, ok := scrollKey(p.view, ev.Code); ok {
+		switch {
+		case ev.Code == input.KeyEnd || p.view.Offset >= p.view.maxOffset():
+			p.follow = true // 滚动到最后一行时恢复跟踪
+		case moved:
+			p.follow = false
+		default:
+			return nil
+		}
+		nav.Invalidate()
+		return nil
+	}
+
+	switch ev.Byte() {
+	case 'f', 'F':
+		p.follow = true
+	case ' ', 'p', 'P':
+		p.paused = !p.paused
+		if !p.paused {
+			p.append(p.pending)
+			p.pending = nil
+		}
+	case 'q', 'Q', 27, 0x7F: // q, Q, ESC, 退格
+		return nav.Pop()
+	default:
+		return nil // 忽略其他键
+	}
+	nav.Invalidate()
+	return nil
+}
@@ -0,0 +1,76 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/system"
+)
+
+// MainTemplateFooter 首页模板中定义客服信息部分的模板名称，没有定义时首页不显示客服信息
+const MainTemplateFooter = "footer"
+
+// mainTemplateSeparator 模板输出中单独占一行时画作分隔线的文字
+const mainTemplateSeparator = "---"
+
+// MainTemplateData 首页模板可以引用的数据：系统信息的各字段，如{{.Uptime}}、{{.Hostname}}、{{.IPAddress}}
+type MainTemplateData struct {
+	*system.SystemInfo
+}
+
+// mainTemplateFuncs 首页模板中可以使用的函数
+var mainTemplateFuncs = template.FuncMap{
+	"tr": i18n.Translate, // 翻译为当前界面语言，如{{tr "设备ID"}}
+}
+
+// SetMainTemplate 用Go text/template定义首页系统信息部分的内容，取代内置的各行
+// 模板输出的每一行显示为首页的一行，放不下时在行内来回滚动；单独一行"---"画作分隔线。
+// 用{{define "footer"}}...{{end}}定义的部分取代二维码下方的客服信息，没有定义时不显示客服信息。
+// 设置前先用空的系统信息试执行一次，引用了不存在的字段时返回错误，首页保持原来的内容
+// 参数text: 模板内容，为空时恢复内置的首页
+func (mr *MenuRenderer) SetMainTemplate(text string) error {
+	if strings.TrimSpace(text) == "" {
+		mr.mainTemplate = nil
+		mr.InvalidateCache()
+		return nil
+	}
+	tmpl, err := template.New("main").Funcs(mainTemplateFuncs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("解析首页模板失败: %v", err)
+	}
+	data := MainTemplateData{SystemInfo: &system.SystemInfo{}}
+	if err := tmpl.Execute(&strings.Builder{}, data); err != nil {
+		return fmt.Errorf("首页模板无效: %v", err)
+	}
+	if footer := tmpl.Lookup(MainTemplateFooter); footer != nil {
+		if err := footer.Execute(&strings.Builder{}, data); err != nil {
+			return fmt.Errorf("首页模板无效: %v", err)
+		}
+	}
+	mr.mainTemplate = tmpl
+	mr.InvalidateCache()
+	return nil
+}
+
+// mainTemplateLines 执行首页模板中名为name的部分，返回输出的各行
+// 执行失败时返回说明原因的一行，不影响首页的其它内容
+// 参数name: 模板名称，为空时执行模板的主体
+func (mr *MenuRenderer) mainTemplateLines(name string, sysInfo *system.SystemInfo) []string {
+	tmpl := mr.mainTemplate
+	if name != "" {
+		if tmpl = tmpl.Lookup(name); tmpl == nil {
+			return nil
+		}
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, MainTemplateData{SystemInfo: sysInfo}); err != nil {
+		return []string{i18n.Translatef("首页模板执行失败: %v", err)}
+	}
+	text := strings.TrimRight(strings.ReplaceAll(out.String(), "\r\n", "\n"), "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,168 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+	"time"
+
+	"go-framebuffer-console/pkg/font"
+)
+
+// DefaultMarqueeSpeed 滚动文字默认的速度（像素/秒）
+const DefaultMarqueeSpeed = 30
+
+// MarqueeFrame 滚动文字每帧的间隔，主循环按该间隔调用AnimateMarquees
+const MarqueeFrame = 100 * time.Millisecond
+
+// marqueePause 滚动到开头或末尾后停留的时间，便于看清两端的内容
+const marqueePause = 2 * time.Second
+
+// Marquee 单行文字，放不下时在所在的区域内来回水平滚动，而不是截断
+// 适合很长但又需要完整显示的值，如CPU型号和IPv6地址；放得下时与普通的单行标签相同
+type Marquee struct {
+	Text  string      // 文字，只显示第一行
+	Color color.Color // 文字颜色，为nil时使用主题的文字颜色
+	Align Alignment   // 放得下时的对齐方式
+}
+
+// NewMarquee 创建滚动文字
+// 参数text: 文字
+func NewMarquee(text string) *Marquee {
+	return &Marquee{Text: text}
+}
+
+// Measure 占一行，宽度为文字的宽度，不超过可用宽度
+func (m *Marquee) Measure(r *font.Renderer, width int) image.Point {
+	w, _ := r.MeasureString(m.Text)
+	if w > width {
+		w = width
+	}
+	return image.Pt(w, lineStep(r))
+}
+
+// Draw 绘制文字，放不下时从开头开始滚动
+func (m *Marquee) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	rect := image.Rect(bounds.Min.X, bounds.Min.Y, bounds.Max.X, bounds.Min.Y+r.LineHeight())
+	return drawMarqueeText(r, dst, rect, m.Text, colorOr(m.Color, theme.Foreground), theme.Background, m.Align)
+}
+
+// mirrorText 文本镜像中显示完整的文字
+func (m *Marquee) mirrorText() []string {
+	return []string{m.Text}
+}
+
+// marqueeSpeed 当前的滚动速度（像素/秒），不大于0时不滚动，放不下的文字截断
+var marqueeSpeed = DefaultMarqueeSpeed
+
+// SetMarqueeSpeed 设置放不下的文字的滚动速度，下次绘制页面时生效
+// 参数speed: 每秒移动的像素数，0表示不滚动，放不下时截断并加省略号
+func (mr *MenuRenderer) SetMarqueeSpeed(speed int) {
+	marqueeSpeed = speed
+	mr.InvalidateCache()
+}
+
+// marqueeArea 屏幕上一处正在滚动的文字
+type marqueeArea struct {
+	r     *font.Renderer
+	dst   draw.Image
+	rect  image.Rectangle // 文字所在的一行
+	text  string
+	color color.Color
+	bg    color.Color // 文字背后的颜色，如列表选中项的高亮条
+	start time.Time   // 开始滚动的时间
+}
+
+// marquees 当前页面中正在滚动的文字，按所在区域登记；控件绘制时登记，清屏时清空
+// 主循环与执行命令时的输出画面可能在不同的goroutine中绘制，因此需要加锁
+var marquees = struct {
+	sync.Mutex
+	areas map[image.Rectangle]*marqueeArea
+	stale map[image.Rectangle]*marqueeArea // 清屏前的登记，重绘相同的内容时接着原来的进度滚动
+}{areas: make(map[image.Rectangle]*marqueeArea)}
+
+// resetMarquees 清屏时调用，之前登记的文字不再滚动，除非重新绘制
+func resetMarquees() {
+	marquees.Lock()
+	defer marquees.Unlock()
+	marquees.stale = marquees.areas
+	marquees.areas = make(map[image.Rectangle]*marqueeArea)
+}
+
+// drawMarqueeText 在rect中绘制一行文字
+// 放得下或不滚动时按对齐方式绘制，放不下的部分截断；否则登记为滚动文字，按当前时间绘制一帧
+func drawMarqueeText(r *font.Renderer, dst draw.Image, rect image.Rectangle, text string, col, bg color.Color, align Alignment) error {
+	w, _ := r.MeasureString(text)
+	if w <= rect.Dx() || marqueeSpeed <= 0 || rect.Dx() <= 0 {
+		// 同一位置之前的文字可能在滚动，如首页只重绘改变的行时，不能再让它覆盖新的内容
+		marquees.Lock()
+		delete(marquees.areas, rect)
+		marquees.Unlock()
+		text = r.TruncateToWidth(text, rect.Dx())
+		w, _ = r.MeasureString(text)
+		if err := r.RenderTextInto(dst, alignX(align, rect, w), rect.Min.Y, text, col); err != nil {
+			return fmt.Errorf("绘制文字失败: %v", err)
+		}
+		return nil
+	}
+
+	area := &marqueeArea{r: r, dst: dst, rect: rect, text: text, color: col, bg: bg, start: time.Now()}
+	marquees.Lock()
+	for _, old := range []*marqueeArea{marquees.areas[rect], marquees.stale[rect]} {
+		if old != nil && old.text == text && old.dst == dst {
+			area.start = old.start
+			break
+		}
+	}
+	marquees.areas[rect] = area
+	marquees.Unlock()
+	return area.draw(time.Now())
+}
+
+// offset 返回now时文字向左移动的像素数
+// 文字在开头停留marqueePause后向左滚动到末尾，停留后再滚回开头，如此往复
+func (a *marqueeArea) offset(now time.Time) int {
+	w, _ := a.r.MeasureString(a.text)
+	overflow := w - a.rect.Dx()
+	if overflow <= 0 {
+		return 0
+	}
+	travel := time.Duration(overflow) * time.Second / time.Duration(marqueeSpeed)
+	period := 2 * (marqueePause + travel)
+	t := now.Sub(a.start) % period
+	switch {
+	case t < marqueePause:
+		return 0
+	case t < marqueePause+travel:
+		return int((t - marqueePause) * time.Duration(marqueeSpeed) / time.Second)
+	case t < 2*marqueePause+travel:
+		return overflow
+	}
+	return overflow - int((t-2*marqueePause-travel)*time.Duration(marqueeSpeed)/time.Second)
+}
+
+// draw 在临时画布上绘制移动后的文字，再整块复制到所在区域，文字不会画到区域以外
+func (a *marqueeArea) draw(now time.Time) error {
+	canvas := image.NewRGBA(image.Rect(0, 0, a.rect.Dx(), a.rect.Dy()))
+	draw.Draw(canvas, canvas.Bounds(), &image.Uniform{a.bg}, image.Point{}, draw.Src)
+	if err := a.r.RenderTextInto(canvas, -a.offset(now), 0, a.text, a.color); err != nil {
+		return fmt.Errorf("绘制滚动文字失败: %v", err)
+	}
+	draw.Draw(a.dst, a.rect.Intersect(a.dst.Bounds()), canvas, a.rect.Intersect(a.dst.Bounds()).Min.Sub(a.rect.Min), draw.Src)
+	return nil
+}
+
+// AnimateMarquees 把当前页面中放不下的文字滚动到now时的位置
+// 返回是否重绘了屏幕上的内容，没有需要滚动的文字时不做任何处理
+func (mr *MenuRenderer) AnimateMarquees(now time.Time) (bool, error) {
+	marquees.Lock()
+	defer marquees.Unlock()
+	for _, a := range marquees.areas {
+		if err := a.draw(now); err != nil {
+			return false, err
+		}
+	}
+	return len(marquees.areas) > 0, nil
+}
@@ -0,0 +1,187 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+
+	"go-framebuffer-console/pkg/font"
+)
+
+// MessageLevel 消息的级别，决定消息框的边框颜色和图标
+type MessageLevel int
+
+// 消息级别常量
+const (
+	LevelInfo    MessageLevel = iota // 提示，强调色边框和"i"图标
+	LevelSuccess                     // 成功，正常状态颜色边框和对勾图标
+	LevelWarning                     // 警告，警告颜色边框和"!"图标
+	LevelError                       // 错误，错误颜色边框和叉号图标
+)
+
+// 消息框的外观
+const (
+	messageBorder  = 2  // 边框粗细（像素）
+	messagePadding = 10 // 边框与内容之间的距离（像素）
+	messageIconGap = 10 // 图标与文字之间的距离（像素）
+)
+
+// Color 返回级别对应的主题颜色
+func (l MessageLevel) Color() color.Color {
+	switch l {
+	case LevelSuccess:
+		return theme.Success
+	case LevelWarning:
+		return theme.Warning
+	case LevelError:
+		return theme.Error
+	}
+	return theme.Accent
+}
+
+// tag 返回级别在文本镜像中的标记
+func (l MessageLevel) tag() string {
+	switch l {
+	case LevelSuccess:
+		return "[v]"
+	case LevelWarning:
+		return "[!]"
+	case LevelError:
+		return "[x]"
+	}
+	return "[i]"
+}
+
+// MessageBox 带级别的消息框：按级别着色的边框，左上角为级别图标，右侧为可滚动的消息文本
+// 消息超出页面时与ScrollView一样只显示一屏，由所在页面处理滚动按键
+type MessageBox struct {
+	Level MessageLevel // 消息级别
+	View  *ScrollView  // 消息文本
+}
+
+// NewMessageBox 创建消息框
+// 参数level: 消息级别
+// 参数lines: 消息文本行
+// 参数styles: 与lines一一对应的行样式，可以为nil
+func NewMessageBox(level MessageLevel, lines []string, styles []font.LineStyle) *MessageBox {
+	return &MessageBox{Level: level, View: newMessageView(lines, styles)}
+}
+
+// newMessageView 创建消息文本，超出宽度的行（如较长的错误信息）折行显示
+func newMessageView(lines []string, styles []font.LineStyle) *ScrollView {
+	sv := NewScrollView(lines, styles)
+	sv.Wrap = true
+	return sv
+}
+
+// iconSize 返回图标的边长，与一行文字等高
+func (m *MessageBox) iconSize(r *font.Renderer) int {
+	return r.LineHeight()
+}
+
+// Measure 消息框占满可用宽度，高度为全部文本加上边框和留白
+func (m *MessageBox) Measure(r *font.Renderer, width int) image.Point {
+	inset := messageBorder + messagePadding
+	text := m.View.Measure(r, width-2*inset-m.iconSize(r)-messageIconGap)
+	return image.Pt(width, text.Y+2*inset)
+}
+
+// Draw 绘制边框、图标和消息文本
+func (m *MessageBox) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	col := m.Level.Color()
+	for i := 0; i < messageBorder; i++ {
+		drawOutline(dst, bounds.Inset(i), col)
+	}
+
+	inner := bounds.Inset(messageBorder + messagePadding)
+	size := m.iconSize(r)
+	m.drawIcon(dst, image.Rect(inner.Min.X, inner.Min.Y, inner.Min.X+size, inner.Min.Y+size), col)
+	inner.Min.X += size + messageIconGap
+	if inner.Empty() {
+		return nil
+	}
+	return m.View.Draw(r, dst, inner)
+}
+
+// drawIcon 在rect中绘制以级别颜色填充的圆形图标，圆内以背景色画出级别的符号
+// 符号用线条绘制，不依赖字体中是否有相应的字符
+func (m *MessageBox) drawIcon(dst draw.Image, rect image.Rectangle, col color.Color) {
+	fillCircle(dst, rect, col)
+
+	bg := theme.Background
+	c := image.Pt((rect.Min.X+rect.Max.X)/2, (rect.Min.Y+rect.Max.Y)/2)
+	u := rect.Dx() / 4 // 符号的半径
+	thick := func(from, to image.Point) {
+		drawLine(dst, from, to, bg)
+		drawLine(dst, from.Add(image.Pt(1, 0)), to.Add(image.Pt(1, 0)), bg)
+	}
+	switch m.Level {
+	case LevelSuccess:
+		thick(image.Pt(c.X-u, c.Y), image.Pt(c.X-u/3, c.Y+u*2/3))
+		thick(image.Pt(c.X-u/3, c.Y+u*2/3), image.Pt(c.X+u, c.Y-u*2/3))
+	case LevelError:
+		thick(image.Pt(c.X-u, c.Y-u), image.Pt(c.X+u, c.Y+u))
+		thick(image.Pt(c.X-u, c.Y+u), image.Pt(c.X+u, c.Y-u))
+	case LevelWarning:
+		thick(image.Pt(c.X, c.Y-u-1), image.Pt(c.X, c.Y+u/3))
+		thick(image.Pt(c.X, c.Y+u), image.Pt(c.X, c.Y+u))
+	default:
+		thick(image.Pt(c.X, c.Y-u), image.Pt(c.X, c.Y-u))
+		thick(image.Pt(c.X, c.Y-u/3), image.Pt(c.X, c.Y+u))
+	}
+}
+
+// fillCircle 用颜色填充rect的内切圆
+func fillCircle(dst draw.Image, rect image.Rectangle, col color.Color) {
+	d := rect.Dx()
+	if rect.Dy() < d {
+		d = rect.Dy()
+	}
+	// 以2倍坐标计算，直径为偶数时圆心落在像素之间
+	cx, cy := 2*rect.Min.X+d, 2*rect.Min.Y+d
+	src := &image.Uniform{col}
+	for y := rect.Min.Y; y < rect.Min.Y+d; y++ {
+		for x := rect.Min.X; x < rect.Min.X+d; x++ {
+			dx, dy := 2*x+1-cx, 2*y+1-cy
+			if dx*dx+dy*dy <= d*d {
+				draw.Draw(dst, image.Rect(x, y, x+1, y+1).Intersect(dst.Bounds()), src, image.Point{}, draw.Src)
+			}
+		}
+	}
+}
+
+// mirrorText 文本镜像中在第一行前加上级别标记，如"[x] "
+func (m *MessageBox) mirrorText() []string {
+	lines := append([]string(nil), m.View.mirrorText()...)
+	if len(lines) == 0 {
+		return []string{m.Level.tag()}
+	}
+	lines[0] = strings.TrimSpace(m.Level.tag() + " " + lines[0])
+	return lines
+}
+
+// SetErrorBeep 设置显示错误级别的消息时的提示音
+// 参数beep: 显示错误消息时调用，如让PC喇叭鸣响；应尽快返回，nil表示不提示
+func (mr *MenuRenderer) SetErrorBeep(beep func()) {
+	mr.beep = beep
+}
+
+// alert 显示消息时按级别发出提示，目前只有错误级别的消息鸣响
+func (mr *MenuRenderer) alert(level MessageLevel) {
+	if level == LevelError && mr.beep != nil {
+		mr.beep()
+	}
+}
+
+// RenderLevelMessage 清屏并绘制带级别的消息框，错误级别的消息同时发出提示音
+// 参数level: 消息级别
+// 参数message: 消息文本，可包含多行
+func (mr *MenuRenderer) RenderLevelMessage(level MessageLevel, message string) error {
+	mr.alert(level)
+	if err := mr.RenderLayout(mr.NewLayout(NewMessageBox(level, strings.Split(message, "\n"), nil))); err != nil {
+		return fmt.Errorf("failed to render message: %v", err)
+	}
+	return nil
+}
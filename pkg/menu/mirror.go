@@ -0,0 +1,55 @@
+package menu
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SetTextMirror 设置页面文本的镜像输出
+// 设置后每次渲染页面时，同时把页面上的文字以纯文本形式写入w，
+// 用于没有显示器时通过串口终端查看界面；w为nil时关闭镜像
+func (mr *MenuRenderer) SetTextMirror(w io.Writer) {
+	mr.mirror = w
+	mr.mirrorLines = nil
+	mr.lastMirror = ""
+}
+
+// beginMirror 开始收集一个页面的文本
+func (mr *MenuRenderer) beginMirror() {
+	mr.mirrorLines = mr.mirrorLines[:0]
+}
+
+// addMirror 向正在收集的页面文本追加若干行，未设置镜像输出时什么也不做
+func (mr *MenuRenderer) addMirror(lines ...string) {
+	if mr.mirror != nil {
+		mr.mirrorLines = append(mr.mirrorLines, lines...)
+	}
+}
+
+// flushMirror 把收集到的页面文本写入镜像输出
+// 先清屏并回到左上角，行尾使用\r\n以适应原始模式下的串口终端；有页脚时附在末尾；
+// 与上次写入的内容相同时跳过，避免主页面每秒刷新时反复输出
+func (mr *MenuRenderer) flushMirror() {
+	if mr.mirror == nil {
+		return
+	}
+	lines := mr.mirrorLines
+	if footer := mr.footerText(); footer != "" {
+		lines = append(lines[:len(lines):len(lines)], "", footer)
+	}
+	text := strings.Join(lines, "\r\n")
+	if text == mr.lastMirror {
+		return
+	}
+	mr.lastMirror = text
+	// 镜像只是辅助显示，写入失败（如串口断开）不影响屏幕渲染
+	fmt.Fprintf(mr.mirror, "\033[2J\033[H%s\r\n", text)
+}
+
+// mirrorPage 把整页文本写入镜像输出
+func (mr *MenuRenderer) mirrorPage(lines []string) {
+	mr.beginMirror()
+	mr.addMirror(lines...)
+	mr.flushMirror()
+}
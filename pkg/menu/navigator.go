@@ -0,0 +1,255 @@
+package menu
+
+import (
+	"time"
+
+	"go-framebuffer-console/pkg/input"
+)
+
+// Page 可以压入导航栈的页面
+// 每个页面只负责绘制自身和处理自己的按键，页面之间的跳转统一通过Navigator完成，
+// 因此页面之间互不依赖，也不需要各自循环读取按键
+type Page interface {
+	// Render 绘制页面，在页面成为栈顶或调用Invalidate后由导航栈调用
+	Render(mr *MenuRenderer) error
+	// HandleKey 处理一个按键，可以通过nav压入新页面或返回上一页
+	HandleKey(nav *Navigator, ev input.KeyEvent) error
+	// OnEnter 页面成为栈顶时调用：首次压入，或上层页面弹出后重新显示
+	OnEnter(nav *Navigator) error
+	// OnExit 页面不再是栈顶时调用：被弹出，或被新压入的页面覆盖
+	OnExit(nav *Navigator)
+}
+
+// BasePage 提供空的OnEnter和OnExit，嵌入后只需实现Render和HandleKey
+type BasePage struct{}
+
+// OnEnter 不做任何处理
+func (BasePage) OnEnter(nav *Navigator) error { return nil }
+
+// OnExit 不做任何处理
+func (BasePage) OnExit(nav *Navigator) {}
+
+// ClickDevice 点击可点击区域产生的按键事件的设备标识，用于与键盘输入区分
+const ClickDevice = "pointer"
+
+// ClickEvent 把点击可点击区域得到的按键转换为按键事件
+func ClickEvent(key byte) input.KeyEvent {
+	return input.KeyEvent{Code: input.KeyRune, Rune: rune(key), Pressed: true, Time: time.Now(), Device: ClickDevice}
+}
+
+// Navigator 页面导航栈
+// 栈底是首页，不会被弹出；栈顶是当前显示的页面。压入和弹出页面只调整栈并标记需要重绘，
+// 由Flush统一绘制，这样一次按键引起的多次跳转只绘制最终的页面
+type Navigator struct {
+	renderer *MenuRenderer
+	stack    []Page
+	dirty    bool // 栈顶页面需要重绘
+
+	nextRefresh time.Time // 栈顶页面下次自动刷新的时间，为零值时不自动刷新
+	switched    int       // 绘制前切换了页面：1为进入新页面，-1为返回，0为没有切换
+
+	// AfterRender 每次绘制页面后调用，如重新绘制鼠标指针，可以为nil
+	AfterRender func()
+	// GlobalHints 返回当前可用的全局热键提示，追加在页面自己的提示之后显示在页脚，
+	// 为nil时只显示页面自己的提示
+	GlobalHints func() []Hint
+	// ShowFooter 是否在页面底部显示按键提示
+	ShowFooter bool
+}
+
+// NewNavigator 创建导航栈
+// 参数mr: 绘制页面使用的菜单渲染器
+// 参数root: 首页，始终位于栈底
+func NewNavigator(mr *MenuRenderer, root Page) *Navigator {
+	return &Navigator{renderer: mr, stack: []Page{root}, dirty: true}
+}
+
+// Start 进入首页并绘制
+func (n *Navigator) Start() error {
+	n.scheduleRefresh(time.Now())
+	if err := n.stack[0].OnEnter(n); err != nil {
+		return err
+	}
+	return n.Render()
+}
+
+// Renderer 返回绘制页面使用的菜单渲染器
+func (n *Navigator) Renderer() *MenuRenderer {
+	return n.renderer
+}
+
+// Top 返回当前显示的页面
+func (n *Navigator) Top() Page {
+	return n.stack[len(n.stack)-1]
+}
+
+// Depth 返回栈中的页面数，只有首页时为1
+func (n *Navigator) Depth() int {
+	return len(n.stack)
+}
+
+// AtRoot 返回当前是否在首页
+func (n *Navigator) AtRoot() bool {
+	return len(n.stack) == 1
+}
+
+// Push 压入新页面，使其成为当前页面
+func (n *Navigator) Push(p Page) error {
+	n.Top().OnExit(n)
+	n.stack = append(n.stack, p)
+	n.dirty = true
+	n.switched = 1
+	n.scheduleRefresh(time.Now())
+	return p.OnEnter(n)
+}
+
+// Pop 返回上一页，已在首页时不做任何处理
+func (n *Navigator) Pop() error {
+	if n.AtRoot() {
+		return nil
+	}
+	n.Top().OnExit(n)
+	n.stack[len(n.stack)-1] = nil
+	n.stack = n.stack[:len(n.stack)-1]
+	n.dirty = true
+	n.switched = -1
+	n.scheduleRefresh(time.Now())
+	return n.Top().OnEnter(n)
+}
+
+// PopToRoot 弹出所有页面回到首页，中间的页面只调用OnExit，不会重新进入
+func (n *Navigator) PopToRoot() error {
+	if n.AtRoot() {
+		return nil
+	}
+	n.Top().OnExit(n)
+	for i := 1; i < len(n.stack); i++ {
+		n.stack[i] = nil
+	}
+	n.stack = n.stack[:1]
+	n.dirty = true
+	n.switched = -1
+	n.scheduleRefresh(time.Now())
+	return n.Top().OnEnter(n)
+}
+
+// Replace 用新页面替换当前页面，下层页面不会重新进入
+// 已在首页时等同于Push
+func (n *Navigator) Replace(p Page) error {
+	if n.AtRoot() {
+		return n.Push(p)
+	}
+	n.Top().OnExit(n)
+	n.stack[len(n.stack)-1] = p
+	n.dirty = true
+	n.switched = 1
+	n.scheduleRefresh(time.Now())
+	return p.OnEnter(n)
+}
+
+// Invalidate 标记当前页面需要重绘，下次Flush时绘制
+func (n *Navigator) Invalidate() {
+	n.dirty = true
+}
+
+// HandleKey 把按键交给当前页面处理，之后绘制需要重绘的页面
+// 抬起事件被忽略
+func (n *Navigator) HandleKey(ev input.KeyEvent) error {
+	if !ev.Pressed {
+		return nil
+	}
+	err := n.Top().HandleKey(n, ev)
+	if flushErr := n.Flush(); err == nil {
+		err = flushErr
+	}
+	return err
+}
+
+// HandleClick 处理屏幕上的点击，点中可点击区域时等同于按下对应的按键
+// 返回是否点中了可点击区域
+func (n *Navigator) HandleClick(x, y int) (bool, error) {
+	key, ok := n.renderer.HitTest(x, y)
+	if !ok {
+		return false, nil
+	}
+	return true, n.HandleKey(ClickEvent(key))
+}
+
+// Flush 当前页面需要重绘时绘制
+func (n *Navigator) Flush() error {
+	if !n.dirty {
+		return nil
+	}
+	return n.Render()
+}
+
+// Ticker 需要定时更新的页面，如仪表盘
+// 主循环每秒调用一次当前页面的Tick，页面可以只重绘变化的部分，不必整页重绘
+type Ticker interface {
+	Tick(nav *Navigator, now time.Time) error
+}
+
+// Tick 当前页面实现了Ticker时调用其Tick，实现了AutoRefresher且到了刷新时间时调用其Refresh，
+// 之后绘制需要重绘的页面
+func (n *Navigator) Tick(now time.Time) error {
+	var err error
+	if t, ok := n.Top().(Ticker); ok {
+		err = t.Tick(n, now)
+	}
+	if err == nil {
+		err = n.autoRefresh(now)
+	}
+	if flushErr := n.Flush(); err == nil {
+		err = flushErr
+	}
+	return err
+}
+
+// Render 立即重绘当前页面
+// 切换了页面且设置了过渡效果时，先在后备缓冲区中绘制新页面，再逐帧过渡到新页面；全屏页面不使用过渡效果
+func (n *Navigator) Render() error {
+	n.dirty = false
+	top := n.Top()
+	// 绘制前设置页眉和页脚，页面据此为它们留出空间；绘制后重新取得提示，
+	// 因为有的提示取决于绘制结果，如内容是否超过一屏
+	_, full := top.(fullScreener)
+	switched := n.switched
+	n.switched = 0
+	animate := switched != 0 && !full && n.renderer.beginTransition()
+	n.renderer.setHeaderVisible(!full)
+	n.renderer.setFooter(n.hints(top))
+	err := top.Render(n.renderer)
+	if err == nil {
+		err = n.renderer.drawHeader()
+	}
+	if err == nil {
+		n.renderer.setFooter(n.hints(top))
+		err = n.renderer.drawFooter()
+	}
+	if animate {
+		n.renderer.finishTransition(switched < 0)
+	}
+	n.renderer.setFooter(nil)
+	if n.AfterRender != nil {
+		n.AfterRender()
+	}
+	return err
+}
+
+// hints 返回页面在页脚显示的按键提示：页面自己的提示在前，全局热键在后
+func (n *Navigator) hints(p Page) []Hint {
+	if !n.ShowFooter {
+		return nil
+	}
+	if _, ok := p.(fullScreener); ok {
+		return nil
+	}
+	var hints []Hint
+	if h, ok := p.(Hinter); ok {
+		hints = append(hints, h.Hints()...)
+	}
+	if n.GlobalHints != nil {
+		hints = append(hints, n.GlobalHints()...)
+	}
+	return hints
+}
@@ -0,0 +1,137 @@
+package menu
+
+import (
+	"image"
+	"image/draw"
+	"sync"
+	"time"
+
+	"go-framebuffer-console/pkg/font"
+)
+
+// 实时输出画面的参数
+const (
+	outputKeepLines = 1000    // 最多保留的输出行数，超过时丢弃最早的行
+	outputFill      = 1 << 20 // 输出区域测量的高度，由布局裁剪为页面剩余的高度
+)
+
+// outputPane 占满页面剩余高度的输出区域，内容增加后始终显示最后一屏
+type outputPane struct {
+	view *ScrollView
+}
+
+// Measure 输出区域占满剩余的宽度和高度
+func (p *outputPane) Measure(r *font.Renderer, width int) image.Point {
+	return image.Pt(width, outputFill)
+}
+
+// Draw 滚动到最后一行后绘制
+func (p *outputPane) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	p.view.Offset = len(p.view.Lines) * outputFill // 由ScrollView限制到最大值
+	return p.view.Draw(r, dst, bounds)
+}
+
+// mirrorText 文本镜像输出绘制时已有的全部输出
+func (p *outputPane) mirrorText() []string {
+	return p.view.mirrorText()
+}
+
+// OutputView 执行外部命令期间显示的实时输出画面
+// 画面由标题、转圈指示器和命令输出组成，输出超过一屏时自动滚动到最后一行。
+// 与BusyIndicator一样由后台goroutine绘制，命令本身可以阻塞调用方；Append只记录新的行，
+// 由下一帧动画重绘输出区域，命令输出很快时也不会因逐行绘制而变慢
+type OutputView struct {
+	mr      *MenuRenderer
+	mu      sync.Mutex
+	layout  *Layout
+	spinner *Spinner
+	pane    *outputPane
+	dirty   bool // 有新的输出尚未绘制
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// StartOutput 显示实时输出画面并开始播放动画
+// 参数title: 画面标题，如菜单项的名称
+// 参数status: 显示在转圈指示器后面的说明，如正在执行的命令
+func (mr *MenuRenderer) StartOutput(title, status string) *OutputView {
+	view := NewScrollView(nil, nil)
+	view.Wrap = true
+	o := &OutputView{
+		mr:      mr,
+		spinner: &Spinner{Text: status},
+		pane:    &outputPane{view: view},
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	o.layout = mr.NewLayout(&Label{Text: title, Color: theme.Accent}, NewSeparator(), o.spinner, o.pane)
+	// 绘制失败只影响输出显示，不中断命令
+	if mr.RenderLayout(o.layout) == nil {
+		_ = mr.drawHeader()
+	}
+	go o.animate()
+	return o
+}
+
+// Append 追加一行输出，可以在任意goroutine中调用
+// 参数line: 输出的一行，不含换行符
+func (o *OutputView) Append(line string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	view := o.pane.view
+	view.Lines = append(view.Lines, line)
+	if n := len(view.Lines) - outputKeepLines; n > 0 {
+		view.Lines = append(view.Lines[:0], view.Lines[n:]...)
+	}
+	o.dirty = true
+}
+
+// Lines 返回目前保留的全部输出
+func (o *OutputView) Lines() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]string(nil), o.pane.view.Lines...)
+}
+
+// animate 按固定间隔推进转圈指示器，有新的输出时重绘输出区域
+func (o *OutputView) animate() {
+	defer close(o.done)
+	ticker := time.NewTicker(busyFrameRate)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-o.stop:
+			return
+		case <-ticker.C:
+			o.mu.Lock()
+			o.spinner.Frame++
+			_ = o.layout.RedrawWidget(o.mr.fb, o.spinner)
+			if o.dirty {
+				o.dirty = false
+				_ = o.layout.RedrawWidget(o.mr.fb, o.pane)
+			}
+			o.mu.Unlock()
+		}
+	}
+}
+
+// Stop 停止动画，等待后台goroutine退出后返回，之后可以正常绘制其它页面
+func (o *OutputView) Stop() {
+	close(o.stop)
+	<-o.done
+}
+
+// NewCommandResultPage 创建显示命令执行结果的页面
+// 第一行以级别对应的颜色显示退出状态，之后是命令的输出，超过一屏时可以滚动
+// 参数level: 消息级别，命令成功时为LevelSuccess，失败时为LevelError
+// 参数status: 退出状态的说明，如"退出状态: 0"
+// 参数output: 命令的输出
+func NewCommandResultPage(level MessageLevel, status string, output []string) *TextPage {
+	lines := append([]string{status, ""}, output...)
+	styles := []font.LineStyle{{Color: level.Color()}}
+	return NewTextPage(func(mr *MenuRenderer) *Layout {
+		mr.alert(level)
+		return mr.NewLayout(NewMessageBox(level, lines, styles))
+	})
+}
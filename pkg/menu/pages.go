@@ -0,0 +1,534 @@
+package menu
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"go-framebuffer-console/pkg/font"
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/input"
+	"go-framebuffer-console/pkg/system"
+)
+
+// MenuItem 菜单中的一个选项
+type MenuItem struct {
+	Text   string                     // 显示的文字，如"1. 查看网卡信息"
+	Key    byte                       // 直接选择该项的快捷键
+	Action func(nav *Navigator) error // 选择该项时执行，可以压入新页面
+}
+
+// MenuPage 选项菜单页面
+// 方向键移动高亮条、回车执行，快捷键直接执行对应的选项，q、ESC或退格返回上一页；
+// 页面对象保存在导航栈中，从子页面返回时高亮条停留在上次选择的选项上
+type MenuPage struct {
+	BasePage
+	Title string     // 标题
+	Hint  string     // 底部的操作提示，点击等同于返回
+	Items []MenuItem // 选项
+
+	list *List
+}
+
+// NewMenuPage 创建选项菜单页面
+// 参数title: 标题
+// 参数hint: 底部的操作提示
+// 参数items: 选项
+func NewMenuPage(title, hint string, items ...MenuItem) *MenuPage {
+	list := &List{Selectable: true}
+	for _, item := range items {
+		list.Items = append(list.Items, ListItem{Text: item.Text, Key: item.Key})
+	}
+	return &MenuPage{Title: title, Hint: hint, Items: items, list: list}
+}
+
+// Render 绘制标题、选项列表和操作提示
+func (p *MenuPage) Render(mr *MenuRenderer) error {
+	return mr.RenderLayout(mr.NewLayout(
+		NewSeparator(),
+		NewLabel(p.Title),
+		NewSeparator(),
+		p.list,
+		NewSeparator(),
+		&Label{Text: p.Hint, Key: 'q', Wrap: true},
+	))
+}
+
+// Hints 页脚的按键提示
+func (p *MenuPage) Hints() []Hint {
+	return []Hint{
+		{Key: i18n.Translate("方向键"), Text: i18n.Translate("选择")},
+		{Key: "Enter", Text: i18n.Translate("确认")},
+		{Key: "Q", Text: i18n.Translate("返回")},
+	}
+}
+
+// HandleKey 移动高亮条、执行选项或返回上一页
+func (p *MenuPage) HandleKey(nav *Navigator, ev input.KeyEvent) error {
+	switch ev.Code {
+	case input.KeyUp:
+		p.list.Move(-1)
+		nav.Invalidate()
+		return nil
+	case input.KeyDown:
+		p.list.Move(1)
+		nav.Invalidate()
+		return nil
+	case input.KeyHome:
+		p.list.Selected = 0
+		nav.Invalidate()
+		return nil
+	case input.KeyEnd:
+		p.list.Selected = len(p.list.Items) - 1
+		nav.Invalidate()
+		return nil
+	}
+
+	key := ev.Byte()
+	switch key {
+	case '\r':
+		key, _ = p.list.SelectedKey()
+	case 'q', 'Q', 27, 0x7F: // q, Q, ESC, 退格（数字小键盘上用于返回）
+		return nav.Pop()
+	}
+	for _, item := range p.Items {
+		if key != 0 && item.Key == key {
+			p.list.Select(key)
+			nav.Invalidate()
+			if item.Action == nil {
+				return nil
+			}
+			return item.Action(nav)
+		}
+	}
+	return nil // 忽略其他键
+}
+
+// TextPage 只读的信息页面
+// 方向键和翻页键滚动页面中的长文本，其它按键返回上一页；
+// 内容不超过一屏时任意键都返回上一页
+type TextPage struct {
+	BasePage
+	build  func(mr *MenuRenderer) *Layout
+	layout *Layout
+}
+
+// NewTextPage 创建信息页面
+// 参数build: 首次绘制时调用，组合页面内容；之后重绘时复用，保留滚动位置
+func NewTextPage(build func(mr *MenuRenderer) *Layout) *TextPage {
+	return &TextPage{build: build}
+}
+
+// NewMessagePage 创建显示一段消息的页面
+// 参数message: 消息文本，可包含多行
+func NewMessagePage(message string) *TextPage {
+	return NewStyledMessagePage(strings.Split(message, "\n"), nil)
+}
+
+// NewStyledMessagePage 创建带有逐行颜色的消息页面
+// 参数lines: 消息文本行
+// 参数styles: 与lines一一对应的行样式，未指定颜色的行使用主题的文字颜色
+func NewStyledMessagePage(lines []string, styles []font.LineStyle) *TextPage {
+	return NewTextPage(func(mr *MenuRenderer) *Layout {
+		return mr.NewLayout(newMessageView(lines, styles))
+	})
+}
+
+// NewLevelMessagePage 创建以带级别的消息框显示一段消息的页面
+// 错误级别的消息在页面首次绘制时发出提示音，之后滚动重绘时不再重复
+// 参数level: 消息级别
+// 参数message: 消息文本，可包含多行
+func NewLevelMessagePage(level MessageLevel, message string) *TextPage {
+	return NewTextPage(func(mr *MenuRenderer) *Layout {
+		mr.alert(level)
+		return mr.NewLayout(NewMessageBox(level, strings.Split(message, "\n"), nil))
+	})
+}
+
+// Render 绘制页面内容
+func (p *TextPage) Render(mr *MenuRenderer) error {
+	if p.layout == nil {
+		p.layout = p.build(mr)
+	}
+	return mr.RenderLayout(p.layout)
+}
+
+// Hints 页脚的按键提示，内容超过一屏时提示可以滚动
+func (p *TextPage) Hints() []Hint {
+	var hints []Hint
+	if p.layout != nil {
+		if sv := p.layout.scrollView(); sv != nil && sv.Scrollable() {
+			hints = append(hints, Hint{Key: i18n.Translate("方向键"), Text: i18n.Translate("滚动")})
+		}
+	}
+	return append(hints, Hint{Key: i18n.Translate("任意键"), Text: i18n.Translate("返回")})
+}
+
+// HandleKey 滚动长文本或返回上一页
+func (p *TextPage) HandleKey(nav *Navigator, ev input.KeyEvent) error {
+	if p.layout != nil {
+		if sv := p.layout.scrollView(); sv != nil && sv.Scrollable() {
+			if moved, ok := scrollKey(sv, ev.Code); ok {
+				if moved {
+					nav.Invalidate()
+				}
+				return nil
+			}
+		}
+	}
+	return nav.Pop()
+}
+
+// NetworkInfoPage 网卡信息页面
+// 网卡较多时分页显示，左右方向键翻页；上下方向键滚动IPv6地址，其它按键返回上一页
+type NetworkInfoPage struct {
+	BasePage
+	// Reload 刷新时重新获取网卡信息，为nil时只重绘已有的内容
+	Reload func() ([]system.NetworkInterface, error)
+	// Interval 自动刷新的间隔，不大于0时只在手动刷新时更新
+	Interval time.Duration
+	// Settings 获取网关、路由和DNS服务器，首次绘制和每次刷新时调用；为nil时不显示这些内容
+	Settings func() (*system.NetworkSettings, error)
+
+	interfaces  []system.NetworkInterface
+	settings    *system.NetworkSettings
+	settingsErr error
+	loaded      bool // 是否已调用过Settings
+	perPage     int
+	page        int // 当前页的下标，从0开始
+	layout      *Layout
+}
+
+// NewNetworkInfoPage 创建网卡信息页面
+// 参数interfaces: 要显示的网卡
+// 参数perPage: 每页显示的网卡数，不大于0时全部显示在一页
+func NewNetworkInfoPage(interfaces []system.NetworkInterface, perPage int) *NetworkInfoPage {
+	return &NetworkInfoPage{interfaces: interfaces, perPage: perPage}
+}
+
+// pageSize 返回每页的网卡数，不分页时为全部网卡数；刷新后网卡数可能变化，因此每次重新计算
+func (p *NetworkInfoPage) pageSize() int {
+	if p.perPage <= 0 {
+		return len(p.interfaces)
+	}
+	return p.perPage
+}
+
+// pages 返回总页数，没有网卡时为1
+func (p *NetworkInfoPage) pages() int {
+	if len(p.interfaces) == 0 {
+		return 1
+	}
+	return (len(p.interfaces) + p.pageSize() - 1) / p.pageSize()
+}
+
+// Render 绘制当前页的网卡，翻页后重新组合页面，同一页重绘时保留滚动位置
+func (p *NetworkInfoPage) Render(mr *MenuRenderer) error {
+	if p.Settings != nil && !p.loaded {
+		p.settings, p.settingsErr = p.Settings()
+		p.loaded = true
+	}
+	if p.layout == nil {
+		start := p.page * p.pageSize()
+		end := start + p.pageSize()
+		if end > len(p.interfaces) {
+			end = len(p.interfaces)
+		}
+		p.layout = mr.networkInfoLayout(p.interfaces[start:end], p.settings, p.settingsErr, p.page+1, p.pages())
+	}
+	return mr.RenderLayout(p.layout)
+}
+
+// Refresh 重新获取网卡信息，网卡减少后当前页超出范围时停在最后一页
+func (p *NetworkInfoPage) Refresh(nav *Navigator) error {
+	if p.Reload == nil {
+		return nil
+	}
+	interfaces, err := p.Reload()
+	if err != nil {
+		return err
+	}
+	p.interfaces = interfaces
+	if p.page >= p.pages() {
+		p.page = p.pages() - 1
+	}
+	p.loaded = false
+	p.layout = nil
+	nav.Invalidate()
+	return nil
+}
+
+// RefreshInterval 返回自动刷新的间隔
+func (p *NetworkInfoPage) RefreshInterval() time.Duration {
+	return p.Interval
+}
+
+// interfaceStatusColor 网卡状态列的颜色：已启用并接通（Up, Running）为正常色，
+// 已启用但未接通（如未接网线）为警告色，未启用（Down）为错误色
+func interfaceStatusColor(status string) color.Color {
+	switch {
+	case strings.Contains(status, "Running"):
+		return theme.Success
+	case strings.Contains(status, "Up"):
+		return theme.Warning
+	}
+	return theme.Error
+}
+
+// linkColor 链路列的颜色：接通为正常色，半双工（常见于两端协商不一致）为警告色，未接通为错误色
+func linkColor(link string) color.Color {
+	switch {
+	case !strings.HasSuffix(link, "link up"):
+		return theme.Error
+	case strings.Contains(link, "Half"):
+		return theme.Warning
+	}
+	return theme.Success
+}
+
+// Hints 页脚的按键提示，多于一页时提示可以翻页
+func (p *NetworkInfoPage) Hints() []Hint {
+	var hints []Hint
+	if p.pages() > 1 {
+		hints = append(hints, Hint{Key: i18n.Translate("左右键"), Text: i18n.Translate("翻页")})
+	}
+	if p.layout != nil {
+		if sv := p.layout.scrollView(); sv != nil && sv.Scrollable() {
+			hints = append(hints, Hint{Key: i18n.Translate("上下键"), Text: i18n.Translate("滚动")})
+		}
+	}
+	return append(hints, Hint{Key: i18n.Translate("任意键"), Text: i18n.Translate("返回")})
+}
+
+// Help 说明网卡表格各列、流量曲线、IPv6地址、网关、DNS服务器和路由的含义
+func (p *NetworkInfoPage) Help() []HelpItem {
+	return []HelpItem{
+		{Name: i18n.Translate("接口"), Text: i18n.Translate("物理网卡的名称，不包括虚拟网卡")},
+		{Name: i18n.Translate("状态"), Text: i18n.Translate("绿色的Up, Running表示已启用并接通，黄色的Up表示已启用但未接网线，红色的Down表示未启用")},
+		{Name: i18n.Translate("驱动"), Text: i18n.Translate("网卡使用的内核驱动，与ethtool -i显示的相同")},
+		{Name: i18n.Translate("链路"), Text: i18n.Translate("协商的速率、双工模式和是否接通网线；半双工显示为警告色，未接通显示为错误色")},
+		{Name: i18n.Translate("MAC地址"), Text: i18n.Translate("网卡的硬件地址")},
+		{Name: i18n.Translate("IPv4地址"), Text: i18n.Translate("网卡的第一个IPv4地址")},
+		{Name: i18n.Translate("已接收/已发送"), Text: i18n.Translate("网卡启用以来累计收发的字节数，重新加载驱动后从0开始")},
+		{Name: i18n.Translate("流量曲线"), Text: i18n.Translate("最近5分钟的接收和发送速率，每5秒采样一次，右侧为最近一次采样的速率")},
+		{Name: i18n.Translate("IPv6地址"), Text: i18n.Translate("各网卡的全部IPv6地址，较多时可以滚动查看")},
+		{Name: i18n.Translate("默认网关"), Text: i18n.Translate("跃点数最小的默认路由的网关和出口网卡，没有默认路由时显示为错误色")},
+		{Name: i18n.Translate("DNS服务器"), Text: i18n.Translate("/etc/resolv.conf中的nameserver，使用systemd-resolved时显示其上游服务器")},
+		{Name: i18n.Translate("路由"), Text: i18n.Translate("/proc/net/route中的全部IPv4路由，格式与ip route相同")},
+	}
+}
+
+// HandleKey 翻页、滚动或返回上一页，已在第一页或最后一页时左右方向键不做任何处理
+func (p *NetworkInfoPage) HandleKey(nav *Navigator, ev input.KeyEvent) error {
+	switch ev.Code {
+	case input.KeyLeft, input.KeyRight:
+		page := p.page + 1
+		if ev.Code == input.KeyLeft {
+			page = p.page - 1
+		}
+		if page >= 0 && page < p.pages() {
+			p.page = page
+			p.layout = nil
+			nav.Invalidate()
+		}
+		return nil
+	}
+	if p.layout != nil {
+		if sv := p.layout.scrollView(); sv != nil && sv.Scrollable() {
+			if moved, ok := scrollKey(sv, ev.Code); ok {
+				if moved {
+					nav.Invalidate()
+				}
+				return nil
+			}
+		}
+	}
+	return nav.Pop()
+}
+
+// QRCodeEntry 扫码页面中的一个二维码
+type QRCodeEntry struct {
+	Title   string // 名称，如"管理页面"
+	Content string // 编码内容
+	Text    string // 显示在二维码上方的说明，如网址，为空时不显示
+}
+
+// QRCodesPage 扫码页面
+// 一次显示一个二维码，以屏幕能放下的最大尺寸显示，便于远距离扫码；左右方向键切换，其它按键返回上一页
+type QRCodesPage struct {
+	BasePage
+	codes []QRCodeEntry
+	index int // 当前显示的二维码
+}
+
+// NewQRCodesPage 创建扫码页面
+// 参数codes: 要显示的二维码，按顺序切换
+func NewQRCodesPage(codes []QRCodeEntry) *QRCodesPage {
+	return &QRCodesPage{codes: codes}
+}
+
+// Render 绘制当前的二维码，纠错等级和留白与首页二维码相同
+func (p *QRCodesPage) Render(mr *MenuRenderer) error {
+	if len(p.codes) == 0 {
+		return mr.RenderLayout(mr.NewLayout(NewMessageBox(LevelInfo, []string{i18n.Translate("没有可以显示的二维码")}, nil)))
+	}
+	code := p.codes[p.index]
+	title := i18n.Translate(code.Title)
+	if len(p.codes) > 1 {
+		title += fmt.Sprintf("  (%d/%d)", p.index+1, len(p.codes))
+	}
+	layout := mr.NewLayout(&Label{Text: title, Color: theme.Accent}, NewSeparator())
+	if code.Text != "" {
+		layout.Add(&Label{Text: code.Text, Align: AlignCenter, Wrap: true})
+	}
+	layout.Add(&Spacer{Height: lineStep(mr.renderer) / 2}, &QRView{Content: code.Content, Level: mr.qrCode.Level, QuietZone: mr.qrCode.QuietZone})
+	return mr.RenderLayout(layout)
+}
+
+// Hints 页脚的按键提示，有多个二维码时提示可以切换
+func (p *QRCodesPage) Hints() []Hint {
+	var hints []Hint
+	if len(p.codes) > 1 {
+		hints = append(hints, Hint{Key: i18n.Translate("左右键"), Text: i18n.Translate("切换")})
+	}
+	return append(hints, Hint{Key: i18n.Translate("任意键"), Text: i18n.Translate("返回")})
+}
+
+// HandleKey 切换二维码或返回上一页，两端回绕
+func (p *QRCodesPage) HandleKey(nav *Navigator, ev input.KeyEvent) error {
+	if n := len(p.codes); n > 1 {
+		switch ev.Code {
+		case input.KeyLeft:
+			p.index = (p.index + n - 1) % n
+			nav.Invalidate()
+			return nil
+		case input.KeyRight:
+			p.index = (p.index + 1) % n
+			nav.Invalidate()
+			return nil
+		}
+	}
+	return nav.Pop()
+}
+
+// scrollKey 按方向键和翻页键滚动文本
+// 返回位置是否改变，以及按键是否为滚动按键
+func scrollKey(sv *ScrollView, code input.Key) (moved, ok bool) {
+	switch code {
+	case input.KeyUp:
+		return sv.ScrollBy(-1), true
+	case input.KeyDown:
+		return sv.ScrollBy(1), true
+	case input.KeyPageUp:
+		return sv.ScrollPages(-1), true
+	case input.KeyPageDown:
+		return sv.ScrollPages(1), true
+	case input.KeyHome:
+		return sv.ScrollBy(-len(sv.rows)), true
+	case input.KeyEnd:
+		return sv.ScrollBy(len(sv.rows)), true
+	}
+	return false, false
+}
+
+// DialogPage 显示对话框并处理焦点的页面
+// 左右方向键或Tab切换焦点，回车选择焦点所在的按钮，按钮的快捷键直接选择，ESC或退格选择取消。
+// 带输入框时，焦点在输入框上的按键用于编辑文本，回车确认
+type DialogPage struct {
+	BasePage
+	Dialog *Dialog
+
+	// Validate 确认输入前检查输入内容，不通过时在输入框下方显示原因并继续编辑，可以为nil
+	Validate func(text string) error
+	// OnResult 选择按钮后调用，此时对话框已经弹出；取消时key为Dialog.Cancel
+	OnResult func(nav *Navigator, key byte, text string) error
+
+	editor *input.LineEditor
+}
+
+// NewDialogPage 创建对话框页面
+// 参数d: 对话框
+// 参数onResult: 选择按钮后调用，可以为nil
+func NewDialogPage(d *Dialog, onResult func(nav *Navigator, key byte, text string) error) *DialogPage {
+	return &DialogPage{Dialog: d, OnResult: onResult, editor: input.NewLineEditor(d.Text)}
+}
+
+// Render 在屏幕中央绘制对话框
+func (p *DialogPage) Render(mr *MenuRenderer) error {
+	p.Dialog.Text, p.Dialog.Cursor = p.editor.Text(), p.editor.Cursor()
+	return mr.RenderDialog(p.Dialog)
+}
+
+// Hints 页脚的按键提示
+func (p *DialogPage) Hints() []Hint {
+	return []Hint{
+		{Key: "Tab", Text: i18n.Translate("切换")},
+		{Key: "Enter", Text: i18n.Translate("确认")},
+		{Key: "Esc", Text: i18n.Translate("取消")},
+	}
+}
+
+// HandleKey 切换焦点、编辑输入或选择按钮
+func (p *DialogPage) HandleKey(nav *Navigator, ev input.KeyEvent) error {
+	d := p.Dialog
+	nav.Invalidate()
+
+	switch {
+	case ev.Code == input.KeyTab && ev.Modifiers&input.ModShift != 0:
+		d.FocusPrev()
+		return nil
+	case ev.Code == input.KeyTab:
+		d.FocusNext()
+		return nil
+	case d.InputFocused():
+		// 点击按钮产生的按键不作为输入内容
+		if key, ok := d.ButtonKey(ev.Byte()); ok && ev.Device == ClickDevice {
+			return p.finish(nav, key)
+		}
+		switch p.editor.HandleKey(ev) {
+		case input.EditAccept:
+			return p.finish(nav, ButtonOK.Key)
+		case input.EditCancel:
+			return p.finish(nav, d.Cancel)
+		}
+		return nil
+	}
+
+	switch {
+	case ev.Code == input.KeyLeft || ev.Code == input.KeyUp:
+		d.FocusPrev()
+	case ev.Code == input.KeyRight || ev.Code == input.KeyDown:
+		d.FocusNext()
+	case ev.Code == input.KeyEscape || ev.Code == input.KeyBackspace:
+		return p.finish(nav, d.Cancel)
+	case ev.Code == input.KeyEnter:
+		if key, ok := d.FocusedKey(); ok {
+			return p.finish(nav, key)
+		}
+	default:
+		if key, ok := d.ButtonKey(ev.Byte()); ok {
+			return p.finish(nav, key)
+		}
+	}
+	return nil
+}
+
+// finish 选择了按钮：检查输入后弹出对话框并通知调用方
+func (p *DialogPage) finish(nav *Navigator, key byte) error {
+	text := p.editor.Text()
+	if p.Dialog.Input && key != p.Dialog.Cancel && p.Validate != nil {
+		if err := p.Validate(text); err != nil {
+			p.Dialog.Status = err.Error()
+			p.Dialog.Focus = -1
+			return nil
+		}
+	}
+	if err := nav.Pop(); err != nil {
+		return err
+	}
+	if p.OnResult == nil {
+		return nil
+	}
+	return p.OnResult(nav, key, text)
+}
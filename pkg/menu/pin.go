@@ -0,0 +1,273 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"strings"
+
+	"go-framebuffer-console/pkg/font"
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/input"
+)
+
+// PIN键盘上删除和确认按钮的按键，与键盘上的退格键和回车键相同
+const (
+	pinKeyDelete byte = 0x7F
+	pinKeyEnter  byte = '\r'
+)
+
+// pinKeypad 数字键盘的排列，从上到下每行三个按键
+var pinKeypad = []byte{'1', '2', '3', '4', '5', '6', '7', '8', '9', pinKeyDelete, '0', pinKeyEnter}
+
+// PinPad PIN输入框：居中显示的对话框，输入的数字以*代替，下方是可以点击的数字键盘
+// 数字键盘方便只有触摸屏或前面板小键盘的设备输入，键盘上的数字键、退格键和回车键同样有效
+type PinPad struct {
+	Title  string // 标题，如要执行的操作"重启设备"
+	Prompt string // 提示，如"请输入管理员PIN"
+	Length int    // 最多可以输入的位数
+	Status string // 输入框下方的提示，如PIN错误的原因，以错误色显示
+
+	digits []byte
+}
+
+// NewPinPad 创建PIN输入框
+// 参数title: 标题
+// 参数length: 最多可以输入的位数
+func NewPinPad(title string, length int) *PinPad {
+	return &PinPad{Title: title, Prompt: i18n.Translate("请输入管理员PIN"), Length: length}
+}
+
+// Add 追加一位数字，已达到最多位数时忽略
+func (p *PinPad) Add(digit byte) {
+	if len(p.digits) < p.Length {
+		p.digits = append(p.digits, digit)
+	}
+}
+
+// Delete 删除最后一位数字
+func (p *PinPad) Delete() {
+	if len(p.digits) > 0 {
+		p.digits = p.digits[:len(p.digits)-1]
+	}
+}
+
+// Clear 清空已输入的数字
+func (p *PinPad) Clear() {
+	p.digits = p.digits[:0]
+}
+
+// Value 返回已输入的数字
+func (p *PinPad) Value() string {
+	return string(p.digits)
+}
+
+// keyButton 返回数字键盘上按键对应的按钮
+func keyButton(key byte) DialogButton {
+	switch key {
+	case pinKeyDelete:
+		return DialogButton{Text: i18n.Translate("删除"), Key: key}
+	case pinKeyEnter:
+		return DialogButton{Text: i18n.Translate("确定"), Key: key}
+	}
+	return DialogButton{Text: string(key), Key: key}
+}
+
+// keySize 返回数字键盘上每个按钮的尺寸，所有按钮一样大
+func (p *PinPad) keySize(r *font.Renderer) image.Point {
+	var size image.Point
+	for _, key := range pinKeypad {
+		if s := buttonSize(r, keyButton(key)); s.X > size.X {
+			size = s
+		}
+	}
+	return size
+}
+
+// keypadSize 返回数字键盘的尺寸
+func (p *PinPad) keypadSize(r *font.Renderer) image.Point {
+	key := p.keySize(r)
+	rows := len(pinKeypad) / 3
+	return image.Pt(3*key.X+2*dialogButtonGap, rows*key.Y+(rows-1)*dialogButtonGap)
+}
+
+// Measure 返回对话框外框的尺寸
+func (p *PinPad) Measure(r *font.Renderer, width int) image.Point {
+	step := lineStep(r)
+	w := p.keypadSize(r).X
+	for _, text := range []string{p.Title, p.Prompt, p.Status} {
+		if tw, _ := r.MeasureString(text); tw > w {
+			w = tw
+		}
+	}
+	w += 2 * dialogPadding
+	if w > width {
+		w = width
+	}
+	h := 2*dialogPadding + step + defaultLineSpacing + step + inputHeight(r) + step + step/2 + p.keypadSize(r).Y
+	return image.Pt(w, h)
+}
+
+// box 返回对话框外框在区域中的位置
+func (p *PinPad) box(r *font.Renderer, bounds image.Rectangle) image.Rectangle {
+	size := p.Measure(r, bounds.Dx())
+	x := alignX(AlignCenter, bounds, size.X)
+	return image.Rect(x, bounds.Min.Y, x+size.X, bounds.Min.Y+size.Y)
+}
+
+// keyRects 返回数字键盘各按钮的位置，键盘在对话框底部居中排列
+func (p *PinPad) keyRects(r *font.Renderer, inner image.Rectangle) []image.Rectangle {
+	key := p.keySize(r)
+	pad := p.keypadSize(r)
+	x0 := alignX(AlignCenter, inner, pad.X)
+	y0 := inner.Max.Y - pad.Y
+	rects := make([]image.Rectangle, len(pinKeypad))
+	for i := range pinKeypad {
+		x := x0 + (i%3)*(key.X+dialogButtonGap)
+		y := y0 + (i/3)*(key.Y+dialogButtonGap)
+		rects[i] = image.Rect(x, y, x+key.X, y+key.Y)
+	}
+	return rects
+}
+
+// Draw 绘制标题、提示、以*代替数字的输入框、错误提示和数字键盘
+func (p *PinPad) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	box := p.box(r, bounds)
+	draw.Draw(dst, box.Intersect(dst.Bounds()), &image.Uniform{theme.Background}, image.Point{}, draw.Src)
+	drawOutline(dst, box, theme.Foreground)
+
+	inner := box.Inset(dialogPadding)
+	step := lineStep(r)
+	y := inner.Min.Y
+
+	if err := r.RenderTextInto(dst, inner.Min.X, y, r.TruncateToWidth(p.Title, inner.Dx()), theme.Accent); err != nil {
+		return fmt.Errorf("绘制PIN输入框失败: %v", err)
+	}
+	y += step
+	line := image.Rect(box.Min.X, y, box.Max.X, y+1)
+	draw.Draw(dst, line.Intersect(dst.Bounds()), &image.Uniform{theme.Line}, image.Point{}, draw.Src)
+	y += defaultLineSpacing
+
+	if err := r.RenderTextInto(dst, inner.Min.X, y, r.TruncateToWidth(p.Prompt, inner.Dx()), theme.Foreground); err != nil {
+		return fmt.Errorf("绘制PIN输入框失败: %v", err)
+	}
+	y += step
+
+	masked := strings.Repeat("*", len(p.digits))
+	field := image.Rect(inner.Min.X, y, inner.Max.X, y+inputHeight(r))
+	if err := drawInputBox(r, dst, field, masked, len(p.digits), true); err != nil {
+		return err
+	}
+	y = field.Max.Y
+
+	if p.Status != "" {
+		status := r.TruncateToWidth(p.Status, inner.Dx())
+		if err := r.RenderTextInto(dst, inner.Min.X, y+defaultLineSpacing, status, theme.Error); err != nil {
+			return fmt.Errorf("绘制PIN输入框失败: %v", err)
+		}
+	}
+
+	for i, rect := range p.keyRects(r, inner) {
+		if err := drawButton(r, dst, rect, keyButton(pinKeypad[i]), false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hitAreas 数字键盘的每个按钮可以点击
+func (p *PinPad) hitAreas(r *font.Renderer, bounds image.Rectangle) []HitArea {
+	inner := p.box(r, bounds).Inset(dialogPadding)
+	var areas []HitArea
+	for i, rect := range p.keyRects(r, inner) {
+		areas = append(areas, HitArea{Rect: rect, Key: pinKeypad[i]})
+	}
+	return areas
+}
+
+// mirrorText 文本镜像中同样以*代替已输入的数字
+func (p *PinPad) mirrorText() []string {
+	lines := []string{"[ " + p.Title + " ]", p.Prompt, "> " + strings.Repeat("*", len(p.digits))}
+	if p.Status != "" {
+		lines = append(lines, p.Status)
+	}
+	return lines
+}
+
+// PinPage 输入PIN的页面，用于保护重启、关机等危险操作
+// 数字键输入，退格键删除，回车键确认，ESC取消；PIN正确时弹出页面后执行操作，错误时清空输入并显示原因
+type PinPage struct {
+	BasePage
+	Pad *PinPad
+
+	// Check 检查输入的PIN，不通过时返回原因
+	Check func(pin string) error
+	// OnSuccess PIN正确时调用，此时页面已经弹出
+	OnSuccess func(nav *Navigator) error
+}
+
+// NewPinPage 创建输入PIN的页面
+// 参数title: 标题，如要执行的操作
+// 参数length: 最多可以输入的位数
+// 参数check: 检查输入的PIN
+// 参数onSuccess: PIN正确时调用
+func NewPinPage(title string, length int, check func(pin string) error, onSuccess func(nav *Navigator) error) *PinPage {
+	return &PinPage{Pad: NewPinPad(title, length), Check: check, OnSuccess: onSuccess}
+}
+
+// Render 在屏幕中央绘制PIN输入框
+func (p *PinPage) Render(mr *MenuRenderer) error {
+	return mr.RenderLayout(mr.centeredLayout(p.Pad))
+}
+
+// Hints 页脚的按键提示
+func (p *PinPage) Hints() []Hint {
+	return []Hint{
+		{Key: "0-9", Text: i18n.Translate("输入")},
+		{Key: "Enter", Text: i18n.Translate("确认")},
+		{Key: "Esc", Text: i18n.Translate("取消")},
+	}
+}
+
+// editingText 数字键属于输入内容，不作为全局热键
+func (p *PinPage) editingText() bool {
+	return true
+}
+
+// HandleKey 输入、删除数字，确认或取消
+func (p *PinPage) HandleKey(nav *Navigator, ev input.KeyEvent) error {
+	key := ev.Byte()
+	switch {
+	case key >= '0' && key <= '9':
+		p.Pad.Add(key)
+	case key == pinKeyDelete || key == '\b':
+		p.Pad.Delete()
+	case key == pinKeyEnter || key == '\n':
+		return p.submit(nav)
+	case key == 27 || key == 'q' || key == 'Q':
+		return nav.Pop()
+	default:
+		return nil
+	}
+	nav.Invalidate()
+	return nil
+}
+
+// submit 检查输入的PIN，正确时弹出页面并执行操作
+func (p *PinPage) submit(nav *Navigator) error {
+	nav.Invalidate()
+	if p.Check != nil {
+		if err := p.Check(p.Pad.Value()); err != nil {
+			p.Pad.Clear()
+			p.Pad.Status = err.Error()
+			return nil
+		}
+	}
+	if err := nav.Pop(); err != nil {
+		return err
+	}
+	if p.OnSuccess == nil {
+		return nil
+	}
+	return p.OnSuccess(nav)
+}
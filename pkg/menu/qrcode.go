@@ -0,0 +1,236 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"os"
+	"strings"
+
+	"go-framebuffer-console/pkg/font"
+	"go-framebuffer-console/pkg/i18n"
+	"go-framebuffer-console/pkg/system"
+	"rsc.io/qr"
+)
+
+// 二维码的默认参数
+const (
+	DefaultQRContent   = "{deviceID}" // 默认编码设备ID
+	DefaultQRQuietZone = 2            // 默认四周留白（模块数）
+	qrMaxModuleSize    = 8            // 首页自动缩放时每个模块的最大边长（像素）
+)
+
+// QRCode 首页二维码的参数
+type QRCode struct {
+	Content    string   // 编码内容的模板，可使用{deviceID}、{ip}、{hostname}、{url}变量
+	URL        string   // 模板中{url}的取值
+	Caption    string   // 二维码上方的说明文字，为空时使用默认说明
+	ModuleSize int      // 每个模块的边长（像素），0表示按首页剩余的空间自动缩放
+	QuietZone  int      // 四周留白（模块数）
+	Level      qr.Level // 纠错等级
+}
+
+// DefaultQRCode 返回与此前固定行为一致的参数：编码设备ID，M级纠错，按剩余空间缩放
+func DefaultQRCode() QRCode {
+	return QRCode{Content: DefaultQRContent, QuietZone: DefaultQRQuietZone, Level: qr.M}
+}
+
+// ParseQRLevel 解析纠错等级：L、M、Q、H（不区分大小写），等级越高越耐污损，但二维码越大
+func ParseQRLevel(s string) (qr.Level, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "L":
+		return qr.L, nil
+	case "M":
+		return qr.M, nil
+	case "Q":
+		return qr.Q, nil
+	case "H":
+		return qr.H, nil
+	}
+	return qr.M, fmt.Errorf("无效的二维码纠错等级: %q", s)
+}
+
+// SetQRCode 设置首页二维码的参数
+func (mr *MenuRenderer) SetQRCode(q QRCode) {
+	mr.qrCode = q
+	mr.InvalidateCache()
+}
+
+// qrContent 展开首页二维码编码内容的模板
+func (mr *MenuRenderer) qrContent(sysInfo *system.SystemInfo) (string, error) {
+	tmpl := mr.qrCode.Content
+	if tmpl == "" {
+		tmpl = DefaultQRContent
+	}
+	return mr.ExpandQRTemplate(tmpl, sysInfo)
+}
+
+// qrVariable 二维码内容模板中的一个变量
+type qrVariable struct {
+	name    string // 变量，如"{ip}"
+	value   string // 取值，为空表示没有取值
+	missing string // 模板用到该变量但没有取值时的错误提示
+}
+
+// ExpandQRTemplate 展开二维码内容的模板，替换{deviceID}、{ip}、{hostname}、{url}变量，
+// 以及用于资产登记的{vendor}、{model}、{serial}（BIOS中记录的厂商、型号和序列号，只在模板用到时读取）
+// 模板用到的变量没有取值时（如未获取到设备ID）返回错误，说明缺少的内容
+// 参数tmpl: 模板，如"http://{ip}"
+// 参数sysInfo: 提供设备ID和IP地址的系统信息
+func (mr *MenuRenderer) ExpandQRTemplate(tmpl string, sysInfo *system.SystemInfo) (string, error) {
+	deviceID := sysInfo.QianKunCloudID
+	if deviceID == "未获取到" {
+		deviceID = ""
+	}
+	hostname, _ := os.Hostname()
+	vars := []qrVariable{
+		{"{deviceID}", deviceID, "无法获取乾坤云设备ID"},
+		{"{ip}", sysInfo.IPAddress, "无法获取设备IP地址"},
+		{"{hostname}", hostname, "无法获取主机名"},
+		{"{url}", mr.qrCode.URL, "没有配置二维码的url"},
+	}
+	if strings.Contains(tmpl, "{vendor}") || strings.Contains(tmpl, "{model}") || strings.Contains(tmpl, "{serial}") {
+		hw, err := system.GetHardware()
+		if err != nil {
+			hw = &system.Hardware{}
+		}
+		vars = append(vars,
+			qrVariable{"{vendor}", hw.Vendor, "无法获取设备厂商"},
+			qrVariable{"{model}", hw.Product, "无法获取设备型号"},
+			qrVariable{"{serial}", hw.Serial, "无法获取设备序列号，需要root权限"},
+		)
+	}
+	content := tmpl
+	for _, v := range vars {
+		if !strings.Contains(content, v.name) {
+			continue
+		}
+		if v.value == "" {
+			return "", fmt.Errorf("%s", i18n.Translate(v.missing))
+		}
+		content = strings.ReplaceAll(content, v.name, v.value)
+	}
+	return content, nil
+}
+
+// qrCaption 返回二维码上方的说明文字
+func (mr *MenuRenderer) qrCaption() string {
+	if mr.qrCode.Caption != "" {
+		return i18n.Translate(mr.qrCode.Caption)
+	}
+	if mr.qrCode.Content == "" || mr.qrCode.Content == DefaultQRContent {
+		return i18n.Translate("此处为二维码展示，二维码的值为设备ID")
+	}
+	return i18n.Translate("扫描二维码")
+}
+
+// qrCodeImage 按首页二维码的参数生成二维码图像
+// 参数content: 编码内容
+// 参数maxSize: 可用的最大边长（像素），未配置模块大小时据此选择能放下的最大模块
+func (mr *MenuRenderer) qrCodeImage(content string, maxSize int) (image.Image, error) {
+	return encodeQR(content, mr.qrCode.Level, mr.qrCode.QuietZone, mr.qrCode.ModuleSize, maxSize, qrMaxModuleSize)
+}
+
+// encodeQR 生成二维码图像（白色背景，四周留白）
+// 参数moduleSize: 每个模块的边长（像素），不大于0时按maxSize选择能放下的最大模块
+// 参数maxModule: 自动选择时模块边长的上限，0表示不限
+func encodeQR(content string, level qr.Level, quiet, moduleSize, maxSize, maxModule int) (image.Image, error) {
+	code, err := qr.Encode(content, level)
+	if err != nil {
+		return nil, err
+	}
+
+	if quiet < 0 {
+		quiet = 0
+	}
+	modules := code.Size + 2*quiet
+	pixelSize := moduleSize
+	if pixelSize <= 0 {
+		pixelSize = maxSize / modules
+		if maxModule > 0 && pixelSize > maxModule {
+			pixelSize = maxModule
+		}
+		if pixelSize < 1 {
+			pixelSize = 1 // 屏幕太小时仍按最小尺寸显示，超出部分被裁掉
+		}
+	}
+
+	border := quiet * pixelSize
+	total := modules * pixelSize
+	qrImg := image.NewRGBA(image.Rect(0, 0, total, total))
+	draw.Draw(qrImg, qrImg.Bounds(), &image.Uniform{color.White}, image.Point{}, draw.Src)
+	for qy := 0; qy < code.Size; qy++ {
+		for qx := 0; qx < code.Size; qx++ {
+			if code.Black(qx, qy) {
+				module := image.Rect(0, 0, pixelSize, pixelSize).Add(image.Pt(border+qx*pixelSize, border+qy*pixelSize))
+				draw.Draw(qrImg, module, &image.Uniform{color.Black}, image.Point{}, draw.Src)
+			}
+		}
+	}
+	return qrImg, nil
+}
+
+// WiFiQRContent 生成手机扫码连接无线网络使用的"WIFI:"字符串
+// 参数ssid: 网络名称
+// 参数password: 密码，开放网络为空
+// 参数security: 加密方式：WPA（包括WPA2/WPA3）、WEP或nopass，为空时有密码按WPA、没有密码按nopass
+// 参数hidden: 网络是否隐藏，不广播名称
+func WiFiQRContent(ssid, password, security string, hidden bool) string {
+	security = strings.ToUpper(security)
+	if security == "" {
+		security = "WPA"
+		if password == "" {
+			security = "nopass"
+		}
+	} else if security == "NOPASS" {
+		security = "nopass"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "WIFI:T:%s;S:%s;", security, wifiEscaper.Replace(ssid))
+	if security != "nopass" {
+		fmt.Fprintf(&b, "P:%s;", wifiEscaper.Replace(password))
+	}
+	if hidden {
+		b.WriteString("H:true;")
+	}
+	b.WriteString(";")
+	return b.String()
+}
+
+// wifiEscaper 转义WIFI:字符串中有特殊含义的字符
+var wifiEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, `:`, `\:`, `"`, `\"`)
+
+// QRView 二维码控件，在分配到的区域内以能放下的最大尺寸居中显示
+type QRView struct {
+	Content   string   // 编码内容
+	Level     qr.Level // 纠错等级
+	QuietZone int      // 四周留白（模块数）
+}
+
+// Measure 二维码为正方形，边长取可用宽度，页面放不下时由布局裁剪高度
+func (v *QRView) Measure(r *font.Renderer, width int) image.Point {
+	return image.Pt(width, width)
+}
+
+// Draw 按区域的短边选择模块尺寸，在区域内水平居中绘制
+func (v *QRView) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	size := bounds.Dx()
+	if bounds.Dy() < size {
+		size = bounds.Dy()
+	}
+	img, err := encodeQR(v.Content, v.Level, v.QuietZone, 0, size, 0)
+	if err != nil {
+		return fmt.Errorf("生成二维码失败: %v", err)
+	}
+	x := alignX(AlignCenter, bounds, img.Bounds().Dx())
+	rect := img.Bounds().Add(image.Pt(x, bounds.Min.Y)).Intersect(bounds)
+	draw.Draw(dst, rect, img, image.Point{}, draw.Src)
+	return nil
+}
+
+// mirrorText 文本镜像中输出编码的内容
+func (v *QRView) mirrorText() []string {
+	return []string{"[QR] " + v.Content}
+}
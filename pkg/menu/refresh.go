@@ -0,0 +1,76 @@
+package menu
+
+import (
+	"strings"
+	"time"
+)
+
+// Refresher 可以重新获取显示内容的页面，按F5等刷新热键时由导航栈调用
+type Refresher interface {
+	// Refresh 重新获取页面的数据；内容有变化需要重绘时调用nav.Invalidate
+	Refresh(nav *Navigator) error
+}
+
+// AutoRefresher 按自己的间隔自动刷新的页面，如首页每5秒刷新一次
+// 页面成为栈顶后开始计时，到时由Tick调用Refresh；按热键手动刷新后重新计时
+type AutoRefresher interface {
+	Refresher
+	// RefreshInterval 返回自动刷新的间隔，不大于0时只在手动刷新时更新
+	RefreshInterval() time.Duration
+}
+
+// scheduleRefresh 从now开始为栈顶页面安排下一次自动刷新，页面不需要自动刷新时取消
+func (n *Navigator) scheduleRefresh(now time.Time) {
+	n.nextRefresh = time.Time{}
+	if r, ok := n.Top().(AutoRefresher); ok {
+		if interval := r.RefreshInterval(); interval > 0 {
+			n.nextRefresh = now.Add(interval)
+		}
+	}
+}
+
+// autoRefresh 栈顶页面的自动刷新到时时刷新页面并安排下一次
+func (n *Navigator) autoRefresh(now time.Time) error {
+	if n.nextRefresh.IsZero() || now.Before(n.nextRefresh) {
+		return nil
+	}
+	n.scheduleRefresh(now)
+	return n.Top().(Refresher).Refresh(n)
+}
+
+// CanRefresh 返回当前页面是否可以手动刷新
+func (n *Navigator) CanRefresh() bool {
+	_, ok := n.Top().(Refresher)
+	return ok
+}
+
+// Refresh 立即刷新当前页面并整页重绘，自动刷新从现在重新计时
+// 返回当前页面是否可以刷新，不能刷新时不做任何处理
+func (n *Navigator) Refresh() (bool, error) {
+	r, ok := n.Top().(Refresher)
+	if !ok {
+		return false, nil
+	}
+	n.scheduleRefresh(time.Now())
+	err := r.Refresh(n)
+	n.Invalidate()
+	if flushErr := n.Flush(); err == nil {
+		err = flushErr
+	}
+	return true, err
+}
+
+// UsesKey 返回当前页面的按键提示中是否有key（不区分大小写），
+// 用于让单个字母的全局热键让位于页面自己的同名按键，如服务管理页面的r
+func (n *Navigator) UsesKey(key string) bool {
+	h, ok := n.Top().(Hinter)
+	if !ok {
+		return false
+	}
+	for _, hint := range h.Hints() {
+		if strings.EqualFold(hint.Key, key) {
+			return true
+		}
+	}
+	return false
+}
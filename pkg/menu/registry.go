@@ -0,0 +1,50 @@
+package menu
+
+import (
+	"fmt"
+	"sync"
+)
+
+// PageFactory 创建页面，每次从配置菜单进入时调用一次
+// 返回错误时不进入页面，错误信息显示给用户
+type PageFactory func() (Page, error)
+
+// PageEntry 登记的页面
+type PageEntry struct {
+	ID      string      // 页面标识，在配置文件的[menu_item]中作为action引用
+	Title   string      // 在配置菜单中显示的名称
+	Factory PageFactory // 创建页面
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []PageEntry
+)
+
+// RegisterPage 登记可以从配置菜单进入的页面，通常在init函数中调用
+// 登记的页面按登记顺序自动追加到内置配置菜单的末尾，按键事件由导航栈交给页面处理；
+// 配置了[menu_item]时，页面只在action引用其ID时显示。ID重复或factory为nil时panic
+// 参数id: 页面标识，如"raid"
+// 参数title: 菜单中显示的名称，显示前经过翻译
+// 参数factory: 创建页面
+func RegisterPage(id, title string, factory PageFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if factory == nil {
+		panic(fmt.Sprintf("页面 %q 的factory为nil", id))
+	}
+	for _, e := range registry {
+		if e.ID == id {
+			panic(fmt.Sprintf("页面 %q 重复登记", id))
+		}
+	}
+	registry = append(registry, PageEntry{ID: id, Title: title, Factory: factory})
+}
+
+// RegisteredPages 返回所有登记的页面，按登记顺序排列
+func RegisteredPages() []PageEntry {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	return append([]PageEntry(nil), registry...)
+}
@@ -5,12 +5,14 @@ import (
 	"image"
 	"image/color"
 	"image/draw"
+	"io"
 	"strings"
+	"text/template"
 
 	"go-framebuffer-console/pkg/font"
 	"go-framebuffer-console/pkg/framebuffer"
+	"go-framebuffer-console/pkg/i18n"
 	"go-framebuffer-console/pkg/system"
-	"rsc.io/qr"
 )
 
 type MenuRenderer struct {
@@ -19,10 +21,53 @@ type MenuRenderer struct {
 	width    int
 	height   int
 	// 智能刷新相关
-	lastContent       string // 上次显示的内容
-	needsClear        bool   // 是否需要清屏
-	staticRendered    bool   // 静态内容是否已渲染
-	lastDynamicHeight int    // 上次动态区域的高度，用于清除残留
+	mainRows          []screenRow // 上次绘制的首页各行，用于只重绘改变的行
+	needsClear        bool        // 是否需要清屏
+	staticRendered    bool        // 静态内容是否已渲染
+	lastDynamicHeight int         // 上次动态区域的高度，用于清除残留
+	// 鼠标点击相关
+	hitAreas []HitArea // 当前页面中可点击的区域
+	// 文本镜像相关
+	mirror      io.Writer // 页面文本的镜像输出（如串口），nil表示不镜像
+	mirrorLines []string  // 正在渲染的页面中已绘制的文本行
+	lastMirror  string    // 上次写入镜像输出的页面文本
+	// 首页图表
+	statusChart *Chart // 首页系统信息下方的图表（如CPU使用率曲线），nil表示不显示
+	// 页眉和页脚
+	header     *Header // 页面顶部的状态栏，nil表示不显示
+	showHeader bool    // 当前页面是否显示状态栏
+	footer     []Hint  // 正在绘制的页面的按键提示，nil表示不显示页脚
+	// 错误提示音
+	beep func() // 显示错误级别的消息时调用，nil表示不提示
+	// 首页二维码
+	qrCode QRCode // 二维码的内容模板、尺寸和纠错等级
+	// 首页分栏
+	mainLayout MainLayout // 单列或左右两列
+	// 首页告警横幅
+	alertBanner func() []string // 返回当前超过阈值的告警消息，nil表示不显示横幅
+	// 页面切换
+	transition TransitionStyle // 切换页面时的过渡效果
+	// 首页模板
+	mainTemplate *template.Template // 定义首页系统信息和客服信息的模板，nil表示使用内置的内容
+}
+
+// HitArea 页面中可点击的区域，点击效果等同于按下对应的按键
+type HitArea struct {
+	Rect image.Rectangle // 区域在屏幕上的位置
+	Key  byte            // 点击时模拟的按键
+}
+
+// HitTest 查找坐标所在的可点击区域
+// 参数x,y: 屏幕坐标
+// 返回该区域对应的按键，以及坐标是否落在某个区域内
+func (mr *MenuRenderer) HitTest(x, y int) (byte, bool) {
+	pt := image.Pt(x, y)
+	for _, area := range mr.hitAreas {
+		if pt.In(area.Rect) {
+			return area.Key, true
+		}
+	}
+	return 0, false
 }
 
 func NewMenuRenderer(fb *framebuffer.FrameBuffer, fontRenderer *font.Renderer) *MenuRenderer {
@@ -35,31 +80,42 @@ func NewMenuRenderer(fb *framebuffer.FrameBuffer, fontRenderer *font.Renderer) *
 		needsClear:        true, // 初始需要清屏
 		staticRendered:    false,
 		lastDynamicHeight: 0,
+		qrCode:            DefaultQRCode(),
 	}
 }
 
 func (mr *MenuRenderer) RenderMainMenu(sysInfo *system.SystemInfo) error {
+	mr.hitAreas = nil
+
 	// 使用14号字体
 	mr.renderer.SetSize(14)
 
-	// 生成当前内容
-	currentContent := mr.generateNewMainMenuContent(sysInfo)
-
-	// 检查是否需要刷新
-	if currentContent == mr.lastContent && mr.staticRendered {
-		return nil // 内容没有变化，无需刷新
+	// 按新格式生成主菜单的各行
+	rows, err := mr.mainMenuRows(sysInfo)
+	if err != nil {
+		return err
 	}
 
-	// 清屏并重新渲染
-	mr.fb.Clear()
-	mr.needsClear = false
-
-	// 按新格式渲染整个主菜单
-	if err := mr.renderNewMainMenu(sysInfo); err != nil {
+	mr.beginMirror()
+	for _, row := range rows {
+		mr.addMirror(row.text...)
+	}
+	if mr.staticRendered && sameRowLayout(rows, mr.mainRows) {
+		// 版面没有变化：只重绘内容改变的行（如时间、内存），静态文字保持不动，避免整屏闪烁
+		err = mr.drawRows(rows, mr.mainRows)
+	} else {
+		// 首次绘制或版面改变：清屏并重新渲染全部内容
+		mr.clearScreen()
+		mr.needsClear = false
+		err = mr.drawRows(rows, nil)
+	}
+	if err != nil {
+		mr.staticRendered = false // 绘制了一半，下次整屏重绘
 		return err
 	}
+	mr.flushMirror()
 
-	mr.lastContent = currentContent
+	mr.mainRows = rows
 	mr.staticRendered = true
 	return nil
 }
@@ -78,7 +134,7 @@ func (mr *MenuRenderer) renderStaticContent() error {
 - 系统状态每5秒自动更新`
 
 	lines := strings.Split(staticContent, "\n")
-	img, err := mr.renderer.RenderMultilineText(lines, color.RGBA{255, 255, 255, 255}, 3)
+	img, err := mr.renderer.RenderMultilineText(lines, theme.Foreground, 3)
 	if err != nil {
 		return fmt.Errorf("failed to render static content: %v", err)
 	}
@@ -112,7 +168,7 @@ func (mr *MenuRenderer) renderDynamicContent(sysInfo *system.SystemInfo) error {
 	)
 
 	lines := strings.Split(dynamicContent, "\n")
-	img, err := mr.renderer.RenderMultilineText(lines, color.RGBA{255, 255, 255, 255}, 3)
+	img, err := mr.renderer.RenderMultilineText(lines, theme.Foreground, 3)
 	if err != nil {
 		return fmt.Errorf("failed to render dynamic content: %v", err)
 	}
@@ -142,13 +198,22 @@ func (mr *MenuRenderer) clearDynamicArea(width, height int) {
 	// 只清除动态内容区域，而不是整个屏幕
 	for dy := 0; dy < height; dy++ {
 		for dx := 0; dx < width; dx++ {
-			mr.fb.SetPixel(x+dx, y+dy, color.RGBA{0, 0, 0, 255})
+			mr.fb.SetPixel(x+dx, y+dy, theme.Background)
 		}
 	}
 }
 
-func (mr *MenuRenderer) RenderConfigMenu() error {
-	mr.fb.Clear()
+// clearScreen 用主题的背景色清屏，之前页面中的滚动文字随之停止
+func (mr *MenuRenderer) clearScreen() {
+	mr.fb.Fill(theme.Background)
+	resetMarquees()
+}
+
+// RenderLayout 清屏并绘制由控件组成的页面
+// 页面中按钮和列表项的区域登记为可点击区域，页面文本同步输出到文本镜像
+// 参数page: 要绘制的页面
+func (mr *MenuRenderer) RenderLayout(layout *Layout) error {
+	mr.clearScreen()
 
 	// 标记需要重新渲染主菜单
 	mr.needsClear = true
@@ -157,69 +222,201 @@ func (mr *MenuRenderer) RenderConfigMenu() error {
 	// 使用14号字体
 	mr.renderer.SetSize(14)
 
-	content := mr.generateConfigMenuContent()
-	lines := strings.Split(content, "\n")
+	// 有页脚时页面内容不超过页脚上方
+	if err := layout.Render(mr.content()); err != nil {
+		return err
+	}
+	mr.hitAreas = layout.HitAreas()
+	mr.mirrorPage(layout.Text())
+	return nil
+}
 
-	img, err := mr.renderer.RenderMultilineText(lines, color.RGBA{255, 255, 255, 255}, 3)
-	if err != nil {
-		return fmt.Errorf("failed to render config menu: %v", err)
+// RenderDialog 清屏并在屏幕中央绘制对话框
+// 对话框的按钮登记为可点击区域，点击等同于按下按钮的快捷键
+// 参数d: 要绘制的对话框
+func (mr *MenuRenderer) RenderDialog(d *Dialog) error {
+	if err := mr.RenderLayout(mr.centeredLayout(d)); err != nil {
+		return fmt.Errorf("failed to render dialog: %v", err)
 	}
+	return nil
+}
 
-	// 左上角左对齐显示，留出边距
-	x := 20
-	y := 20
+// RenderSplash 清屏并绘制启动画面：居中的logo、产品名称和版本号
+// 参数logo: logo图片，过大时按比例缩小到屏幕高度的一半以内，为nil时只显示文字
+// 参数product: 产品名称，以主题的强调色显示
+// 参数version: 版本号，为空时不显示
+func (mr *MenuRenderer) RenderSplash(logo image.Image, product, version string) error {
+	var widgets []Widget
+	if logo != nil {
+		view := NewImageView(logo)
+		view.MaxHeight = mr.height / 2
+		widgets = append(widgets, view, &Spacer{Height: mr.renderer.LineHeight()})
+	}
+	widgets = append(widgets, splashLabels(product, version)...)
 
-	mr.fb.DrawImage(img, x, y)
+	if err := mr.RenderLayout(mr.centeredLayout(widgets...)); err != nil {
+		return fmt.Errorf("failed to render splash: %v", err)
+	}
 	return nil
 }
 
+// splashLabels 返回启动画面下方的产品名称和版本号
+func splashLabels(product, version string) []Widget {
+	widgets := []Widget{&Label{Text: product, Color: theme.Accent, Align: AlignCenter}}
+	if version != "" {
+		widgets = append(widgets, &Label{Text: i18n.Translatef("版本 %s", version), Align: AlignCenter})
+	}
+	return widgets
+}
+
+// centeredLayout 创建在屏幕中垂直居中的页面布局
+// 先按14号字体测量各控件的总高度，再在上方加入相应高度的空白
+// 参数widgets: 自上而下排列的控件
+func (mr *MenuRenderer) centeredLayout(widgets ...Widget) *Layout {
+	mr.renderer.SetSize(14)
+	layout := mr.NewLayout()
+	height := 0
+	for i, w := range widgets {
+		if i > 0 {
+			height += layout.Spacing
+		}
+		height += w.Measure(mr.renderer, mr.width-2*layout.Margin).Y
+	}
+	if top := (mr.height - 2*layout.Margin - height) / 2; top > layout.Spacing {
+		layout.Add(&Spacer{Height: top - layout.Spacing})
+	}
+	return layout.Add(widgets...)
+}
+
+// trafficSamples 网卡流量迷你曲线的宽度对应的采样数（每5秒一次，共5分钟）
+const trafficSamples = 60
+
+// SetStatusChart 设置首页系统信息下方显示的图表
+// 图表的采样由调用方追加，下次绘制首页时显示最新的曲线
+// 参数c: 图表，nil表示不显示
+func (mr *MenuRenderer) SetStatusChart(c *Chart) {
+	mr.statusChart = c
+	mr.InvalidateCache()
+}
+
+// NewLayout 创建使用菜单字体的空白页面布局
+// 参数widgets: 自上而下排列的控件
+func (mr *MenuRenderer) NewLayout(widgets ...Widget) *Layout {
+	return NewLayout(mr.renderer, widgets...)
+}
+
 // InvalidateCache 使缓存失效，强制重新渲染
 func (mr *MenuRenderer) InvalidateCache() {
 	mr.needsClear = true
 	mr.staticRendered = false
-	mr.lastContent = ""
+	mr.mainRows = nil
 }
 
 func (mr *MenuRenderer) RenderNetworkInfo(interfaces []system.NetworkInterface) error {
-	mr.fb.Clear()
-
-	// 使用14号字体
-	mr.renderer.SetSize(14)
-
-	content := mr.generateNetworkInfoContent(interfaces)
-	lines := strings.Split(content, "\n")
-
-	img, err := mr.renderer.RenderMultilineText(lines, color.RGBA{255, 255, 255, 255}, 3)
-	if err != nil {
+	if err := mr.RenderLayout(mr.networkInfoLayout(interfaces, nil, nil, 1, 1)); err != nil {
 		return fmt.Errorf("failed to render network info: %v", err)
 	}
+	return nil
+}
 
-	// 左上角左对齐显示，留出边距
-	x := 20
-	y := 20
+func (mr *MenuRenderer) RenderMessage(message string) error {
+	return mr.RenderStyledMessage(strings.Split(message, "\n"), nil)
+}
 
-	mr.fb.DrawImage(img, x, y)
+// RenderStyledMessage 渲染带有逐行颜色的消息页面
+// 参数lines: 消息文本行
+// 参数styles: 与lines一一对应的行样式，未指定颜色的行显示为白色
+func (mr *MenuRenderer) RenderStyledMessage(lines []string, styles []font.LineStyle) error {
+	if err := mr.RenderLayout(mr.NewLayout(newMessageView(lines, styles))); err != nil {
+		return fmt.Errorf("failed to render message: %v", err)
+	}
 	return nil
 }
 
-func (mr *MenuRenderer) RenderMessage(message string) error {
-	mr.fb.Clear()
+// RenderInput 渲染文本输入页面
+// 参数prompt: 输入框上方的提示文字，可包含多行
+// 参数text: 当前输入的文本
+// 参数cursor: 光标位置（字符下标）
+// 固定prompt后即可作为input.LineRenderFunc，用于显示行编辑器的输入内容和光标
+func (mr *MenuRenderer) RenderInput(prompt, text string, cursor int) error {
+	return mr.RenderInputWithStatus(prompt, text, cursor, "")
+}
+
+// RenderInputWithStatus 渲染文本输入页面，并在输入框下方显示一行状态
+// 参数status: 状态行，如拼音输入法的拼音串和候选字，为空时不显示
+func (mr *MenuRenderer) RenderInputWithStatus(prompt, text string, cursor int, status string) error {
+	mr.clearScreen()
+	mr.hitAreas = nil
+
+	// 标记需要重新渲染主菜单
+	mr.needsClear = true
+	mr.staticRendered = false
 
 	// 使用14号字体
 	mr.renderer.SetSize(14)
 
-	lines := strings.Split(message, "\n")
+	fg := theme.Foreground
+	x := 20
+	y := 20
+	lineStep := mr.renderer.LineHeight() + 3
+	mr.beginMirror()
+	defer mr.flushMirror()
+	// 提示文字超出屏幕宽度时折行显示
+	for _, line := range mr.renderer.WrapText(prompt, mr.width-2*x) {
+		if err := mr.renderTextAt(line, x, y); err != nil {
+			return err
+		}
+		y += lineStep
+	}
+	y += lineStep / 2
+
+	// 输入框
+	const padding = 6
+	boxWidth := mr.width - 2*x
+	boxHeight := mr.renderer.LineHeight() + 2*padding
+	if boxWidth <= 2*padding {
+		return nil
+	}
+	img := image.NewRGBA(image.Rect(0, 0, boxWidth, boxHeight))
+	mr.drawRect(img, 0, 0, boxWidth, boxHeight, fg, true)
 
-	img, err := mr.renderer.RenderMultilineText(lines, color.RGBA{255, 255, 255, 255}, 3)
-	if err != nil {
-		return fmt.Errorf("failed to render message: %v", err)
+	runes := []rune(text)
+	if cursor < 0 {
+		cursor = 0
+	}
+	if cursor > len(runes) {
+		cursor = len(runes)
 	}
 
-	// 左上角左对齐显示，留出边距
-	x := 20
-	y := 20
+	// 镜像输出中以"|"标出光标位置
+	mr.addMirror("", "> "+string(runes[:cursor])+"|"+string(runes[cursor:]), "")
+
+	// 文本超出输入框时向左滚动，保证光标始终可见
+	inner := boxWidth - 2*padding
+	start := 0
+	for start < cursor {
+		if w, _ := mr.renderer.MeasureString(string(runes[start:cursor])); w <= inner {
+			break
+		}
+		start++
+	}
+	visible := mr.renderer.TruncateToWidth(string(runes[start:]), inner)
+	if err := mr.renderer.RenderTextInto(img, padding, padding, visible, fg); err != nil {
+		return fmt.Errorf("failed to render input text: %v", err)
+	}
+
+	// 光标竖线
+	cursorX, _ := mr.renderer.MeasureString(string(runes[start:cursor]))
+	mr.drawRect(img, padding+cursorX-1, padding, 2, mr.renderer.LineHeight(), fg, false)
 
 	mr.fb.DrawImage(img, x, y)
+
+	if status != "" {
+		status = mr.renderer.TruncateToWidth(status, boxWidth)
+		if err := mr.renderTextAt(status, x, y+boxHeight+lineStep/2); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -242,52 +439,129 @@ func (mr *MenuRenderer) generateMainMenuContent(sysInfo *system.SystemInfo) stri
 	)
 }
 
-func (mr *MenuRenderer) generateConfigMenuContent() string {
-	return "============================\n" +
-		"配置菜单\n" +
-		"============================\n" +
-		"1. 查看网卡信息\n" +
-		"2. 重启系统服务\n" +
-		"3. 检测设备网络\n" +
-		"4. 重启设备\n" +
-		"5. 关机\n" +
-		"============================\n" +
-		"请输入选项(1-5)，按q返回首页"
-}
-
-func (mr *MenuRenderer) generateNetworkInfoContent(interfaces []system.NetworkInterface) string {
+// networkInfoLayout 组合网卡信息页面
+// 各网卡的驱动、状态、链路、MAC、IPv4地址和累计收发字节数以表格对齐显示，表格下方是默认网关和DNS服务器；
+// 路由和较长的IPv6地址列在最下方，超出一屏时可以滚动
+// 参数interfaces: 当前页的网卡
+// 参数settings: 网关、路由和DNS服务器，为nil且err为nil时不显示
+// 参数err: 获取settings失败的原因
+// 参数page: 当前页码，从1开始
+// 参数pages: 总页数，多于1页时在表格下方显示页码
+func (mr *MenuRenderer) networkInfoLayout(interfaces []system.NetworkInterface, settings *system.NetworkSettings, err error, page, pages int) *Layout {
 	if len(interfaces) == 0 {
-		return "未找到任何物理网络接口。\n\n按任意键返回"
+		return mr.NewLayout(NewScrollView([]string{i18n.Translate("未找到任何物理网络接口。"), "", i18n.Translate("按任意键返回")}, nil))
 	}
 
-	var builder strings.Builder
-	builder.WriteString("物理网卡信息:\n")
-	builder.WriteString("========================================\n")
-
+	table := NewTable(i18n.Translate("接口"), i18n.Translate("驱动"), i18n.Translate("状态"), i18n.Translate("链路"),
+		i18n.Translate("MAC地址"), i18n.Translate("IPv4地址"), i18n.Translate("已接收"), i18n.Translate("已发送"))
+	// 状态前加圆点，按是否接通显示为正常、警告或错误颜色
+	table.Columns[2].Color = interfaceStatusColor
+	table.Columns[3].Color = linkColor
+	table.Columns[6].Align = AlignRight
+	table.Columns[7].Align = AlignRight
+	dot := mr.StatusDot()
+	var details []string
+	if settings != nil {
+		details = append(details, i18n.Translate("路由:"))
+		for _, route := range settings.Routes {
+			details = append(details, "  "+route.String())
+		}
+		details = append(details, "")
+	}
+	details = append(details, i18n.Translate("IPv6地址:"))
 	for _, iface := range interfaces {
-		builder.WriteString(fmt.Sprintf("接口名称: %s\n", iface.Name))
-		builder.WriteString(fmt.Sprintf("  状态: %s\n", iface.Status))
-		builder.WriteString(fmt.Sprintf("  MAC地址: %s\n", iface.MAC))
+		ipv4 := iface.IPv4Address
+		if ipv4 == "" {
+			ipv4 = i18n.Translate("(未配置)")
+		}
+		driver := iface.Driver
+		if driver == "" {
+			driver = "-"
+		}
+		table.AddRow(iface.Name, driver, dot+" "+iface.Status, iface.LinkSummary(), iface.MAC, ipv4,
+			system.FormatBytes(int64(iface.RXBytes)), system.FormatBytes(int64(iface.TXBytes)))
 
-		builder.WriteString("  IPv4地址:\n")
-		if iface.IPv4Address != "" {
-			builder.WriteString(fmt.Sprintf("    - %s\n", iface.IPv4Address))
-		} else {
-			builder.WriteString("    - (未配置)\n")
+		if len(iface.IPv6Addresses) == 0 {
+			details = append(details, fmt.Sprintf("  %s: %s", iface.Name, i18n.Translate("(未配置)")))
+			continue
 		}
+		for _, ip := range iface.IPv6Addresses {
+			details = append(details, fmt.Sprintf("  %s: %s", iface.Name, ip))
+		}
+	}
+	details = append(details, "", i18n.Translate("按任意键返回"))
+
+	layout := mr.NewLayout(
+		NewLabel(i18n.Translate("物理网卡信息:")),
+		NewSeparator(),
+		table,
+	)
+	if pages > 1 {
+		layout.Add(&Label{Text: i18n.Translatef("第 %d/%d 页", page, pages), Color: theme.Accent, Align: AlignCenter})
+	}
+	if err != nil {
+		layout.Add(NewSeparator(), &Label{Text: err.Error(), Color: theme.Error})
+	} else if settings != nil {
+		layout.Add(NewSeparator())
+		layout.Add(networkSettingsLabels(settings)...)
+	}
+	if traffic := mr.trafficSparklines(interfaces); len(traffic) > 0 {
+		layout.Add(NewSeparator(), NewLabel(i18n.Translate("网卡流量（最近5分钟）:")))
+		for _, s := range traffic {
+			layout.Add(s)
+		}
+	}
+	// 较长的IPv6地址在行内来回滚动，不截断
+	ipv6 := NewScrollView(details, nil)
+	ipv6.Scroll = true
+	return layout.Add(
+		NewSeparator(),
+		ipv6,
+	)
+}
 
-		builder.WriteString("  IPv6地址:\n")
-		if len(iface.IPv6Addresses) > 0 {
-			for _, ip := range iface.IPv6Addresses {
-				builder.WriteString(fmt.Sprintf("    - %s\n", ip))
+// networkSettingsLabels 默认网关和DNS服务器各占一行，没有配置时以错误色显示
+func networkSettingsLabels(settings *system.NetworkSettings) []Widget {
+	gateway := &Label{Text: i18n.Translate("默认网关: (未配置)"), Color: theme.Error}
+	if settings.Gateway != nil {
+		gateway = NewLabel(i18n.Translatef("默认网关: %s（%s）", settings.Gateway, settings.GatewayInterface))
+	}
+	dns := &Label{Text: i18n.Translate("DNS服务器: (未配置)"), Color: theme.Error}
+	if len(settings.DNSServers) > 0 {
+		dns = NewLabel(i18n.Translatef("DNS服务器: %s", strings.Join(settings.DNSServers, ", ")))
+	}
+	return []Widget{gateway, dns}
+}
+
+// trafficSparklines 为每个有速率采样的网卡生成接收和发送两条迷你曲线
+// 同一网卡的两条曲线使用相同的纵轴范围，便于比较收发流量；标签列上下对齐
+func (mr *MenuRenderer) trafficSparklines(interfaces []system.NetworkInterface) []*Sparkline {
+	var lines []*Sparkline
+	for _, iface := range interfaces {
+		if len(iface.Traffic) == 0 {
+			continue
+		}
+		rx := make([]float64, len(iface.Traffic))
+		tx := make([]float64, len(iface.Traffic))
+		max := 0.0
+		for i, bw := range iface.Traffic {
+			rx[i], tx[i] = bw.RX, bw.TX
+			if bw.RX > max {
+				max = bw.RX
+			}
+			if bw.TX > max {
+				max = bw.TX
 			}
-		} else {
-			builder.WriteString("    - (未配置)\n")
 		}
-		builder.WriteString("----------------------------------------\n")
+		latest := iface.Rate()
+		lines = append(lines,
+			&Sparkline{Label: i18n.Translatef("%s 接收", iface.Name), Samples: rx, Max: max, Text: system.FormatRate(latest.RX), Color: theme.Success},
+			&Sparkline{Label: i18n.Translatef("%s 发送", iface.Name), Samples: tx, Max: max, Text: system.FormatRate(latest.TX)},
+		)
 	}
-	builder.WriteString("\n按任意键返回")
-	return builder.String()
+
+	mr.AlignSparklines(lines, trafficSamples)
+	return lines
 }
 
 func (mr *MenuRenderer) generateBuddha() string {
@@ -314,8 +588,68 @@ func (mr *MenuRenderer) generateBuddha() string {
            佛祖保佑       永不宕机`
 }
 
+// RenderScreensaver 清屏并绘制屏幕保护画面：大号时钟和下方的一行信息
+// 整组内容在屏幕范围内沿对角线往返移动，phase每加1移动一步，避免长时间显示固定内容造成烧屏；
+// 两次移动之间由clock.Tick只重绘变化的数字
+// 参数clock: 大号时钟，数字高度约为屏幕高度的1/6
+// 参数info: 时钟下方的小字信息，如设备IP
+// 参数phase: 位置序号，调用方按时间递增
+func (mr *MenuRenderer) RenderScreensaver(clock *Clock, info string, phase int) error {
+	mr.clearScreen()
+	mr.hitAreas = nil
+
+	// 标记需要重新渲染主菜单
+	mr.needsClear = true
+	mr.staticRendered = false
+
+	// 时钟高度约为屏幕高度的1/6
+	clock.Height = mr.height / 6
+	mr.renderer.SetSize(14)
+	size := clock.Measure(mr.renderer, mr.width)
+	clockWidth, clockHeight := size.X, size.Y
+	infoWidth, _ := mr.renderer.MeasureString(info)
+	infoHeight := mr.renderer.LineHeight()
+
+	width := clockWidth
+	if infoWidth > width {
+		width = infoWidth
+	}
+	height := clockHeight + infoHeight + 3
+
+	x := bounce(phase*screensaverStepX, mr.width-width)
+	y := bounce(phase*screensaverStepY, mr.height-height)
+
+	if err := clock.Draw(mr.renderer, mr.fb, image.Rect(x, y, x+width, y+clockHeight)); err != nil {
+		return fmt.Errorf("failed to render clock: %v", err)
+	}
+	if err := mr.renderer.RenderTextInto(mr.fb, x+(width-infoWidth)/2, y+clockHeight+3, info, theme.Line); err != nil {
+		return fmt.Errorf("failed to render screensaver info: %v", err)
+	}
+
+	mr.mirrorPage(append(clock.mirrorText(), info))
+	return nil
+}
+
+// 屏幕保护内容每一步在水平和垂直方向移动的距离（像素），两者不同使移动轨迹覆盖整个屏幕
+const (
+	screensaverStepX = 17
+	screensaverStepY = 11
+)
+
+// bounce 把不断增加的偏移量折返到[0, limit]范围内，得到来回移动的位置
+func bounce(offset, limit int) int {
+	if limit <= 0 {
+		return 0
+	}
+	offset %= 2 * limit
+	if offset > limit {
+		return 2*limit - offset
+	}
+	return offset
+}
+
 func (mr *MenuRenderer) ShowProgressBar(progress float64, message string) error {
-	mr.fb.Clear()
+	mr.clearScreen()
 
 	mr.renderer.SetSize(18)
 
@@ -326,19 +660,13 @@ func (mr *MenuRenderer) ShowProgressBar(progress float64, message string) error
 	barY := mr.height / 2
 
 	img := image.NewRGBA(image.Rect(0, 0, mr.width, mr.height))
-	draw.Draw(img, img.Bounds(), &image.Uniform{color.RGBA{0, 0, 0, 255}}, image.Point{}, draw.Src)
+	draw.Draw(img, img.Bounds(), &image.Uniform{theme.Background}, image.Point{}, draw.Src)
 
-	// 优化：使用更高效的矩形绘制方法
-	mr.drawRect(img, barX, barY, barWidth, barHeight, color.RGBA{255, 255, 255, 255}, true)
-
-	// 绘制进度条填充部分
-	fillWidth := int(float64(barWidth-4) * progress)
-	if fillWidth > 0 {
-		mr.drawRect(img, barX+2, barY+2, fillWidth, barHeight-4, color.RGBA{0, 255, 0, 255}, false)
-	}
+	// 与进度条控件使用相同的绘制方法
+	drawBar(img, image.Rect(barX, barY, barX+barWidth, barY+barHeight), progress, theme.Success)
 
 	if message != "" {
-		textImg, err := mr.renderer.RenderText(message, color.RGBA{255, 255, 255, 255})
+		textImg, err := mr.renderer.RenderText(message, theme.Foreground)
 		if err == nil {
 			textBounds := textImg.Bounds()
 			textX := (mr.width - textBounds.Dx()) / 2
@@ -349,11 +677,13 @@ func (mr *MenuRenderer) ShowProgressBar(progress float64, message string) error
 	}
 
 	mr.fb.DrawImage(img, 0, 0)
+
+	mr.mirrorPage([]string{message, fmt.Sprintf("%s %d%%", textBar(progress, gaugeMirror), int(progress*100))})
 	return nil
 }
 
 // drawRect 高效绘制矩形的辅助方法
-func (mr *MenuRenderer) drawRect(img *image.RGBA, x, y, width, height int, col color.RGBA, outline bool) {
+func (mr *MenuRenderer) drawRect(img *image.RGBA, x, y, width, height int, col color.Color, outline bool) {
 	if outline {
 		// 绘制边框
 		for i := 0; i < width; i++ {
@@ -374,178 +704,273 @@ func (mr *MenuRenderer) drawRect(img *image.RGBA, x, y, width, height int, col c
 	}
 }
 
-// generateNewMainMenuContent 生成新的主菜单内容（用于内容比较）
-func (mr *MenuRenderer) generateNewMainMenuContent(sysInfo *system.SystemInfo) string {
-	return fmt.Sprintf(
-		"%s|%s|%d|%s|%s|%d|%s|%s|%s",
-		sysInfo.Uptime,
-		sysInfo.CPUModel,
-		sysInfo.CPUCores,
-		sysInfo.MemoryUsage,
-		sysInfo.DiskSize,
-		sysInfo.DiskCount,
-		sysInfo.CurrentTime,
-		sysInfo.IPAddress,
-		sysInfo.QianKunCloudID,
-	)
-}
+// mainMenuRows 按新格式生成主菜单的各行，每行记录位置、内容和绘制方法
+// 各行的位置与内容无关（过长的内容截断为一行），刷新时多数行位置不变，只需重绘内容改变的行。
+// 分为两列时系统信息在左列，二维码和客服信息在右列，否则依次排列在同一列中
+func (mr *MenuRenderer) mainMenuRows(sysInfo *system.SystemInfo) ([]screenRow, error) {
+	// 使用字体度量给出的统一行高，保证中英文混排时行距一致
+	lineHeight := mr.renderer.LineHeight()
+	top := mr.headerHeight() + lineHeight // 上边距为1行的高度，显示状态栏时从状态栏下方开始
+	y := top
+
+	// 当前列：文字从列的左边距开始，可用宽度为列宽减去两侧边距
+	columns := mr.mainColumns()
+	col := columns[0]
+	x, textWidth := col.Min.X+20, col.Dx()-40
+
+	var rows []screenRow
+	// 0. 超过告警阈值时在状态栏下方显示横跨整个屏幕的横幅，内容从横幅下方开始
+	if banner := mr.banner(); banner != nil {
+		bounds := image.Rect(0, mr.headerHeight(), mr.width, mr.headerHeight()+banner.Measure(mr.renderer, mr.width).Y)
+		rows = append(rows, screenRow{
+			rect: bounds,
+			key:  strings.Join(banner.Messages, "\n"),
+			text: banner.mirrorText(),
+			draw: func() error { return banner.Draw(mr.renderer, mr.fb, bounds) },
+		})
+		top += bounds.Dy()
+		y = top
+	}
 
-// renderNewMainMenu 按新格式渲染主菜单
-func (mr *MenuRenderer) renderNewMainMenu(sysInfo *system.SystemInfo) error {
-	// 计算汉字宽度作为上边距
-	_, charHeight := mr.renderer.GetTextBounds("字")
-	y := charHeight + 10 // 上边距为1个汉字的高度加10像素
+	// addText 在当前位置添加一行文字
+	addText := func(text string) {
+		x, top, col := x, y, col
+		// 重绘时清除的区域不包括列的边缘，两列之间的竖线不会被擦除
+		row := screenRow{rect: image.Rect(col.Min.X+10, top, col.Max.X-10, top+lineHeight), key: text, text: []string{text}}
+		if text != "" { // 空行不渲染
+			row.draw = func() error { return mr.drawTextAt(text, x, top) }
+		}
+		rows = append(rows, row)
+	}
+	// addWidget 在当前位置添加一个控件，占据bounds区域
+	addWidget := func(w Widget, bounds image.Rectangle, key string) {
+		row := screenRow{rect: bounds, key: key, draw: func() error { return w.Draw(mr.renderer, mr.fb, bounds) }}
+		if t, ok := w.(textual); ok {
+			row.text = t.mirrorText()
+		}
+		rows = append(rows, row)
+	}
 
 	// 1. 系统信息标题
-	titleContent := "系统信息"
-	if err := mr.renderTextAt(titleContent, 20, y); err != nil {
-		return err
-	}
-	y += charHeight + 5
+	addText(i18n.Translate("系统信息"))
+	y += lineHeight + 2
 
-	// 2. 第一条分隔线
-	separatorLine := "================================"
-	if err := mr.renderTextAt(separatorLine, 20, y); err != nil {
-		return err
+	// 2. 第一条分隔线，按主题的样式画线
+	separator := NewSeparator()
+	addSeparator := func() {
+		addWidget(separator, image.Rect(x, y, x+textWidth, y+lineHeight), "separator")
 	}
-	y += charHeight + 5
+	addSeparator()
+	y += lineHeight + 2
 
 	// 3. 系统信息内容
-	systemContent := []string{
-		fmt.Sprintf("操作系统运行时间：%s", sysInfo.Uptime),
-		fmt.Sprintf("处理器型号：%s *%d 核", sysInfo.CPUModel, sysInfo.CPUCores),
-		fmt.Sprintf("内存使用状态：%s", sysInfo.MemoryUsage),
-		fmt.Sprintf("系统安装磁盘大小：%s（共%d个磁盘）", sysInfo.DiskSize, sysInfo.DiskCount),
-		fmt.Sprintf("当前系统时间：%s", sysInfo.CurrentTime),
-		fmt.Sprintf("设备IP地址：%s", sysInfo.IPAddress),
-		"",
-		fmt.Sprintf("设备ID：%s", sysInfo.QianKunCloudID),
+	addLines := func(lines []string) {
+		for _, line := range lines {
+			// 过长的内容（如CPU型号）截断为一行，避免超出屏幕
+			addText(mr.renderer.TruncateToWidth(line, textWidth))
+			y += lineHeight
+		}
 	}
 
-	for _, line := range systemContent {
-		if err := mr.renderTextAt(line, 20, y); err != nil {
-			return err
+	// addTemplateLine 添加首页模板输出的一行：放不下时在行内来回滚动，单独的"---"画作分隔线
+	addTemplateLine := func(line string) {
+		if line == mainTemplateSeparator {
+			addSeparator()
+		} else {
+			text := NewMarquee(line)
+			addWidget(text, image.Rect(x, y, x+textWidth, y+lineHeight), line)
+		}
+		y += lineHeight
+	}
+
+	if mr.mainTemplate != nil {
+		for _, line := range mr.mainTemplateLines("", sysInfo) {
+			addTemplateLine(line)
 		}
-		y += charHeight + 3
+	} else {
+		// 主机名、发行版和内核放在最前面，拍下屏幕即可确认是哪台设备
+		addLines([]string{
+			i18n.Translatef("主机名：%s", sysInfo.Hostname),
+			i18n.Translatef("操作系统：%s（内核 %s，%s）", sysInfo.OSName, sysInfo.KernelVersion, sysInfo.Architecture),
+			i18n.Translatef("操作系统运行时间：%s", sysInfo.Uptime),
+		})
+		// 较长的CPU型号在行内来回滚动，不截断
+		cpu := NewMarquee(i18n.Translatef("处理器型号：%s *%d 核", sysInfo.CPUModel, sysInfo.CPUCores))
+		addWidget(cpu, image.Rect(x, y, x+textWidth, y+lineHeight), cpu.Text)
+		y += lineHeight
+
+		// 3.1 CPU、内存和根分区的使用率以进度条显示，标签列对齐
+		for _, gauge := range mr.usageGauges(sysInfo, textWidth) {
+			bounds := image.Rect(x, y, x+textWidth, y+lineHeight)
+			addWidget(gauge, bounds, fmt.Sprint(gauge.Label, gauge.Value, gauge.Max, gauge.Text))
+			y += lineHeight
+		}
+
+		addLines([]string{i18n.Translatef("系统安装磁盘大小：%s（共%d个磁盘）", sysInfo.DiskSize, sysInfo.DiskCount)})
+
+		// 3.2 系统时间后注明同步状态，未同步时以警告色显示
+		clock := timeLabel(sysInfo)
+		addWidget(clock, image.Rect(x, y, x+textWidth, y+lineHeight), clock.Text)
+		y += lineHeight
+
+		addLines([]string{
+			i18n.Translatef("设备IP地址：%s", sysInfo.IPAddress),
+			"",
+			i18n.Translatef("设备ID：%s", i18n.Translate(sysInfo.QianKunCloudID)),
+		})
+	}
+
+	// 3.3 图表（如最近5分钟的CPU使用率）
+	if mr.statusChart != nil {
+		y += 5
+		size := mr.statusChart.Measure(mr.renderer, textWidth)
+		bounds := image.Rect(x, y, x+size.X, y+size.Y)
+		addWidget(mr.statusChart, bounds, fmt.Sprint(mr.statusChart.Samples()))
+		y += size.Y + 5
 	}
 
 	// 4. 第二条分隔线
-	if err := mr.renderTextAt(separatorLine, 20, y); err != nil {
-		return err
+	addSeparator()
+	y += lineHeight + 5
+
+	// 4.1 两列时二维码和客服信息从右列的顶部开始，两列之间画一条竖线
+	if len(columns) > 1 {
+		col = columns[1]
+		x, textWidth, y = col.Min.X+20, col.Dx()-40, top
+		divider := image.Rect(col.Min.X, top, col.Min.X+1, mr.height-mr.footerHeight()-lineHeight)
+		rows = append(rows, screenRow{rect: divider, key: "divider", draw: func() error {
+			draw.Draw(mr.fb, divider, &image.Uniform{theme.Line}, image.Point{}, draw.Src)
+			return nil
+		}})
+	}
+
+	// 客服信息显示在二维码下方，使用首页模板时由模板中的footer部分定义
+	customerServiceContent := []string{
+		i18n.Translate("如有问题请咨询技术客服：微信：your-service-wechat"),
+		"",
+		i18n.Translate("按回车键进入配置菜单"),
+	}
+	if mr.mainTemplate != nil {
+		customerServiceContent = mr.mainTemplateLines(MainTemplateFooter, sysInfo)
 	}
-	y += charHeight + 10
 
-	// 5. 生成并显示二维码
-	if sysInfo.QianKunCloudID != "" && sysInfo.QianKunCloudID != "未获取到" {
-		qrY, err := mr.renderQRCode(sysInfo.QianKunCloudID, 20, y)
+	// 5. 生成并显示二维码，编码内容按配置的模板展开
+	if content, err := mr.qrContent(sysInfo); err == nil {
+		// 二维码说明
+		addText(mr.qrCaption())
+		y += lineHeight + 5
+
+		// 二维码下方还有分隔线和客服信息，未配置模块大小时按剩余的空间缩放
+		tail := 20
+		if len(customerServiceContent) > 0 {
+			tail += lineHeight + 5 + len(customerServiceContent)*lineHeight
+		}
+		maxSize := mr.height - mr.footerHeight() - y - tail
+		if maxSize > textWidth {
+			maxSize = textWidth
+		}
+		qrImg, err := mr.qrCodeImage(content, maxSize)
 		if err != nil {
-			return err
+			// 如果生成失败，显示错误信息
+			addText(i18n.Translatef("二维码生成失败: %v", err))
+			y += lineHeight
+		} else {
+			x, top := x, y
+			rows = append(rows, screenRow{
+				rect: image.Rect(x, top, x+qrImg.Bounds().Dx(), top+qrImg.Bounds().Dy()),
+				key:  fmt.Sprint(content, qrImg.Bounds().Dx()),
+				draw: func() error {
+					mr.fb.DrawImage(qrImg, x, top)
+					return nil
+				},
+			})
+			y += qrImg.Bounds().Dy()
 		}
-		y = qrY + 20
+		y += 20
 	} else {
-		// 如果无法获取设备ID，显示提示信息
-		if err := mr.renderTextAt("二维码生成失败：无法获取乾坤云设备ID", 20, y); err != nil {
-			return err
-		}
-		y += charHeight + 20
+		// 如果模板用到的内容无法获取（如设备ID），显示提示信息
+		addText(i18n.Translatef("二维码生成失败：%v", err))
+		y += lineHeight + 15
 	}
 
-	// 6. 第三条分隔线
-	separatorLine2 := "==============================="
-	if err := mr.renderTextAt(separatorLine2, 20, y); err != nil {
-		return err
+	// 6. 第三条分隔线，模板没有定义客服信息时不画
+	if len(customerServiceContent) > 0 {
+		addSeparator()
+		y += lineHeight + 5
 	}
-	y += charHeight + 10
 
 	// 7. 客服信息
-	customerServiceContent := []string{
-		"如有问题请咨询技术客服：微信：your-service-wechat",
-		"",
-		"按回车键进入配置菜单",
+	for _, line := range customerServiceContent {
+		if mr.mainTemplate != nil {
+			addTemplateLine(line)
+			continue
+		}
+		addText(line)
+		y += lineHeight
 	}
 
-	for _, line := range customerServiceContent {
-		if err := mr.renderTextAt(line, 20, y); err != nil {
-			return err
+	return rows, nil
+}
+
+// usageGauges 生成首页的内存和根分区使用率进度条
+// 使用量未获取到时，进度条为空，百分比显示为"--"
+// 参数width: 进度条所在列的可用宽度（像素）
+func (mr *MenuRenderer) usageGauges(sysInfo *system.SystemInfo, width int) []*Gauge {
+	// CPU使用率获取失败时最大值为0，进度条显示为"--"
+	cpuMax := 100.0
+	if sysInfo.CPUUsage < 0 {
+		cpuMax = 0
+	}
+	cpu := NewGauge(i18n.Translate("CPU使用率："), sysInfo.CPUUsage, cpuMax, "")
+	memory := NewGauge(i18n.Translate("内存使用状态："), float64(sysInfo.MemoryUsed), float64(sysInfo.MemoryTotal), sysInfo.MemoryUsage)
+	root := NewGauge(i18n.Translate("根分区使用状态："), float64(sysInfo.RootUsed), float64(sysInfo.RootTotal), sysInfo.RootUsage)
+
+	// 各进度条的标签列取最宽者，进度条固定为可用宽度的三分之一
+	gauges := []*Gauge{cpu, memory, root}
+	labelWidth := 0
+	for _, g := range gauges {
+		if w, _ := mr.renderer.MeasureString(g.Label); w > labelWidth {
+			labelWidth = w
 		}
-		y += charHeight + 3
 	}
+	for _, g := range gauges {
+		g.LabelWidth = labelWidth
+		g.BarWidth = width / 3
+	}
+	return gauges
+}
 
-	return nil
+// timeLabel 首页的系统时间一行，后面注明时钟是否已同步和与时间服务器的偏差
+// 时钟未同步时以警告色显示；没有可用的时间同步服务时只显示时间
+func timeLabel(sysInfo *system.SystemInfo) *Label {
+	text := i18n.Translatef("当前系统时间：%s", sysInfo.CurrentTime)
+	sync := sysInfo.TimeSync
+	switch {
+	case sync == nil:
+		return NewLabel(text)
+	case !sync.Synchronized:
+		return &Label{Text: text + i18n.Translate("（时间未同步）"), Color: theme.Warning}
+	case sync.HasOffset:
+		return NewLabel(text + i18n.Translatef("（已同步，偏差 %s）", sync.OffsetText()))
+	}
+	return NewLabel(text + i18n.Translate("（已同步）"))
 }
 
-// renderTextAt 在指定位置渲染文本
+// renderTextAt 在指定位置渲染文本，并输出到文本镜像
 func (mr *MenuRenderer) renderTextAt(text string, x, y int) error {
+	mr.addMirror(text)
+	return mr.drawTextAt(text, x, y)
+}
+
+// drawTextAt 在指定位置绘制文本，不输出到文本镜像
+func (mr *MenuRenderer) drawTextAt(text string, x, y int) error {
 	if text == "" {
 		return nil // 空行不渲染
 	}
 
-	textImg, err := mr.renderer.RenderText(text, color.RGBA{255, 255, 255, 255})
-	if err != nil {
+	// 直接绘制到帧缓冲区，避免每次刷新为每行文本分配新图像
+	if err := mr.renderer.RenderTextInto(mr.fb, x, y, text, theme.Foreground); err != nil {
 		return fmt.Errorf("failed to render text '%s': %v", text, err)
 	}
-
-	mr.fb.DrawImage(textImg, x, y)
 	return nil
 }
-
-// renderQRCode 生成并渲染二维码
-func (mr *MenuRenderer) renderQRCode(content string, x, y int) (int, error) {
-	// 计算二维码的显示区域
-	currentY := y
-	
-	// 显示二维码说明
-	headerText := "此处为二维码展示，二维码的值为设备ID"
-	if err := mr.renderTextAt(headerText, x, currentY); err != nil {
-		return currentY, err
-	}
-	
-	_, charHeight := mr.renderer.GetTextBounds("字")
-	currentY += charHeight + 10
-	
-	// 使用rsc.io/qr生成二维码
-	code, err := qr.Encode(content, qr.M)
-	if err != nil {
-		// 如果生成失败，显示错误信息
-		if err := mr.renderTextAt(fmt.Sprintf("二维码生成失败: %v", err), x, currentY); err != nil {
-			return currentY, err
-		}
-		return currentY + charHeight, nil
-	}
-	
-	// 计算二维码尺寸
-	qrSize := code.Size
-	pixelSize := 4 // 每个二维码像素放大4倍
-	border := 2 * pixelSize // 左右边距各2个像素单位
-	
-	// 创建二维码图像（白色背景）
-	totalWidth := qrSize*pixelSize + border*2
-	totalHeight := qrSize*pixelSize + border*2
-	
-	qrImg := image.NewRGBA(image.Rect(0, 0, totalWidth, totalHeight))
-	
-	// 填充白色背景
-	draw.Draw(qrImg, qrImg.Bounds(), &image.Uniform{color.RGBA{255, 255, 255, 255}}, image.Point{}, draw.Src)
-	
-	// 绘制二维码像素
-	for qy := 0; qy < qrSize; qy++ {
-		for qx := 0; qx < qrSize; qx++ {
-			if code.Black(qx, qy) {
-				// 绘制黑色像素块
-				for py := 0; py < pixelSize; py++ {
-					for px := 0; px < pixelSize; px++ {
-						imgX := border + qx*pixelSize + px
-						imgY := border + qy*pixelSize + py
-						qrImg.Set(imgX, imgY, color.RGBA{0, 0, 0, 255})
-					}
-				}
-			}
-		}
-	}
-	
-	// 将二维码图像绘制到帧缓冲区
-	mr.fb.DrawImage(qrImg, x, currentY)
-	
-	// 返回二维码结束位置
-	return currentY + totalHeight, nil
-}
@@ -0,0 +1,51 @@
+package menu
+
+import (
+	"image"
+	"image/draw"
+)
+
+// screenRow 直接绘制在屏幕上的页面（如首页）中的一行或一块内容
+// 页面按行生成内容后与上次绘制的各行逐行比较，只重绘内容改变的行，避免每次刷新整屏闪烁
+type screenRow struct {
+	rect image.Rectangle // 所在区域，重绘前用背景色清除
+	key  string          // 内容，与上次相同时不重绘
+	text []string        // 输出到文本镜像的文字
+	draw func() error    // 绘制内容，为nil时只占位置（如空行）
+}
+
+// sameRowLayout 判断两次生成的各行位置是否完全相同
+// 位置相同时可以只重绘改变的行；行数或位置改变（如换了字体、显示了状态栏）时需要整屏重绘
+func sameRowLayout(rows, prev []screenRow) bool {
+	if len(rows) != len(prev) {
+		return false
+	}
+	for i := range rows {
+		if rows[i].rect != prev[i].rect {
+			return false
+		}
+	}
+	return true
+}
+
+// drawRows 绘制各行
+// prev为nil时屏幕已经清空，绘制全部行；否则只清除并重绘内容与prev中对应行不同的行
+// 参数rows: 本次生成的各行
+// 参数prev: 上次绘制的各行，位置须与rows相同
+func (mr *MenuRenderer) drawRows(rows, prev []screenRow) error {
+	for i, row := range rows {
+		if prev != nil {
+			if row.key == prev[i].key {
+				continue
+			}
+			draw.Draw(mr.fb, row.rect.Intersect(mr.fb.Bounds()), &image.Uniform{theme.Background}, image.Point{}, draw.Src)
+		}
+		if row.draw == nil {
+			continue
+		}
+		if err := row.draw(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"strings"
+
+	"go-framebuffer-console/pkg/font"
+)
+
+// 滚动条的外观
+const (
+	scrollbarWidth = 4 // 滚动条宽度（像素）
+	scrollbarGap   = 4 // 文字与滚动条之间的距离（像素）
+)
+
+// ScrollView 可滚动的多行文本
+// 内容超出页面时只显示一屏，右侧显示滚动条指示当前位置；
+// 可见的行数在每次绘制时根据分配到的高度计算，翻页按此行数进行。
+// 开启Wrap后超出宽度的行折成多行显示，滚动和翻页按折行后的行计算
+type ScrollView struct {
+	Lines  []string         // 文本行
+	Styles []font.LineStyle // 与Lines一一对应的行样式，未指定颜色的行使用主题的文字颜色
+	Offset int              // 第一个可见行的下标（折行后的行）
+	Wrap   bool             // 是否折行显示超出宽度的行，否则截断并加省略号
+	Scroll bool             // 不折行时超出宽度的行是否来回水平滚动（见Marquee），而不是截断
+
+	visible   int              // 最近一次绘制时可见的行数
+	rows      []string         // 最近一次测量或绘制时显示的各行，不折行时与Lines相同
+	rowStyles []font.LineStyle // 与rows一一对应的行样式
+}
+
+// NewScrollView 创建可滚动文本，行内的换行符被拆分为独立的行并沿用原行的样式
+// 参数lines: 文本行
+// 参数styles: 与lines一一对应的行样式，可以为nil
+func NewScrollView(lines []string, styles []font.LineStyle) *ScrollView {
+	sv := &ScrollView{}
+	for i, line := range lines {
+		var style font.LineStyle
+		if i < len(styles) {
+			style = styles[i]
+		}
+		line = strings.ReplaceAll(line, "\r\n", "\n")
+		for _, part := range strings.Split(line, "\n") {
+			sv.Lines = append(sv.Lines, part)
+			sv.Styles = append(sv.Styles, style)
+		}
+	}
+	sv.rows, sv.rowStyles = sv.Lines, sv.Styles
+	return sv
+}
+
+// layoutRows 按宽度重新计算显示的各行，折行得到的各行沿用原行的样式
+func (sv *ScrollView) layoutRows(r *font.Renderer, width int) {
+	if !sv.Wrap {
+		sv.rows, sv.rowStyles = sv.Lines, sv.Styles
+		return
+	}
+	sv.rows, sv.rowStyles = nil, nil
+	for i, line := range sv.Lines {
+		var style font.LineStyle
+		if i < len(sv.Styles) {
+			style = sv.Styles[i]
+		}
+		for _, row := range r.WrapText(line, width) {
+			sv.rows = append(sv.rows, row)
+			sv.rowStyles = append(sv.rowStyles, style)
+		}
+	}
+}
+
+// Measure 返回全部内容的尺寸，页面放不下时由布局裁剪高度
+func (sv *ScrollView) Measure(r *font.Renderer, width int) image.Point {
+	sv.layoutRows(r, width)
+	w := 0
+	for _, line := range sv.rows {
+		if lw, _ := r.MeasureString(line); lw > w {
+			w = lw
+		}
+	}
+	if w > width {
+		w = width
+	}
+	return image.Pt(w, len(sv.rows)*lineStep(r))
+}
+
+// Draw 绘制从Offset开始的一屏文本，内容超出时在右侧绘制滚动条
+func (sv *ScrollView) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	step := lineStep(r)
+	sv.visible = bounds.Dy() / step
+	if sv.visible < 1 {
+		sv.visible = 1
+	}
+
+	textWidth := bounds.Dx()
+	sv.layoutRows(r, textWidth)
+	if sv.Scrollable() {
+		// 滚动条占用右侧的宽度，折行时按剩余的宽度重新折行
+		textWidth -= scrollbarWidth + scrollbarGap
+		sv.layoutRows(r, textWidth)
+	}
+	sv.clamp()
+	if sv.Scrollable() {
+		sv.drawScrollbar(dst, bounds)
+	}
+
+	y := bounds.Min.Y
+	for i := sv.Offset; i < len(sv.rows) && i < sv.Offset+sv.visible; i++ {
+		col := colorOr(sv.rowStyles[i].Color, theme.Foreground)
+		if sv.Scroll {
+			row := image.Rect(bounds.Min.X, y, bounds.Min.X+textWidth, y+r.LineHeight())
+			if err := drawMarqueeText(r, dst, row, sv.rows[i], col, theme.Background, AlignLeft); err != nil {
+				return err
+			}
+		} else if err := r.RenderTextInto(dst, bounds.Min.X, y, r.TruncateToWidth(sv.rows[i], textWidth), col); err != nil {
+			return fmt.Errorf("绘制文字失败: %v", err)
+		}
+		y += step
+	}
+	return nil
+}
+
+// drawScrollbar 在区域右侧绘制滚动条，滑块的长度和位置与可见部分在全文中的比例一致
+func (sv *ScrollView) drawScrollbar(dst draw.Image, bounds image.Rectangle) {
+	track := image.Rect(bounds.Max.X-scrollbarWidth, bounds.Min.Y, bounds.Max.X, bounds.Max.Y)
+	draw.Draw(dst, track.Intersect(dst.Bounds()), &image.Uniform{theme.Line}, image.Point{}, draw.Src)
+
+	total := len(sv.rows)
+	height := track.Dy() * sv.visible / total
+	if height < scrollbarWidth {
+		height = scrollbarWidth
+	}
+	top := track.Min.Y + (track.Dy()-height)*sv.Offset/(total-sv.visible)
+	thumb := image.Rect(track.Min.X, top, track.Max.X, top+height)
+	draw.Draw(dst, thumb.Intersect(dst.Bounds()), &image.Uniform{theme.Foreground}, image.Point{}, draw.Src)
+}
+
+// mirrorText 文本镜像没有屏幕大小的限制，输出全部内容，不折行
+func (sv *ScrollView) mirrorText() []string {
+	return sv.Lines
+}
+
+// maxOffset 返回Offset允许的最大值
+func (sv *ScrollView) maxOffset() int {
+	if max := len(sv.rows) - sv.visible; max > 0 {
+		return max
+	}
+	return 0
+}
+
+// clamp 把Offset限制在有效范围内
+func (sv *ScrollView) clamp() {
+	if sv.Offset > sv.maxOffset() {
+		sv.Offset = sv.maxOffset()
+	}
+	if sv.Offset < 0 {
+		sv.Offset = 0
+	}
+}
+
+// Scrollable 返回内容是否超出一屏，需在绘制之后调用
+func (sv *ScrollView) Scrollable() bool {
+	return sv.visible > 0 && len(sv.rows) > sv.visible
+}
+
+// ScrollBy 滚动指定的行数，正数向下、负数向上，返回位置是否改变
+func (sv *ScrollView) ScrollBy(lines int) bool {
+	old := sv.Offset
+	sv.Offset += lines
+	sv.clamp()
+	return sv.Offset != old
+}
+
+// ScrollPages 滚动指定的屏数，相邻两屏之间保留一行作为衔接，返回位置是否改变
+func (sv *ScrollView) ScrollPages(pages int) bool {
+	page := sv.visible - 1
+	if page < 1 {
+		page = 1
+	}
+	return sv.ScrollBy(pages * page)
+}
@@ -0,0 +1,134 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"strings"
+
+	"go-framebuffer-console/pkg/font"
+	"go-framebuffer-console/pkg/framebuffer"
+)
+
+// GoldenTolerance 比较golden图片时每个颜色分量允许的最大差值，吸收不同色深换算造成的误差
+const GoldenTolerance = 8
+
+// NewSnapshotRenderer 创建绘制到内存而不是显示设备的菜单渲染器
+// 用于在没有显示设备的环境中（如单元测试）检查页面的画面，绘制方法与真实设备完全一致
+// 参数width,height: 虚拟屏幕的分辨率
+// 参数fontRenderer: 字体渲染器，golden图片应使用同一字体生成，如内置字体
+func NewSnapshotRenderer(width, height int, fontRenderer *font.Renderer) (*MenuRenderer, error) {
+	fb, err := framebuffer.NewMemoryFrameBuffer(width, height)
+	if err != nil {
+		return nil, err
+	}
+	return NewMenuRenderer(fb, fontRenderer), nil
+}
+
+// Snapshot 返回屏幕当前内容的副本
+func (mr *MenuRenderer) Snapshot() *image.RGBA {
+	return mr.fb.Snapshot()
+}
+
+// SnapshotPage 以页面为首页绘制一次，返回绘制后的画面
+// 页面经由导航栈绘制，与实际显示时一样带有状态栏和页脚
+// 参数p: 要绘制的页面
+// 参数footer: 是否显示页脚的按键提示
+func (mr *MenuRenderer) SnapshotPage(p Page, footer bool) (*image.RGBA, error) {
+	nav := NewNavigator(mr, p)
+	nav.ShowFooter = footer
+	if err := nav.Start(); err != nil {
+		return nil, err
+	}
+	return mr.Snapshot(), nil
+}
+
+// ImageDiff 逐像素比较两张尺寸相同的图片
+// 参数got,want: 要比较的图片
+// 参数tolerance: 每个颜色分量允许的最大差值
+// 返回不同像素的个数和差异图：相同的像素以暗色显示want的内容，不同的像素标为红色
+func ImageDiff(got, want image.Image, tolerance uint8) (int, *image.RGBA) {
+	gb, wb := got.Bounds(), want.Bounds()
+	diff := image.NewRGBA(image.Rect(0, 0, wb.Dx(), wb.Dy()))
+	count := 0
+	for y := 0; y < wb.Dy(); y++ {
+		for x := 0; x < wb.Dx(); x++ {
+			g := color.RGBAModel.Convert(got.At(gb.Min.X+x, gb.Min.Y+y)).(color.RGBA)
+			w := color.RGBAModel.Convert(want.At(wb.Min.X+x, wb.Min.Y+y)).(color.RGBA)
+			if channelDiff(g.R, w.R) > tolerance || channelDiff(g.G, w.G) > tolerance || channelDiff(g.B, w.B) > tolerance {
+				count++
+				diff.SetRGBA(x, y, color.RGBA{255, 0, 0, 255})
+				continue
+			}
+			diff.SetRGBA(x, y, color.RGBA{w.R / 4, w.G / 4, w.B / 4, 255})
+		}
+	}
+	return count, diff
+}
+
+// channelDiff 返回两个颜色分量之差的绝对值
+func channelDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// CompareGolden 把画面与golden图片比较
+// 不一致时在golden图片旁写入实际画面（.actual.png）和差异图（.diff.png），便于查看哪里不同
+// 参数got: 实际的画面，通常来自Snapshot或SnapshotPage
+// 参数path: golden图片（PNG）的路径
+// 参数update: 为true时用实际画面更新golden图片，不做比较；页面有意修改后用于重新生成
+// 返回不一致的原因，一致时返回nil
+func CompareGolden(got image.Image, path string, update bool) error {
+	if update {
+		return savePNG(path, got)
+	}
+	want, err := loadPNG(path)
+	if err != nil {
+		return fmt.Errorf("读取golden图片失败: %v", err)
+	}
+	base := strings.TrimSuffix(path, ".png")
+	if got.Bounds().Size() != want.Bounds().Size() {
+		if err := savePNG(base+".actual.png", got); err != nil {
+			return err
+		}
+		return fmt.Errorf("画面尺寸%v与golden图片%s的尺寸%v不同", got.Bounds().Size(), path, want.Bounds().Size())
+	}
+	count, diff := ImageDiff(got, want, GoldenTolerance)
+	if count == 0 {
+		return nil
+	}
+	if err := savePNG(base+".actual.png", got); err != nil {
+		return err
+	}
+	if err := savePNG(base+".diff.png", diff); err != nil {
+		return err
+	}
+	return fmt.Errorf("画面与golden图片%s有%d个像素不同，差异图见%s", path, count, base+".diff.png")
+}
+
+// loadPNG 读取PNG图片
+func loadPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+// savePNG 把图片保存为PNG文件
+func savePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("无法创建图片文件: %v", err)
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return fmt.Errorf("保存图片失败: %v", err)
+	}
+	return f.Close()
+}
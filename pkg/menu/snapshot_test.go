@@ -0,0 +1,80 @@
+package menu
+
+import (
+	"flag"
+	"testing"
+
+	"go-framebuffer-console/pkg/font"
+	"go-framebuffer-console/pkg/system"
+)
+
+// update 为true时用实际画面重新生成testdata中的golden图片：go test ./pkg/menu -update
+var update = flag.Bool("update", false, "用实际画面更新testdata中的golden图片")
+
+// 生成golden图片使用的虚拟屏幕分辨率
+const (
+	snapshotWidth  = 800
+	snapshotHeight = 600
+)
+
+// newTestRenderer 创建使用内置字体、绘制到内存的菜单渲染器
+func newTestRenderer(t *testing.T) *MenuRenderer {
+	t.Helper()
+	r, err := font.NewEmbeddedRenderer(14, 72)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mr, err := NewSnapshotRenderer(snapshotWidth, snapshotHeight, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mr
+}
+
+// fixedSystemInfo 内容固定的系统信息，首页的画面不随运行环境变化
+func fixedSystemInfo() *system.SystemInfo {
+	return &system.SystemInfo{
+		Hostname:       "console-01",
+		OSName:         "Debian GNU/Linux 12 (bookworm)",
+		KernelVersion:  "6.1.0-18-amd64",
+		Architecture:   "x86_64",
+		Uptime:         "3天 4小时 5分钟",
+		CPUModel:       "Intel(R) Celeron(R) N5105 @ 2.00GHz",
+		CPUCores:       4,
+		CPUUsage:       12.5,
+		MemoryUsage:    "1024MB/4096MB",
+		MemoryUsed:     1 << 30,
+		MemoryTotal:    4 << 30,
+		DiskSize:       "256.0 GB",
+		DiskCount:      1,
+		RootUsage:      "12.3 GB/50.0 GB",
+		RootUsed:       12300 << 20,
+		RootTotal:      50000 << 20,
+		CurrentTime:    "2024-01-02 03:04:05",
+		TimeSync:       &system.TimeSync{Synchronized: true},
+		IPAddress:      "192.168.1.10",
+		QianKunCloudID: "QK-0001",
+	}
+}
+
+func TestMainMenuGolden(t *testing.T) {
+	mr := newTestRenderer(t)
+	if err := mr.RenderMainMenu(fixedSystemInfo()); err != nil {
+		t.Fatal(err)
+	}
+	if err := CompareGolden(mr.Snapshot(), "testdata/main.png", *update); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConfirmDialogGolden(t *testing.T) {
+	mr := newTestRenderer(t)
+	page := NewDialogPage(NewConfirmDialog("重启设备", "确定要重启设备吗？"), nil)
+	img, err := mr.SnapshotPage(page, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := CompareGolden(img, "testdata/confirm_dialog.png", *update); err != nil {
+		t.Error(err)
+	}
+}
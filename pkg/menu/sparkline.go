@@ -0,0 +1,129 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+
+	"go-framebuffer-console/pkg/font"
+)
+
+// sparklineWidth 未指定宽度时迷你曲线的宽度（像素）
+const sparklineWidth = 120
+
+// Sparkline 迷你曲线控件：一行中依次为标签、不带坐标轴的小曲线和说明文字
+// 用于在有限的空间里显示变化趋势，如网卡最近几分钟的收发速率；纵轴自动按窗口中的最大值缩放
+type Sparkline struct {
+	Label      string      // 左侧标签
+	Samples    []float64   // 按时间先后排列的采样值，最新的画在最右侧
+	Capacity   int         // 曲线宽度对应的采样数，采样不足时左侧留空，0表示按len(Samples)
+	Max        float64     // 纵轴最大值，0表示按采样中的最大值
+	Text       string      // 曲线右侧的说明，如当前速率
+	Color      color.Color // 曲线颜色，为nil时使用主题的强调色
+	LabelWidth int         // 标签列宽度（像素），多条曲线上下对齐时设为相同的值，0表示按标签文字宽度
+	Width      int         // 曲线宽度（像素），0表示120
+}
+
+// Measure 迷你曲线占满可用宽度，高度为一行
+func (s *Sparkline) Measure(r *font.Renderer, width int) image.Point {
+	return image.Pt(width, lineStep(r))
+}
+
+// Draw 绘制标签、曲线和说明文字
+func (s *Sparkline) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	labelWidth := s.LabelWidth
+	if labelWidth <= 0 {
+		labelWidth, _ = r.MeasureString(s.Label)
+	}
+	if err := r.RenderTextInto(dst, bounds.Min.X, bounds.Min.Y, r.TruncateToWidth(s.Label, labelWidth), theme.Foreground); err != nil {
+		return fmt.Errorf("绘制迷你曲线标签失败: %v", err)
+	}
+
+	x := bounds.Min.X + labelWidth + gaugeGap
+	width := s.Width
+	if width <= 0 {
+		width = sparklineWidth
+	}
+	if width > bounds.Max.X-x {
+		width = bounds.Max.X - x
+	}
+	if width < 2 {
+		return nil
+	}
+	plot := image.Rect(x, bounds.Min.Y+1, x+width, bounds.Min.Y+r.LineHeight()-1)
+	s.drawCurve(dst, plot)
+
+	x = plot.Max.X + gaugeGap
+	text := r.TruncateToWidth(s.Text, bounds.Max.X-x)
+	if err := r.RenderTextInto(dst, x, bounds.Min.Y, text, theme.Foreground); err != nil {
+		return fmt.Errorf("绘制迷你曲线说明失败: %v", err)
+	}
+	return nil
+}
+
+// drawCurve 在plot区域内绘制基线和曲线
+func (s *Sparkline) drawCurve(dst draw.Image, plot image.Rectangle) {
+	baseline := image.Rect(plot.Min.X, plot.Max.Y-1, plot.Max.X, plot.Max.Y)
+	draw.Draw(dst, baseline.Intersect(dst.Bounds()), &image.Uniform{theme.Line}, image.Point{}, draw.Src)
+	if len(s.Samples) == 0 {
+		return
+	}
+
+	max := s.Max
+	if max <= 0 {
+		for _, v := range s.Samples {
+			if v > max {
+				max = v
+			}
+		}
+	}
+	capacity := s.Capacity
+	if capacity < len(s.Samples) {
+		capacity = len(s.Samples)
+	}
+	if capacity < 2 {
+		capacity = 2
+	}
+
+	step := float64(plot.Dx()-1) / float64(capacity-1)
+	offset := capacity - len(s.Samples)
+	height := plot.Dy() - 1
+	lineColor := colorOr(s.Color, theme.Accent)
+	var prev image.Point
+	for i, v := range s.Samples {
+		ratio := 0.0
+		if max > 0 {
+			ratio = clampRatio(v / max)
+		}
+		p := image.Pt(plot.Min.X+int(float64(offset+i)*step+0.5), plot.Max.Y-1-int(ratio*float64(height)+0.5))
+		if i == 0 {
+			prev = p
+		}
+		drawLine(dst, prev, p, lineColor)
+		prev = p
+	}
+}
+
+// mirrorText 文本镜像中只输出标签和说明文字
+func (s *Sparkline) mirrorText() []string {
+	return []string{s.Label + " " + s.Text}
+}
+
+// AlignSparklines 让多条迷你曲线的标签列取最宽者，上下对齐
+// 参数lines: 要对齐的曲线
+// 参数capacity: 曲线宽度对应的采样数，0表示不修改各曲线的Capacity
+func (mr *MenuRenderer) AlignSparklines(lines []*Sparkline, capacity int) {
+	labelWidth := 0
+	for _, s := range lines {
+		if w, _ := mr.renderer.MeasureString(s.Label); w > labelWidth {
+			labelWidth = w
+		}
+	}
+	for _, s := range lines {
+		s.LabelWidth = labelWidth
+		if capacity > 0 {
+			s.Capacity = capacity
+		}
+	}
+}
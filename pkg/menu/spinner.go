@@ -0,0 +1,213 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"go-framebuffer-console/pkg/font"
+)
+
+// 动画参数
+const (
+	spinnerDots      = 8                      // 转圈指示器的圆点数
+	spinnerGap       = 8                      // 转圈指示器与文字之间的距离（像素）
+	activityBarWidth = 4                      // 不确定进度条中滑块占总宽度的比例（1/4）
+	activityBarStep  = 12                     // 不确定进度条每帧移动的距离（像素）
+	busyFrameRate    = 100 * time.Millisecond // 忙碌画面的动画帧间隔
+)
+
+// Spinner 转圈指示器，后面跟一行说明文字
+// 一圈圆点中亮起的位置随Frame转动，用于无法估计剩余时间的操作
+type Spinner struct {
+	Text  string // 说明文字
+	Frame int    // 动画帧序号，每加1亮点前进一格
+}
+
+// Measure 转圈指示器与文字占一行
+func (s *Spinner) Measure(r *font.Renderer, width int) image.Point {
+	w, _ := r.MeasureString(s.Text)
+	return image.Pt(r.LineHeight()+spinnerGap+w, lineStep(r))
+}
+
+// Draw 绘制一圈圆点：当前位置最亮，之后的两个逐渐变暗，其余为线条颜色
+func (s *Spinner) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	size := r.LineHeight()
+	dot := size / 6
+	if dot < 2 {
+		dot = 2
+	}
+	cx := float64(bounds.Min.X) + float64(size)/2
+	cy := float64(bounds.Min.Y) + float64(size)/2
+	radius := float64(size)/2 - float64(dot)
+
+	for i := 0; i < spinnerDots; i++ {
+		col := theme.Line
+		switch (s.Frame - i + spinnerDots*1024) % spinnerDots {
+		case 0:
+			col = theme.Foreground
+		case 1, 2:
+			col = blend(theme.Foreground, theme.Line)
+		}
+		angle := 2 * math.Pi * float64(i) / spinnerDots
+		x := int(cx + radius*math.Sin(angle))
+		y := int(cy - radius*math.Cos(angle))
+		rect := image.Rect(x-dot/2, y-dot/2, x-dot/2+dot, y-dot/2+dot)
+		draw.Draw(dst, rect.Intersect(bounds).Intersect(dst.Bounds()), &image.Uniform{col}, image.Point{}, draw.Src)
+	}
+
+	x := bounds.Min.X + size + spinnerGap
+	text := r.TruncateToWidth(s.Text, bounds.Max.X-x)
+	if err := r.RenderTextInto(dst, x, bounds.Min.Y, text, theme.Foreground); err != nil {
+		return fmt.Errorf("绘制转圈指示器失败: %v", err)
+	}
+	return nil
+}
+
+// mirrorText 文本镜像中只输出说明文字，动画帧变化时镜像内容不变
+func (s *Spinner) mirrorText() []string {
+	return []string{"... " + s.Text}
+}
+
+// blend 返回两种颜色的中间色
+func blend(a, b color.Color) color.Color {
+	ar, ag, ab, _ := a.RGBA()
+	br, bg, bb, _ := b.RGBA()
+	return color.RGBA{uint8((ar + br) >> 9), uint8((ag + bg) >> 9), uint8((ab + bb) >> 9), 255}
+}
+
+// ActivityBar 不确定进度条：边框内的滑块来回移动，表示操作仍在进行
+// Known为true时改为按Ratio填充的普通进度条
+type ActivityBar struct {
+	Height int     // 高度（像素），0表示半行高
+	Frame  int     // 动画帧序号，每加1滑块移动一步
+	Known  bool    // 进度是否已知
+	Ratio  float64 // 已知的进度（0-1）
+}
+
+// Measure 进度条占满可用宽度
+func (b *ActivityBar) Measure(r *font.Renderer, width int) image.Point {
+	if b.Height > 0 {
+		return image.Pt(width, b.Height)
+	}
+	return image.Pt(width, r.LineHeight()/2)
+}
+
+// Draw 绘制边框和当前位置的滑块
+func (b *ActivityBar) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	if bounds.Dx() < 4 || bounds.Dy() < 4 {
+		return nil
+	}
+	if b.Known {
+		drawBar(dst, bounds, b.Ratio, theme.Accent)
+		return nil
+	}
+	drawOutline(dst, bounds, theme.Line)
+
+	inner := bounds.Inset(2)
+	w := inner.Dx() / activityBarWidth
+	x := inner.Min.X + bounce(b.Frame*activityBarStep, inner.Dx()-w)
+	slider := image.Rect(x, inner.Min.Y, x+w, inner.Max.Y)
+	draw.Draw(dst, slider.Intersect(dst.Bounds()), &image.Uniform{theme.Accent}, image.Point{}, draw.Src)
+	return nil
+}
+
+// mirrorText 进度已知时文本镜像中输出进度条和百分比，不确定进度时不输出
+func (b *ActivityBar) mirrorText() []string {
+	if !b.Known {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s %d%%", textBar(b.Ratio, gaugeMirror), int(clampRatio(b.Ratio)*100))}
+}
+
+// BusyIndicator 长时间操作期间显示的忙碌画面
+// 画面由标题、转圈指示器、说明文字和不确定进度条（调用SetProgress后为普通进度条）组成；后台goroutine每隔一小段时间只重绘动画部分，
+// 操作本身可以阻塞调用它的goroutine，只需通过SetMessage报告进度。绘制由同一把锁保护，
+// 在Stop返回之前调用方不能再用菜单渲染器绘制其它页面
+type BusyIndicator struct {
+	mr      *MenuRenderer
+	mu      sync.Mutex
+	title   string
+	layout  *Layout
+	spinner *Spinner
+	bar     *ActivityBar
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// StartBusy 显示忙碌画面并开始播放动画
+// 参数title: 画面标题，如"网络连通性测试"
+// 参数message: 说明文字，可包含多行，第一行显示在转圈指示器后面
+func (mr *MenuRenderer) StartBusy(title, message string) *BusyIndicator {
+	b := &BusyIndicator{
+		mr:      mr,
+		title:   title,
+		spinner: &Spinner{},
+		bar:     &ActivityBar{},
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	b.SetMessage(message)
+	go b.animate()
+	return b
+}
+
+// SetMessage 更新说明文字并重绘整个画面
+// 参数message: 说明文字，可包含多行，第一行显示在转圈指示器后面
+func (b *BusyIndicator) SetMessage(message string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	lines := strings.Split(message, "\n")
+	b.spinner.Text = lines[0]
+	b.layout = b.mr.NewLayout(&Label{Text: b.title, Color: theme.Accent}, NewSeparator(), b.spinner)
+	if rest := strings.TrimLeft(strings.Join(lines[1:], "\n"), "\n"); rest != "" {
+		b.layout.Add(NewLabel(rest))
+	}
+	b.layout.Add(&Spacer{Height: lineStep(b.mr.renderer) / 2}, b.bar)
+	// 绘制失败只影响进度显示，不中断操作
+	if b.mr.RenderLayout(b.layout) == nil {
+		_ = b.mr.drawHeader()
+	}
+}
+
+// SetProgress 把进度条改为按比例填充并更新说明文字，用于能够估计进度的操作（如按时长进行的测速）
+// 参数ratio: 进度（0-1）
+// 参数message: 说明文字，与SetMessage相同
+func (b *BusyIndicator) SetProgress(ratio float64, message string) {
+	b.mu.Lock()
+	b.bar.Known, b.bar.Ratio = true, clampRatio(ratio)
+	b.mu.Unlock()
+	b.SetMessage(message)
+}
+
+// animate 按固定间隔推进动画帧，只重绘转圈指示器和进度条
+func (b *BusyIndicator) animate() {
+	defer close(b.done)
+	ticker := time.NewTicker(busyFrameRate)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-ticker.C:
+			b.mu.Lock()
+			b.spinner.Frame++
+			b.bar.Frame++
+			_ = b.layout.RedrawWidget(b.mr.fb, b.spinner)
+			_ = b.layout.RedrawWidget(b.mr.fb, b.bar)
+			b.mu.Unlock()
+		}
+	}
+}
+
+// Stop 停止动画，等待后台goroutine退出后返回，之后可以正常绘制其它页面
+func (b *BusyIndicator) Stop() {
+	close(b.stop)
+	<-b.done
+}
@@ -0,0 +1,281 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+
+	"golang.org/x/text/width"
+
+	"go-framebuffer-console/pkg/font"
+)
+
+// defaultColumnGap 表格列与列之间的默认间距（像素）
+const defaultColumnGap = 16
+
+// Column 表格的一列
+type Column struct {
+	Title string    // 列标题，所有列的标题都为空时不显示表头
+	Width int       // 列宽（像素），0表示按标题和内容的实际宽度自动计算
+	Align Alignment // 单元格的水平对齐方式
+	// Color 按单元格的内容决定文字颜色，如状态列；为nil或返回nil时使用行的颜色
+	Color func(cell string) color.Color
+}
+
+// Table 按像素宽度对齐的表格
+// 列宽由字体实际测量得到，中英文混排时各列依然对齐；
+// 总宽度超过可用宽度时，最宽的列依次收窄，放不下的单元格在列内来回滚动（见Marquee）
+type Table struct {
+	Columns     []Column      // 列定义
+	Rows        [][]string    // 各行的单元格文字，缺少的单元格视为空
+	RowColors   []color.Color // 与Rows一一对应的行颜色，未指定时使用Color
+	Color       color.Color   // 单元格文字颜色，为nil时使用主题的文字颜色
+	HeaderColor color.Color   // 表头文字颜色，为nil时使用主题的强调色
+	HeaderLine  bool          // 是否在表头下方画分隔线
+	Gap         int           // 列间距（像素），0表示使用默认间距
+}
+
+// NewTable 创建表格，表头下方带分隔线
+// 参数titles: 各列的标题
+func NewTable(titles ...string) *Table {
+	t := &Table{HeaderLine: true}
+	for _, title := range titles {
+		t.Columns = append(t.Columns, Column{Title: title})
+	}
+	return t
+}
+
+// AddRow 追加一行
+// 参数cells: 各单元格的文字
+func (t *Table) AddRow(cells ...string) *Table {
+	t.Rows = append(t.Rows, cells)
+	return t
+}
+
+// AddColoredRow 追加一行指定颜色的单元格
+// 参数c: 该行文字的颜色
+// 参数cells: 各单元格的文字
+func (t *Table) AddColoredRow(c color.Color, cells ...string) *Table {
+	for len(t.RowColors) < len(t.Rows) {
+		t.RowColors = append(t.RowColors, nil)
+	}
+	t.Rows = append(t.Rows, cells)
+	t.RowColors = append(t.RowColors, c)
+	return t
+}
+
+// hasHeader 判断是否需要显示表头
+func (t *Table) hasHeader() bool {
+	for _, col := range t.Columns {
+		if col.Title != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// gap 返回列间距
+func (t *Table) gap() int {
+	if t.Gap <= 0 {
+		return defaultColumnGap
+	}
+	return t.Gap
+}
+
+// cell 返回指定行列的单元格文字
+func (t *Table) cell(row []string, col int) string {
+	if col < len(row) {
+		return row[col]
+	}
+	return ""
+}
+
+// columnWidths 计算各列的像素宽度，总宽度不超过maxWidth
+func (t *Table) columnWidths(r *font.Renderer, maxWidth int) []int {
+	widths := make([]int, len(t.Columns))
+	total := 0
+	for i, col := range t.Columns {
+		w := col.Width
+		if w <= 0 {
+			w, _ = r.MeasureString(col.Title)
+			for _, row := range t.Rows {
+				if cw, _ := r.MeasureString(t.cell(row, i)); cw > w {
+					w = cw
+				}
+			}
+		}
+		widths[i] = w
+		total += w
+	}
+	if n := len(widths); n > 0 {
+		total += (n - 1) * t.gap()
+		// 放不下时逐像素收窄当前最宽的列，使各列尽量都能显示一部分内容
+		for over := total - maxWidth; over > 0; over-- {
+			widest := 0
+			for i, w := range widths {
+				if w > widths[widest] {
+					widest = i
+				}
+			}
+			if widths[widest] == 0 {
+				break
+			}
+			widths[widest]--
+		}
+	}
+	return widths
+}
+
+// rowCount 返回表格占用的行数（含表头）
+func (t *Table) rowCount() int {
+	if t.hasHeader() {
+		return len(t.Rows) + 1
+	}
+	return len(t.Rows)
+}
+
+// headerLineHeight 返回表头分隔线占用的高度
+func (t *Table) headerLineHeight() int {
+	if t.hasHeader() && t.HeaderLine {
+		return defaultLineSpacing + 1
+	}
+	return 0
+}
+
+// Measure 返回表格的尺寸
+func (t *Table) Measure(r *font.Renderer, width int) image.Point {
+	w := 0
+	for _, cw := range t.columnWidths(r, width) {
+		w += cw
+	}
+	if n := len(t.Columns); n > 1 {
+		w += (n - 1) * t.gap()
+	}
+	return image.Pt(w, t.rowCount()*lineStep(r)+t.headerLineHeight())
+}
+
+// Draw 绘制表头和各行
+func (t *Table) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	widths := t.columnWidths(r, bounds.Dx())
+	y := bounds.Min.Y
+
+	if t.hasHeader() {
+		titles := make([]string, len(t.Columns))
+		for i, col := range t.Columns {
+			titles[i] = col.Title
+		}
+		if err := t.drawRow(r, dst, bounds, y, widths, titles, colorOr(t.HeaderColor, theme.Accent), true); err != nil {
+			return err
+		}
+		y += lineStep(r)
+		if t.HeaderLine {
+			total := 0
+			for _, w := range widths {
+				total += w
+			}
+			total += (len(widths) - 1) * t.gap()
+			line := image.Rect(bounds.Min.X, y, bounds.Min.X+total, y+1)
+			draw.Draw(dst, line.Intersect(dst.Bounds()), &image.Uniform{theme.Line}, image.Point{}, draw.Src)
+			y += t.headerLineHeight()
+		}
+	}
+
+	for i, row := range t.Rows {
+		if y+r.LineHeight() > bounds.Max.Y {
+			break // 被页面底部裁掉的行
+		}
+		col := colorOr(t.Color, theme.Foreground)
+		if i < len(t.RowColors) && t.RowColors[i] != nil {
+			col = t.RowColors[i]
+		}
+		if err := t.drawRow(r, dst, bounds, y, widths, row, col, false); err != nil {
+			return err
+		}
+		y += lineStep(r)
+	}
+	return nil
+}
+
+// drawRow 按列宽和对齐方式绘制一行单元格
+// 参数header: 是否是表头，表头不使用列的Color
+func (t *Table) drawRow(r *font.Renderer, dst draw.Image, bounds image.Rectangle, y int, widths []int, cells []string, col color.Color, header bool) error {
+	x := bounds.Min.X
+	for i, w := range widths {
+		cellBounds := image.Rect(x, y, x+w, y+r.LineHeight())
+		text := t.cell(cells, i)
+		cellColor := col
+		if t.Columns[i].Color != nil && !header {
+			cellColor = colorOr(t.Columns[i].Color(text), col)
+		}
+		if err := drawMarqueeText(r, dst, cellBounds, text, cellColor, theme.Background, t.Columns[i].Align); err != nil {
+			return fmt.Errorf("绘制表格失败: %v", err)
+		}
+		x += w + t.gap()
+	}
+	return nil
+}
+
+// mirrorText 文本镜像中按字符宽度（全角字符计为2）用空格对齐各列
+func (t *Table) mirrorText() []string {
+	rows := t.Rows
+	if t.hasHeader() {
+		titles := make([]string, len(t.Columns))
+		for i, col := range t.Columns {
+			titles[i] = col.Title
+		}
+		rows = append([][]string{titles}, rows...)
+	}
+
+	widths := make([]int, len(t.Columns))
+	for _, row := range rows {
+		for i := range widths {
+			if w := textColumns(t.cell(row, i)); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	var lines []string
+	for n, row := range rows {
+		var b strings.Builder
+		for i, w := range widths {
+			text := t.cell(row, i)
+			pad := strings.Repeat(" ", w-textColumns(text))
+			if t.Columns[i].Align == AlignRight {
+				b.WriteString(pad + text)
+			} else if i < len(widths)-1 {
+				b.WriteString(text + pad)
+			} else {
+				b.WriteString(text)
+			}
+			if i < len(widths)-1 {
+				b.WriteString("  ")
+			}
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+		if n == 0 && t.hasHeader() && t.HeaderLine {
+			total := 0
+			for _, w := range widths {
+				total += w + 2
+			}
+			lines = append(lines, strings.Repeat("-", total-2))
+		}
+	}
+	return lines
+}
+
+// textColumns 返回文本在等宽终端中占用的列数，全角和宽字符计为2
+func textColumns(s string) int {
+	n := 0
+	for _, ch := range s {
+		switch width.LookupRune(ch).Kind() {
+		case width.EastAsianWide, width.EastAsianFullwidth:
+			n += 2
+		default:
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,122 @@
+package menu
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+)
+
+// SeparatorStyle 分隔线的样式
+type SeparatorStyle int
+
+const (
+	SeparatorSolid  SeparatorStyle = iota // 实线
+	SeparatorDashed                       // 虚线
+	SeparatorDouble                       // 双线
+	SeparatorNone                         // 不画线，只保留间距
+)
+
+// ParseSeparatorStyle 解析配置文件中的分隔线样式名称（solid、dashed、double、none）
+func ParseSeparatorStyle(name string) (SeparatorStyle, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "solid":
+		return SeparatorSolid, nil
+	case "dashed":
+		return SeparatorDashed, nil
+	case "double":
+		return SeparatorDouble, nil
+	case "none":
+		return SeparatorNone, nil
+	}
+	return SeparatorSolid, fmt.Errorf("未知的分隔线样式: %s", name)
+}
+
+// Theme 界面配色
+// 主题对菜单渲染器绘制的所有页面和控件生效；控件自身指定了颜色时优先使用控件的颜色
+type Theme struct {
+	Background color.Color    // 背景色，也用于高亮条和焦点按钮上的反色文字
+	Foreground color.Color    // 文字颜色
+	Accent     color.Color    // 强调色，用于表头、对话框标题和提示
+	Line       color.Color    // 分隔线、未聚焦的边框和滚动条轨道颜色
+	Success    color.Color    // 正常状态颜色
+	Warning    color.Color    // 警告颜色
+	Error      color.Color    // 错误颜色
+	Separator  SeparatorStyle // 分隔线样式
+}
+
+// 预设主题
+var themes = map[string]Theme{
+	"dark": {
+		Background: color.RGBA{0, 0, 0, 255},
+		Foreground: color.RGBA{255, 255, 255, 255},
+		Accent:     color.RGBA{160, 200, 255, 255},
+		Line:       color.RGBA{128, 128, 128, 255},
+		Success:    color.RGBA{0, 220, 0, 255},
+		Warning:    color.RGBA{255, 200, 0, 255},
+		Error:      color.RGBA{255, 60, 60, 255},
+	},
+	"light": {
+		Background: color.RGBA{240, 240, 240, 255},
+		Foreground: color.RGBA{20, 20, 20, 255},
+		Accent:     color.RGBA{0, 80, 170, 255},
+		Line:       color.RGBA{150, 150, 150, 255},
+		Success:    color.RGBA{0, 130, 0, 255},
+		Warning:    color.RGBA{180, 110, 0, 255},
+		Error:      color.RGBA{200, 0, 0, 255},
+	},
+}
+
+// DefaultTheme 返回默认主题：黑底白字
+func DefaultTheme() Theme {
+	return themes["dark"]
+}
+
+// ThemeNamed 返回预设主题
+// 参数name: 主题名称（dark、light），为空时返回默认主题
+func ThemeNamed(name string) (Theme, error) {
+	if name == "" {
+		return DefaultTheme(), nil
+	}
+	t, ok := themes[strings.ToLower(name)]
+	if !ok {
+		return DefaultTheme(), fmt.Errorf("未知的主题: %s", name)
+	}
+	return t, nil
+}
+
+// withDefaults 用默认主题补全未设置的颜色
+func (t Theme) withDefaults() Theme {
+	def := DefaultTheme()
+	t.Background = colorOr(t.Background, def.Background)
+	t.Foreground = colorOr(t.Foreground, def.Foreground)
+	t.Accent = colorOr(t.Accent, def.Accent)
+	t.Line = colorOr(t.Line, def.Line)
+	t.Success = colorOr(t.Success, def.Success)
+	t.Warning = colorOr(t.Warning, def.Warning)
+	t.Error = colorOr(t.Error, def.Error)
+	return t
+}
+
+// theme 当前使用的主题，控件绘制时从这里取默认颜色
+var theme = DefaultTheme()
+
+// SetTheme 切换界面主题，下次绘制页面时生效
+// 参数t: 新主题，未设置的颜色使用默认主题的颜色
+func (mr *MenuRenderer) SetTheme(t Theme) {
+	theme = t.withDefaults()
+	mr.InvalidateCache()
+}
+
+// Theme 返回当前使用的主题
+func (mr *MenuRenderer) Theme() Theme {
+	return theme
+}
+
+// StatusDot 返回放在状态文字前的圆点，配合正常、警告、错误颜色表示状态
+// 当前字体没有"●"的字形时（如只含拉丁字符的字体）使用"*"，避免显示为方框
+func (mr *MenuRenderer) StatusDot() string {
+	if mr.renderer.HasGlyph('●') {
+		return "●"
+	}
+	return "*"
+}
@@ -0,0 +1,80 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"strings"
+	"time"
+
+	"go-framebuffer-console/pkg/framebuffer"
+)
+
+// TransitionStyle 页面切换时的过渡效果
+type TransitionStyle int
+
+const (
+	TransitionNone  TransitionStyle = iota // 直接显示新页面
+	TransitionSlide                        // 进入下一页时新页面从右侧推入，返回时从左侧推入
+	TransitionFade                         // 上一页逐渐变为新页面
+)
+
+// ParseTransitionStyle 解析配置文件中的过渡效果名称（none、slide、fade）
+func ParseTransitionStyle(name string) (TransitionStyle, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "none":
+		return TransitionNone, nil
+	case "slide":
+		return TransitionSlide, nil
+	case "fade":
+		return TransitionFade, nil
+	}
+	return TransitionNone, fmt.Errorf("未知的过渡效果: %s", name)
+}
+
+// 过渡动画的时长和帧数
+const (
+	transitionDuration = 240 * time.Millisecond
+	transitionFrames   = 8
+)
+
+// SetTransition 设置页面切换时的过渡效果
+// 第一帧的合成时间超过帧间隔的设备（如CPU较慢或分辨率很高）会自动关闭过渡效果，直接显示新页面
+// 参数style: 过渡效果
+func (mr *MenuRenderer) SetTransition(style TransitionStyle) {
+	mr.transition = style
+}
+
+// beginTransition 需要过渡效果时开始在后备缓冲区中绘制新页面，返回是否开始
+func (mr *MenuRenderer) beginTransition() bool {
+	return mr.transition != TransitionNone && mr.fb.BeginBackBuffer()
+}
+
+// finishTransition 结束在后备缓冲区中的绘制，逐帧显示从上一页到新页面的过渡
+// 页眉和页脚不参与过渡，直接显示新页面的内容
+// 参数back: 是否是返回上一页，滑动时决定新页面推入的方向
+func (mr *MenuRenderer) finishTransition(back bool) {
+	mr.fb.EndBackBuffer()
+	effect := framebuffer.EffectFade
+	if mr.transition == TransitionSlide {
+		effect = framebuffer.EffectSlideLeft
+		if back {
+			effect = framebuffer.EffectSlideRight
+		}
+	}
+	area := image.Rect(0, mr.headerHeight(), mr.width, mr.height-mr.footerHeight())
+
+	frame := transitionDuration / transitionFrames
+	start := time.Now()
+	for i := 1; i <= transitionFrames; i++ {
+		// 先快后慢，接近结束时放缓
+		p := float64(i) / transitionFrames
+		mr.fb.Transition(effect, area, 1-(1-p)*(1-p)*(1-p))
+		if i == 1 && time.Since(start) > frame {
+			// 设备太慢，动画会一顿一顿的：直接显示新页面，以后不再使用过渡效果
+			mr.fb.Transition(effect, area, 1)
+			mr.transition = TransitionNone
+			return
+		}
+		time.Sleep(time.Until(start.Add(time.Duration(i) * frame)))
+	}
+}
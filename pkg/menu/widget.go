@@ -0,0 +1,536 @@
+package menu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+
+	"go-framebuffer-console/pkg/font"
+)
+
+// 页面布局的默认参数，与原有页面的边距和行距一致
+const (
+	defaultPageMargin  = 20 // 页面四周的边距（像素）
+	defaultLineSpacing = 3  // 行与行、控件与控件之间的间距（像素）
+	buttonPadding      = 6  // 按钮文字与边框之间的距离（像素）
+)
+
+// Widget 界面控件
+// 页面由若干控件自上而下排列组成，控件只负责测量和绘制自身，位置由Layout统一布局
+type Widget interface {
+	// Measure 返回控件在给定可用宽度下需要的尺寸
+	Measure(r *font.Renderer, width int) image.Point
+	// Draw 在bounds指定的区域内绘制控件
+	Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error
+}
+
+// clickable 可以被鼠标点击的控件
+type clickable interface {
+	// hitAreas 返回控件绘制在bounds区域时的可点击区域
+	hitAreas(r *font.Renderer, bounds image.Rectangle) []HitArea
+}
+
+// textual 可以输出到文本镜像的控件
+type textual interface {
+	// mirrorText 返回控件的纯文本形式
+	mirrorText() []string
+}
+
+// Alignment 文字的水平对齐方式
+type Alignment int
+
+// 对齐方式常量
+const (
+	AlignLeft   Alignment = iota // 左对齐
+	AlignCenter                  // 居中
+	AlignRight                   // 右对齐
+)
+
+// alignX 返回宽度为w的内容在区域内按对齐方式摆放时的左边界
+func alignX(align Alignment, bounds image.Rectangle, w int) int {
+	switch align {
+	case AlignCenter:
+		return bounds.Min.X + (bounds.Dx()-w)/2
+	case AlignRight:
+		return bounds.Max.X - w
+	}
+	return bounds.Min.X
+}
+
+// lineStep 返回一行文字占用的高度（含行距）
+func lineStep(r *font.Renderer) int {
+	return r.LineHeight() + defaultLineSpacing
+}
+
+// colorOr 返回c，c为nil时返回默认颜色
+func colorOr(c, def color.Color) color.Color {
+	if c == nil {
+		return def
+	}
+	return c
+}
+
+// Label 文字标签，文本中的换行符分隔多行，超出宽度的行被截断并加省略号，开启Wrap时折行显示
+type Label struct {
+	Text  string      // 文字
+	Color color.Color // 文字颜色，为nil时使用主题的文字颜色
+	Align Alignment   // 水平对齐方式
+	Key   byte        // 点击时模拟的按键，0表示不可点击
+	Wrap  bool        // 是否折行显示超出宽度的行
+}
+
+// NewLabel 创建左对齐、使用主题文字颜色的标签
+func NewLabel(text string) *Label {
+	return &Label{Text: text}
+}
+
+// lines 返回标签的各行文字
+func (l *Label) lines() []string {
+	return strings.Split(l.Text, "\n")
+}
+
+// rows 返回宽度为width时显示的各行，开启Wrap时为折行后的各行
+func (l *Label) rows(r *font.Renderer, width int) []string {
+	if l.Wrap {
+		return r.WrapText(l.Text, width)
+	}
+	return l.lines()
+}
+
+// Measure 返回标签的尺寸：最宽一行的宽度和所有行的高度
+func (l *Label) Measure(r *font.Renderer, width int) image.Point {
+	lines := l.rows(r, width)
+	w := 0
+	for _, line := range lines {
+		if lw, _ := r.MeasureString(line); lw > w {
+			w = lw
+		}
+	}
+	if w > width {
+		w = width
+	}
+	return image.Pt(w, len(lines)*lineStep(r))
+}
+
+// Draw 逐行绘制文字
+func (l *Label) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	y := bounds.Min.Y
+	for _, line := range l.rows(r, bounds.Dx()) {
+		if y+r.LineHeight() > bounds.Max.Y {
+			break // 被页面底部裁掉的行
+		}
+		line = r.TruncateToWidth(line, bounds.Dx())
+		w, _ := r.MeasureString(line)
+		if err := r.RenderTextInto(dst, alignX(l.Align, bounds, w), y, line, colorOr(l.Color, theme.Foreground)); err != nil {
+			return fmt.Errorf("绘制文字失败: %v", err)
+		}
+		y += lineStep(r)
+	}
+	return nil
+}
+
+// hitAreas 设置了按键的标签整体可以点击
+func (l *Label) hitAreas(r *font.Renderer, bounds image.Rectangle) []HitArea {
+	if l.Key == 0 {
+		return nil
+	}
+	return []HitArea{{Rect: bounds, Key: l.Key}}
+}
+
+// mirrorText 返回标签的文字
+func (l *Label) mirrorText() []string {
+	return l.lines()
+}
+
+// Separator 水平分隔线，占用半行的高度，线条位于中间
+// 线条的样式（实线、虚线、双线）由主题决定
+type Separator struct {
+	Color     color.Color // 线条颜色，为nil时使用主题的线条颜色
+	Thickness int         // 线条粗细（像素），0表示1像素
+}
+
+// 虚线每段的长度和间隔（像素）
+const (
+	dashLength = 6
+	dashGap    = 4
+)
+
+// NewSeparator 创建默认样式的分隔线
+func NewSeparator() *Separator {
+	return &Separator{}
+}
+
+// Measure 分隔线占满可用宽度
+func (s *Separator) Measure(r *font.Renderer, width int) image.Point {
+	return image.Pt(width, lineStep(r)/2+s.height())
+}
+
+// thickness 返回线条粗细
+func (s *Separator) thickness() int {
+	if s.Thickness <= 0 {
+		return 1
+	}
+	return s.Thickness
+}
+
+// height 返回线条占用的总高度，双线为两条线加上中间的间隔
+func (s *Separator) height() int {
+	if theme.Separator == SeparatorDouble {
+		return 3 * s.thickness()
+	}
+	return s.thickness()
+}
+
+// Draw 在区域中间按主题的样式画横线
+func (s *Separator) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	col := &image.Uniform{colorOr(s.Color, theme.Line)}
+	t := s.thickness()
+	y := bounds.Min.Y + (bounds.Dy()-s.height())/2
+	fill := func(rect image.Rectangle) {
+		draw.Draw(dst, rect.Intersect(bounds).Intersect(dst.Bounds()), col, image.Point{}, draw.Src)
+	}
+
+	switch theme.Separator {
+	case SeparatorNone:
+	case SeparatorDashed:
+		for x := bounds.Min.X; x < bounds.Max.X; x += dashLength + dashGap {
+			fill(image.Rect(x, y, x+dashLength, y+t))
+		}
+	case SeparatorDouble:
+		fill(image.Rect(bounds.Min.X, y, bounds.Max.X, y+t))
+		fill(image.Rect(bounds.Min.X, y+2*t, bounds.Max.X, y+3*t))
+	default:
+		fill(image.Rect(bounds.Min.X, y, bounds.Max.X, y+t))
+	}
+	return nil
+}
+
+// mirrorText 文本镜像中以与样式对应的制表符横线表示分隔线
+func (s *Separator) mirrorText() []string {
+	if theme.Separator == SeparatorNone {
+		return []string{""}
+	}
+	return []string{strings.Repeat(mirrorBoxChars().horizontal, frameMirrorWidth)}
+}
+
+// Spacer 空白，用于在控件之间留出固定的高度
+type Spacer struct {
+	Height int // 空白的高度（像素）
+}
+
+// Measure 空白只占高度
+func (s *Spacer) Measure(r *font.Renderer, width int) image.Point {
+	return image.Pt(0, s.Height)
+}
+
+// Draw 空白不绘制任何内容
+func (s *Spacer) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	return nil
+}
+
+// Button 带边框的按钮，点击效果等同于按下对应的按键
+type Button struct {
+	Text  string      // 按钮文字
+	Key   byte        // 点击时模拟的按键
+	Color color.Color // 文字和边框颜色，为nil时使用主题的文字颜色
+}
+
+// NewButton 创建按钮
+// 参数text: 按钮文字
+// 参数key: 点击时模拟的按键
+func NewButton(text string, key byte) *Button {
+	return &Button{Text: text, Key: key}
+}
+
+// Measure 按钮的尺寸为文字加四周留白
+func (b *Button) Measure(r *font.Renderer, width int) image.Point {
+	w, _ := r.MeasureString(b.Text)
+	w += 2 * buttonPadding
+	if w > width {
+		w = width
+	}
+	return image.Pt(w, r.LineHeight()+2*buttonPadding)
+}
+
+// Draw 绘制边框和居中的文字
+func (b *Button) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	size := b.Measure(r, bounds.Dx())
+	box := image.Rect(bounds.Min.X, bounds.Min.Y, bounds.Min.X+size.X, bounds.Min.Y+size.Y)
+	col := colorOr(b.Color, theme.Foreground)
+	drawOutline(dst, box, col)
+
+	text := r.TruncateToWidth(b.Text, box.Dx()-2*buttonPadding)
+	w, _ := r.MeasureString(text)
+	if err := r.RenderTextInto(dst, alignX(AlignCenter, box, w), box.Min.Y+buttonPadding, text, col); err != nil {
+		return fmt.Errorf("绘制按钮失败: %v", err)
+	}
+	return nil
+}
+
+// hitAreas 按钮边框以内可以点击
+func (b *Button) hitAreas(r *font.Renderer, bounds image.Rectangle) []HitArea {
+	size := b.Measure(r, bounds.Dx())
+	return []HitArea{{Rect: image.Rect(bounds.Min.X, bounds.Min.Y, bounds.Min.X+size.X, bounds.Min.Y+size.Y), Key: b.Key}}
+}
+
+// mirrorText 文本镜像中按钮显示为[文字]
+func (b *Button) mirrorText() []string {
+	return []string{"[" + b.Text + "]"}
+}
+
+// ListItem 列表中的一项
+type ListItem struct {
+	Text  string      // 显示的文字
+	Key   byte        // 选择该项时模拟的按键，0表示不可选择
+	Color color.Color // 文字颜色，为nil时使用列表的颜色
+}
+
+// List 每行一项的列表，点击某一项等同于按下该项的按键
+// 开启Selectable后，当前选中项以反色高亮条显示，可以用方向键移动选中项、回车激活
+type List struct {
+	Items      []ListItem  // 列表项
+	Color      color.Color // 文字颜色，为nil时使用主题的文字颜色
+	Selectable bool        // 是否显示选中项高亮条
+	Selected   int         // 当前选中项的下标
+}
+
+// NewList 创建列表
+func NewList(items ...ListItem) *List {
+	return &List{Items: items}
+}
+
+// selectable 判断下标对应的项是否可以被选中
+func (l *List) selectable(i int) bool {
+	return i >= 0 && i < len(l.Items) && l.Items[i].Key != 0
+}
+
+// Move 把选中项向下（delta为正）或向上（delta为负）移动，跳过不可选择的项，到达两端后回绕
+func (l *List) Move(delta int) {
+	n := len(l.Items)
+	if n == 0 || delta == 0 {
+		return
+	}
+	step := 1
+	if delta < 0 {
+		step, delta = -1, -delta
+	}
+	i := l.Selected
+	for ; delta > 0; delta-- {
+		for tries := 0; tries < n; tries++ {
+			i = ((i+step)%n + n) % n
+			if l.selectable(i) {
+				break
+			}
+		}
+	}
+	if l.selectable(i) {
+		l.Selected = i
+	}
+}
+
+// Select 选中按键为key的项，返回是否找到
+func (l *List) Select(key byte) bool {
+	for i, item := range l.Items {
+		if item.Key != 0 && item.Key == key {
+			l.Selected = i
+			return true
+		}
+	}
+	return false
+}
+
+// SelectedKey 返回当前选中项的按键，没有可选中的项时返回false
+func (l *List) SelectedKey() (byte, bool) {
+	if !l.selectable(l.Selected) {
+		return 0, false
+	}
+	return l.Items[l.Selected].Key, true
+}
+
+// Measure 列表的尺寸：最宽一项的宽度和所有项的高度
+func (l *List) Measure(r *font.Renderer, width int) image.Point {
+	w := 0
+	for _, item := range l.Items {
+		if iw, _ := r.MeasureString(item.Text); iw > w {
+			w = iw
+		}
+	}
+	if w > width {
+		w = width
+	}
+	return image.Pt(w, len(l.Items)*lineStep(r))
+}
+
+// Draw 逐行绘制列表项，选中项先铺满一行前景色再以背景色绘制文字；放不下的项来回滚动
+func (l *List) Draw(r *font.Renderer, dst draw.Image, bounds image.Rectangle) error {
+	y := bounds.Min.Y
+	step := lineStep(r)
+	for i, item := range l.Items {
+		if y+r.LineHeight() > bounds.Max.Y {
+			break // 被页面底部裁掉的项
+		}
+		col := colorOr(item.Color, colorOr(l.Color, theme.Foreground))
+		bg := theme.Background
+		if l.Selectable && i == l.Selected && l.selectable(i) {
+			bar := image.Rect(bounds.Min.X, y, bounds.Max.X, y+step)
+			draw.Draw(dst, bar.Intersect(dst.Bounds()), &image.Uniform{col}, image.Point{}, draw.Src)
+			col, bg = theme.Background, col
+		}
+		row := image.Rect(bounds.Min.X, y, bounds.Max.X, y+r.LineHeight())
+		if err := drawMarqueeText(r, dst, row, item.Text, col, bg, AlignLeft); err != nil {
+			return fmt.Errorf("绘制列表项失败: %v", err)
+		}
+		y += step
+	}
+	return nil
+}
+
+// hitAreas 每个设置了按键的列表项占一行可点击区域
+func (l *List) hitAreas(r *font.Renderer, bounds image.Rectangle) []HitArea {
+	var areas []HitArea
+	step := lineStep(r)
+	for i, item := range l.Items {
+		if item.Key == 0 {
+			continue
+		}
+		top := bounds.Min.Y + i*step
+		areas = append(areas, HitArea{Rect: image.Rect(bounds.Min.X, top, bounds.Max.X, top+step), Key: item.Key})
+	}
+	return areas
+}
+
+// mirrorText 返回各项的文字，选中项在文本镜像中以"> "标出
+func (l *List) mirrorText() []string {
+	lines := make([]string, len(l.Items))
+	for i, item := range l.Items {
+		lines[i] = item.Text
+		if l.Selectable {
+			if i == l.Selected && l.selectable(i) {
+				lines[i] = "> " + item.Text
+			} else {
+				lines[i] = "  " + item.Text
+			}
+		}
+	}
+	return lines
+}
+
+// drawOutline 绘制矩形边框
+func drawOutline(dst draw.Image, rect image.Rectangle, col color.Color) {
+	src := &image.Uniform{col}
+	edges := []image.Rectangle{
+		image.Rect(rect.Min.X, rect.Min.Y, rect.Max.X, rect.Min.Y+1),
+		image.Rect(rect.Min.X, rect.Max.Y-1, rect.Max.X, rect.Max.Y),
+		image.Rect(rect.Min.X, rect.Min.Y, rect.Min.X+1, rect.Max.Y),
+		image.Rect(rect.Max.X-1, rect.Min.Y, rect.Max.X, rect.Max.Y),
+	}
+	for _, edge := range edges {
+		draw.Draw(dst, edge.Intersect(dst.Bounds()), src, image.Point{}, draw.Src)
+	}
+}
+
+// Layout 由控件自上而下排列组成的页面布局
+// 新页面只需组合控件，不必再拼接字符串、计算行坐标和点击区域
+type Layout struct {
+	Widgets []Widget // 自上而下排列的控件
+	Margin  int      // 页面四周的边距（像素）
+	Spacing int      // 控件之间的间距（像素）
+
+	renderer *font.Renderer
+	areas    []HitArea                  // 最近一次Render得到的可点击区域
+	text     []string                   // 最近一次Render得到的页面文本
+	bounds   map[Widget]image.Rectangle // 最近一次Render时各控件所在的区域
+}
+
+// NewLayout 创建使用默认边距和间距的页面布局
+// 参数r: 绘制文字使用的字体渲染器
+// 参数widgets: 自上而下排列的控件
+func NewLayout(r *font.Renderer, widgets ...Widget) *Layout {
+	return &Layout{
+		Widgets:  widgets,
+		Margin:   defaultPageMargin,
+		Spacing:  defaultLineSpacing,
+		renderer: r,
+	}
+}
+
+// Add 在页面末尾追加控件
+func (l *Layout) Add(widgets ...Widget) *Layout {
+	l.Widgets = append(l.Widgets, widgets...)
+	return l
+}
+
+// Render 按顺序布局并绘制所有控件，不清除原有内容
+// 超出目标底部的控件不再绘制，跨过底部的控件只得到剩余的高度
+// 参数dst: 绘制目标，如帧缓冲区
+func (l *Layout) Render(dst draw.Image) error {
+	l.areas = nil
+	l.text = nil
+	l.bounds = make(map[Widget]image.Rectangle)
+
+	area := dst.Bounds().Inset(l.Margin)
+	y := area.Min.Y
+	for _, w := range l.Widgets {
+		if y >= area.Max.Y {
+			break
+		}
+		// 超出底部的部分被裁掉，可滚动的控件据此计算可见的行数
+		size := w.Measure(l.renderer, area.Dx())
+		bounds := image.Rect(area.Min.X, y, area.Max.X, y+size.Y).Intersect(area)
+		if err := w.Draw(l.renderer, dst, bounds); err != nil {
+			return err
+		}
+		l.bounds[w] = bounds
+		if c, ok := w.(clickable); ok {
+			l.areas = append(l.areas, c.hitAreas(l.renderer, bounds)...)
+		}
+		if t, ok := w.(textual); ok {
+			l.text = append(l.text, t.mirrorText()...)
+		}
+		y += size.Y + l.Spacing
+	}
+	return nil
+}
+
+// scrollView 返回页面中第一个可滚动的文本（包括消息框中的文本），没有时返回nil
+func (l *Layout) scrollView() *ScrollView {
+	for _, w := range l.Widgets {
+		switch v := w.(type) {
+		case *ScrollView:
+			return v
+		case *MessageBox:
+			return v.View
+		case *Frame:
+			if sv, ok := v.Child.(*ScrollView); ok {
+				return sv
+			}
+		}
+	}
+	return nil
+}
+
+// HitAreas 返回最近一次Render得到的可点击区域
+func (l *Layout) HitAreas() []HitArea {
+	return l.areas
+}
+
+// RedrawWidget 只重绘页面中的一个控件，如动画的下一帧
+// 先用背景色清除控件在最近一次Render时的区域再绘制，页面其它部分保持不变；
+// 控件不在页面中或尚未绘制时不做任何处理
+// 参数dst: 绘制目标，需与Render时相同
+// 参数w: 要重绘的控件
+func (l *Layout) RedrawWidget(dst draw.Image, w Widget) error {
+	bounds, ok := l.bounds[w]
+	if !ok {
+		return nil
+	}
+	draw.Draw(dst, bounds.Intersect(dst.Bounds()), &image.Uniform{theme.Background}, image.Point{}, draw.Src)
+	return w.Draw(l.renderer, dst, bounds)
+}
+
+// Text 返回最近一次Render绘制的页面文本，用于文本镜像
+func (l *Layout) Text() []string {
+	return l.text
+}
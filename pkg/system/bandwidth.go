@@ -0,0 +1,144 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bandwidth 网卡在一个采样周期内的平均收发速率（字节/秒）
+type Bandwidth struct {
+	RX float64 // 接收速率
+	TX float64 // 发送速率
+}
+
+// netCounters 网卡的累计收发字节数
+type netCounters struct {
+	rx, tx uint64
+}
+
+// BandwidthSampler 根据/sys/class/net下各网卡的累计收发字节数计算收发速率
+// 每次Sample为每个网卡追加一个采样周期的平均速率，只保留最近capacity个采样；
+// 不是并发安全的，应在同一个goroutine中采样和读取
+type BandwidthSampler struct {
+	capacity int
+	last     map[string]netCounters // 上次采样时的累计字节数
+	lastTime time.Time              // 上次采样的时间
+	history  map[string][]Bandwidth // 各网卡最近的速率，按时间先后排列
+}
+
+// NewBandwidthSampler 创建网卡速率采样器
+// 参数capacity: 每个网卡保留的采样数，如每5秒采样一次、保留5分钟时为60
+func NewBandwidthSampler(capacity int) *BandwidthSampler {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &BandwidthSampler{
+		capacity: capacity,
+		last:     make(map[string]netCounters),
+		history:  make(map[string][]Bandwidth),
+	}
+}
+
+// Sample 读取所有网卡的累计收发字节数，追加自上次采样以来的平均速率
+// 第一次采样只记录累计字节数；已经移除的网卡同时丢弃其历史速率
+func (s *BandwidthSampler) Sample() error {
+	entries, err := os.ReadDir("/sys/class/net")
+	if err != nil {
+		return fmt.Errorf("读取网卡列表失败: %v", err)
+	}
+
+	now := time.Now()
+	elapsed := now.Sub(s.lastTime).Seconds()
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		counters, err := readNetCounters(name)
+		if err != nil {
+			continue
+		}
+		seen[name] = true
+
+		prev, ok := s.last[name]
+		s.last[name] = counters
+		if !ok || elapsed <= 0 {
+			continue
+		}
+		s.push(name, Bandwidth{
+			RX: counterRate(prev.rx, counters.rx, elapsed),
+			TX: counterRate(prev.tx, counters.tx, elapsed),
+		})
+	}
+	s.lastTime = now
+
+	for name := range s.last {
+		if !seen[name] {
+			delete(s.last, name)
+			delete(s.history, name)
+		}
+	}
+	return nil
+}
+
+// push 追加一个采样，超出容量时丢弃最早的采样
+func (s *BandwidthSampler) push(name string, bw Bandwidth) {
+	samples := append(s.history[name], bw)
+	if len(samples) > s.capacity {
+		samples = samples[len(samples)-s.capacity:]
+	}
+	s.history[name] = samples
+}
+
+// History 返回网卡最近的收发速率，按时间先后排列，没有采样时返回nil
+// 参数name: 网卡名称，如"eth0"
+func (s *BandwidthSampler) History(name string) []Bandwidth {
+	samples := s.history[name]
+	if len(samples) == 0 {
+		return nil
+	}
+	return append([]Bandwidth(nil), samples...)
+}
+
+// readNetCounters 读取网卡的累计收发字节数
+func readNetCounters(name string) (netCounters, error) {
+	var counters netCounters
+	dir := filepath.Join("/sys/class/net", name, "statistics")
+	for _, f := range []struct {
+		file  string
+		value *uint64
+	}{
+		{"rx_bytes", &counters.rx},
+		{"tx_bytes", &counters.tx},
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, f.file))
+		if err != nil {
+			return counters, fmt.Errorf("读取网卡流量统计失败: %v", err)
+		}
+		if *f.value, err = strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64); err != nil {
+			return counters, fmt.Errorf("解析网卡流量统计失败: %v", err)
+		}
+	}
+	return counters, nil
+}
+
+// counterRate 根据累计计数的增量计算速率，计数器归零（如网卡重新加载驱动）时返回0
+func counterRate(prev, cur uint64, seconds float64) float64 {
+	if cur < prev {
+		return 0
+	}
+	return float64(cur-prev) / seconds
+}
+
+// FormatRate 把速率格式化为带单位的字符串，如"1.2 MB/s"
+// 参数bytesPerSecond: 每秒字节数
+func FormatRate(bytesPerSecond float64) string {
+	return formatBytes(int64(bytesPerSecond)) + "/s"
+}
+
+// FormatBytes 把字节数格式化为带单位的字符串，如"1.5 GB"
+func FormatBytes(bytes int64) string {
+	return formatBytes(bytes)
+}
@@ -0,0 +1,54 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+)
+
+// kiocSound 控制台ioctl：让PC喇叭以给定的分频值发声，参数为0时停止
+const kiocSound = 0x4B2F
+
+// pitClock PC喇叭定时器的输入时钟频率（Hz），分频值为pitClock/频率
+const pitClock = 1193180
+
+// beepDevices 可以控制PC喇叭的控制台设备，按顺序尝试
+var beepDevices = []string{"/dev/console", "/dev/tty0"}
+
+// Beep 让PC喇叭鸣响一段时间，在鸣响结束后返回
+// 需要root权限，没有PC喇叭（如大多数ARM设备）时返回错误
+// 参数frequency: 频率（Hz）
+// 参数duration: 鸣响时长
+func Beep(frequency int, duration time.Duration) error {
+	if frequency <= 0 {
+		return fmt.Errorf("无效的蜂鸣频率: %d", frequency)
+	}
+
+	var f *os.File
+	var err error
+	for _, path := range beepDevices {
+		if f, err = os.OpenFile(path, os.O_WRONLY, 0); err == nil {
+			break
+		}
+	}
+	if f == nil {
+		return fmt.Errorf("打开控制台设备失败: %v", err)
+	}
+	defer f.Close()
+
+	if err := soundIoctl(f, pitClock/frequency); err != nil {
+		return err
+	}
+	time.Sleep(duration)
+	return soundIoctl(f, 0)
+}
+
+// soundIoctl 设置PC喇叭的分频值，0表示停止发声
+func soundIoctl(f *os.File, divisor int) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), kiocSound, uintptr(divisor))
+	if errno != 0 {
+		return fmt.Errorf("控制PC喇叭失败: %v", errno)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package system
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// maxOutputLine 流式读取命令输出时单行的最大长度，遇到更长的行时不再显示之后的输出
+const maxOutputLine = 64 * 1024
+
+// CommandResult 命令执行结果
+type CommandResult struct {
+	ExitCode int           // 退出状态，命令未能启动或被信号结束时为-1
+	TimedOut bool          // 是否因超时被结束
+	Duration time.Duration // 执行耗时
+}
+
+// CheckCommand 检查命令的程序是否在白名单中
+// 程序必须写成绝对路径，并与白名单中的某一项完全相同，避免通过PATH或相对路径执行其它程序
+// 参数command: 命令，如"/usr/local/bin/cleanup.sh --force"
+// 参数allowed: 允许执行的程序路径
+func CheckCommand(command string, allowed []string) error {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return fmt.Errorf("命令不能为空")
+	}
+	program := args[0]
+	if !filepath.IsAbs(program) {
+		return fmt.Errorf("程序必须使用绝对路径: %s", program)
+	}
+	program = filepath.Clean(program)
+	for _, a := range allowed {
+		if filepath.Clean(a) == program {
+			return nil
+		}
+	}
+	return fmt.Errorf("程序不在允许执行的列表中: %s", program)
+}
+
+// StreamCommand 执行命令，标准输出和标准错误按行合并，每读到一行调用一次onLine
+// 命令按空白拆分为程序路径和参数，不经过shell，因此不支持管道、重定向和引号；
+// 命令以非0状态退出时返回错误，退出状态记录在结果中
+// 参数command: 命令，如"/usr/local/bin/cleanup.sh --force"
+// 参数timeout: 最长执行时间，超时后结束命令
+// 参数onLine: 处理一行输出，不含换行符，在调用StreamCommand的goroutine中调用
+func StreamCommand(command string, timeout time.Duration, onLine func(line string)) (CommandResult, error) {
+	result := CommandResult{ExitCode: -1}
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return result, fmt.Errorf("命令不能为空")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return result, fmt.Errorf("创建输出管道失败: %v", err)
+	}
+	cmd.Stderr = cmd.Stdout
+
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		return result, fmt.Errorf("启动命令失败: %v", err)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 4096), maxOutputLine)
+	for scanner.Scan() {
+		onLine(strings.TrimRight(scanner.Text(), "\r"))
+	}
+	// 行太长而停止读取时继续排空管道，避免命令因写满管道而阻塞
+	_, _ = io.Copy(io.Discard, stdout)
+	err = cmd.Wait()
+	result.Duration = time.Since(start)
+	result.ExitCode = cmd.ProcessState.ExitCode()
+
+	if ctx.Err() == context.DeadlineExceeded {
+		result.TimedOut = true
+		return result, fmt.Errorf("命令执行超时")
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && result.ExitCode > 0 {
+		return result, fmt.Errorf("命令退出状态为%d", result.ExitCode)
+	}
+	return result, err
+}
@@ -0,0 +1,156 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 访问容器运行时的超时时间
+const (
+	containerQueryTimeout   = 5 * time.Second  // 列出和查询容器
+	containerRestartTimeout = 60 * time.Second // 重启容器，包括等待容器停止的时间
+	containerStopWait       = 10               // 重启时等待容器自行停止的秒数，超过后强制结束
+)
+
+// ContainerSockets 自动探测的容器运行时套接字，按顺序使用第一个存在的
+// podman需要启用podman.socket，它提供与Docker兼容的接口
+var ContainerSockets = []string{
+	"/var/run/docker.sock",
+	"/run/podman/podman.sock",
+}
+
+// Container 一个容器的状态
+type Container struct {
+	ID       string
+	Name     string // 容器名称，不含开头的"/"
+	Image    string // 镜像名称
+	State    string // 运行状态，如running、exited、restarting、paused
+	Status   string // 运行时给出的说明，如"Up 3 hours"、"Exited (1) 2 minutes ago"
+	Restarts int    // 按重启策略自动重启的次数
+}
+
+// ContainerRuntime 通过unix套接字上与Docker兼容的HTTP接口访问docker或podman
+type ContainerRuntime struct {
+	socket string
+	client *http.Client
+}
+
+// FindContainerRuntime 查找容器运行时的套接字
+// 参数socket: 套接字路径，为空时依次尝试ContainerSockets
+func FindContainerRuntime(socket string) (*ContainerRuntime, error) {
+	candidates := ContainerSockets
+	if socket != "" {
+		candidates = []string{socket}
+	}
+	for _, path := range candidates {
+		if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+			return newContainerRuntime(path), nil
+		}
+	}
+	return nil, fmt.Errorf("未找到容器运行时的套接字: %s", strings.Join(candidates, ", "))
+}
+
+// newContainerRuntime 创建通过指定套接字访问的客户端
+func newContainerRuntime(socket string) *ContainerRuntime {
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socket)
+		},
+	}
+	return &ContainerRuntime{socket: socket, client: &http.Client{Transport: transport}}
+}
+
+// Socket 返回使用的套接字路径
+func (r *ContainerRuntime) Socket() string {
+	return r.socket
+}
+
+// do 发送请求，状态码不是2xx时返回运行时给出的错误信息
+// 参数result: 解析JSON响应的目标，为nil时忽略响应内容
+func (r *ContainerRuntime) do(ctx context.Context, method, path string, result interface{}) error {
+	// 主机名只是占位，实际连接的是套接字
+	req, err := http.NewRequestWithContext(ctx, method, "http://localhost"+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var body struct {
+			Message string `json:"message"`
+		}
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if json.Unmarshal(data, &body) == nil && body.Message != "" {
+			return fmt.Errorf("%s", body.Message)
+		}
+		return fmt.Errorf("%s", resp.Status)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// ListContainers 列出所有容器（包括已停止的），按名称排序
+// 重启次数需要逐个查询容器详情，查询失败的容器重启次数为0
+func (r *ContainerRuntime) ListContainers() ([]Container, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), containerQueryTimeout)
+	defer cancel()
+
+	var list []struct {
+		ID     string   `json:"Id"`
+		Names  []string `json:"Names"`
+		Image  string   `json:"Image"`
+		State  string   `json:"State"`
+		Status string   `json:"Status"`
+	}
+	if err := r.do(ctx, http.MethodGet, "/containers/json?all=1", &list); err != nil {
+		return nil, fmt.Errorf("列出容器失败: %v", err)
+	}
+
+	containers := make([]Container, 0, len(list))
+	for _, c := range list {
+		container := Container{ID: c.ID, Image: c.Image, State: c.State, Status: c.Status}
+		if len(c.Names) > 0 {
+			container.Name = strings.TrimPrefix(c.Names[0], "/")
+		} else if len(c.ID) > 12 {
+			container.Name = c.ID[:12]
+		}
+		var detail struct {
+			RestartCount int `json:"RestartCount"`
+		}
+		if err := r.do(ctx, http.MethodGet, "/containers/"+url.PathEscape(c.ID)+"/json", &detail); err == nil {
+			container.Restarts = detail.RestartCount
+		}
+		containers = append(containers, container)
+	}
+	sort.Slice(containers, func(i, j int) bool { return containers[i].Name < containers[j].Name })
+	return containers, nil
+}
+
+// RestartContainer 重启容器，容器在containerStopWait秒内没有停止时强制结束
+// 参数id: 容器ID或名称
+func (r *ContainerRuntime) RestartContainer(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), containerRestartTimeout)
+	defer cancel()
+
+	path := fmt.Sprintf("/containers/%s/restart?t=%d", url.PathEscape(id), containerStopWait)
+	if err := r.do(ctx, http.MethodPost, path, nil); err != nil {
+		return fmt.Errorf("重启容器 %s 失败: %v", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// CPUUsage CPU使用率（0-100）
+type CPUUsage struct {
+	Total float64   // 所有CPU的平均使用率
+	Cores []float64 // 各逻辑CPU的使用率，按/proc/stat中的顺序（cpu0、cpu1……）
+}
+
+// cpuTimes 一个CPU的累计空闲时间（含等待IO）和总时间（单位为时钟周期）
+type cpuTimes struct {
+	idle  uint64
+	total uint64
+}
+
+// usage 返回从prev到t之间的使用率（0-100）
+// 两次采样间隔过短或计数器异常（如CPU下线后重新上线）时返回0
+func (t cpuTimes) usage(prev cpuTimes) float64 {
+	if t.total <= prev.total || t.idle < prev.idle || t.idle-prev.idle > t.total-prev.total {
+		return 0
+	}
+	deltaIdle, deltaTotal := t.idle-prev.idle, t.total-prev.total
+	return float64(deltaTotal-deltaIdle) * 100 / float64(deltaTotal)
+}
+
+// CPUSampler 根据/proc/stat中CPU时间的增量计算CPU使用率
+// 每次Sample返回自上次采样以来的平均使用率；第一次采样返回开机以来的平均使用率
+type CPUSampler struct {
+	prev map[string]cpuTimes // 上次采样时各CPU的时间，键为/proc/stat中的名称，"cpu"为所有CPU的合计
+}
+
+// Sample 读取CPU时间，返回自上次采样以来所有CPU的平均使用率（0-100）
+func (s *CPUSampler) Sample() (float64, error) {
+	usage, err := s.SampleCores()
+	if err != nil {
+		return 0, err
+	}
+	return usage.Total, nil
+}
+
+// SampleCores 读取CPU时间，返回自上次采样以来的平均使用率和各逻辑CPU的使用率
+// 上次采样时没有的CPU（如新上线的CPU）返回开机以来的平均使用率
+func (s *CPUSampler) SampleCores() (*CPUUsage, error) {
+	names, times, err := readCPUTimes()
+	if err != nil {
+		return nil, err
+	}
+	usage := &CPUUsage{}
+	prev := make(map[string]cpuTimes, len(names))
+	for i, name := range names {
+		u := times[i].usage(s.prev[name])
+		if name == "cpu" {
+			usage.Total = u
+		} else {
+			usage.Cores = append(usage.Cores, u)
+		}
+		prev[name] = times[i]
+	}
+	s.prev = prev
+	return usage, nil
+}
+
+// readCPUTimes 读取/proc/stat中所有CPU合计（"cpu"）和各逻辑CPU（"cpu0"……）的时间
+// 返回的名称和时间一一对应，第一个为所有CPU的合计
+func readCPUTimes() ([]string, []cpuTimes, error) {
+	data, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取CPU时间失败: %v", err)
+	}
+
+	var names []string
+	var times []cpuTimes
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(line, "cpu") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			return nil, nil, fmt.Errorf("CPU时间格式错误: %s", line)
+		}
+		t, err := parseCPUTimes(fields[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		names = append(names, fields[0])
+		times = append(times, t)
+	}
+	if len(names) == 0 || names[0] != "cpu" {
+		return nil, nil, fmt.Errorf("CPU时间格式错误: 缺少cpu行")
+	}
+	return names, times, nil
+}
+
+// parseCPUTimes 解析/proc/stat中一个CPU的各项时间
+// 字段依次为user nice system idle iowait irq softirq steal guest guest_nice，
+// guest时间已计入user，不重复累加
+func parseCPUTimes(fields []string) (cpuTimes, error) {
+	var t cpuTimes
+	for i, field := range fields {
+		if i >= 8 {
+			break
+		}
+		v, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return cpuTimes{}, fmt.Errorf("解析CPU时间失败: %v", err)
+		}
+		t.total += v
+		if i == 3 || i == 4 {
+			t.idle += v
+		}
+	}
+	return t, nil
+}
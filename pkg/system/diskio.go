@@ -0,0 +1,173 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// diskSectorSize /proc/diskstats中扇区数的单位，与磁盘实际的扇区大小无关
+const diskSectorSize = 512
+
+// DiskIO 磁盘在一个采样周期内的平均读写速率
+type DiskIO struct {
+	Read      float64 // 读取速率（字节/秒）
+	Write     float64 // 写入速率（字节/秒）
+	ReadIOPS  float64 // 每秒完成的读请求数
+	WriteIOPS float64 // 每秒完成的写请求数
+}
+
+// diskCounters 磁盘的累计读写次数和扇区数
+type diskCounters struct {
+	reads, readSectors, writes, writeSectors uint64
+}
+
+// DiskIOSampler 根据/proc/diskstats中各磁盘的累计读写计数计算读写速率和IOPS
+// 每次Sample为每个磁盘追加一个采样周期的平均值，只保留最近capacity个采样；
+// 只统计整块磁盘（/sys/block下的设备），不统计分区、loop和ram设备；
+// 不是并发安全的，应在同一个goroutine中采样和读取
+type DiskIOSampler struct {
+	capacity int
+	last     map[string]diskCounters // 上次采样时的累计计数
+	lastTime time.Time               // 上次采样的时间
+	history  map[string][]DiskIO     // 各磁盘最近的读写速率，按时间先后排列
+}
+
+// NewDiskIOSampler 创建磁盘读写速率采样器
+// 参数capacity: 每个磁盘保留的采样数，如每5秒采样一次、保留5分钟时为60
+func NewDiskIOSampler(capacity int) *DiskIOSampler {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &DiskIOSampler{
+		capacity: capacity,
+		last:     make(map[string]diskCounters),
+		history:  make(map[string][]DiskIO),
+	}
+}
+
+// Sample 读取所有磁盘的累计读写计数，追加自上次采样以来的平均速率
+// 第一次采样只记录累计计数；已经移除的磁盘同时丢弃其历史数据
+func (s *DiskIOSampler) Sample() error {
+	counters, err := readDiskCounters()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	elapsed := now.Sub(s.lastTime).Seconds()
+	for name, cur := range counters {
+		prev, ok := s.last[name]
+		s.last[name] = cur
+		if !ok || elapsed <= 0 {
+			continue
+		}
+		s.push(name, DiskIO{
+			Read:      counterRate(prev.readSectors, cur.readSectors, elapsed) * diskSectorSize,
+			Write:     counterRate(prev.writeSectors, cur.writeSectors, elapsed) * diskSectorSize,
+			ReadIOPS:  counterRate(prev.reads, cur.reads, elapsed),
+			WriteIOPS: counterRate(prev.writes, cur.writes, elapsed),
+		})
+	}
+	s.lastTime = now
+
+	for name := range s.last {
+		if _, ok := counters[name]; !ok {
+			delete(s.last, name)
+			delete(s.history, name)
+		}
+	}
+	return nil
+}
+
+// push 追加一个采样，超出容量时丢弃最早的采样
+func (s *DiskIOSampler) push(name string, io DiskIO) {
+	samples := append(s.history[name], io)
+	if len(samples) > s.capacity {
+		samples = samples[len(samples)-s.capacity:]
+	}
+	s.history[name] = samples
+}
+
+// Capacity 返回每个磁盘保留的采样数
+func (s *DiskIOSampler) Capacity() int {
+	return s.capacity
+}
+
+// Devices 返回已有采样的磁盘名称，按名称排序
+func (s *DiskIOSampler) Devices() []string {
+	var names []string
+	for name, samples := range s.history {
+		if len(samples) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// History 返回磁盘最近的读写速率，按时间先后排列，没有采样时返回nil
+// 参数name: 磁盘名称，如"sda"、"nvme0n1"
+func (s *DiskIOSampler) History(name string) []DiskIO {
+	samples := s.history[name]
+	if len(samples) == 0 {
+		return nil
+	}
+	return append([]DiskIO(nil), samples...)
+}
+
+// Latest 返回所有磁盘最近一次采样的读写速率之和，还没有采样时返回零值
+func (s *DiskIOSampler) Latest() DiskIO {
+	var total DiskIO
+	for _, samples := range s.history {
+		if len(samples) == 0 {
+			continue
+		}
+		io := samples[len(samples)-1]
+		total.Read += io.Read
+		total.Write += io.Write
+		total.ReadIOPS += io.ReadIOPS
+		total.WriteIOPS += io.WriteIOPS
+	}
+	return total
+}
+
+// readDiskCounters 读取/proc/diskstats中各整块磁盘的累计读写计数
+// 每行的字段依次为主设备号、次设备号、设备名、完成的读请求数、合并的读请求数、读扇区数、读耗时、
+// 完成的写请求数、合并的写请求数、写扇区数……
+func readDiskCounters() (map[string]diskCounters, error) {
+	data, err := os.ReadFile("/proc/diskstats")
+	if err != nil {
+		return nil, fmt.Errorf("读取磁盘读写统计失败: %v", err)
+	}
+
+	counters := make(map[string]diskCounters)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 10 || !isWholeDisk(fields[2]) {
+			continue
+		}
+		var values [4]uint64
+		for i, col := range []int{3, 5, 7, 9} {
+			if values[i], err = strconv.ParseUint(fields[col], 10, 64); err != nil {
+				return nil, fmt.Errorf("解析磁盘读写统计失败: %v", err)
+			}
+		}
+		counters[fields[2]] = diskCounters{reads: values[0], readSectors: values[1], writes: values[2], writeSectors: values[3]}
+	}
+	return counters, nil
+}
+
+// isWholeDisk 判断设备是否为整块磁盘：/sys/block下有该设备，并且不是loop、ram或zram设备
+func isWholeDisk(name string) bool {
+	for _, prefix := range []string{"loop", "ram", "zram"} {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	_, err := os.Stat("/sys/block/" + name)
+	return err == nil
+}
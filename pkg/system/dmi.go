@@ -0,0 +1,67 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// dmiRoot 内核导出的DMI（SMBIOS）信息所在的目录
+const dmiRoot = "/sys/class/dmi/id"
+
+// dmiPlaceholders 厂商没有填写时BIOS中常见的占位内容，视为未填写
+var dmiPlaceholders = map[string]bool{
+	"to be filled by o.e.m.": true,
+	"default string":         true,
+	"system product name":    true,
+	"system manufacturer":    true,
+	"system serial number":   true,
+	"not specified":          true,
+	"not applicable":         true,
+	"none":                   true,
+	"0123456789":             true,
+}
+
+// Hardware 主板BIOS中记录的设备信息，未填写或无法读取的项为空
+type Hardware struct {
+	Vendor      string // 整机厂商，如"Dell Inc."
+	Product     string // 整机型号，如"PowerEdge R740"
+	Serial      string // 整机序列号，读取需要root权限
+	BoardVendor string // 主板厂商
+	BoardName   string // 主板型号
+	BIOSVendor  string // BIOS厂商
+	BIOSVersion string // BIOS版本
+	BIOSDate    string // BIOS发布日期
+}
+
+// GetHardware 读取/sys/class/dmi/id中的设备信息
+// 没有DMI信息（如部分ARM设备）时返回错误；序列号等个别项只有root可读，读取失败时为空
+func GetHardware() (*Hardware, error) {
+	if _, err := os.Stat(dmiRoot); err != nil {
+		return nil, fmt.Errorf("读取设备信息失败: %v", err)
+	}
+	return &Hardware{
+		Vendor:      readDMI("sys_vendor"),
+		Product:     readDMI("product_name"),
+		Serial:      readDMI("product_serial"),
+		BoardVendor: readDMI("board_vendor"),
+		BoardName:   readDMI("board_name"),
+		BIOSVendor:  readDMI("bios_vendor"),
+		BIOSVersion: readDMI("bios_version"),
+		BIOSDate:    readDMI("bios_date"),
+	}, nil
+}
+
+// readDMI 读取一项DMI信息，读取失败或为占位内容时返回空字符串
+func readDMI(name string) string {
+	data, err := os.ReadFile(filepath.Join(dmiRoot, name))
+	if err != nil {
+		return ""
+	}
+	value := strings.TrimSpace(string(data))
+	if dmiPlaceholders[strings.ToLower(value)] {
+		return ""
+	}
+	return value
+}
@@ -0,0 +1,287 @@
+package system
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"go-framebuffer-console/pkg/i18n"
+)
+
+// dnsQueryTimeout 每次DNS查询等待回复的时间
+const dnsQueryTimeout = 3 * time.Second
+
+// DNSResult 用一个DNS服务器解析一个主机名的结果
+type DNSResult struct {
+	Server  string        // DNS服务器地址
+	Host    string        // 解析的主机名
+	Addrs   []string      // 解析得到的IPv4地址
+	Latency time.Duration // 从发出查询到收到回复的时间，失败时为0
+	Err     error         // 解析失败的原因，成功时为nil
+}
+
+// TestDNS 用每个DNS服务器解析每个主机名，结果按服务器、主机名的顺序排列
+// 直接向服务器发送查询，不经过/etc/hosts和本机的缓存，因此能区分"DNS服务器不可用"和"目标不通"
+// 参数hosts: 主机名，IP地址会被跳过
+// 参数servers: DNS服务器地址，为空时使用GetDNSServers返回的服务器
+func TestDNS(hosts, servers []string) ([]DNSResult, error) {
+	if len(servers) == 0 {
+		var err error
+		if servers, err = GetDNSServers(); err != nil {
+			return nil, err
+		}
+		if len(servers) == 0 {
+			return nil, fmt.Errorf("%s", i18n.Translate("没有配置DNS服务器"))
+		}
+	}
+	var names []string
+	for _, host := range hosts {
+		if net.ParseIP(host) == nil {
+			names = append(names, host)
+		}
+	}
+
+	results := make([]DNSResult, 0, len(servers)*len(names))
+	for _, server := range servers {
+		for _, host := range names {
+			results = append(results, DNSResult{Server: server, Host: host})
+		}
+	}
+
+	// 各查询由最多networkTestWorkers个goroutine同时进行
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < networkTestWorkers && w < len(results); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				ctx, cancel := context.WithTimeout(context.Background(), dnsQueryTimeout)
+				results[i] = ResolveWith(ctx, results[i].Server, results[i].Host)
+				cancel()
+			}
+		}()
+	}
+	for i := range results {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results, nil
+}
+
+// ResolveWith 向指定的DNS服务器查询主机名的IPv4地址（A记录）
+// 先通过UDP查询，回复被截断（TC位）时改用TCP重新查询
+// 参数server: DNS服务器地址，可以带端口，默认为53
+// 参数host: 主机名
+func ResolveWith(ctx context.Context, server, host string) DNSResult {
+	result := DNSResult{Server: server, Host: host}
+	address := server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		address = net.JoinHostPort(server, "53")
+	}
+
+	query, id, err := buildDNSQuery(host)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	start := time.Now()
+	reply, err := exchangeUDP(ctx, address, query, id)
+	if err == nil && dnsTruncated(reply) {
+		reply, err = exchangeTCP(ctx, address, query, id)
+	}
+	if err != nil {
+		result.Err = dnsNetError(err)
+		return result
+	}
+	result.Latency = time.Since(start)
+	result.Addrs, result.Err = parseDNSResponse(reply)
+	return result
+}
+
+// exchangeUDP 通过UDP发送查询并等待标识符为id的回复
+func exchangeUDP(ctx context.Context, address string, query []byte, id uint16) ([]byte, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if _, err := conn.Write(query); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 1500)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		// 标识符不同的回复是更早的查询迟到的回复
+		if n < 12 || binary.BigEndian.Uint16(buf) != id {
+			continue
+		}
+		return buf[:n], nil
+	}
+}
+
+// exchangeTCP 通过TCP发送查询并读取回复，报文前各有两字节的长度
+func exchangeTCP(ctx context.Context, address string, query []byte, id uint16) ([]byte, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	msg := binary.BigEndian.AppendUint16(make([]byte, 0, 2+len(query)), uint16(len(query)))
+	if _, err := conn.Write(append(msg, query...)); err != nil {
+		return nil, err
+	}
+	var length [2]byte
+	if _, err := io.ReadFull(conn, length[:]); err != nil {
+		return nil, err
+	}
+	reply := make([]byte, binary.BigEndian.Uint16(length[:]))
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return nil, err
+	}
+	if len(reply) < 12 || binary.BigEndian.Uint16(reply) != id {
+		return nil, fmt.Errorf("%s", i18n.Translate("回复格式错误"))
+	}
+	return reply, nil
+}
+
+// dnsTruncated 返回回复是否被截断（头部的TC位），被截断的回复中的记录不完整
+func dnsTruncated(msg []byte) bool {
+	return len(msg) >= 3 && msg[2]&0x02 != 0
+}
+
+// dnsNetError 把查询时的超时和连接被拒绝转换为容易理解的原因，其它错误原样返回
+func dnsNetError(err error) error {
+	if ne, ok := err.(net.Error); ok && ne.Timeout() {
+		return fmt.Errorf("%s", i18n.Translate("查询超时"))
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return fmt.Errorf("%s", i18n.Translate("连接被拒绝，服务器上没有DNS服务"))
+	}
+	return err
+}
+
+// buildDNSQuery 生成查询A记录的DNS报文，返回报文和其中的标识符
+func buildDNSQuery(host string) ([]byte, uint16, error) {
+	id := uint16(rand.Intn(1 << 16))
+	msg := make([]byte, 12, 12+len(host)+6)
+	binary.BigEndian.PutUint16(msg[0:], id)
+	binary.BigEndian.PutUint16(msg[2:], 0x0100) // 标准查询，请求递归
+	binary.BigEndian.PutUint16(msg[4:], 1)      // 一个问题
+	for _, label := range strings.Split(strings.TrimSuffix(host, "."), ".") {
+		if label == "" || len(label) > 63 {
+			return nil, 0, fmt.Errorf("%s", i18n.Translatef("主机名无效: %s", host))
+		}
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	msg = append(msg, 0, 0, 1, 0, 1) // 根标签，类型A，类IN
+	return msg, id, nil
+}
+
+// dnsRcodeErrors DNS回复中的错误码对应的原因
+var dnsRcodeErrors = map[int]string{
+	1: "查询格式错误",
+	2: "服务器内部错误",
+	3: "域名不存在",
+	4: "服务器不支持该查询",
+	5: "服务器拒绝查询",
+}
+
+// parseDNSResponse 解析DNS回复中的A记录
+// 报文长度不足、域名或记录超出报文末尾时返回"回复格式错误"
+func parseDNSResponse(msg []byte) ([]string, error) {
+	malformed := fmt.Errorf("%s", i18n.Translate("回复格式错误"))
+	if len(msg) < 12 {
+		return nil, malformed
+	}
+	if rcode := int(msg[3] & 0x0f); rcode != 0 {
+		if reason, ok := dnsRcodeErrors[rcode]; ok {
+			return nil, fmt.Errorf("%s", i18n.Translate(reason))
+		}
+		return nil, fmt.Errorf("%s", i18n.Translatef("服务器返回错误码 %d", rcode))
+	}
+	if dnsTruncated(msg) {
+		return nil, fmt.Errorf("%s", i18n.Translate("回复被截断"))
+	}
+	questions := int(binary.BigEndian.Uint16(msg[4:]))
+	answers := int(binary.BigEndian.Uint16(msg[6:]))
+
+	offset := 12
+	for i := 0; i < questions; i++ {
+		end, err := skipDNSName(msg, offset)
+		if err != nil {
+			return nil, err
+		}
+		offset = end + 4 // 类型和类
+		if offset > len(msg) {
+			return nil, malformed
+		}
+	}
+	var addrs []string
+	for i := 0; i < answers; i++ {
+		end, err := skipDNSName(msg, offset)
+		if err != nil {
+			return nil, err
+		}
+		if end+10 > len(msg) {
+			return nil, malformed
+		}
+		typ := binary.BigEndian.Uint16(msg[end:])
+		length := int(binary.BigEndian.Uint16(msg[end+8:]))
+		data := end + 10
+		if data+length > len(msg) {
+			return nil, malformed
+		}
+		// 只取A记录，CNAME等其它记录跳过
+		if typ == 1 && length == 4 {
+			addrs = append(addrs, net.IP(msg[data:data+4]).String())
+		}
+		offset = data + length
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("%s", i18n.Translate("没有IPv4地址"))
+	}
+	return addrs, nil
+}
+
+// skipDNSName 跳过报文中offset处的域名，返回域名之后的位置；域名可能以压缩指针结尾
+// 标签或压缩指针超出报文末尾时返回错误
+func skipDNSName(msg []byte, offset int) (int, error) {
+	for offset < len(msg) {
+		n := int(msg[offset])
+		switch {
+		case n == 0:
+			return offset + 1, nil
+		case n&0xc0 == 0xc0 && offset+2 <= len(msg):
+			return offset + 2, nil
+		case n&0xc0 != 0:
+			// 截断的压缩指针，或者已经废弃的0x40、0x80开头的标签类型
+			return 0, fmt.Errorf("%s", i18n.Translate("回复格式错误"))
+		}
+		offset += 1 + n
+	}
+	return 0, fmt.Errorf("%s", i18n.Translate("回复格式错误"))
+}
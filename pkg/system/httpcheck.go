@@ -0,0 +1,102 @@
+package system
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go-framebuffer-console/pkg/i18n"
+)
+
+// HTTP检查的参数
+const (
+	DefaultHTTPTimeout = 10 * time.Second // 没有指定超时时等待响应的时间
+	certWarnDays       = 14               // 证书剩余有效期少于该天数时提示
+)
+
+// HTTPResult HTTP检查的结果
+type HTTPResult struct {
+	StatusCode int           // 响应的状态码，没有收到响应时为0
+	Latency    time.Duration // 从发出请求到收到响应头的时间
+	CertExpiry time.Time     // HTTPS服务器证书的到期时间，HTTP时为零值
+}
+
+// CertDaysLeft 返回证书剩余的有效天数，已过期时为负数
+func (r *HTTPResult) CertDaysLeft() int {
+	return int(time.Until(r.CertExpiry).Hours() / 24)
+}
+
+// Status 返回状态码及其说明，如"200 OK"
+func (r *HTTPResult) Status() string {
+	return fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode))
+}
+
+// targetURL 返回HTTP检查请求的URL，没有指定URL时请求主机的根路径
+func targetURL(target NetworkTestTarget) string {
+	if target.URL != "" {
+		return target.URL
+	}
+	return "http://" + target.Host + "/"
+}
+
+// expectedStatus 判断状态码是否符合目标的要求，没有指定时2xx和3xx都算正常
+func expectedStatus(target NetworkTestTarget, code int) bool {
+	if target.ExpectStatus != 0 {
+		return code == target.ExpectStatus
+	}
+	return code >= 200 && code < 400
+}
+
+// testHTTPTarget 请求目标的URL，检查状态码，HTTPS时同时检查证书的有效期
+// 不跟随重定向，重定向本身的状态码即为结果，便于检查管理平台的入口
+func testHTTPTarget(target NetworkTestTarget) NetworkTestResult {
+	result := NetworkTestResult{Target: target, PacketLoss: 100.0, AvgLatency: "N/A"}
+	timeout := DefaultHTTPTimeout
+	if target.Timeout > 0 {
+		timeout = target.Timeout
+	}
+	client := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: target.Insecure},
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	defer client.CloseIdleConnections()
+
+	start := time.Now()
+	resp, err := client.Get(targetURL(target))
+	if err != nil {
+		result.ErrorMsg = i18n.Translatef("请求失败: %v", err)
+		return result
+	}
+	resp.Body.Close()
+
+	check := &HTTPResult{StatusCode: resp.StatusCode, Latency: time.Since(start)}
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		check.CertExpiry = resp.TLS.PeerCertificates[0].NotAfter
+	}
+	result.HTTP = check
+	result.AvgLatency = FormatRTT(check.Latency)
+
+	if !expectedStatus(target, resp.StatusCode) {
+		result.ErrorMsg = i18n.Translatef("状态码 %d 不符合要求", resp.StatusCode)
+		return result
+	}
+	result.Success, result.PacketLoss = true, 0
+	if !check.CertExpiry.IsZero() {
+		// 不校验证书时过期的证书也能连接，此时标记为异常
+		switch days := check.CertDaysLeft(); {
+		case days < 0:
+			result.Success, result.PacketLoss = false, 100.0
+			result.ErrorMsg = i18n.Translate("证书已过期")
+		case days < certWarnDays:
+			result.ErrorMsg = i18n.Translatef("证书将在 %d 天后过期", days)
+		}
+	}
+	return result
+}
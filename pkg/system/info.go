@@ -14,18 +14,33 @@ import (
 	"strings"
 	"syscall"
 	"time"
+
+	"go-framebuffer-console/pkg/i18n"
 )
 
 // SystemInfo 系统信息结构体
 // 包含了系统运行状态、硬件配置、网络信息等核心数据
 type SystemInfo struct {
+	Hostname        string // 主机名
+	OSName          string // 操作系统发行版，如"Debian GNU/Linux 12 (bookworm)"
+	KernelVersion   string // 内核版本，与uname -r相同
+	Architecture    string // 硬件架构，与uname -m相同，如"x86_64"
 	Uptime          string // 系统运行时间（格式化为天、小时、分钟）
 	CPUModel        string // CPU型号名称
 	CPUCores        int    // CPU核心数量
+	CPUUsage        float64 // CPU使用率（0-100），GetSystemInfo不采样，由调用者填写，未知时为-1
 	MemoryUsage     string // 内存使用情况（MB单位）
+	MemoryUsed      int64  // 已用内存（字节），获取失败时为0
+	MemoryTotal     int64  // 内存总量（字节），获取失败时为0
+	SwapUsed        int64  // 已用交换空间（字节），没有交换空间时为0
+	SwapTotal       int64  // 交换空间总量（字节），没有交换空间时为0
 	DiskSize        string // 物理磁盘总大小
 	DiskCount       int    // 物理磁盘设备数量
+	RootUsage       string // 根分区使用情况，如"12.3 GB/50.0 GB"
+	RootUsed        int64  // 根分区已用空间（字节），获取失败时为0
+	RootTotal       int64  // 根分区总空间（字节），获取失败时为0
 	CurrentTime     string // 当前系统时间
+	TimeSync        *TimeSync // 时间同步状态，没有可用的时间同步服务时为nil
 	IPAddress       string // 默认路由的IP地址
 	QianKunCloudID  string // 设备ID
 }
@@ -34,33 +49,63 @@ func GetSystemInfo() (*SystemInfo, error) {
 	info := &SystemInfo{}
 
 	var err error
+	info.Hostname, err = os.Hostname()
+	if err != nil {
+		info.Hostname = i18n.Translate("未知")
+	}
+	info.OSName, err = getOSName()
+	if err != nil {
+		info.OSName = "Linux"
+	}
+	info.KernelVersion, err = getKernelVersion()
+	if err != nil {
+		info.KernelVersion = i18n.Translate("未知")
+	}
+	info.Architecture = getArchitecture()
+
 	info.Uptime, err = getUptime()
 	if err != nil {
-		info.Uptime = "未知"
+		info.Uptime = i18n.Translate("未知")
 	}
 
 	info.CPUModel, info.CPUCores, err = getCPUInfo()
 	if err != nil {
-		info.CPUModel = "未知"
+		info.CPUModel = i18n.Translate("未知")
 		info.CPUCores = runtime.NumCPU()
 	}
 
+	// CPU使用率需要两次采样之间的增量，由调用者用自己的CPUSampler填写
+	info.CPUUsage = -1
+
 	info.MemoryUsage, err = getMemoryUsageMB()
 	if err != nil {
-		info.MemoryUsage = "未知"
+		info.MemoryUsage = i18n.Translate("未知")
+	}
+	info.MemoryUsed, info.MemoryTotal, _ = GetMemoryStats()
+	info.SwapUsed, info.SwapTotal, _ = GetSwapStats()
+	if info.SwapTotal > 0 && info.MemoryTotal > 0 {
+		// 小内存设备上交换空间的使用量反映内存压力，附在内存使用情况后面
+		info.MemoryUsage += i18n.Translatef("，交换 %dM/%dMB", info.SwapUsed/1024/1024, info.SwapTotal/1024/1024)
 	}
 
 	info.DiskSize, info.DiskCount, err = getPhysicalDiskInfo()
 	if err != nil {
-		info.DiskSize = "未知"
+		info.DiskSize = i18n.Translate("未知")
 		info.DiskCount = 0
 	}
+	info.RootUsed, info.RootTotal, err = GetRootUsage()
+	if err != nil || info.RootTotal == 0 {
+		info.RootUsage = i18n.Translate("未知")
+	} else {
+		info.RootUsage = formatBytes(info.RootUsed) + "/" + formatBytes(info.RootTotal)
+	}
 
 	info.CurrentTime = time.Now().Format("2006-01-02 15:04:05")
+	info.TimeSync, _ = GetTimeSync()
 
 	info.IPAddress, err = getDefaultRouteIP()
 	if err != nil {
-		info.IPAddress = "未知"
+		info.IPAddress = i18n.Translate("未知")
 	}
 
 	info.QianKunCloudID, err = getQianKunCloudID()
@@ -96,7 +141,7 @@ func getUptime() (string, error) {
 	hours := (int(uptimeSeconds) % 86400) / 3600
 	minutes := (int(uptimeSeconds) % 3600) / 60
 
-	return fmt.Sprintf("%d天 %d小时 %d分钟", days, hours, minutes), nil
+	return i18n.Translatef("%d天 %d小时 %d分钟", days, hours, minutes), nil
 }
 
 func getCPUInfo() (string, int, error) {
@@ -135,7 +180,7 @@ func getCPUInfo() (string, int, error) {
 	}
 
 	if cpuModel == "" {
-		cpuModel = "未知处理器"
+		cpuModel = i18n.Translate("未知处理器")
 	}
 	if cpuCount == 0 {
 		cpuCount = runtime.NumCPU()
@@ -179,7 +224,7 @@ func getMemoryUsage() (string, error) {
 
 	// 数据有效性检查
 	if memTotal <= 0 || memTotal > 1024*1024*1024 { // 限制最大1TB
-		return "未知", nil
+		return i18n.Translate("未知"), nil
 	}
 	if memAvailable < 0 || memAvailable > memTotal {
 		memAvailable = 0
@@ -188,7 +233,7 @@ func getMemoryUsage() (string, error) {
 	memUsed := memTotal - memAvailable
 	usagePercent := float64(memUsed) / float64(memTotal) * 100
 
-	return fmt.Sprintf("%.1f%% (已用: %s / 总计: %s)",
+	return i18n.Translatef("%.1f%% (已用: %s / 总计: %s)",
 		usagePercent,
 		formatBytes(memUsed*1024),
 		formatBytes(memTotal*1024)), nil
@@ -203,13 +248,13 @@ func getDiskInfo() (string, int, error) {
 
 	// 检查数据的合理性
 	if stat.Blocks == 0 || stat.Bsize == 0 {
-		return "未知", 1, nil
+		return i18n.Translate("未知"), 1, nil
 	}
 
 	totalBytes := stat.Blocks * uint64(stat.Bsize)
 	// 防止溢出
 	if totalBytes > 1024*1024*1024*1024*1024 { // 限制最大1PB
-		return "过大", 1, nil
+		return i18n.Translate("过大"), 1, nil
 	}
 
 	diskSize := formatBytes(int64(totalBytes))
@@ -267,7 +312,7 @@ func getIPAddress() (string, error) {
 		}
 	}
 
-	return "未获取到IP", nil
+	return i18n.Translate("未获取到IP"), nil
 }
 
 func formatBytes(bytes int64) string {
@@ -340,12 +385,24 @@ func GetNetworkInterfaces() ([]NetworkInterface, error) {
 			status += ", Running"
 		}
 
+		// 4. 累计收发字节数，读取失败时为0
+		counters, _ := readNetCounters(iface.Name)
+
+		// 5. 链路速率、双工模式、是否接通和驱动
+		speed, duplex, carrier := readLinkInfo(iface.Name)
+
 		physicalInterfaces = append(physicalInterfaces, NetworkInterface{
 			Name:          iface.Name,
 			Status:        status,
 			MAC:           iface.HardwareAddr.String(),
 			IPv4Address:   ipv4Addr,
 			IPv6Addresses: ipv6s,
+			RXBytes:       counters.rx,
+			TXBytes:       counters.tx,
+			Speed:         speed,
+			Duplex:        duplex,
+			Carrier:       carrier,
+			Driver:        readDriver(iface.Name),
 		})
 	}
 
@@ -359,173 +416,21 @@ type NetworkInterface struct {
 	MAC           string
 	IPv4Address   string
 	IPv6Addresses []string
+	Traffic       []Bandwidth // 最近的收发速率，按时间先后排列，由调用方从BandwidthSampler取得
+	RXBytes       uint64      // 网卡启用以来累计接收的字节数
+	TXBytes       uint64      // 网卡启用以来累计发送的字节数
+	Speed         int         // 链路速率（Mb/s），未知时为-1
+	Duplex        string      // 双工模式："full"、"half"，未知时为空
+	Carrier       bool        // 是否接通（检测到载波）
+	Driver        string      // 驱动名称，如"e1000e"
 }
 
-// NetworkTestTarget 网络测试目标
-type NetworkTestTarget struct {
-	Name        string // 显示名称
-	Host        string // 主机地址
-	Description string // 描述
-}
-
-// NetworkTestResult 网络测试结果
-type NetworkTestResult struct {
-	Target       NetworkTestTarget
-	Success      bool
-	PacketsSent  int
-	PacketsRecv  int
-	PacketLoss   float64
-	AvgLatency   string
-	ErrorMsg     string
-}
-
-// NetworkTestProgress 网络测试进度回调
-type NetworkTestProgress func(target string, current, total int, message string)
-
-// TestNetworkConnectivity 简单网络测试（保持向后兼容）
-func TestNetworkConnectivity() (bool, error) {
-	return TestNetworkConnectivityWithTimeout(5 * time.Second)
-}
-
-func TestNetworkConnectivityWithTimeout(timeout time.Duration) (bool, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, "ping", "-c", "1", "-W", "3", "8.8.8.8")
-	err := cmd.Run()
-
-	if ctx.Err() == context.DeadlineExceeded {
-		return false, fmt.Errorf("网络测试超时")
-	}
-
-	return err == nil, err
-}
-
-// TestAdvancedNetworkConnectivity 高级网络连通性测试
-func TestAdvancedNetworkConnectivity(progressCallback NetworkTestProgress) ([]NetworkTestResult, error) {
-	// 定义测试目标
-	targets := []NetworkTestTarget{
-		{Name: "字节跳动", Host: "bytedance.com", Description: "字节跳动官网"},
-		{Name: "百度", Host: "baidu.com", Description: "百度首页"},
-		{Name: "哔哩哔哩", Host: "bilibili.com", Description: "哔哩哔哩"},
-		{Name: "腾讯", Host: "tencent.com", Description: "腾讯官网"},
-		{Name: "阿里DNS", Host: "223.5.5.5", Description: "阿里云DNS服务器"},
-	}
-
-	results := make([]NetworkTestResult, len(targets))
-	
-	for i, target := range targets {
-		if progressCallback != nil {
-			progressCallback(target.Name, i+1, len(targets), fmt.Sprintf("正在测试 %s...", target.Description))
-		}
-		
-		result := testSingleTarget(target)
-		results[i] = result
-		
-		if progressCallback != nil {
-			status := "成功"
-			if !result.Success {
-				status = "失败"
-			}
-			progressCallback(target.Name, i+1, len(targets), fmt.Sprintf("%s 测试%s", target.Description, status))
-		}
+// Rate 返回最近一次采样的收发速率，还没有采样时返回零值
+func (n NetworkInterface) Rate() Bandwidth {
+	if len(n.Traffic) == 0 {
+		return Bandwidth{}
 	}
-	
-	return results, nil
-}
-
-// testSingleTarget 测试单个目标
-func testSingleTarget(target NetworkTestTarget) NetworkTestResult {
-	result := NetworkTestResult{
-		Target:      target,
-		PacketsSent: 4,
-		PacketsRecv: 0,
-	}
-	
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
-	defer cancel()
-	
-	// 使用ping命令测试，发送4个包
-	cmd := exec.CommandContext(ctx, "ping", "-c", "4", "-W", "3", target.Host)
-	output, err := cmd.CombinedOutput()
-	
-	if ctx.Err() == context.DeadlineExceeded {
-		result.ErrorMsg = "测试超时"
-		result.PacketLoss = 100.0
-		return result
-	}
-	
-	if err != nil {
-		result.ErrorMsg = fmt.Sprintf("ping失败: %v", err)
-		result.PacketLoss = 100.0
-		return result
-	}
-	
-	// 解析ping输出结果
-	outputStr := string(output)
-	result.Success = true
-	
-	// 解析统计信息
-	if strings.Contains(outputStr, "packets transmitted") {
-		lines := strings.Split(outputStr, "\n")
-		for _, line := range lines {
-			line = strings.TrimSpace(line)
-			
-			// 解析包统计: "4 packets transmitted, 4 received, 0% packet loss"
-			if strings.Contains(line, "packets transmitted") && strings.Contains(line, "received") {
-				fields := strings.Fields(line)
-				for i, field := range fields {
-					if field == "received," && i > 0 {
-						if recv, parseErr := strconv.Atoi(fields[i-1]); parseErr == nil {
-							result.PacketsRecv = recv
-						}
-					}
-					if strings.HasSuffix(field, "%") && strings.Contains(line, "packet loss") {
-						lossStr := strings.TrimSuffix(field, "%")
-						if loss, parseErr := strconv.ParseFloat(lossStr, 64); parseErr == nil {
-							result.PacketLoss = loss
-						}
-					}
-				}
-			}
-			
-			// 解析延迟统计: "round-trip min/avg/max/stddev = 1.234/2.345/3.456/0.123 ms"
-			if strings.Contains(line, "round-trip") && strings.Contains(line, "=") {
-				parts := strings.Split(line, "=")
-				if len(parts) > 1 {
-					latencyPart := strings.TrimSpace(parts[1])
-					latencyValues := strings.Split(latencyPart, "/")
-					if len(latencyValues) >= 2 {
-						result.AvgLatency = fmt.Sprintf("%.1f ms", parseFloat(latencyValues[1]))
-					}
-				}
-			}
-		}
-	}
-	
-	// 如果丢包率大于0，标记为部分失败
-	if result.PacketLoss > 0 {
-		if result.PacketLoss == 100 {
-			result.Success = false
-			result.ErrorMsg = "所有数据包丢失"
-		} else {
-			result.ErrorMsg = fmt.Sprintf("%.1f%% 数据包丢失", result.PacketLoss)
-		}
-	}
-	
-	if result.AvgLatency == "" {
-		result.AvgLatency = "N/A"
-	}
-	
-	return result
-}
-
-// parseFloat 安全解析浮点数
-func parseFloat(s string) float64 {
-	if val, err := strconv.ParseFloat(s, 64); err == nil {
-		return val
-	}
-	return 0.0
+	return n.Traffic[len(n.Traffic)-1]
 }
 
 func RebootSystem() error {
@@ -599,9 +504,27 @@ func RestartSystemService(serviceName string) error {
 
 // getMemoryUsageMB 获取内存使用状态（MB单位）
 func getMemoryUsageMB() (string, error) {
+	memUsed, memTotal, err := GetMemoryStats()
+	if err != nil {
+		return "", err
+	}
+	if memTotal <= 0 {
+		return i18n.Translate("未知"), nil
+	}
+
+	// 转换为MB（字节转MB）
+	memUsedMB := memUsed / 1024 / 1024
+	memTotalMB := memTotal / 1024 / 1024
+
+	return fmt.Sprintf("%dM/%dMB", memUsedMB, memTotalMB), nil
+}
+
+// GetMemoryStats 获取已用内存和内存总量（字节），内存总量未知时都为0
+// 已用内存按 MemTotal - MemAvailable 计算，不包括可以回收的缓存
+func GetMemoryStats() (int64, int64, error) {
 	data, err := os.ReadFile("/proc/meminfo")
 	if err != nil {
-		return "", fmt.Errorf("读取内存信息失败: %v", err)
+		return 0, 0, fmt.Errorf("读取内存信息失败: %v", err)
 	}
 
 	lines := strings.Split(string(data), "\n")
@@ -632,18 +555,67 @@ func getMemoryUsageMB() (string, error) {
 	}
 
 	if memTotal <= 0 {
-		return "未知", nil
+		return 0, 0, nil
 	}
 	if memAvailable < 0 || memAvailable > memTotal {
 		memAvailable = 0
 	}
 
-	memUsed := memTotal - memAvailable
-	// 转换为MB（KB转MB）
-	memUsedMB := memUsed / 1024
-	memTotalMB := memTotal / 1024
+	// /proc/meminfo中的单位为KB
+	return (memTotal - memAvailable) * 1024, memTotal * 1024, nil
+}
 
-	return fmt.Sprintf("%dM/%dMB", memUsedMB, memTotalMB), nil
+// GetSwapStats 获取已用交换空间和交换空间总量（字节），没有交换空间时都为0
+// 已用交换空间按 SwapTotal - SwapFree 计算
+func GetSwapStats() (int64, int64, error) {
+	data, err := os.ReadFile("/proc/meminfo")
+	if err != nil {
+		return 0, 0, fmt.Errorf("读取内存信息失败: %v", err)
+	}
+
+	var swapTotal, swapFree int64
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		val, parseErr := strconv.ParseInt(fields[1], 10, 64)
+		if parseErr != nil {
+			continue
+		}
+		switch fields[0] {
+		case "SwapTotal:":
+			swapTotal = val
+		case "SwapFree:":
+			swapFree = val
+		}
+	}
+
+	if swapTotal <= 0 {
+		return 0, 0, nil
+	}
+	if swapFree < 0 || swapFree > swapTotal {
+		swapFree = swapTotal
+	}
+
+	// /proc/meminfo中的单位为KB
+	return (swapTotal - swapFree) * 1024, swapTotal * 1024, nil
+}
+
+// GetRootUsage 获取根分区的已用空间和总空间（字节），总空间未知时都为0
+// 总空间为已用加普通用户可用的空间，不含为root用户保留的块，因此已用占总空间的比例与df的Use%一致
+func GetRootUsage() (int64, int64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs("/", &stat); err != nil {
+		return 0, 0, fmt.Errorf("获取根分区使用情况失败: %v", err)
+	}
+	if stat.Blocks == 0 || stat.Bsize == 0 {
+		return 0, 0, nil
+	}
+
+	used := int64(stat.Blocks-stat.Bfree) * int64(stat.Bsize)
+	total := used + int64(stat.Bavail)*int64(stat.Bsize)
+	return used, total, nil
 }
 
 // getPhysicalDiskInfo 获取物理磁盘信息
@@ -682,7 +654,7 @@ func getPhysicalDiskInfo() (string, int, error) {
 	}
 
 	if len(diskSizes) == 0 {
-		return "未知", 0, nil
+		return i18n.Translate("未知"), 0, nil
 	}
 
 	var totalSizeKB int64
@@ -744,6 +716,16 @@ func formatDiskSize(bytes int64) string {
 	return fmt.Sprintf("%d B", bytes)
 }
 
+// GetIPAddress 获取设备的IP地址（默认路由所在网卡的IPv4地址）
+// 获取失败时返回"未知"，用于只需要IP地址、不必收集全部系统信息的场合
+func GetIPAddress() string {
+	ip, err := getDefaultRouteIP()
+	if err != nil {
+		return i18n.Translate("未知")
+	}
+	return ip
+}
+
 // getDefaultRouteIP 获取设备本身的IP地址（通过默认路由的网卡）
 func getDefaultRouteIP() (string, error) {
 	// 执行ip route命令获取默认路由
@@ -846,7 +828,7 @@ func getFirstNonLoopbackIP() (string, error) {
 		}
 	}
 
-	return "未获取到IP", nil
+	return i18n.Translate("未获取到IP"), nil
 }
 
 // getQianKunCloudID 读取设备ID
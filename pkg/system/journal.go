@@ -0,0 +1,69 @@
+package system
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+)
+
+// journalArgs 生成读取服务日志的journalctl参数
+// 参数unit: 服务名称
+// 参数lines: 读取的最近行数
+// 参数follow: 是否持续输出新的日志
+func journalArgs(unit string, lines int, follow bool) []string {
+	args := []string{"--no-pager", "-o", "short", "-n", strconv.Itoa(lines), "-u", unit}
+	if follow {
+		args = append([]string{"-f"}, args...)
+	}
+	return args
+}
+
+// GetJournal 通过journalctl读取一个systemd服务的日志
+// 读取在后台进行，调用方通过返回的跟踪器的Drain取走各行，Stop会取消读取并结束journalctl
+// 不跟踪时journalctl输出完最近的日志后正常退出，Err仍返回nil
+// 参数unit: 服务名称，如"nginx"
+// 参数lines: 开始时读取的最近行数
+// 参数follow: 是否像journalctl -f一样持续读取新的日志
+func GetJournal(unit string, lines int, follow bool) (*LogFollower, error) {
+	if err := checkUnitName(unit); err != nil {
+		return nil, err
+	}
+
+	f := newLogFollower()
+	cmd := exec.CommandContext(f.ctx, "journalctl", journalArgs(unit, lines, follow)...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		f.cancel()
+		return nil, fmt.Errorf("创建输出管道失败: %v", err)
+	}
+	cmd.Stderr = cmd.Stdout
+	if err := cmd.Start(); err != nil {
+		f.cancel()
+		return nil, fmt.Errorf("启动journalctl失败: %v", err)
+	}
+
+	go func() {
+		defer close(f.done)
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 0, 4096), 64*1024)
+		for scanner.Scan() {
+			f.push(scanner.Text())
+		}
+		// 超长的行导致扫描中止时读完剩余的输出，避免journalctl阻塞在写管道上
+		_, _ = io.Copy(io.Discard, stdout)
+		err := cmd.Wait()
+		if f.ctx.Err() != nil {
+			return // 主动停止，不是错误
+		}
+		if err == nil {
+			if !follow {
+				return
+			}
+			err = fmt.Errorf("journalctl已退出")
+		}
+		f.fail(fmt.Errorf("读取服务 %s 的日志失败: %v", unit, err))
+	}()
+	return f, nil
+}
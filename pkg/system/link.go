@@ -0,0 +1,56 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// readLinkInfo 读取网卡的链路状态，相当于ethtool显示的速率、双工模式和是否接通
+// 网卡未启用或驱动不支持时速率为-1、双工模式为空；读取carrier失败（网卡未启用）视为未接通
+// 参数name: 网卡名称，如"eth0"
+func readLinkInfo(name string) (speed int, duplex string, carrier bool) {
+	dir := filepath.Join("/sys/class/net", name)
+	speed = -1
+	if v, err := readSysInt(filepath.Join(dir, "speed")); err == nil && v > 0 {
+		speed = int(v)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "duplex")); err == nil {
+		if d := strings.TrimSpace(string(data)); d == "full" || d == "half" {
+			duplex = d
+		}
+	}
+	if v, err := readSysInt(filepath.Join(dir, "carrier")); err == nil {
+		carrier = v == 1
+	}
+	return speed, duplex, carrier
+}
+
+// readDriver 返回网卡驱动的名称，如"e1000e"，虚拟网卡或读取失败时返回空字符串
+func readDriver(name string) string {
+	target, err := os.Readlink(filepath.Join("/sys/class/net", name, "device", "driver"))
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(target)
+}
+
+// LinkSummary 返回链路状态的简要说明，如"1000Mb/s Full, link up"，速率或双工模式未知时省略
+func (n NetworkInterface) LinkSummary() string {
+	var parts []string
+	if n.Speed > 0 {
+		parts = append(parts, strconv.Itoa(n.Speed)+"Mb/s")
+	}
+	if n.Duplex != "" {
+		parts = append(parts, strings.ToUpper(n.Duplex[:1])+n.Duplex[1:])
+	}
+	state := "link down"
+	if n.Carrier {
+		state = "link up"
+	}
+	if len(parts) == 0 {
+		return state
+	}
+	return strings.Join(parts, " ") + ", " + state
+}
@@ -0,0 +1,180 @@
+package system
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// 日志跟踪的参数
+const (
+	logPollInterval  = 500 * time.Millisecond // 检查日志文件新内容的间隔
+	logPendingLimit  = 5000                   // 尚未取走的最多行数，超过时丢弃最早的行
+	logBacklogPerRow = 256                    // 读取文件末尾若干行时按每行的平均字节数估计读取的范围
+)
+
+// LogFollower 持续读取日志的新内容，类似tail -f
+// 读取在后台goroutine中进行，新的行暂存起来，由调用方定时通过Drain取走
+type LogFollower struct {
+	mu      sync.Mutex
+	pending []string // 尚未取走的行
+	err     error    // 读取停止的原因，正常跟踪时为nil
+	ctx     context.Context
+	cancel  context.CancelFunc // 停止读取，同时结束journalctl子进程
+	done    chan struct{}
+}
+
+// newLogFollower 创建尚未开始读取的日志跟踪器
+func newLogFollower() *LogFollower {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &LogFollower{ctx: ctx, cancel: cancel, done: make(chan struct{})}
+}
+
+// push 暂存读到的一行，超出上限时丢弃最早的行
+func (f *LogFollower) push(line string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pending = append(f.pending, line)
+	if n := len(f.pending) - logPendingLimit; n > 0 {
+		f.pending = append(f.pending[:0], f.pending[n:]...)
+	}
+}
+
+// fail 记录读取停止的原因
+func (f *LogFollower) fail(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+// Drain 取走上次调用以来读到的新行，没有新内容时返回nil
+func (f *LogFollower) Drain() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	lines := f.pending
+	f.pending = nil
+	return lines
+}
+
+// Err 返回读取停止的原因，仍在跟踪时返回nil
+func (f *LogFollower) Err() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
+
+// Stop 停止跟踪，等待后台goroutine退出后返回，可以重复调用
+func (f *LogFollower) Stop() {
+	f.cancel()
+	<-f.done
+}
+
+// FollowFile 读取日志文件末尾的若干行，之后持续读取追加的内容
+// 文件被截断或替换为更短的文件时从头重新读取
+// 参数path: 日志文件路径
+// 参数backlog: 开始时读取的末尾行数
+func FollowFile(path string, backlog int) (*LogFollower, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("打开日志文件失败: %v", err)
+	}
+	offset, lines, err := readTail(file, backlog)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	f := newLogFollower()
+	f.pending = lines
+	go f.pollFile(path, file, offset)
+	return f, nil
+}
+
+// readTail 读取文件末尾的若干行，返回读到的位置和各行（不含最后不完整的一行）
+func readTail(file *os.File, backlog int) (int64, []string, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return 0, nil, fmt.Errorf("读取日志文件信息失败: %v", err)
+	}
+	start := info.Size() - int64(backlog)*logBacklogPerRow
+	if start < 0 {
+		start = 0
+	}
+	data := make([]byte, info.Size()-start)
+	if _, err := file.ReadAt(data, start); err != nil && err != io.EOF {
+		return 0, nil, fmt.Errorf("读取日志文件失败: %v", err)
+	}
+	if start > 0 {
+		// 从文件中间开始读取时丢弃第一行不完整的部分
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			data, start = data[i+1:], start+int64(i+1)
+		}
+	}
+	// 最后一行尚未写完时留到下次读取
+	end := bytes.LastIndexByte(data, '\n') + 1
+	lines := splitLines(data[:end])
+	if len(lines) > backlog {
+		lines = lines[len(lines)-backlog:]
+	}
+	return start + int64(end), lines, nil
+}
+
+// splitLines 把完整的若干行拆分为字符串，去掉行尾的换行符
+func splitLines(data []byte) []string {
+	var lines []string
+	for len(data) > 0 {
+		i := bytes.IndexByte(data, '\n')
+		lines = append(lines, string(bytes.TrimRight(data[:i], "\r")))
+		data = data[i+1:]
+	}
+	return lines
+}
+
+// pollFile 定时检查文件是否有追加的内容，只把完整的行交给调用方
+func (f *LogFollower) pollFile(path string, file *os.File, offset int64) {
+	defer close(f.done)
+	defer func() { file.Close() }()
+
+	ticker := time.NewTicker(logPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-f.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			continue // 日志轮转期间文件可能暂时不存在
+		}
+		if cur, err := file.Stat(); err != nil || !os.SameFile(cur, info) || info.Size() < offset {
+			// 文件被替换或截断，重新打开并从头读取
+			reopened, err := os.Open(path)
+			if err != nil {
+				continue
+			}
+			file.Close()
+			file, offset = reopened, 0
+		}
+		if info.Size() == offset {
+			continue
+		}
+
+		data := make([]byte, info.Size()-offset)
+		n, err := file.ReadAt(data, offset)
+		if err != nil && err != io.EOF {
+			f.fail(fmt.Errorf("读取日志文件失败: %v", err))
+			return
+		}
+		end := bytes.LastIndexByte(data[:n], '\n') + 1
+		for _, line := range splitLines(data[:end]) {
+			f.push(line)
+		}
+		offset += int64(end)
+	}
+}
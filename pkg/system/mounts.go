@@ -0,0 +1,89 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// virtualFilesystems 不占用磁盘空间的文件系统类型，GetMounts不列出这些挂载点
+// squashfs通常是snap等只读镜像，总是显示为100%已用，同样不列出
+var virtualFilesystems = map[string]bool{
+	"proc": true, "sysfs": true, "devtmpfs": true, "devpts": true, "tmpfs": true, "ramfs": true,
+	"cgroup": true, "cgroup2": true, "securityfs": true, "pstore": true, "debugfs": true, "tracefs": true,
+	"configfs": true, "fusectl": true, "mqueue": true, "hugetlbfs": true, "bpf": true, "autofs": true,
+	"binfmt_misc": true, "rpc_pipefs": true, "nsfs": true, "efivarfs": true, "squashfs": true,
+}
+
+// Mount 一个挂载点的容量和使用情况
+type Mount struct {
+	Device string // 设备，如"/dev/sda1"、"server:/export"
+	Path   string // 挂载点，如"/"、"/data"
+	FSType string // 文件系统类型，如"ext4"、"xfs"、"nfs4"
+	Total  int64  // 总容量（字节）
+	Used   int64  // 已用空间（字节）
+	Avail  int64  // 普通用户可用的空间（字节），不含为root保留的空间
+}
+
+// UsePercent 返回使用率（0-100），与df的Use%相同：已用空间占已用加可用空间的比例
+func (m Mount) UsePercent() float64 {
+	if m.Used+m.Avail <= 0 {
+		return 0
+	}
+	return float64(m.Used) * 100 / float64(m.Used+m.Avail)
+}
+
+// GetMounts 返回所有实际占用存储空间的挂载点，按/proc/self/mounts中的顺序
+// 跳过proc、tmpfs等虚拟文件系统和容量为0的挂载点；同一设备挂载在多处时（如bind挂载）只列出第一个挂载点
+func GetMounts() ([]Mount, error) {
+	data, err := os.ReadFile("/proc/self/mounts")
+	if err != nil {
+		return nil, fmt.Errorf("读取挂载点失败: %v", err)
+	}
+
+	var mounts []Mount
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || virtualFilesystems[fields[2]] {
+			continue
+		}
+		m := Mount{Device: unescapeMountField(fields[0]), Path: unescapeMountField(fields[1]), FSType: fields[2]}
+		if seen[m.Device] {
+			continue
+		}
+
+		var stat syscall.Statfs_t
+		if err := syscall.Statfs(m.Path, &stat); err != nil || stat.Blocks == 0 {
+			continue // 无法访问（如断开的网络文件系统）或不占用空间
+		}
+		bsize := int64(stat.Bsize)
+		m.Total = int64(stat.Blocks) * bsize
+		m.Used = int64(stat.Blocks-stat.Bfree) * bsize
+		m.Avail = int64(stat.Bavail) * bsize
+		seen[m.Device] = true
+		mounts = append(mounts, m)
+	}
+	return mounts, nil
+}
+
+// unescapeMountField 还原/proc/self/mounts中以八进制转义的空格、制表符等字符，如"\040"
+func unescapeMountField(field string) string {
+	if !strings.Contains(field, `\`) {
+		return field
+	}
+	var b strings.Builder
+	for i := 0; i < len(field); i++ {
+		if field[i] == '\\' && i+3 < len(field) {
+			if v, err := strconv.ParseUint(field[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(field[i])
+	}
+	return b.String()
+}
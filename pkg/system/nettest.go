@@ -0,0 +1,232 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"go-framebuffer-console/pkg/i18n"
+)
+
+// 网络测试目标的类型
+const (
+	TargetPing = "ping" // 发送ICMP回显请求
+	TargetHTTP = "http" // 请求URL，检查状态码和证书有效期
+	TargetTCP  = "tcp"  // 连接指定的端口
+)
+
+// NetworkTestTypes 支持的测试类型
+var NetworkTestTypes = []string{TargetPing, TargetHTTP, TargetTCP}
+
+// NetworkTestTarget 网络测试目标
+type NetworkTestTarget struct {
+	Name        string        // 显示名称
+	Host        string        // 主机地址
+	Description string        // 描述
+	Type        string        // 测试类型，为空时按ping测试
+	Count       int           // 尝试次数（ping为发送的数据包数，tcp为连接次数），0使用默认值
+	Timeout     time.Duration // 每次尝试等待的时间（ping为每个数据包，http为整个请求，tcp为每次连接），0使用默认值
+	Port        int           // 端口，仅用于tcp
+
+	// 以下仅用于http
+	URL          string // 请求的URL，为空时请求http://Host/
+	ExpectStatus int    // 期望的状态码，0表示2xx和3xx都算正常
+	Insecure     bool   // 是否跳过HTTPS证书的校验
+}
+
+// Address 返回显示用的地址：tcp目标为"主机:端口"，其它为主机
+func (t NetworkTestTarget) Address() string {
+	if t.Type == TargetTCP && t.Port > 0 {
+		return net.JoinHostPort(t.Host, strconv.Itoa(t.Port))
+	}
+	return t.Host
+}
+
+// DefaultNetworkTestTargets 配置文件中没有指定测试目标时使用的目标
+var DefaultNetworkTestTargets = []NetworkTestTarget{
+	{Name: "字节跳动", Host: "bytedance.com", Description: "字节跳动官网"},
+	{Name: "百度", Host: "baidu.com", Description: "百度首页"},
+	{Name: "哔哩哔哩", Host: "bilibili.com", Description: "哔哩哔哩"},
+	{Name: "腾讯", Host: "tencent.com", Description: "腾讯官网"},
+	{Name: "阿里DNS", Host: "223.5.5.5", Description: "阿里云DNS服务器"},
+}
+
+// NetworkTestResult 网络测试结果
+type NetworkTestResult struct {
+	Target      NetworkTestTarget
+	Success     bool
+	PacketsSent int
+	PacketsRecv int
+	PacketLoss  float64
+	AvgLatency  string
+	ErrorMsg    string
+	Ping        *PingResult // 各数据包的往返时间（tcp为各次连接的耗时），解析主机或创建套接字失败时为nil
+	HTTP        *HTTPResult // http检查的状态码和证书，没有收到响应时为nil
+}
+
+// NetworkTestProgress 网络测试进度回调
+type NetworkTestProgress func(target string, current, total int, message string)
+
+// TestNetworkConnectivity 简单网络测试（保持向后兼容）
+func TestNetworkConnectivity() (bool, error) {
+	return TestNetworkConnectivityWithTimeout(5 * time.Second)
+}
+
+func TestNetworkConnectivityWithTimeout(timeout time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	result, err := Ping(ctx, "8.8.8.8", PingOptions{Count: 1, Timeout: 3 * time.Second})
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return false, fmt.Errorf("网络测试超时")
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return result.Received() > 0, nil
+}
+
+// networkTestWorkers 同时测试的目标数上限
+const networkTestWorkers = 8
+
+// TestAdvancedNetworkConnectivity 高级网络连通性测试，测试DefaultNetworkTestTargets中的目标
+func TestAdvancedNetworkConnectivity(progressCallback NetworkTestProgress) ([]NetworkTestResult, error) {
+	return TestNetworkTargets(DefaultNetworkTestTargets, progressCallback)
+}
+
+// TestNetworkTargets 测试指定的目标，结果与targets一一对应
+// 各目标由最多networkTestWorkers个goroutine同时测试，总耗时约为最慢的一个目标的耗时
+// progressCallback在开始和完成每个目标时调用，调用是串行的，current为已完成的目标数
+func TestNetworkTargets(targets []NetworkTestTarget, progressCallback NetworkTestProgress) ([]NetworkTestResult, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("没有测试目标")
+	}
+	results := make([]NetworkTestResult, len(targets))
+
+	var mu sync.Mutex // 保护done并串行调用progressCallback
+	done := 0
+	report := func(target NetworkTestTarget, finished bool, message string) {
+		mu.Lock()
+		defer mu.Unlock()
+		if finished {
+			done++
+		}
+		if progressCallback != nil {
+			progressCallback(target.Name, done, len(targets), message)
+		}
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < networkTestWorkers && w < len(targets); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				target := targets[i]
+				report(target, false, i18n.Translatef("正在测试 %s...", target.Description))
+
+				results[i] = testSingleTarget(target)
+
+				status := i18n.Translatef("%s 测试成功", target.Description)
+				if !results[i].Success {
+					status = i18n.Translatef("%s 测试失败", target.Description)
+				}
+				report(target, true, status)
+			}
+		}()
+	}
+	for i := range targets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, nil
+}
+
+// testSingleTarget 按目标的类型测试单个目标
+func testSingleTarget(target NetworkTestTarget) NetworkTestResult {
+	switch target.Type {
+	case "", TargetPing:
+		return testPingTarget(target)
+	case TargetHTTP:
+		return testHTTPTarget(target)
+	case TargetTCP:
+		return testTCPTarget(target)
+	}
+	return NetworkTestResult{
+		Target:     target,
+		PacketLoss: 100.0,
+		ErrorMsg:   i18n.Translatef("不支持的测试类型: %s", target.Type),
+	}
+}
+
+// testPingTarget 向目标发送ICMP回显请求，默认发送4个
+func testPingTarget(target NetworkTestTarget) NetworkTestResult {
+	opts := DefaultPingOptions
+	if target.Count > 0 {
+		opts.Count = target.Count
+	}
+	if target.Timeout > 0 {
+		opts.Timeout = target.Timeout
+	}
+	result := NetworkTestResult{
+		Target:      target,
+		PacketsSent: opts.Count,
+		PacketsRecv: 0,
+	}
+
+	// 每个数据包最多占用超时时间和发送间隔中较长的一个，另留出解析主机名的时间
+	wait := opts.Timeout
+	if opts.Interval > wait {
+		wait = opts.Interval
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(opts.Count)*wait+5*time.Second)
+	defer cancel()
+
+	ping, err := Ping(ctx, target.Host, opts)
+	result.Ping = ping
+
+	if ctx.Err() == context.DeadlineExceeded {
+		result.ErrorMsg = i18n.Translate("测试超时")
+		result.PacketLoss = 100.0
+		return result
+	}
+
+	if err != nil {
+		result.ErrorMsg = i18n.Translatef("ping失败: %v", err)
+		result.PacketLoss = 100.0
+		return result
+	}
+
+	result.Success = true
+	result.PacketsSent = ping.Sent()
+	result.PacketsRecv = ping.Received()
+	result.PacketLoss = ping.Loss()
+	if result.PacketsRecv > 0 {
+		_, avg, _ := ping.RTTStats()
+		result.AvgLatency = FormatRTT(avg)
+	}
+
+	// 如果丢包率大于0，标记为部分失败
+	if result.PacketLoss > 0 {
+		if result.PacketLoss == 100 {
+			result.Success = false
+			result.ErrorMsg = i18n.Translate("所有数据包丢失")
+		} else {
+			result.ErrorMsg = i18n.Translatef("%.1f%% 数据包丢失", result.PacketLoss)
+		}
+	}
+
+	if result.AvgLatency == "" {
+		result.AvgLatency = "N/A"
+	}
+
+	return result
+}
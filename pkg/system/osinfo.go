@@ -0,0 +1,70 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// osReleaseFiles 操作系统发行版信息文件，按优先顺序排列，见os-release(5)
+var osReleaseFiles = []string{"/etc/os-release", "/usr/lib/os-release"}
+
+// getOSName 返回发行版的名称，如"Debian GNU/Linux 12 (bookworm)"
+// 优先使用PRETTY_NAME，没有时由NAME和VERSION组成
+func getOSName() (string, error) {
+	for _, path := range osReleaseFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		vars := parseOSRelease(string(data))
+		if name := vars["PRETTY_NAME"]; name != "" {
+			return name, nil
+		}
+		if name := strings.TrimSpace(vars["NAME"] + " " + vars["VERSION"]); name != "" {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("读取os-release失败")
+}
+
+// parseOSRelease 解析os-release中KEY=VALUE形式的各行，值可以带引号
+func parseOSRelease(data string) map[string]string {
+	vars := make(map[string]string)
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		} else {
+			value = strings.Trim(value, `'"`)
+		}
+		vars[key] = value
+	}
+	return vars
+}
+
+// getKernelVersion 返回内核版本，与uname -r相同，如"6.1.0-18-amd64"
+func getKernelVersion() (string, error) {
+	data, err := os.ReadFile("/proc/sys/kernel/osrelease")
+	if err != nil {
+		return "", fmt.Errorf("读取内核版本失败: %v", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// getArchitecture 返回硬件架构，与uname -m相同，如"x86_64"
+// 较旧的内核没有/proc/sys/kernel/arch，此时使用程序编译时的架构，如"amd64"
+func getArchitecture() string {
+	if data, err := os.ReadFile("/proc/sys/kernel/arch"); err == nil {
+		if arch := strings.TrimSpace(string(data)); arch != "" {
+			return arch
+		}
+	}
+	return runtime.GOARCH
+}
@@ -0,0 +1,325 @@
+package system
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// ICMP回显请求和回复的类型
+const (
+	icmpv4EchoRequest = 8
+	icmpv4EchoReply   = 0
+	icmpv6EchoRequest = 128
+	icmpv6EchoReply   = 129
+)
+
+// pingPayloadSize 回显请求携带的数据长度，与ping命令的默认值相同
+const pingPayloadSize = 56
+
+// PingOptions ping的参数
+type PingOptions struct {
+	Count    int           // 发送的数据包数
+	Interval time.Duration // 相邻两个数据包的发送间隔
+	Timeout  time.Duration // 每个数据包等待回复的时间
+}
+
+// DefaultPingOptions 网络测试使用的参数，与ping -c 4 -W 3相同
+var DefaultPingOptions = PingOptions{Count: 4, Interval: time.Second, Timeout: 3 * time.Second}
+
+// PingReply 一个数据包的结果
+type PingReply struct {
+	Seq      int           // 序号，从1开始
+	Received bool          // 是否在超时前收到回复
+	RTT      time.Duration // 往返时间，没有收到回复时为0
+}
+
+// PingResult 一次ping的结果
+type PingResult struct {
+	Host    string      // 测试的主机名或地址
+	Addr    net.IP      // 解析得到的地址
+	Replies []PingReply // 各数据包的结果，按发送顺序
+}
+
+// Sent 返回发送的数据包数
+func (r *PingResult) Sent() int {
+	return len(r.Replies)
+}
+
+// Received 返回收到回复的数据包数
+func (r *PingResult) Received() int {
+	n := 0
+	for _, reply := range r.Replies {
+		if reply.Received {
+			n++
+		}
+	}
+	return n
+}
+
+// Loss 返回丢包率（0-100），没有发送数据包时返回100
+func (r *PingResult) Loss() float64 {
+	if len(r.Replies) == 0 {
+		return 100
+	}
+	return float64(len(r.Replies)-r.Received()) * 100 / float64(len(r.Replies))
+}
+
+// RTTStats 返回收到回复的数据包往返时间的最小值、平均值和最大值，没有回复时都为0
+func (r *PingResult) RTTStats() (min, avg, max time.Duration) {
+	var total time.Duration
+	n := 0
+	for _, reply := range r.Replies {
+		if !reply.Received {
+			continue
+		}
+		if n == 0 || reply.RTT < min {
+			min = reply.RTT
+		}
+		if reply.RTT > max {
+			max = reply.RTT
+		}
+		total += reply.RTT
+		n++
+	}
+	if n > 0 {
+		avg = total / time.Duration(n)
+	}
+	return min, avg, max
+}
+
+// Jitter 返回抖动：相邻两个收到回复的数据包往返时间之差的平均值，少于两个回复时为0
+func (r *PingResult) Jitter() time.Duration {
+	var total, prev time.Duration
+	n := 0
+	for _, reply := range r.Replies {
+		if !reply.Received {
+			continue
+		}
+		if n > 0 {
+			diff := reply.RTT - prev
+			if diff < 0 {
+				diff = -diff
+			}
+			total += diff
+		}
+		prev = reply.RTT
+		n++
+	}
+	if n < 2 {
+		return 0
+	}
+	return total / time.Duration(n-1)
+}
+
+// FormatRTT 把往返时间格式化为毫秒，如"12.3 ms"
+func FormatRTT(d time.Duration) string {
+	return fmt.Sprintf("%.1f ms", float64(d)/float64(time.Millisecond))
+}
+
+// Ping 向主机发送ICMP回显请求，统计各数据包的往返时间
+// 优先使用原始套接字，没有权限时改用内核提供的ICMP数据报套接字（不需要root，但需要net.ipv4.ping_group_range包含当前用户组）
+// ctx被取消时停止发送，返回已经得到的结果和ctx的错误
+// 参数host: 主机名或IP地址
+// 参数opts: 数据包数、发送间隔和超时时间
+func Ping(ctx context.Context, host string, opts PingOptions) (*PingResult, error) {
+	addr, err := resolveHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := listenICMP(addr.To4() == nil)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	result := &PingResult{Host: host, Addr: addr}
+	for seq := 1; seq <= opts.Count; seq++ {
+		start := time.Now()
+		if err := conn.sendEcho(addr, seq); err != nil {
+			return result, fmt.Errorf("发送ICMP数据包失败: %v", err)
+		}
+		deadline := start.Add(opts.Timeout)
+		if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+			deadline = d
+		}
+		reply := PingReply{Seq: seq}
+		if err := conn.waitReply(addr, seq, deadline); err == nil {
+			reply.Received, reply.RTT = true, time.Since(start)
+		} else if !errors.Is(err, os.ErrDeadlineExceeded) {
+			return result, fmt.Errorf("接收ICMP数据包失败: %v", err)
+		}
+		result.Replies = append(result.Replies, reply)
+
+		if seq == opts.Count {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(time.Until(start.Add(opts.Interval))):
+		}
+	}
+	return result, ctx.Err()
+}
+
+// resolveHost 解析主机名，有IPv4地址时优先使用IPv4
+func resolveHost(ctx context.Context, host string) (net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return ip, nil
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("解析主机 %s 失败: %v", host, err)
+	}
+	for _, a := range addrs {
+		if a.IP.To4() != nil {
+			return a.IP, nil
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("解析主机 %s 失败: 没有地址", host)
+	}
+	return addrs[0].IP, nil
+}
+
+// pingID 为每个原始套接字分配不同的回显标识符，同一进程中同时进行的多个ping不会收到彼此的回复
+var pingID atomic.Uint32
+
+func init() {
+	pingID.Store(uint32(os.Getpid()))
+}
+
+// icmpConn 发送回显请求、接收回复的ICMP套接字
+type icmpConn struct {
+	conn net.PacketConn
+	ipv6 bool
+	raw  bool   // 原始套接字会收到本机所有的ICMP报文，需要按标识符和来源过滤；数据报套接字由内核过滤
+	id   uint16 // 回显标识符，数据报套接字的标识符由内核改写为本地端口
+}
+
+// listenICMP 创建ICMP套接字，原始套接字没有权限时改用数据报套接字
+// 参数ipv6: 是否用于IPv6地址
+func listenICMP(ipv6 bool) (*icmpConn, error) {
+	network, address, family, proto := "ip4:icmp", "0.0.0.0", syscall.AF_INET, syscall.IPPROTO_ICMP
+	if ipv6 {
+		network, address, family, proto = "ip6:ipv6-icmp", "::", syscall.AF_INET6, syscall.IPPROTO_ICMPV6
+	}
+	id := uint16(pingID.Add(1))
+	conn, rawErr := net.ListenPacket(network, address)
+	if rawErr == nil {
+		return &icmpConn{conn: conn, ipv6: ipv6, raw: true, id: id}, nil
+	}
+
+	conn, err := listenICMPDatagram(family, proto)
+	if err != nil {
+		return nil, fmt.Errorf("创建ICMP套接字失败: %v（需要root权限，或在net.ipv4.ping_group_range中允许当前用户组）", rawErr)
+	}
+	return &icmpConn{conn: conn, ipv6: ipv6, id: id}, nil
+}
+
+// listenICMPDatagram 创建内核提供的ICMP数据报套接字（ping套接字）
+func listenICMPDatagram(family, proto int) (net.PacketConn, error) {
+	fd, err := syscall.Socket(family, syscall.SOCK_DGRAM|syscall.SOCK_CLOEXEC, proto)
+	if err != nil {
+		return nil, err
+	}
+	var sa syscall.Sockaddr = &syscall.SockaddrInet4{}
+	if family == syscall.AF_INET6 {
+		sa = &syscall.SockaddrInet6{}
+	}
+	if err := syscall.Bind(fd, sa); err != nil {
+		syscall.Close(fd)
+		return nil, err
+	}
+	f := os.NewFile(uintptr(fd), "icmp")
+	defer f.Close()
+	return net.FilePacketConn(f)
+}
+
+// Close 关闭套接字
+func (c *icmpConn) Close() error {
+	return c.conn.Close()
+}
+
+// sendEcho 发送一个回显请求
+func (c *icmpConn) sendEcho(dst net.IP, seq int) error {
+	msg := make([]byte, 8+pingPayloadSize)
+	msg[0] = icmpv4EchoRequest
+	if c.ipv6 {
+		msg[0] = icmpv6EchoRequest
+	}
+	binary.BigEndian.PutUint16(msg[4:], c.id)
+	binary.BigEndian.PutUint16(msg[6:], uint16(seq))
+	binary.BigEndian.PutUint64(msg[8:], uint64(time.Now().UnixNano()))
+	if !c.ipv6 {
+		// ICMPv6的校验和包含IP伪首部，由内核计算
+		binary.BigEndian.PutUint16(msg[2:], icmpChecksum(msg))
+	}
+
+	var addr net.Addr = &net.UDPAddr{IP: dst}
+	if c.raw {
+		addr = &net.IPAddr{IP: dst}
+	}
+	_, err := c.conn.WriteTo(msg, addr)
+	return err
+}
+
+// waitReply 等待序号为seq的回显回复，到达deadline时返回os.ErrDeadlineExceeded
+// 其它报文（如更早的数据包迟到的回复、其它程序的ICMP报文）被忽略
+func (c *icmpConn) waitReply(dst net.IP, seq int, deadline time.Time) error {
+	if err := c.conn.SetReadDeadline(deadline); err != nil {
+		return err
+	}
+	replyType := byte(icmpv4EchoReply)
+	if c.ipv6 {
+		replyType = icmpv6EchoReply
+	}
+	buf := make([]byte, 1500)
+	for {
+		n, from, err := c.conn.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+		msg := buf[:n]
+		if n < 8 || msg[0] != replyType || binary.BigEndian.Uint16(msg[6:]) != uint16(seq) {
+			continue
+		}
+		if c.raw && (binary.BigEndian.Uint16(msg[4:]) != c.id || !addrIP(from).Equal(dst)) {
+			continue
+		}
+		return nil
+	}
+}
+
+// addrIP 返回套接字地址中的IP
+func addrIP(addr net.Addr) net.IP {
+	switch a := addr.(type) {
+	case *net.IPAddr:
+		return a.IP
+	case *net.UDPAddr:
+		return a.IP
+	}
+	return nil
+}
+
+// icmpChecksum 计算ICMPv4报文的校验和（校验和字段为0时计算）
+func icmpChecksum(msg []byte) uint16 {
+	var sum uint32
+	for i := 0; i+1 < len(msg); i += 2 {
+		sum += uint32(msg[i])<<8 | uint32(msg[i+1])
+	}
+	if len(msg)%2 == 1 {
+		sum += uint32(msg[len(msg)-1]) << 8
+	}
+	for sum>>16 != 0 {
+		sum = sum&0xffff + sum>>16
+	}
+	return ^uint16(sum)
+}
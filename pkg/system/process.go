@@ -0,0 +1,186 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Process 一个进程的资源占用
+type Process struct {
+	PID     int
+	Name    string  // 进程名称，即/proc/[pid]/status中的Name
+	State   string  // 进程状态，如"R"（运行）、"S"（睡眠）、"D"（不可中断）、"Z"（僵尸）
+	Threads int     // 线程数
+	CPU     float64 // 自上次采样以来占用所有CPU时间的百分比（0-100），与CPUUsage.Total的口径相同
+	RSS     int64   // 常驻内存（字节），内核线程为0
+}
+
+// ProcessOrder 进程列表的排序方式
+type ProcessOrder int
+
+const (
+	OrderByCPU    ProcessOrder = iota // 按CPU占用从高到低，相同时按内存
+	OrderByMemory                     // 按常驻内存从大到小，相同时按CPU
+)
+
+// ProcessSampler 根据/proc/[pid]/stat中进程CPU时间的增量计算各进程的CPU占用
+// 第一次采样返回各进程开机以来的平均占用，之后返回自上次采样以来的占用
+type ProcessSampler struct {
+	prevTotal uint64         // 上次采样时所有CPU的总时间（时钟周期）
+	prev      map[int]uint64 // 上次采样时各进程的用户态与内核态时间之和，键为PID
+}
+
+// Sample 读取所有进程，返回按order排序的前n个，n<=0时返回全部
+func (s *ProcessSampler) Sample(n int, order ProcessOrder) ([]Process, error) {
+	_, times, err := readCPUTimes()
+	if err != nil {
+		return nil, err
+	}
+	total := times[0].total
+
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, fmt.Errorf("读取进程列表失败: %v", err)
+	}
+	var procs []Process
+	prev := make(map[int]uint64, len(entries))
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		// 读取期间进程可能已经退出，跳过即可
+		p, ticks, err := readProcess(pid)
+		if err != nil {
+			continue
+		}
+		if total > s.prevTotal {
+			if last, ok := s.prev[pid]; !ok || ticks >= last {
+				p.CPU = float64(ticks-last) * 100 / float64(total-s.prevTotal)
+			}
+		}
+		prev[pid] = ticks
+		procs = append(procs, p)
+	}
+	s.prevTotal, s.prev = total, prev
+
+	SortProcesses(procs, order)
+	if n > 0 && len(procs) > n {
+		procs = procs[:n]
+	}
+	return procs, nil
+}
+
+// SortProcesses 按指定方式排序进程，占用相同时按PID从小到大
+func SortProcesses(procs []Process, order ProcessOrder) {
+	sort.SliceStable(procs, func(i, j int) bool {
+		a, b := procs[i], procs[j]
+		if order == OrderByMemory && a.RSS != b.RSS {
+			return a.RSS > b.RSS
+		}
+		if a.CPU != b.CPU {
+			return a.CPU > b.CPU
+		}
+		if a.RSS != b.RSS {
+			return a.RSS > b.RSS
+		}
+		return a.PID < b.PID
+	})
+}
+
+// readProcess 读取一个进程的名称、状态、线程数和常驻内存，同时返回它累计的CPU时间（时钟周期）
+func readProcess(pid int) (Process, uint64, error) {
+	dir := "/proc/" + strconv.Itoa(pid)
+	stat, err := os.ReadFile(dir + "/stat")
+	if err != nil {
+		return Process{}, 0, err
+	}
+	p := Process{PID: pid}
+	ticks, err := parseProcStat(string(stat), &p)
+	if err != nil {
+		return Process{}, 0, err
+	}
+
+	status, err := os.ReadFile(dir + "/status")
+	if err != nil {
+		return Process{}, 0, err
+	}
+	parseProcStatus(string(status), &p)
+	return p, ticks, nil
+}
+
+// parseProcStat 解析/proc/[pid]/stat，填写进程状态和线程数，返回用户态与内核态时间之和
+// 第二个字段是括号中的进程名，可能包含空格和括号，因此从最后一个右括号之后开始拆分
+func parseProcStat(data string, p *Process) (uint64, error) {
+	end := strings.LastIndexByte(data, ')')
+	if end < 0 {
+		return 0, fmt.Errorf("进程状态格式错误: %s", data)
+	}
+	if start := strings.IndexByte(data, '('); start >= 0 && start < end {
+		p.Name = data[start+1 : end]
+	}
+	// 右括号之后依次为state ppid pgrp session tty_nr tpgid flags minflt cminflt majflt cmajflt utime stime ……
+	fields := strings.Fields(data[end+1:])
+	if len(fields) < 18 {
+		return 0, fmt.Errorf("进程状态格式错误: %s", data)
+	}
+	p.State = fields[0]
+	utime, err := strconv.ParseUint(fields[11], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("解析进程CPU时间失败: %v", err)
+	}
+	stime, err := strconv.ParseUint(fields[12], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("解析进程CPU时间失败: %v", err)
+	}
+	p.Threads, _ = strconv.Atoi(fields[17])
+	return utime + stime, nil
+}
+
+// parseProcStatus 解析/proc/[pid]/status中的进程名和常驻内存
+// stat中的进程名最多15个字符且可能被截断，二者都有时以status为准
+func parseProcStatus(data string, p *Process) {
+	for _, line := range strings.Split(data, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Name":
+			if value != "" {
+				p.Name = value
+			}
+		case "VmRSS":
+			// 格式为"1234 kB"
+			if kb, err := strconv.ParseInt(strings.TrimSuffix(value, " kB"), 10, 64); err == nil {
+				p.RSS = kb * 1024
+			}
+		}
+	}
+}
+
+// processSampler GetTopProcesses使用的采样器，访问时需要加锁
+var processSampler struct {
+	sync.Mutex
+	ProcessSampler
+}
+
+// GetTopProcesses 返回CPU占用最高的n个进程，CPU占用为自上次调用以来的平均值
+// 参数n: 返回的进程数，n<=0时返回全部
+func GetTopProcesses(n int) ([]Process, error) {
+	return GetTopProcessesBy(n, OrderByCPU)
+}
+
+// GetTopProcessesBy 按指定方式返回占用最高的n个进程
+// 参数n: 返回的进程数，n<=0时返回全部
+// 参数order: 排序方式
+func GetTopProcessesBy(n int, order ProcessOrder) ([]Process, error) {
+	processSampler.Lock()
+	defer processSampler.Unlock()
+	return processSampler.Sample(n, order)
+}
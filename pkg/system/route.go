@@ -0,0 +1,198 @@
+package system
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 路由标志位，见/usr/include/linux/route.h
+const (
+	routeFlagUp      = 0x0001 // 路由可用
+	routeFlagGateway = 0x0002 // 经由网关转发
+)
+
+// resolvedStub systemd-resolved在/etc/resolv.conf中写入的本地DNS地址，真正的上游服务器在resolvedUpstream中
+const (
+	resolvedStub     = "127.0.0.53"
+	resolvedUpstream = "/run/systemd/resolve/resolv.conf"
+)
+
+// Route 一条IPv4路由
+type Route struct {
+	Destination net.IPNet // 目的网络，默认路由为0.0.0.0/0
+	Gateway     net.IP    // 网关，直连网络为nil
+	Interface   string    // 出口网卡
+	Metric      int       // 跃点数，越小越优先
+}
+
+// IsDefault 判断是否为默认路由
+func (r Route) IsDefault() bool {
+	ones, _ := r.Destination.Mask.Size()
+	return ones == 0
+}
+
+// String 按ip route的格式返回路由，如"default via 192.168.1.1 dev eth0 metric 100"
+func (r Route) String() string {
+	dest := r.Destination.String()
+	if r.IsDefault() {
+		dest = "default"
+	}
+	parts := []string{dest}
+	if r.Gateway != nil {
+		parts = append(parts, "via", r.Gateway.String())
+	}
+	parts = append(parts, "dev", r.Interface)
+	if r.Metric > 0 {
+		parts = append(parts, "metric", strconv.Itoa(r.Metric))
+	}
+	return strings.Join(parts, " ")
+}
+
+// NetworkSettings 设备的网关、路由和DNS服务器，用于检查网络配置是否正确
+type NetworkSettings struct {
+	Gateway          net.IP   // 默认网关，没有默认路由时为nil
+	GatewayInterface string   // 默认路由的出口网卡
+	Routes           []Route  // 全部IPv4路由，按跃点数排序
+	DNSServers       []string // DNS服务器，按配置的顺序排列
+}
+
+// GetNetworkSettings 读取网关、路由和DNS服务器
+// 读取路由失败时返回错误；没有配置DNS服务器不算错误，DNSServers为空
+func GetNetworkSettings() (*NetworkSettings, error) {
+	routes, err := GetRoutes()
+	if err != nil {
+		return nil, err
+	}
+	settings := &NetworkSettings{Routes: routes}
+	if def := defaultRoute(routes); def != nil {
+		settings.Gateway = def.Gateway
+		settings.GatewayInterface = def.Interface
+	}
+	settings.DNSServers, _ = GetDNSServers()
+	return settings, nil
+}
+
+// GetRoutes 读取/proc/net/route中可用的IPv4路由，按跃点数排序，跃点数相同时默认路由在前
+func GetRoutes() ([]Route, error) {
+	f, err := os.Open("/proc/net/route")
+	if err != nil {
+		return nil, fmt.Errorf("读取路由表失败: %v", err)
+	}
+	defer f.Close()
+
+	var routes []Route
+	scanner := bufio.NewScanner(f)
+	scanner.Scan() // 跳过表头
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 8 {
+			continue
+		}
+		flags, err := strconv.ParseUint(fields[3], 16, 32)
+		if err != nil || flags&routeFlagUp == 0 {
+			continue
+		}
+		dest, err1 := parseRouteAddr(fields[1])
+		gateway, err2 := parseRouteAddr(fields[2])
+		mask, err3 := parseRouteAddr(fields[7])
+		metric, err4 := strconv.Atoi(fields[6])
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+			return nil, fmt.Errorf("解析路由表失败: %s", scanner.Text())
+		}
+		route := Route{
+			Destination: net.IPNet{IP: dest, Mask: net.IPMask(mask)},
+			Interface:   fields[0],
+			Metric:      metric,
+		}
+		if flags&routeFlagGateway != 0 {
+			route.Gateway = gateway
+		}
+		routes = append(routes, route)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取路由表失败: %v", err)
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Metric != routes[j].Metric {
+			return routes[i].Metric < routes[j].Metric
+		}
+		return routes[i].IsDefault() && !routes[j].IsDefault()
+	})
+	return routes, nil
+}
+
+// GetGateway 返回默认网关的地址，没有默认路由时返回错误
+func GetGateway() (string, error) {
+	routes, err := GetRoutes()
+	if err != nil {
+		return "", err
+	}
+	def := defaultRoute(routes)
+	if def == nil || def.Gateway == nil {
+		return "", fmt.Errorf("没有默认网关")
+	}
+	return def.Gateway.String(), nil
+}
+
+// defaultRoute 返回跃点数最小的默认路由，没有时返回nil
+// 参数routes: 已按跃点数排序的路由
+func defaultRoute(routes []Route) *Route {
+	for i := range routes {
+		if routes[i].IsDefault() {
+			return &routes[i]
+		}
+	}
+	return nil
+}
+
+// parseRouteAddr 解析/proc/net/route中的地址：按本机字节序输出的十六进制数，内存中的字节即网络字节序的地址
+func parseRouteAddr(s string) (net.IP, error) {
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return nil, err
+	}
+	ip := make(net.IP, net.IPv4len)
+	binary.NativeEndian.PutUint32(ip, uint32(v))
+	return ip, nil
+}
+
+// GetDNSServers 返回/etc/resolv.conf中配置的DNS服务器
+// 使用systemd-resolved时/etc/resolv.conf中只有本地地址127.0.0.53，此时改为读取其上游服务器
+func GetDNSServers() ([]string, error) {
+	servers, err := readNameservers("/etc/resolv.conf")
+	if err != nil {
+		return nil, err
+	}
+	if len(servers) == 1 && servers[0] == resolvedStub {
+		if upstream, err := readNameservers(resolvedUpstream); err == nil && len(upstream) > 0 {
+			return upstream, nil
+		}
+	}
+	return servers, nil
+}
+
+// readNameservers 读取resolv.conf格式文件中的nameserver，去掉重复的地址
+func readNameservers(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取DNS配置失败: %v", err)
+	}
+	var servers []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "nameserver" || seen[fields[1]] {
+			continue
+		}
+		seen[fields[1]] = true
+		servers = append(servers, fields[1])
+	}
+	return servers, nil
+}
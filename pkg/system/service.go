@@ -0,0 +1,237 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-framebuffer-console/pkg/i18n"
+)
+
+// serviceQueryTimeout 查询一个服务状态的最长时间
+const serviceQueryTimeout = 3 * time.Second
+
+// ServiceState 通过systemctl is-active查询服务的运行状态
+// 返回active、inactive、failed、activating等状态；服务不存在时为inactive。
+// 服务名称包含非法字符或无法执行systemctl时返回错误
+// 参数name: 服务名称，如"sshd"或"nginx.service"
+func ServiceState(name string) (string, error) {
+	if err := checkUnitName(name); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), serviceQueryTimeout)
+	defer cancel()
+
+	// 服务没有运行时is-active以非0状态退出，状态仍然输出在标准输出中
+	output, err := exec.CommandContext(ctx, "systemctl", "is-active", name).Output()
+	state := strings.TrimSpace(string(output))
+	if state == "" {
+		if err == nil {
+			err = fmt.Errorf("systemctl没有输出")
+		}
+		return "", fmt.Errorf("查询服务 %s 的状态失败: %v", name, err)
+	}
+	return state, nil
+}
+
+// ServiceStatus 服务的详细状态，来自systemctl show
+type ServiceStatus struct {
+	Unit        string        // 服务名称，与查询时给出的相同
+	Description string        // 服务的说明
+	LoadState   string        // loaded、not-found等，not-found表示没有这个服务
+	ActiveState string        // active、inactive、failed、activating等，与ServiceState相同
+	SubState    string        // 更具体的状态，如running、exited、dead
+	Uptime      time.Duration // 进入当前active状态以来的时间，未运行时为0
+}
+
+// String 返回"active (running)"形式的状态
+func (s ServiceStatus) String() string {
+	if s.SubState == "" || s.SubState == s.ActiveState {
+		return s.ActiveState
+	}
+	return s.ActiveState + " (" + s.SubState + ")"
+}
+
+// serviceProperties systemctl show查询的属性
+const serviceProperties = "Id,Description,LoadState,ActiveState,SubState,ActiveEnterTimestampMonotonic"
+
+// GetServiceStatus 通过一次systemctl show查询多个服务的详细状态，结果与units一一对应
+// 不存在的服务LoadState为not-found、ActiveState为inactive，不算错误。
+// 运行时间按开机以来的时间计算，系统休眠过时会偏大
+// 参数units: 服务名称，如"sshd"或"nginx.service"
+func GetServiceStatus(units []string) ([]ServiceStatus, error) {
+	if len(units) == 0 {
+		return nil, nil
+	}
+	for _, unit := range units {
+		if err := checkUnitName(unit); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), serviceQueryTimeout)
+	defer cancel()
+
+	args := append([]string{"show", "--property=" + serviceProperties, "--"}, units...)
+	output, err := exec.CommandContext(ctx, "systemctl", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("查询服务状态失败: %v", err)
+	}
+	uptime, _ := readUptimeSeconds()
+	return parseServiceStatus(string(output), units, uptime)
+}
+
+// parseServiceStatus 解析systemctl show的输出：每个服务一段KEY=VALUE，段与段之间以空行分隔，顺序与参数相同
+// 参数uptime: 开机以来的秒数，用于由ActiveEnterTimestampMonotonic计算运行时间，为0时不计算
+func parseServiceStatus(output string, units []string, uptime float64) ([]ServiceStatus, error) {
+	blocks := strings.Split(strings.TrimSpace(output), "\n\n")
+	if len(blocks) != len(units) {
+		return nil, fmt.Errorf("查询服务状态失败: systemctl返回了%d个服务，应为%d个", len(blocks), len(units))
+	}
+	statuses := make([]ServiceStatus, len(units))
+	for i, block := range blocks {
+		status := ServiceStatus{Unit: units[i]}
+		for _, line := range strings.Split(block, "\n") {
+			key, value, _ := strings.Cut(line, "=")
+			switch key {
+			case "Description":
+				status.Description = value
+			case "LoadState":
+				status.LoadState = value
+			case "ActiveState":
+				status.ActiveState = value
+			case "SubState":
+				status.SubState = value
+			case "ActiveEnterTimestampMonotonic":
+				usec, err := strconv.ParseUint(value, 10, 64)
+				if err == nil && usec > 0 && uptime > 0 {
+					if d := time.Duration(uptime*float64(time.Second)) - time.Duration(usec)*time.Microsecond; d > 0 {
+						status.Uptime = d
+					}
+				}
+			}
+		}
+		if status.ActiveState != "active" {
+			status.Uptime = 0
+		}
+		statuses[i] = status
+	}
+	return statuses, nil
+}
+
+// FailedUnits 返回系统中处于failed状态的全部单元，不限于配置的服务
+func FailedUnits() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), serviceQueryTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "systemctl", "list-units", "--state=failed", "--plain", "--no-legend", "--no-pager").Output()
+	if err != nil {
+		return nil, fmt.Errorf("查询失败的单元失败: %v", err)
+	}
+	var units []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			units = append(units, fields[0])
+		}
+	}
+	return units, nil
+}
+
+// readUptimeSeconds 返回开机以来的秒数
+func readUptimeSeconds() (float64, error) {
+	data, err := os.ReadFile("/proc/uptime")
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("uptime格式错误")
+	}
+	return strconv.ParseFloat(fields[0], 64)
+}
+
+// FormatDuration 把时长格式化为最大的两个单位，如"3天 2小时"、"5分钟 10秒"
+func FormatDuration(d time.Duration) string {
+	seconds := int64(d / time.Second)
+	days, hours, minutes := seconds/86400, seconds%86400/3600, seconds%3600/60
+	switch {
+	case days > 0:
+		return i18n.Translatef("%d天 %d小时", days, hours)
+	case hours > 0:
+		return i18n.Translatef("%d小时 %d分钟", hours, minutes)
+	case minutes > 0:
+		return i18n.Translatef("%d分钟 %d秒", minutes, seconds%60)
+	}
+	return i18n.Translatef("%d秒", seconds)
+}
+
+// serviceControlTimeout 启动、停止或重启一个服务的最长时间
+const serviceControlTimeout = 30 * time.Second
+
+// ControlService 通过systemctl启动、停止或重启服务，需要root权限
+// 失败时错误中包含systemctl输出的原因
+// 参数action: start、stop或restart
+// 参数name: 服务名称
+func ControlService(action, name string) error {
+	switch action {
+	case "start", "stop", "restart":
+	default:
+		return fmt.Errorf("不支持的服务操作: %s", action)
+	}
+	if err := checkUnitName(name); err != nil {
+		return err
+	}
+	if os.Getuid() != 0 {
+		return fmt.Errorf("需要root权限管理系统服务")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), serviceControlTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "systemctl", action, name).CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("操作服务 %s 超时", name)
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			return fmt.Errorf("%v: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// ServiceJournal 读取服务最近的几行日志
+// 参数name: 服务名称
+// 参数lines: 读取的行数
+func ServiceJournal(name string, lines int) ([]string, error) {
+	if err := checkUnitName(name); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), serviceQueryTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "journalctl", journalArgs(name, lines, false)...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("读取服务 %s 的日志失败: %v", name, err)
+	}
+	text := strings.TrimRight(string(output), "\n")
+	if text == "" {
+		return nil, nil
+	}
+	return strings.Split(text, "\n"), nil
+}
+
+// checkUnitName 检查服务名称，只允许作为systemctl、journalctl的一个参数传递的普通名称
+func checkUnitName(name string) error {
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, " ;|&$`()[]{}<>?*\\\n\r\t") {
+		return fmt.Errorf("服务名称无效: %q", name)
+	}
+	return nil
+}
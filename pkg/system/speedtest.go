@@ -0,0 +1,172 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// 测速的阶段
+const (
+	SpeedDownload = "download" // 下载
+	SpeedUpload   = "upload"   // 上传
+)
+
+// speedReportInterval 测速期间报告进度的间隔
+const speedReportInterval = 250 * time.Millisecond
+
+// SpeedSample 一个方向的测速结果
+type SpeedSample struct {
+	Bytes   int64         // 传输的字节数
+	Elapsed time.Duration // 传输用时
+	Err     error         // 传输失败的原因；已经传输了一部分后失败时仍按已传输的部分计算速率
+}
+
+// Mbps 返回平均速率（兆比特每秒），没有传输时为0
+func (s *SpeedSample) Mbps() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Bytes) * 8 / s.Elapsed.Seconds() / 1e6
+}
+
+// SpeedTestResult 测速结果，没有测试的方向为nil
+type SpeedTestResult struct {
+	Download *SpeedSample
+	Upload   *SpeedSample
+}
+
+// SpeedTestProgress 测速进度回调
+// 参数phase: 当前阶段，SpeedDownload或SpeedUpload
+// 参数ratio: 当前阶段已用时间占测试时长的比例（0-1）
+// 参数mbps: 当前阶段到目前为止的平均速率（兆比特每秒）
+type SpeedTestProgress func(phase string, ratio float64, mbps float64)
+
+// FormatMbps 把速率格式化为"12.34 Mbps"
+func FormatMbps(mbps float64) string {
+	return fmt.Sprintf("%.2f Mbps", mbps)
+}
+
+// RunSpeedTest 通过HTTP传输测量下载和上传速率，两个方向依次进行，各持续duration
+// 下载读取downloadURL的内容直到结束或超过duration；上传向uploadURL以POST发送数据直到超过duration
+// 速率为传输的字节数除以用时，包含HTTP和TCP的开销，结果与专门的测速工具相比略低
+// 参数downloadURL, uploadURL: 测速地址，为空时不测对应的方向
+// 参数duration: 每个方向的测试时长
+// 参数progress: 每隔一小段时间调用一次，可以为nil
+func RunSpeedTest(downloadURL, uploadURL string, duration time.Duration, progress SpeedTestProgress) (*SpeedTestResult, error) {
+	if downloadURL == "" && uploadURL == "" {
+		return nil, fmt.Errorf("没有配置测速地址")
+	}
+	result := &SpeedTestResult{}
+	if downloadURL != "" {
+		result.Download = measureTransfer(SpeedDownload, duration, progress, func(ctx context.Context, counter *atomic.Int64) error {
+			return downloadSpeed(ctx, downloadURL, counter)
+		})
+	}
+	if uploadURL != "" {
+		result.Upload = measureTransfer(SpeedUpload, duration, progress, func(ctx context.Context, counter *atomic.Int64) error {
+			return uploadSpeed(ctx, uploadURL, counter)
+		})
+	}
+	return result, nil
+}
+
+// measureTransfer 在后台进行传输，到达duration时取消，期间定时报告进度
+// 参数transfer: 进行传输并把传输的字节数累加到counter，ctx被取消时返回
+func measureTransfer(phase string, duration time.Duration, progress SpeedTestProgress, transfer func(ctx context.Context, counter *atomic.Int64) error) *SpeedSample {
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+
+	var counter atomic.Int64
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- transfer(ctx, &counter) }()
+
+	ticker := time.NewTicker(speedReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-done:
+			sample := &SpeedSample{Bytes: counter.Load(), Elapsed: time.Since(start)}
+			// 到达测试时长而取消不是错误
+			if err != nil && ctx.Err() == nil {
+				sample.Err = err
+			}
+			if progress != nil {
+				progress(phase, 1, sample.Mbps())
+			}
+			return sample
+		case <-ticker.C:
+			if progress != nil {
+				elapsed := time.Since(start)
+				current := SpeedSample{Bytes: counter.Load(), Elapsed: elapsed}
+				progress(phase, float64(elapsed)/float64(duration), current.Mbps())
+			}
+		}
+	}
+}
+
+// downloadSpeed 下载url的内容并丢弃，统计收到的字节数
+func downloadSpeed(ctx context.Context, url string, counter *atomic.Int64) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("服务器返回 %s", resp.Status)
+	}
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := resp.Body.Read(buf)
+		counter.Add(int64(n))
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// uploadSpeed 向url以POST发送数据，统计发出的字节数；数据在ctx被取消时结束
+func uploadSpeed(ctx context.Context, url string, counter *atomic.Int64) error {
+	body := &uploadReader{ctx: ctx, counter: counter}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("服务器返回 %s", resp.Status)
+	}
+	return nil
+}
+
+// uploadReader 上传的数据：全零的内容，ctx被取消前不断提供，读取的字节数累加到counter
+type uploadReader struct {
+	ctx     context.Context
+	counter *atomic.Int64
+}
+
+// Read 填充全零的数据，ctx被取消后返回io.EOF
+func (r *uploadReader) Read(p []byte) (int, error) {
+	if r.ctx.Err() != nil {
+		return 0, io.EOF
+	}
+	clear(p)
+	r.counter.Add(int64(len(p)))
+	return len(p), nil
+}
@@ -0,0 +1,95 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"syscall"
+	"time"
+
+	"go-framebuffer-console/pkg/i18n"
+)
+
+// TCP连接测试的参数
+const (
+	DefaultTCPCount   = 3               // 没有指定次数时尝试连接的次数
+	DefaultTCPTimeout = 3 * time.Second // 没有指定超时时每次连接等待的时间
+	tcpInterval       = time.Second     // 相邻两次连接的间隔
+)
+
+// testTCPTarget 多次连接目标的端口，统计每次建立连接的耗时
+// 各次连接的结果记录在result.Ping中，与ping的数据包一样统计成功次数和耗时
+func testTCPTarget(target NetworkTestTarget) NetworkTestResult {
+	count, timeout := DefaultTCPCount, DefaultTCPTimeout
+	if target.Count > 0 {
+		count = target.Count
+	}
+	if target.Timeout > 0 {
+		timeout = target.Timeout
+	}
+	result := NetworkTestResult{Target: target, PacketsSent: count, PacketLoss: 100.0, AvgLatency: "N/A"}
+	if target.Port <= 0 || target.Port > 65535 {
+		result.ErrorMsg = i18n.Translate("没有指定端口")
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	addr, err := resolveHost(ctx, target.Host)
+	cancel()
+	if err != nil {
+		result.ErrorMsg = err.Error()
+		return result
+	}
+
+	address := net.JoinHostPort(addr.String(), strconv.Itoa(target.Port))
+	attempts := &PingResult{Host: target.Host, Addr: addr}
+	var lastErr error
+	for seq := 1; seq <= count; seq++ {
+		start := time.Now()
+		reply := PingReply{Seq: seq}
+		conn, err := net.DialTimeout("tcp", address, timeout)
+		if err == nil {
+			reply.Received, reply.RTT = true, time.Since(start)
+			conn.Close()
+		} else {
+			lastErr = err
+		}
+		attempts.Replies = append(attempts.Replies, reply)
+		if seq < count {
+			time.Sleep(time.Until(start.Add(tcpInterval)))
+		}
+	}
+	result.Ping = attempts
+	result.PacketsRecv = attempts.Received()
+	result.PacketLoss = attempts.Loss()
+	if result.PacketsRecv > 0 {
+		_, avg, _ := attempts.RTTStats()
+		result.AvgLatency = FormatRTT(avg)
+	}
+
+	switch {
+	case result.PacketsRecv == 0:
+		result.ErrorMsg = tcpErrorText(lastErr)
+	case result.PacketsRecv < count:
+		result.Success = true
+		result.ErrorMsg = i18n.Translatef("%d次连接失败: %s", count-result.PacketsRecv, tcpErrorText(lastErr))
+	default:
+		result.Success = true
+	}
+	return result
+}
+
+// tcpErrorText 把连接失败的原因转换为便于判断的说明：被拒绝说明能到达主机但端口没有服务，超时多为防火墙丢弃
+func tcpErrorText(err error) string {
+	var ne net.Error
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return i18n.Translate("连接被拒绝，端口没有开放")
+	case errors.As(err, &ne) && ne.Timeout():
+		return i18n.Translate("连接超时，可能被防火墙拦截")
+	case errors.Is(err, syscall.EHOSTUNREACH), errors.Is(err, syscall.ENETUNREACH):
+		return i18n.Translate("无法到达主机，请检查路由")
+	}
+	return i18n.Translatef("连接失败: %v", err)
+}
@@ -0,0 +1,166 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go-framebuffer-console/pkg/i18n"
+)
+
+// 内核传感器所在的目录
+const (
+	thermalRoot = "/sys/class/thermal" // 温度区域（thermal_zone*），通常包括CPU温度
+	hwmonRoot   = "/sys/class/hwmon"   // 硬件监控芯片（hwmon*），包括主板温度和风扇转速
+)
+
+// Temperature 一个温度传感器的读数
+type Temperature struct {
+	Zone    string  // 传感器名称，如"x86_pkg_temp"、"cpu-thermal"，硬件监控芯片的传感器为"芯片 标签"，如"coretemp Core 0"
+	Celsius float64 // 温度（摄氏度）
+}
+
+// Fan 一个风扇的转速
+type Fan struct {
+	Name string // 风扇名称，格式为"芯片 标签"，如"nct6775 fan2"
+	RPM  int    // 转速（转/分），0表示停转或未接风扇
+}
+
+// Sensors 温度传感器和风扇的读数
+type Sensors struct {
+	Temperatures []Temperature
+	Fans         []Fan
+}
+
+// GetSensors 读取温度区域和硬件监控芯片中所有的温度和风扇转速
+// 无法读取的传感器被跳过；没有任何温度传感器和风扇时（如部分虚拟机）返回错误
+func GetSensors() (*Sensors, error) {
+	s := &Sensors{Temperatures: readThermalZones()}
+	zones := make(map[string]bool)
+	for _, t := range s.Temperatures {
+		zones[t.Zone] = true
+	}
+	temps, fans := readHwmon(zones)
+	s.Temperatures = append(s.Temperatures, temps...)
+	s.Fans = fans
+	if len(s.Temperatures) == 0 && len(s.Fans) == 0 {
+		return nil, fmt.Errorf("%s", i18n.Translate("没有找到温度传感器和风扇"))
+	}
+	return s, nil
+}
+
+// GetTemperatures 读取温度区域和硬件监控芯片中所有温度传感器的读数
+// 无法读取的传感器被跳过；没有任何传感器时（如部分虚拟机）返回错误
+func GetTemperatures() ([]Temperature, error) {
+	s, err := GetSensors()
+	if err != nil {
+		return nil, err
+	}
+	if len(s.Temperatures) == 0 {
+		return nil, fmt.Errorf("%s", i18n.Translate("没有找到温度传感器"))
+	}
+	return s.Temperatures, nil
+}
+
+// readThermalZones 读取/sys/class/thermal下所有温度区域的读数
+func readThermalZones() []Temperature {
+	zones, _ := filepath.Glob(filepath.Join(thermalRoot, "thermal_zone*"))
+
+	var temps []Temperature
+	for _, zone := range zones {
+		milli, err := readSysInt(filepath.Join(zone, "temp"))
+		if err != nil {
+			continue
+		}
+		name := filepath.Base(zone)
+		if t, err := os.ReadFile(filepath.Join(zone, "type")); err == nil {
+			name = strings.TrimSpace(string(t))
+		}
+		temps = append(temps, Temperature{Zone: name, Celsius: float64(milli) / 1000})
+	}
+	return temps
+}
+
+// readHwmon 读取/sys/class/hwmon下各硬件监控芯片的温度（temp*_input）和风扇转速（fan*_input）
+// 与温度区域同名的芯片（如acpitz）是同一个传感器，不重复读取
+// 参数skip: 已经读取过的温度区域名称
+func readHwmon(skip map[string]bool) ([]Temperature, []Fan) {
+	chips, _ := filepath.Glob(filepath.Join(hwmonRoot, "hwmon*"))
+
+	var temps []Temperature
+	var fans []Fan
+	for _, chip := range chips {
+		name := filepath.Base(chip)
+		if data, err := os.ReadFile(filepath.Join(chip, "name")); err == nil {
+			name = strings.TrimSpace(string(data))
+		}
+		if skip[name] {
+			continue
+		}
+		for _, input := range hwmonInputs(chip, "temp") {
+			milli, err := readSysInt(input)
+			if err != nil {
+				continue
+			}
+			temps = append(temps, Temperature{Zone: name + " " + hwmonLabel(input), Celsius: float64(milli) / 1000})
+		}
+		for _, input := range hwmonInputs(chip, "fan") {
+			rpm, err := readSysInt(input)
+			if err != nil {
+				continue
+			}
+			fans = append(fans, Fan{Name: name + " " + hwmonLabel(input), RPM: int(rpm)})
+		}
+	}
+	return temps, fans
+}
+
+// hwmonInputs 返回芯片目录中某类传感器的读数文件，按编号排序（temp2在temp10之前）
+// 参数kind: 传感器类型，如"temp"、"fan"
+func hwmonInputs(chip, kind string) []string {
+	inputs, _ := filepath.Glob(filepath.Join(chip, kind+"*_input"))
+	index := func(path string) int {
+		n, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), kind), "_input"))
+		return n
+	}
+	sort.Slice(inputs, func(i, j int) bool { return index(inputs[i]) < index(inputs[j]) })
+	return inputs
+}
+
+// hwmonLabel 返回读数文件对应传感器的标签（如"Core 0"），没有标签文件时返回传感器编号（如"temp1"）
+func hwmonLabel(input string) string {
+	base := strings.TrimSuffix(input, "_input")
+	if data, err := os.ReadFile(base + "_label"); err == nil {
+		if label := strings.TrimSpace(string(data)); label != "" {
+			return label
+		}
+	}
+	return filepath.Base(base)
+}
+
+// readSysInt 读取sysfs中只包含一个整数的文件
+func readSysInt(path string) (int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// MaxTemperature 返回读数最高的温度传感器
+func MaxTemperature() (Temperature, error) {
+	temps, err := GetTemperatures()
+	if err != nil {
+		return Temperature{}, err
+	}
+	max := temps[0]
+	for _, t := range temps[1:] {
+		if t.Celsius > max.Celsius {
+			max = t
+		}
+	}
+	return max, nil
+}
@@ -0,0 +1,134 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeSyncTimeout 查询一次时间同步状态的最长时间
+const timeSyncTimeout = 2 * time.Second
+
+// TimeSync 系统时钟的同步状态
+type TimeSync struct {
+	Source       string        // 提供状态的服务：chrony、ntpd或timedatectl
+	Synchronized bool          // 时钟是否已与时间服务器同步
+	Offset       time.Duration // 本机时钟与服务器时间的偏差，本机快时为正
+	HasOffset    bool          // 是否知道偏差，timedatectl不报告偏差
+}
+
+// OffsetText 返回带符号和单位的偏差，如"+0.3 ms"，不知道偏差时返回空字符串
+func (t TimeSync) OffsetText() string {
+	if !t.HasOffset {
+		return ""
+	}
+	ms := float64(t.Offset) / float64(time.Millisecond)
+	if math.Abs(ms) >= 1000 {
+		return fmt.Sprintf("%+.1f s", ms/1000)
+	}
+	return fmt.Sprintf("%+.1f ms", ms)
+}
+
+// GetTimeSync 查询时间同步状态，依次尝试chronyc、ntpq和timedatectl，使用第一个可用的
+// 都不可用时返回错误
+func GetTimeSync() (*TimeSync, error) {
+	if output, err := runTimeSyncCommand("chronyc", "tracking"); err == nil {
+		return parseChronyTracking(output)
+	}
+	if output, err := runTimeSyncCommand("ntpq", "-c", "rv"); err == nil {
+		return parseNtpqVariables(output)
+	}
+	if output, err := runTimeSyncCommand("timedatectl", "status"); err == nil {
+		return parseTimedatectl(output)
+	}
+	return nil, fmt.Errorf("没有找到可用的时间同步服务（chrony、ntpd或systemd-timesyncd）")
+}
+
+// runTimeSyncCommand 执行查询命令并返回标准输出，程序不存在或以非0状态退出时返回错误
+func runTimeSyncCommand(name string, args ...string) (string, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeSyncTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, name, args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("执行%s失败: %v", name, err)
+	}
+	return string(output), nil
+}
+
+// parseChronyTracking 解析chronyc tracking的输出
+// 同步状态取自"Leap status"（未同步时为"Not synchronised"），偏差取自形如
+// "System time : 0.000002716 seconds slow of NTP time"的一行，slow表示本机时钟慢
+func parseChronyTracking(output string) (*TimeSync, error) {
+	sync := &TimeSync{Source: "chrony"}
+	found := false
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "Leap status":
+			found = true
+			sync.Synchronized = value != "Not synchronised"
+		case "System time":
+			fields := strings.Fields(value)
+			if len(fields) < 3 {
+				continue
+			}
+			seconds, err := strconv.ParseFloat(fields[0], 64)
+			if err != nil {
+				continue
+			}
+			if fields[2] == "slow" {
+				seconds = -seconds
+			}
+			sync.Offset = time.Duration(seconds * float64(time.Second))
+			sync.HasOffset = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("无法解析chronyc的输出")
+	}
+	return sync, nil
+}
+
+// parseNtpqVariables 解析ntpq -c rv输出的系统变量
+// leap=11表示未同步，offset为本机时钟相对服务器的偏差（毫秒）
+func parseNtpqVariables(output string) (*TimeSync, error) {
+	vars := make(map[string]string)
+	for _, field := range strings.FieldsFunc(output, func(r rune) bool { return r == ',' || r == '\n' }) {
+		if key, value, ok := strings.Cut(strings.TrimSpace(field), "="); ok {
+			vars[key] = strings.Trim(value, `"`)
+		}
+	}
+	leap, ok := vars["leap"]
+	if !ok {
+		return nil, fmt.Errorf("无法解析ntpq的输出")
+	}
+	sync := &TimeSync{Source: "ntpd", Synchronized: leap != "11" && !strings.Contains(output, "sync_unspec")}
+	if ms, err := strconv.ParseFloat(vars["offset"], 64); err == nil {
+		sync.Offset = time.Duration(ms * float64(time.Millisecond))
+		sync.HasOffset = true
+	}
+	return sync, nil
+}
+
+// parseTimedatectl 解析timedatectl status的输出
+// 新版本为"System clock synchronized: yes"，旧版本为"NTP synchronized: yes"
+func parseTimedatectl(output string) (*TimeSync, error) {
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if ok && strings.HasSuffix(strings.TrimSpace(key), "synchronized") {
+			return &TimeSync{Source: "timedatectl", Synchronized: strings.TrimSpace(value) == "yes"}, nil
+		}
+	}
+	return nil, fmt.Errorf("无法解析timedatectl的输出")
+}
@@ -0,0 +1,218 @@
+package system
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"time"
+)
+
+// 路由追踪用到的ICMP差错报文类型
+const (
+	icmpv4DestUnreachable = 3
+	icmpv4TimeExceeded    = 11
+	icmpv6DestUnreachable = 1
+	icmpv6TimeExceeded    = 3
+)
+
+// TracerouteOptions 路由追踪的参数
+type TracerouteOptions struct {
+	MaxHops int           // 最大跳数
+	Probes  int           // 每一跳发送的探测包数
+	Timeout time.Duration // 每个探测包等待回复的时间
+	// MaxSilent 连续这么多跳都没有任何回复时提前结束，0表示不限制
+	// 目标或沿途的防火墙丢弃探测包时，之后的各跳通常都不会再有回复
+	MaxSilent int
+}
+
+// DefaultTracerouteOptions 与traceroute命令的默认值相近，每个探测包等待的时间更短，连续5跳无回复时结束
+var DefaultTracerouteOptions = TracerouteOptions{MaxHops: 30, Probes: 3, Timeout: 2 * time.Second, MaxSilent: 5}
+
+// TraceHop 一跳的结果
+type TraceHop struct {
+	TTL     int         // 跳数，从1开始
+	Addr    net.IP      // 回复的路由器或目标的地址，所有探测包都超时时为nil
+	Replies []PingReply // 各探测包的结果
+}
+
+// TraceResult 一次路由追踪的结果
+type TraceResult struct {
+	Host        string     // 追踪的主机名或地址
+	Addr        net.IP     // 解析得到的地址
+	Hops        []TraceHop // 各跳的结果，按跳数排列
+	Reached     bool       // 是否收到了目标的回显回复
+	Unreachable bool       // 是否收到了"目标不可达"，此时最后一跳为报告不可达的路由器
+}
+
+// TraceProgress 每完成一跳调用一次
+type TraceProgress func(hop TraceHop)
+
+// Traceroute 逐跳增加回显请求的TTL，由沿途路由器回复的"超时"报文得到路径上的各跳及其往返时间
+// 收到目标的回显回复、收到"目标不可达"、达到最大跳数或连续MaxSilent跳无回复时结束
+// 路由器的差错报文只能用原始套接字接收，因此需要root权限
+// ctx被取消时停止，返回已经得到的结果和ctx的错误
+// 参数host: 主机名或IP地址
+// 参数opts: 最大跳数、每跳的探测包数和超时时间
+// 参数progress: 每完成一跳调用一次，可以为nil
+func Traceroute(ctx context.Context, host string, opts TracerouteOptions, progress TraceProgress) (*TraceResult, error) {
+	addr, err := resolveHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := listenICMP(addr.To4() == nil)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if !conn.raw {
+		return nil, fmt.Errorf("路由追踪需要root权限（接收路由器的ICMP差错报文需要原始套接字）")
+	}
+
+	result := &TraceResult{Host: host, Addr: addr}
+	silent := 0
+	for ttl := 1; ttl <= opts.MaxHops; ttl++ {
+		if err := conn.setTTL(ttl); err != nil {
+			return result, fmt.Errorf("设置TTL失败: %v", err)
+		}
+		hop := TraceHop{TTL: ttl}
+		final := false
+		for probe := 0; probe < opts.Probes; probe++ {
+			seq := ttl*opts.Probes + probe
+			start := time.Now()
+			if err := conn.sendEcho(addr, seq); err != nil {
+				return result, fmt.Errorf("发送ICMP数据包失败: %v", err)
+			}
+			deadline := start.Add(opts.Timeout)
+			if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+				deadline = d
+			}
+			reply := PingReply{Seq: seq}
+			from, kind, err := conn.waitHop(addr, seq, deadline)
+			if err == nil {
+				reply.Received, reply.RTT = true, time.Since(start)
+				if hop.Addr == nil {
+					hop.Addr = from
+				}
+				switch kind {
+				case hopReached:
+					result.Reached, final = true, true
+				case hopUnreachable:
+					result.Unreachable, final = true, true
+				}
+			} else if !errors.Is(err, os.ErrDeadlineExceeded) {
+				return result, fmt.Errorf("接收ICMP数据包失败: %v", err)
+			}
+			hop.Replies = append(hop.Replies, reply)
+			if ctx.Err() != nil {
+				break
+			}
+		}
+		result.Hops = append(result.Hops, hop)
+		if progress != nil {
+			progress(hop)
+		}
+		if hop.Addr == nil {
+			silent++
+		} else {
+			silent = 0
+		}
+		if final || ctx.Err() != nil || (opts.MaxSilent > 0 && silent >= opts.MaxSilent) {
+			break
+		}
+	}
+	return result, ctx.Err()
+}
+
+// 回复探测包的报文种类
+const (
+	hopTransit     = iota // 沿途路由器的"超时"
+	hopReached            // 目标的回显回复
+	hopUnreachable        // "目标不可达"
+)
+
+// setTTL 设置之后发出的数据包的TTL（IPv6为跳数限制）
+func (c *icmpConn) setTTL(ttl int) error {
+	sc, ok := c.conn.(syscall.Conn)
+	if !ok {
+		return fmt.Errorf("套接字不支持设置TTL")
+	}
+	raw, err := sc.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var serr error
+	err = raw.Control(func(fd uintptr) {
+		if c.ipv6 {
+			serr = syscall.SetsockoptInt(int(fd), syscall.IPPROTO_IPV6, syscall.IPV6_UNICAST_HOPS, ttl)
+		} else {
+			serr = syscall.SetsockoptInt(int(fd), syscall.IPPROTO_IP, syscall.IP_TTL, ttl)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return serr
+}
+
+// waitHop 等待序号为seq的探测包的回复：目标的回显回复，或路由器的"超时"、"目标不可达"报文
+// 差错报文中带有引起差错的数据包的开头，由其中的标识符和序号判断是否为本次的探测包
+// 返回回复的地址和报文种类，到达deadline时返回os.ErrDeadlineExceeded
+func (c *icmpConn) waitHop(dst net.IP, seq int, deadline time.Time) (net.IP, int, error) {
+	if err := c.conn.SetReadDeadline(deadline); err != nil {
+		return nil, 0, err
+	}
+	echoReply, timeExceeded, unreachable := byte(icmpv4EchoReply), byte(icmpv4TimeExceeded), byte(icmpv4DestUnreachable)
+	if c.ipv6 {
+		echoReply, timeExceeded, unreachable = icmpv6EchoReply, icmpv6TimeExceeded, icmpv6DestUnreachable
+	}
+	buf := make([]byte, 1500)
+	for {
+		n, from, err := c.conn.ReadFrom(buf)
+		if err != nil {
+			return nil, 0, err
+		}
+		msg := buf[:n]
+		if n < 8 {
+			continue
+		}
+		switch msg[0] {
+		case echoReply:
+			if binary.BigEndian.Uint16(msg[4:]) == c.id && binary.BigEndian.Uint16(msg[6:]) == uint16(seq) && addrIP(from).Equal(dst) {
+				return addrIP(from), hopReached, nil
+			}
+		case timeExceeded, unreachable:
+			if c.matchQuoted(msg[8:], seq) {
+				kind := hopTransit
+				if msg[0] == unreachable {
+					kind = hopUnreachable
+				}
+				return addrIP(from), kind, nil
+			}
+		}
+	}
+}
+
+// matchQuoted 判断差错报文引用的数据包是否为本套接字发出的序号为seq的回显请求
+// 参数quoted: 差错报文首部之后的内容，即原数据包的IP首部和至少8字节的ICMP首部
+func (c *icmpConn) matchQuoted(quoted []byte, seq int) bool {
+	headerLen := 40 // IPv6首部，探测包不带扩展首部
+	request := byte(icmpv6EchoRequest)
+	if !c.ipv6 {
+		if len(quoted) < 1 {
+			return false
+		}
+		headerLen = int(quoted[0]&0x0f) * 4
+		request = icmpv4EchoRequest
+	}
+	if len(quoted) < headerLen+8 {
+		return false
+	}
+	echo := quoted[headerLen:]
+	return echo[0] == request &&
+		binary.BigEndian.Uint16(echo[4:]) == c.id &&
+		binary.BigEndian.Uint16(echo[6:]) == uint16(seq)
+}